@@ -0,0 +1,8 @@
+BEGIN;
+DROP INDEX IF EXISTS tasks_search_vector_idx;
+DROP INDEX IF EXISTS projects_search_vector_idx;
+DROP INDEX IF EXISTS users_search_vector_idx;
+ALTER TABLE tasks DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE projects DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE users DROP COLUMN IF EXISTS search_vector;
+END;
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS workflows (
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    project_id INT PRIMARY KEY,
+    definition JSONB NOT NULL,
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+DELETE FROM users WHERE email = 'admin@example.com' AND role = 'admin';
@@ -0,0 +1,3 @@
+INSERT INTO users (full_name, email, role) VALUES
+    ('Administrator', 'admin@example.com', 'admin')
+ON CONFLICT (email) DO NOTHING;
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS project_members (
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    project_id INT NOT NULL,
+    user_id INT NOT NULL,
+    role VARCHAR(50) NOT NULL CHECK (role IN ('owner', 'maintainer', 'member', 'viewer')),
+    PRIMARY KEY (project_id, user_id),
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS project_members_user_id_idx ON project_members (user_id);
+
+INSERT INTO project_members (project_id, user_id, role)
+SELECT id, manager_id, 'owner'
+FROM projects
+WHERE manager_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+INSERT INTO project_members (project_id, user_id, role)
+SELECT DISTINCT project_id, assignee_id, 'member'
+FROM tasks
+WHERE project_id IS NOT NULL AND assignee_id IS NOT NULL
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,4 @@
+BEGIN;
+DROP TABLE IF EXISTS audit_log CASCADE;
+DROP FUNCTION IF EXISTS audit_log_append_only();
+END;
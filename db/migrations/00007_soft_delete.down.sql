@@ -0,0 +1,23 @@
+ALTER TABLE audit_log
+    DROP CONSTRAINT IF EXISTS audit_log_operation_check,
+    ADD CONSTRAINT audit_log_operation_check CHECK (operation IN ('create', 'update', 'delete')) NOT VALID;
+
+ALTER TABLE tasks
+    DROP CONSTRAINT IF EXISTS tasks_assignee_id_fkey,
+    ADD CONSTRAINT tasks_assignee_id_fkey FOREIGN KEY (assignee_id) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE projects
+    DROP CONSTRAINT IF EXISTS projects_manager_id_fkey,
+    ADD CONSTRAINT projects_manager_id_fkey FOREIGN KEY (manager_id) REFERENCES users(id) ON DELETE CASCADE;
+
+DELETE FROM tasks WHERE deleted_at IS NOT NULL;
+DELETE FROM projects WHERE deleted_at IS NOT NULL;
+DELETE FROM users WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS tasks_deleted_at_idx;
+DROP INDEX IF EXISTS projects_deleted_at_idx;
+DROP INDEX IF EXISTS users_deleted_at_idx;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE projects DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
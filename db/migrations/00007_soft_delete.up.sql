@@ -0,0 +1,20 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS users_deleted_at_idx ON users (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS projects_deleted_at_idx ON projects (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS tasks_deleted_at_idx ON tasks (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- purging a user must not take the projects they manage and the tasks assigned to them along
+ALTER TABLE projects
+    DROP CONSTRAINT IF EXISTS projects_manager_id_fkey,
+    ADD CONSTRAINT projects_manager_id_fkey FOREIGN KEY (manager_id) REFERENCES users(id) ON DELETE RESTRICT;
+
+ALTER TABLE tasks
+    DROP CONSTRAINT IF EXISTS tasks_assignee_id_fkey,
+    ADD CONSTRAINT tasks_assignee_id_fkey FOREIGN KEY (assignee_id) REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE audit_log
+    DROP CONSTRAINT IF EXISTS audit_log_operation_check,
+    ADD CONSTRAINT audit_log_operation_check CHECK (operation IN ('create', 'update', 'delete', 'restore'));
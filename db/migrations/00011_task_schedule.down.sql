@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS task_predecessors CASCADE;
+
+ALTER TABLE tasks
+    DROP CONSTRAINT IF EXISTS tasks_due_date_check,
+    DROP COLUMN IF EXISTS estimate_hours,
+    DROP COLUMN IF EXISTS due_date,
+    DROP COLUMN IF EXISTS start_date;
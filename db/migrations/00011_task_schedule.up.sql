@@ -0,0 +1,19 @@
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS start_date DATE,
+    ADD COLUMN IF NOT EXISTS due_date DATE,
+    ADD COLUMN IF NOT EXISTS estimate_hours NUMERIC(8, 2) CHECK (estimate_hours >= 0);
+
+ALTER TABLE tasks
+    ADD CONSTRAINT tasks_due_date_check CHECK (due_date >= start_date);
+
+CREATE TABLE IF NOT EXISTS task_predecessors (
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    task_id INT NOT NULL,
+    predecessor_id INT NOT NULL,
+    PRIMARY KEY (task_id, predecessor_id),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (predecessor_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    CHECK (task_id <> predecessor_id)
+);
+
+CREATE INDEX IF NOT EXISTS task_predecessors_predecessor_id_idx ON task_predecessors (predecessor_id);
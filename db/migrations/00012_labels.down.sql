@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS task_labels CASCADE;
+DROP TABLE IF EXISTS labels CASCADE;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS comment_mentions CASCADE;
+DROP TABLE IF EXISTS comment_revisions CASCADE;
+DROP TABLE IF EXISTS comments CASCADE;
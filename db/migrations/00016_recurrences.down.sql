@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS recurrence_occurrences CASCADE;
+DROP TABLE IF EXISTS recurrences CASCADE;
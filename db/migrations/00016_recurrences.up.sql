@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS recurrences (
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    id SERIAL PRIMARY KEY,
+    task_id INT NOT NULL UNIQUE,
+    rule VARCHAR(255) NOT NULL,
+    trigger VARCHAR(16) NOT NULL DEFAULT 'completion' CHECK (trigger IN ('completion', 'schedule')),
+    start_date DATE NOT NULL,
+    last_date DATE NOT NULL,
+    next_date DATE,
+    generated INT NOT NULL DEFAULT 1 CHECK (generated > 0),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    CHECK (last_date >= start_date),
+    CHECK (next_date > last_date)
+);
+
+CREATE INDEX IF NOT EXISTS recurrences_next_date_idx ON recurrences (next_date) WHERE next_date IS NOT NULL;
+
+-- one row per occurrence of a series, the primary key makes creating the same
+-- occurrence twice impossible
+CREATE TABLE IF NOT EXISTS recurrence_occurrences (
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    recurrence_id INT NOT NULL,
+    occurrence DATE NOT NULL,
+    task_id INT,
+    PRIMARY KEY (recurrence_id, occurrence),
+    FOREIGN KEY (recurrence_id) REFERENCES recurrences(id) ON DELETE CASCADE,
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS recurrence_occurrences_task_id_idx ON recurrence_occurrences (task_id);
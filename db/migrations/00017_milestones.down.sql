@@ -0,0 +1,4 @@
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS milestone_id;
+
+DROP TABLE IF EXISTS milestones CASCADE;
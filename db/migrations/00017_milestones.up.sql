@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS milestones (
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    id SERIAL PRIMARY KEY,
+    project_id INT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    goal TEXT,
+    start_date DATE,
+    end_date DATE,
+    closed_at DATE,
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+    UNIQUE (project_id, name),
+    CHECK (end_date >= start_date)
+);
+
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS milestone_id INT REFERENCES milestones(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS tasks_milestone_id_idx ON tasks (milestone_id);
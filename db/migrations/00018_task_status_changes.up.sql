@@ -0,0 +1,17 @@
+-- one row per status a task entered, from_status is NULL when it was created
+CREATE TABLE IF NOT EXISTS task_status_changes (
+    id BIGSERIAL PRIMARY KEY,
+    task_id INT NOT NULL,
+    from_status VARCHAR(50),
+    to_status VARCHAR(50) NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS task_status_changes_task_id_changed_at_idx ON task_status_changes (task_id, changed_at);
+
+-- existing tasks start their history in the status they have now
+INSERT INTO task_status_changes (task_id, to_status, changed_at)
+SELECT id, status, COALESCE(created_at, CURRENT_TIMESTAMP)
+FROM tasks
+WHERE NOT EXISTS (SELECT 1 FROM task_status_changes c WHERE c.task_id = tasks.id);
@@ -0,0 +1 @@
+DROP SEQUENCE IF EXISTS event_stream_seq;
@@ -0,0 +1,3 @@
+-- IDs of the events streamed to clients, shared by the replicas that fan them
+-- out through LISTEN/NOTIFY so that a client may resume on any of them
+CREATE SEQUENCE IF NOT EXISTS event_stream_seq;
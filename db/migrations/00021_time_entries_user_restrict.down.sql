@@ -0,0 +1,3 @@
+ALTER TABLE time_entries
+    DROP CONSTRAINT IF EXISTS time_entries_user_id_fkey,
+    ADD CONSTRAINT time_entries_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
@@ -0,0 +1,5 @@
+-- purging a user must not take the time they logged along, it still counts in
+-- the totals of their tasks and projects
+ALTER TABLE time_entries
+    DROP CONSTRAINT IF EXISTS time_entries_user_id_fkey,
+    ADD CONSTRAINT time_entries_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE RESTRICT;
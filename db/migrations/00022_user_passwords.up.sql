@@ -0,0 +1,3 @@
+-- bcrypt hash of the login password, users without one cannot log in until
+-- a password is set for them
+ALTER TABLE users ADD COLUMN IF NOT EXISTS password_hash TEXT;
@@ -5,10 +5,19 @@ import (
 	"flag"
 	"fmt"
 	"hard/internal/config"
+	"hard/internal/domain/access"
+	"hard/internal/domain/attachment"
+	"hard/internal/domain/event"
+	"hard/internal/domain/task"
+	"hard/internal/domain/webhook"
 	"hard/internal/handler"
 	"hard/internal/repository"
 	"hard/internal/service/tasker"
+	"hard/pkg/auth"
+	"hard/pkg/pubsub"
 	"hard/pkg/server"
+	"hard/pkg/store"
+	"hard/pkg/worker"
 	"os"
 	"os/signal"
 	"syscall"
@@ -22,34 +31,126 @@ func Run() {
 		return
 	}
 
-	repositories, err := repository.New(repository.WithPostgresStore(configs.POSTGRES.DSN))
+	blobStore := repository.WithLocalBlobStore(configs.BLOB.Dir)
+	if configs.BLOB.Driver == "s3" {
+		blobStore = repository.WithS3BlobStore(store.S3Config{
+			Endpoint:  configs.BLOB.S3Endpoint,
+			Region:    configs.BLOB.S3Region,
+			Bucket:    configs.BLOB.S3Bucket,
+			AccessKey: configs.BLOB.S3AccessKey,
+			SecretKey: configs.BLOB.S3SecretKey,
+		})
+	}
+
+	repositories, err := repository.New(repository.WithPostgresStore(configs.POSTGRES.DSN), blobStore)
 	if err != nil {
 		fmt.Printf("ERR_INIT_REPOSITORIES: %v", err)
 		return
 	}
 
+	tokens, err := auth.New(auth.Config{
+		Secret:         configs.AUTH.Secret,
+		JWKSFile:       configs.AUTH.JWKSFile,
+		PrivateKeyFile: configs.AUTH.PrivateKeyFile,
+		KeyID:          configs.AUTH.KeyID,
+		Issuer:         configs.AUTH.Issuer,
+		Audience:       configs.AUTH.Audience,
+		TTL:            configs.AUTH.TTL,
+	})
+	if err != nil {
+		fmt.Printf("ERR_INIT_AUTH: %v", err)
+		return
+	}
+
+	policy := access.DefaultPolicy
+	if configs.AUTH.PolicyFile != "" {
+		if policy, err = access.LoadPolicy(configs.AUTH.PolicyFile); err != nil {
+			fmt.Printf("ERR_INIT_AUTH: %v", err)
+			return
+		}
+	}
+
+	closePolicy, err := task.ParseClosePolicy(configs.TASKS.ClosePolicy)
+	if err != nil {
+		fmt.Printf("ERR_INIT_TODO_SERVICE: %v", err)
+		return
+	}
+
+	eventHub := pubsub.NewHub[event.Event](configs.EVENTS.Buffer)
+
 	taskerService, err := tasker.New(
 		tasker.WithUserRepository(repositories.User),
 		tasker.WithTaskRepository(repositories.Task),
 		tasker.WithProjectRepository(repositories.Project),
+		tasker.WithSearchRepository(repositories.Search),
+		tasker.WithWorkflowRepository(repositories.Workflow),
+		tasker.WithMemberRepository(repositories.Member),
+		tasker.WithAuditRepository(repositories.Audit),
+		tasker.WithDependencyRepository(repositories.Dependency),
+		tasker.WithPredecessorRepository(repositories.Predecessor),
+		tasker.WithLabelRepository(repositories.Label),
+		tasker.WithCommentRepository(repositories.Comment),
+		tasker.WithAttachmentRepository(repositories.Attachment),
+		tasker.WithTimeEntryRepository(repositories.TimeEntry),
+		tasker.WithRecurrenceRepository(repositories.Recurrence),
+		tasker.WithMilestoneRepository(repositories.Milestone),
+		tasker.WithReportRepository(repositories.Report),
+		tasker.WithWebhookRepository(repositories.Webhook),
+		tasker.WithEventRepository(repositories.Event),
+		tasker.WithTransactor(repositories.Transactor),
+		tasker.WithBlobStore(repositories.Blob),
+		tasker.WithEventHub(eventHub),
+		tasker.WithIdentityProvider(tasker.NewDirectoryProvider(repositories.User)),
+		tasker.WithTokens(tokens),
+		tasker.WithPolicy(policy),
+		tasker.WithClosePolicy(closePolicy),
+		tasker.WithCrossProjectDependencies(configs.TASKS.CrossProjectDependencies),
+		// without NOTIFY the events only reach the clients of this replica
+		tasker.WithEventNotify(configs.EVENTS.Notify),
+		tasker.WithAttachmentLimits(attachment.Limits{
+			MaxSize: configs.ATTACHMENTS.MaxSize,
+			Types:   configs.ATTACHMENTS.Types,
+		}),
+		tasker.WithWebhookLimits(webhook.Limits{
+			Timeout:     configs.WEBHOOK.Timeout,
+			MaxAttempts: configs.WEBHOOK.MaxAttempts,
+		}),
 	)
 	if err != nil {
 		fmt.Printf("ERR_INIT_TODO_SERVICE: %v", err)
 		return
 	}
 
+	if configs.AUTH.AdminPassword != "" {
+		if err = taskerService.InitPassword(context.Background(), configs.AUTH.AdminEmail, configs.AUTH.AdminPassword); err != nil {
+			fmt.Printf("ERR_INIT_AUTH: %v", err)
+			return
+		}
+	}
+
+	handlerConfigs := []handler.Configuration{handler.WithHTTPHandler()}
+	if configs.APP.GRPCPort != "" {
+		handlerConfigs = append(handlerConfigs, handler.WithGRPCHandler())
+	}
+
 	handlers, err := handler.New(
 		handler.Dependencies{
 			Configs:       configs,
 			TaskerService: taskerService,
+			Tokens:        tokens,
 		},
-		handler.WithHTTPHandler())
+		handlerConfigs...)
 	if err != nil {
 		fmt.Printf("ERR_INIT_HANDLERS: %v", err)
 		return
 	}
 
-	servers, err := server.New(server.WithHTTPServer(handlers.HTTP, configs.APP.Port))
+	serverConfigs := []server.Configuration{server.WithHTTPServer(handlers.HTTP, configs.APP.Port)}
+	if handlers.GRPC != nil {
+		serverConfigs = append(serverConfigs, server.WithGRPCServer(handlers.GRPC, configs.APP.GRPCPort))
+	}
+
+	servers, err := server.New(serverConfigs...)
 	if err != nil {
 		fmt.Printf("ERR_RUN_SERVERS: %v", err)
 		return
@@ -59,6 +160,54 @@ func Run() {
 		return
 	}
 	fmt.Println("http server started on http://localhost:" + configs.APP.Port)
+	if handlers.GRPC != nil {
+		fmt.Println("grpc server started on localhost:" + configs.APP.GRPCPort)
+	}
+
+	var purger *worker.Worker
+	if configs.PURGE.Retention > 0 {
+		purger = worker.New("purge", configs.PURGE.Interval, func(ctx context.Context) error {
+			n, err := taskerService.Purge(ctx, configs.PURGE.Retention)
+			if n > 0 {
+				fmt.Printf("purged %d deleted rows\n", n)
+			}
+			return err
+		})
+		purger.Run()
+	}
+
+	var scheduler *worker.Worker
+	if configs.RECURRENCE.Interval > 0 {
+		scheduler = worker.New("recurrence", configs.RECURRENCE.Interval, func(ctx context.Context) error {
+			n, err := taskerService.RunRecurrences(ctx, time.Now())
+			if n > 0 {
+				fmt.Printf("created %d recurring tasks\n", n)
+			}
+			return err
+		})
+		scheduler.Run()
+	}
+
+	var dispatcher *worker.Worker
+	if configs.WEBHOOK.Interval > 0 {
+		dispatcher = worker.New("webhook", configs.WEBHOOK.Interval, func(ctx context.Context) error {
+			n, err := taskerService.DispatchWebhooks(ctx)
+			if n > 0 {
+				fmt.Printf("delivered %d webhook events\n", n)
+			}
+			return err
+		})
+		dispatcher.Run()
+	}
+
+	listenCtx, stopListening := context.WithCancel(context.Background())
+	listening := make(chan struct{})
+	go func() {
+		defer close(listening)
+		if err := taskerService.ListenEvents(listenCtx); err != nil {
+			fmt.Printf("ERR_LISTEN_EVENTS: %v", err)
+		}
+	}()
 
 	var wait time.Duration
 	flag.DurationVar(&wait, "graceful-timeout", time.Second*15, "the duration for which the httpServer gracefully wait for existing connections to finish - e.g. 15s or 1m")
@@ -71,11 +220,35 @@ func Run() {
 
 	ctx, cancel := context.WithTimeout(context.Background(), wait)
 	defer cancel()
+	// streams last until the client leaves, they are ended for the server to stop
+	eventHub.Close()
+	if handlers.Health != nil {
+		handlers.Health.Shutdown()
+	}
+	// a slow drain must not skip the cleanup of the workers and the listener
 	if err = servers.Stop(ctx); err != nil {
-		panic(err)
+		fmt.Printf("ERR_STOP_SERVERS: %v", err)
 	}
 
 	fmt.Println("running cleanup tasks...")
+	if purger != nil {
+		if err = purger.Stop(ctx); err != nil {
+			fmt.Printf("ERR_STOP_PURGE: %v", err)
+		}
+	}
+	if scheduler != nil {
+		if err = scheduler.Stop(ctx); err != nil {
+			fmt.Printf("ERR_STOP_RECURRENCE: %v", err)
+		}
+	}
+	if dispatcher != nil {
+		if err = dispatcher.Stop(ctx); err != nil {
+			fmt.Printf("ERR_STOP_WEBHOOK: %v", err)
+		}
+	}
+
+	stopListening()
+	<-listening
 
 	fmt.Println("server was successful shutdown.")
 
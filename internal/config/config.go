@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -12,26 +13,147 @@ import (
 const (
 	defaultAppMode    = "dev"
 	defaultAppPort    = "8080"
+	defaultGRPCPort   = "9090"
 	defaultAppPath    = "/"
 	defaultAppTimeout = 60 * time.Second
+	defaultAuthTTL    = 24 * time.Hour
+
+	defaultAuthAdminEmail = "admin@example.com"
+
+	defaultPurgeRetention = 30 * 24 * time.Hour
+	defaultPurgeInterval  = time.Hour
+
+	defaultRecurrenceInterval = time.Minute
+
+	defaultWebhookInterval    = 10 * time.Second
+	defaultWebhookTimeout     = 10 * time.Second
+	defaultWebhookMaxAttempts = 8
+
+	defaultEventsBuffer = 1000
+
+	defaultGraphQLMaxDepth      = 8
+	defaultGraphQLMaxComplexity = 2000
+
+	defaultTasksClosePolicy = "block"
+
+	defaultBlobDriver         = "local"
+	defaultBlobDir            = "./data/blobs"
+	defaultAttachmentsMaxSize = 10 << 20
+	defaultAttachmentsTypes   = "image/png,image/jpeg,image/gif,image/webp,text/plain,application/pdf,application/zip,application/x-gzip"
 )
 
 type (
 	Configs struct {
-		APP      AppConfig
-		POSTGRES StoreConfig
+		APP         AppConfig
+		POSTGRES    StoreConfig
+		AUTH        AuthConfig
+		PURGE       PurgeConfig
+		RECURRENCE  RecurrenceConfig
+		WEBHOOK     WebhookConfig
+		EVENTS      EventsConfig
+		GRAPHQL     GraphQLConfig
+		TASKS       TasksConfig
+		BLOB        BlobConfig
+		ATTACHMENTS AttachmentsConfig
 	}
 
 	AppConfig struct {
-		Mode    string `required:"true"`
-		Port    string
-		Path    string
-		Timeout time.Duration
+		Mode string `required:"true"`
+		Port string
+		// GRPCPort is the port of the gRPC API, empty disables it.
+		GRPCPort string `envconfig:"GRPC_PORT"`
+		Path     string
+		Timeout  time.Duration
 	}
 
 	StoreConfig struct {
 		DSN string
 	}
+
+	AuthConfig struct {
+		Secret         string
+		JWKSFile       string `envconfig:"JWKS_FILE"`
+		PrivateKeyFile string `envconfig:"PRIVATE_KEY_FILE"`
+		KeyID          string `envconfig:"KEY_ID"`
+		Issuer         string
+		Audience       string
+		TTL            time.Duration
+		// PolicyFile is a JSON permission matrix overriding the default one.
+		PolicyFile string `envconfig:"POLICY_FILE"`
+		// AdminEmail and AdminPassword give the admin created by the migrations
+		// its first login password, an existing password is never replaced.
+		AdminEmail    string `envconfig:"ADMIN_EMAIL"`
+		AdminPassword string `envconfig:"ADMIN_PASSWORD"`
+	}
+
+	PurgeConfig struct {
+		// Retention is how long soft deleted rows are kept before they are
+		// hard deleted, zero disables purging.
+		Retention time.Duration
+		Interval  time.Duration
+	}
+
+	RecurrenceConfig struct {
+		// Interval is how often the scheduler creates the due occurrences of
+		// recurring tasks, zero disables it.
+		Interval time.Duration
+	}
+
+	WebhookConfig struct {
+		// Interval is how often the dispatcher sends the due webhook
+		// deliveries, zero disables it.
+		Interval time.Duration
+		// Timeout is how long a receiver has to answer a delivery.
+		Timeout time.Duration
+		// MaxAttempts is how many times a delivery is sent before it is dead.
+		MaxAttempts int `envconfig:"MAX_ATTEMPTS"`
+	}
+
+	EventsConfig struct {
+		// Buffer is how many of the latest events are kept for the clients
+		// resuming with Last-Event-ID.
+		Buffer int
+		// Notify fans the events out through PostgreSQL LISTEN/NOTIFY, so that
+		// the clients of every replica receive them.
+		Notify bool
+	}
+
+	GraphQLConfig struct {
+		// MaxDepth is how deeply the selections of a query may be nested.
+		MaxDepth int `envconfig:"MAX_DEPTH"`
+		// MaxComplexity is the most fields a query may select, a list field
+		// counts its selections once per requested row.
+		MaxComplexity int `envconfig:"MAX_COMPLEXITY"`
+	}
+
+	TasksConfig struct {
+		// ClosePolicy is what closing a task does to its open subtasks: block,
+		// cascade or allow.
+		ClosePolicy string `envconfig:"CLOSE_POLICY"`
+		// CrossProjectDependencies allows a task to be blocked by a task of
+		// another project.
+		CrossProjectDependencies bool `envconfig:"CROSS_PROJECT_DEPENDENCIES"`
+	}
+
+	BlobConfig struct {
+		// Driver is where attachment content is kept: local or s3.
+		Driver string
+		// Dir is the directory of the local driver.
+		Dir string
+		// S3 settings of the s3 driver, any S3-compatible service works.
+		S3Endpoint  string `envconfig:"S3_ENDPOINT"`
+		S3Region    string `envconfig:"S3_REGION"`
+		S3Bucket    string `envconfig:"S3_BUCKET"`
+		S3AccessKey string `envconfig:"S3_ACCESS_KEY"`
+		S3SecretKey string `envconfig:"S3_SECRET_KEY"`
+	}
+
+	AttachmentsConfig struct {
+		// MaxSize is the largest upload in bytes.
+		MaxSize int64 `envconfig:"MAX_SIZE"`
+		// Types are the allowed content types, detected from the content.
+		Types []string
+	}
 )
 
 func New() (cfg Configs, err error) {
@@ -42,10 +164,11 @@ func New() (cfg Configs, err error) {
 	godotenv.Load(filepath.Join(root, ".env"))
 
 	cfg.APP = AppConfig{
-		Mode:    defaultAppMode,
-		Port:    defaultAppPort,
-		Path:    defaultAppPath,
-		Timeout: defaultAppTimeout,
+		Mode:     defaultAppMode,
+		Port:     defaultAppPort,
+		GRPCPort: defaultGRPCPort,
+		Path:     defaultAppPath,
+		Timeout:  defaultAppTimeout,
 	}
 
 	if err = envconfig.Process("APP", &cfg.APP); err != nil {
@@ -56,5 +179,84 @@ func New() (cfg Configs, err error) {
 		return
 	}
 
+	cfg.AUTH = AuthConfig{
+		TTL:        defaultAuthTTL,
+		AdminEmail: defaultAuthAdminEmail,
+	}
+
+	if err = envconfig.Process("AUTH", &cfg.AUTH); err != nil {
+		return
+	}
+
+	cfg.PURGE = PurgeConfig{
+		Retention: defaultPurgeRetention,
+		Interval:  defaultPurgeInterval,
+	}
+
+	if err = envconfig.Process("PURGE", &cfg.PURGE); err != nil {
+		return
+	}
+
+	cfg.RECURRENCE = RecurrenceConfig{
+		Interval: defaultRecurrenceInterval,
+	}
+
+	if err = envconfig.Process("RECURRENCE", &cfg.RECURRENCE); err != nil {
+		return
+	}
+
+	cfg.WEBHOOK = WebhookConfig{
+		Interval:    defaultWebhookInterval,
+		Timeout:     defaultWebhookTimeout,
+		MaxAttempts: defaultWebhookMaxAttempts,
+	}
+
+	if err = envconfig.Process("WEBHOOK", &cfg.WEBHOOK); err != nil {
+		return
+	}
+
+	cfg.EVENTS = EventsConfig{
+		Buffer: defaultEventsBuffer,
+	}
+
+	if err = envconfig.Process("EVENTS", &cfg.EVENTS); err != nil {
+		return
+	}
+
+	cfg.GRAPHQL = GraphQLConfig{
+		MaxDepth:      defaultGraphQLMaxDepth,
+		MaxComplexity: defaultGraphQLMaxComplexity,
+	}
+
+	if err = envconfig.Process("GRAPHQL", &cfg.GRAPHQL); err != nil {
+		return
+	}
+
+	cfg.TASKS = TasksConfig{
+		ClosePolicy: defaultTasksClosePolicy,
+	}
+
+	if err = envconfig.Process("TASKS", &cfg.TASKS); err != nil {
+		return
+	}
+
+	cfg.BLOB = BlobConfig{
+		Driver: defaultBlobDriver,
+		Dir:    defaultBlobDir,
+	}
+
+	if err = envconfig.Process("BLOB", &cfg.BLOB); err != nil {
+		return
+	}
+
+	cfg.ATTACHMENTS = AttachmentsConfig{
+		MaxSize: defaultAttachmentsMaxSize,
+		Types:   strings.Split(defaultAttachmentsTypes, ","),
+	}
+
+	if err = envconfig.Process("ATTACHMENTS", &cfg.ATTACHMENTS); err != nil {
+		return
+	}
+
 	return
 }
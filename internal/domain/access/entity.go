@@ -0,0 +1,164 @@
+package access
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+var ErrorForbidden = errors.New("permission denied")
+
+type Action string
+
+const (
+	ActionCreateUser       Action = "user:create"
+	ActionUpdateUser       Action = "user:update"
+	ActionChangeRole       Action = "user:role"
+	ActionDeleteUser       Action = "user:delete"
+	ActionUpdateProject    Action = "project:update"
+	ActionDeleteProject    Action = "project:delete"
+	ActionUpdateWorkflow   Action = "project:workflow"
+	ActionManageMembers    Action = "project:members"
+	ActionManageLabels     Action = "project:labels"
+	ActionManageMilestones Action = "project:milestones"
+	ActionCreateTask       Action = "task:create"
+	ActionUpdateTask       Action = "task:update"
+	ActionChangeStatus     Action = "task:status"
+	ActionDeleteTask       Action = "task:delete"
+	ActionReadHistory      Action = "task:history"
+	ActionUpdateComment    Action = "comment:update"
+	ActionDeleteComment    Action = "comment:delete"
+	ActionDeleteAttachment Action = "attachment:delete"
+	ActionDeleteTimeEntry  Action = "time:delete"
+	ActionReadTime         Action = "time:read"
+	ActionReadAudit        Action = "audit:read"
+	ActionReadDeleted      Action = "deleted:read"
+	ActionManageWebhooks   Action = "webhook:manage"
+)
+
+// Relation is a relationship between the caller and the resource of an action.
+type Relation string
+
+const (
+	// RelationSelf holds when the resource is the caller's own user or was
+	// written, uploaded or logged by the caller.
+	RelationSelf Relation = "self"
+	// RelationManager holds when the caller is the manager_id of the project.
+	RelationManager Relation = "manager"
+	// RelationAssignee holds when the caller is the assignee of the task.
+	RelationAssignee Relation = "assignee"
+	// RelationOwner, RelationMaintainer and RelationMember hold when the caller
+	// is a member of the project with at least that role.
+	RelationOwner      Relation = "owner"
+	RelationMaintainer Relation = "maintainer"
+	RelationMember     Relation = "member"
+)
+
+var Relations = []Relation{
+	RelationSelf, RelationManager, RelationAssignee,
+	RelationOwner, RelationMaintainer, RelationMember,
+}
+
+const (
+	RoleAdmin = "admin"
+	// RoleAny matches every authenticated user.
+	RoleAny = "*"
+)
+
+// Rule grants an action to the listed roles and to callers in one of the
+// listed relations with the resource.
+type Rule struct {
+	Roles     []string   `json:"roles"`
+	Relations []Relation `json:"relations"`
+}
+
+// Policy is the permission matrix, actions missing from it are denied to
+// everyone.
+type Policy map[Action]Rule
+
+var DefaultPolicy = Policy{
+	ActionCreateUser:       {Roles: []string{RoleAdmin}},
+	ActionUpdateUser:       {Roles: []string{RoleAdmin}, Relations: []Relation{RelationSelf}},
+	ActionChangeRole:       {Roles: []string{RoleAdmin}},
+	ActionDeleteUser:       {Roles: []string{RoleAdmin}},
+	ActionUpdateProject:    {Roles: []string{RoleAdmin}, Relations: []Relation{RelationManager, RelationOwner}},
+	ActionDeleteProject:    {Roles: []string{RoleAdmin}, Relations: []Relation{RelationManager, RelationOwner}},
+	ActionUpdateWorkflow:   {Roles: []string{RoleAdmin}, Relations: []Relation{RelationManager, RelationMaintainer}},
+	ActionManageMembers:    {Roles: []string{RoleAdmin}, Relations: []Relation{RelationManager, RelationMaintainer}},
+	ActionManageLabels:     {Roles: []string{RoleAdmin}, Relations: []Relation{RelationManager, RelationMaintainer}},
+	ActionManageMilestones: {Roles: []string{RoleAdmin}, Relations: []Relation{RelationManager, RelationMaintainer}},
+	ActionCreateTask:       {Roles: []string{RoleAdmin}, Relations: []Relation{RelationManager, RelationMember}},
+	ActionUpdateTask:       {Roles: []string{RoleAdmin}, Relations: []Relation{RelationManager, RelationMember, RelationAssignee}},
+	ActionChangeStatus:     {Roles: []string{RoleAdmin}, Relations: []Relation{RelationManager, RelationMaintainer, RelationAssignee}},
+	ActionDeleteTask:       {Roles: []string{RoleAdmin}, Relations: []Relation{RelationManager, RelationMaintainer}},
+	ActionReadHistory:      {Roles: []string{RoleAdmin}, Relations: []Relation{RelationAssignee, RelationManager, RelationMember}},
+	ActionUpdateComment:    {Roles: []string{RoleAdmin}, Relations: []Relation{RelationSelf}},
+	ActionDeleteComment:    {Roles: []string{RoleAdmin}, Relations: []Relation{RelationSelf, RelationManager, RelationMaintainer}},
+	ActionDeleteAttachment: {Roles: []string{RoleAdmin}, Relations: []Relation{RelationSelf, RelationManager, RelationMaintainer}},
+	ActionDeleteTimeEntry:  {Roles: []string{RoleAdmin}, Relations: []Relation{RelationSelf, RelationManager, RelationMaintainer}},
+	ActionReadTime:         {Roles: []string{RoleAdmin}, Relations: []Relation{RelationSelf}},
+	ActionReadAudit:        {Roles: []string{RoleAdmin}},
+	ActionReadDeleted:      {Roles: []string{RoleAdmin}},
+	ActionManageWebhooks:   {Roles: []string{RoleAdmin}},
+}
+
+// Resource identifies what an action is performed on, only the fields known
+// for the resource are set.
+type Resource struct {
+	UserID     string
+	ProjectID  string
+	AssigneeID string
+}
+
+// LoadPolicy reads a JSON permission matrix such as
+// {"user:delete": {"roles": ["admin"]}}. Actions missing from the file keep
+// their default rule, unknown actions and relations are rejected.
+func LoadPolicy(path string) (policy Policy, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+
+	rules := Policy{}
+	if err = json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("access: %s: %w", path, err)
+	}
+
+	policy = Policy{}
+	for action, rule := range DefaultPolicy {
+		policy[action] = rule
+	}
+	for action, rule := range rules {
+		// a misspelled action would leave the real one on its default rule
+		if _, ok := DefaultPolicy[action]; !ok {
+			return nil, fmt.Errorf("access: %s: unknown action %q", path, action)
+		}
+		for _, relation := range rule.Relations {
+			if !isRelation(relation) {
+				return nil, fmt.Errorf("access: %s: unknown relation %q", action, relation)
+			}
+		}
+		policy[action] = rule
+	}
+
+	return
+}
+
+func (r Rule) HasRole(role string) bool {
+	for _, allowed := range r.Roles {
+		if allowed == RoleAny || allowed == role {
+			return true
+		}
+	}
+	return false
+}
+
+func isRelation(relation Relation) bool {
+	for _, r := range Relations {
+		if r == relation {
+			return true
+		}
+	}
+	return false
+}
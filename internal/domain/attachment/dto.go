@@ -0,0 +1,117 @@
+package attachment
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	ErrorTooLarge = errors.New("file: too large")
+	ErrorType     = errors.New("file: content type is not allowed")
+)
+
+// Limits restricts the uploads, zero MaxSize and empty Types allow anything.
+type Limits struct {
+	MaxSize int64
+	Types   []string
+}
+
+// Allows reports whether files of the content type may be uploaded, the
+// parameters such as charset are ignored.
+func (l Limits) Allows(contentType string) bool {
+	if len(l.Types) == 0 {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range l.Types {
+		if strings.EqualFold(strings.TrimSpace(allowed), mediaType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Request is an upload read from the multipart form.
+type Request struct {
+	FileName string
+	Size     int64
+}
+
+func (s *Request) Validate() error {
+	if name := CleanFileName(s.FileName); name == "" {
+		return errors.New("file: cannot be blank")
+	} else if len(name) > 255 {
+		return errors.New("file: name must be at most 255 characters")
+	}
+
+	if s.Size <= 0 {
+		return errors.New("file: cannot be empty")
+	}
+
+	return nil
+}
+
+// CleanFileName drops the directories some clients send along with the name.
+func CleanFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	if name == "." || name == "/" {
+		return ""
+	}
+	return strings.TrimSpace(name)
+}
+
+type Response struct {
+	ID          string `json:"id"`
+	TaskID      string `json:"task_id"`
+	UploaderID  string `json:"uploader_id"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	CreatedAt   string `json:"created_at,omitempty"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID: data.ID,
+	}
+	if data.TaskID != nil {
+		res.TaskID = *data.TaskID
+	}
+	if data.UploaderID != nil {
+		res.UploaderID = *data.UploaderID
+	}
+	if data.FileName != nil {
+		res.FileName = *data.FileName
+	}
+	if data.ContentType != nil {
+		res.ContentType = *data.ContentType
+	}
+	if data.Size != nil {
+		res.Size = *data.Size
+	}
+	if data.CreatedAt != nil {
+		res.CreatedAt = *data.CreatedAt
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
+
+// TooLarge describes the size limit in the error returned to the client.
+func TooLarge(maxSize int64) error {
+	return fmt.Errorf("%w, the limit is %d bytes", ErrorTooLarge, maxSize)
+}
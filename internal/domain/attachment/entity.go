@@ -0,0 +1,15 @@
+package attachment
+
+// Entity is the metadata of a file attached to a task, the content is kept in
+// the blob store under BlobKey. TaskID is nil once the task was hard deleted
+// and the blob is yet to be removed.
+type Entity struct {
+	ID          string  `db:"id"`
+	TaskID      *string `db:"task_id"`
+	UploaderID  *string `db:"uploader_id"`
+	FileName    *string `db:"file_name"`
+	ContentType *string `db:"content_type"`
+	Size        *int64  `db:"size"`
+	BlobKey     string  `db:"blob_key"`
+	CreatedAt   *string `db:"created_at"`
+}
@@ -0,0 +1,23 @@
+package attachment
+
+import (
+	"context"
+	"hard/pkg/store"
+)
+
+type Repository interface {
+	List(ctx context.Context, taskID string, page store.Page) (dest []Entity, cursor store.Cursor, err error)
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	Add(ctx context.Context, data Entity) (id string, err error)
+	Delete(ctx context.Context, id string) (err error)
+	// ListOrphans returns up to limit attachments whose task was hard deleted.
+	ListOrphans(ctx context.Context, limit int) (dest []Entity, err error)
+}
+
+/*
+GET /tasks/{id}/attachments: получить вложения задачи.
+POST /tasks/{id}/attachments: загрузить вложение.
+GET /tasks/{id}/attachments/{attachment_id}: получить описание вложения.
+GET /tasks/{id}/attachments/{attachment_id}/content: скачать вложение.
+DELETE /tasks/{id}/attachments/{attachment_id}: удалить вложение.
+*/
@@ -0,0 +1,68 @@
+package audit
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Filter narrows the audit log down to an entity type, a single entity or an actor.
+type Filter struct {
+	EntityType string
+	EntityID   string
+	ActorID    string
+}
+
+func (f *Filter) Validate() error {
+	if f.EntityType != "" && !isEntity(f.EntityType) {
+		return errors.New("entity: must be one of " + strings.Join(Entities, ", "))
+	}
+
+	if f.EntityID != "" && f.EntityType == "" {
+		return errors.New("entity: cannot be blank when id is set")
+	}
+
+	return nil
+}
+
+type Response struct {
+	ID        string    `json:"id"`
+	ActorID   string    `json:"actor_id"`
+	Entity    string    `json:"entity"`
+	EntityID  string    `json:"entity_id"`
+	Operation Operation `json:"operation"`
+	Diff      Diff      `json:"diff" swaggertype:"object"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:        data.ID,
+		Entity:    data.EntityType,
+		EntityID:  data.EntityID,
+		Operation: data.Operation,
+		Diff:      data.Diff,
+		CreatedAt: data.CreatedAt,
+	}
+	if data.ActorID != nil {
+		res.ActorID = *data.ActorID
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
+
+func isEntity(entity string) bool {
+	for _, e := range Entities {
+		if e == entity {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+package audit
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"time"
+)
+
+type Operation string
+
+const (
+	OperationCreate  Operation = "create"
+	OperationUpdate  Operation = "update"
+	OperationDelete  Operation = "delete"
+	OperationRestore Operation = "restore"
+)
+
+const (
+	EntityUser        = "user"
+	EntityTask        = "task"
+	EntityProject     = "project"
+	EntityWorkflow    = "workflow"
+	EntityMember      = "member"
+	EntityDependency  = "dependency"
+	EntityPredecessor = "predecessor"
+	EntityLabel       = "label"
+	EntityTaskLabel   = "task_label"
+	EntityComment     = "comment"
+	EntityAttachment  = "attachment"
+	EntityTimeEntry   = "time_entry"
+	EntityRecurrence  = "recurrence"
+	EntityMilestone   = "milestone"
+	EntityWebhook     = "webhook"
+)
+
+var Entities = []string{EntityUser, EntityTask, EntityProject, EntityWorkflow, EntityMember, EntityDependency, EntityPredecessor, EntityLabel, EntityTaskLabel, EntityComment, EntityAttachment, EntityTimeEntry, EntityRecurrence, EntityMilestone, EntityWebhook}
+
+type Entity struct {
+	ID         string    `db:"id"`
+	ActorID    *string   `db:"actor_id"`
+	EntityType string    `db:"entity"`
+	EntityID   string    `db:"entity_id"`
+	Operation  Operation `db:"operation"`
+	Diff       Diff      `db:"diff"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// Change holds the old and new value of a column, nil for NULL or absent.
+type Change struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// Diff maps the changed columns of an entity to their old and new values.
+type Diff map[string]Change
+
+// Compare returns the columns that differ between two snapshots of the same
+// entity type, either of which may be nil. Columns are named after the db
+// tags of the struct.
+func Compare(before, after any) Diff {
+	from, to := columns(before), columns(after)
+
+	diff := Diff{}
+	for name, value := range to {
+		if !reflect.DeepEqual(from[name], value) {
+			diff[name] = Change{Old: from[name], New: value}
+		}
+	}
+	for name, value := range from {
+		if _, ok := to[name]; !ok && value != nil {
+			diff[name] = Change{Old: value}
+		}
+	}
+
+	return diff
+}
+
+// Changes is Compare for a partial update, only the columns set to a non-zero
+// value in patch are taken into account.
+func Changes(before, patch any) Diff {
+	from, to := columns(before), columns(patch)
+
+	diff := Diff{}
+	for name, value := range to {
+		if value == nil || reflect.ValueOf(value).IsZero() {
+			continue
+		}
+		if !reflect.DeepEqual(from[name], value) {
+			diff[name] = Change{Old: from[name], New: value}
+		}
+	}
+
+	return diff
+}
+
+func columns(snapshot any) map[string]any {
+	res := map[string]any{}
+
+	v := reflect.ValueOf(snapshot)
+	if !v.IsValid() || v.Kind() == reflect.Pointer && v.IsNil() {
+		return res
+	}
+	v = reflect.Indirect(v)
+
+	for i := 0; i < v.NumField(); i++ {
+		name := v.Type().Field(i).Tag.Get("db")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		field := v.Field(i)
+		if field.Kind() == reflect.Pointer {
+			if field.IsNil() {
+				res[name] = nil
+				continue
+			}
+			field = field.Elem()
+		}
+		res[name] = field.Interface()
+	}
+
+	return res
+}
+
+func (d Diff) Value() (driver.Value, error) {
+	return json.Marshal(d)
+}
+
+func (d *Diff) Scan(value any) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(b, d)
+}
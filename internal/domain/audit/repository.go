@@ -0,0 +1,16 @@
+package audit
+
+import (
+	"context"
+	"hard/pkg/store"
+)
+
+type Repository interface {
+	Add(ctx context.Context, data Entity) (err error)
+	List(ctx context.Context, filter Filter, page store.Page) (dest []Entity, cursor store.Cursor, err error)
+}
+
+/*
+GET /audit?entity={entity}&id={id}: получить журнал изменений.
+GET /tasks/{id}/history: получить историю изменений задачи.
+*/
@@ -0,0 +1,99 @@
+package bulk
+
+import (
+	"encoding/json"
+)
+
+// Request carries the items of a bulk request, the mode is atomic unless
+// the caller picks partial.
+type Request[T any] struct {
+	Mode  Mode `json:"mode" enums:"atomic,partial"`
+	Items []T  `json:"items"`
+}
+
+func (s *Request[T]) Validate() error {
+	if s.Mode == "" {
+		s.Mode = ModeAtomic
+	}
+	if s.Mode != ModeAtomic && s.Mode != ModePartial {
+		return ErrorMode
+	}
+
+	if len(s.Items) == 0 {
+		return ErrorNoItems
+	}
+	if len(s.Items) > MaxItems {
+		return ErrorTooMany
+	}
+
+	return nil
+}
+
+// Key names the row of an item, Version makes its change conditional like
+// If-Match.
+type Key struct {
+	ID      string `json:"id"`
+	Version *int   `json:"version,omitempty"`
+}
+
+func (s *Key) Validate() error {
+	if s.ID == "" {
+		return ErrorNoID
+	}
+	return nil
+}
+
+// Update is an item of a bulk update, the fields of the row and its key are
+// read from the same JSON object.
+type Update[T any] struct {
+	Key
+	Data T `json:"-"`
+}
+
+func (s *Update[T]) UnmarshalJSON(data []byte) (err error) {
+	if err = json.Unmarshal(data, &s.Key); err != nil {
+		return
+	}
+	return json.Unmarshal(data, &s.Data)
+}
+
+// Result is the outcome of an item, Status is the HTTP status the item would
+// have been answered with on its own.
+type Result struct {
+	Index  int    `json:"index"`
+	ID     string `json:"id,omitempty"`
+	Status int    `json:"status"`
+	Error  string `json:"error,omitempty"`
+	Data   any    `json:"data,omitempty"`
+}
+
+type Response struct {
+	Mode      Mode     `json:"mode"`
+	Succeeded int      `json:"succeeded"`
+	Failed    int      `json:"failed"`
+	Results   []Result `json:"results"`
+}
+
+// ParseFromOutcomes reports the outcomes with the status of each.
+func ParseFromOutcomes(mode Mode, outcomes []Outcome, status func(err error) int) (res Response) {
+	res = Response{
+		Mode:    mode,
+		Results: make([]Result, 0, len(outcomes)),
+	}
+	for i, outcome := range outcomes {
+		result := Result{
+			Index:  i,
+			ID:     outcome.ID,
+			Status: status(outcome.Err),
+			Data:   outcome.Data,
+		}
+		if outcome.Err != nil {
+			result.Error = outcome.Err.Error()
+			res.Failed++
+		} else {
+			res.Succeeded++
+		}
+		res.Results = append(res.Results, result)
+	}
+	return
+}
@@ -0,0 +1,73 @@
+package bulk
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxItems bounds the items of a bulk request, which also keeps a multi-row
+// insert under the limit of query parameters.
+const MaxItems = 1000
+
+var (
+	ErrorNoItems  = errors.New("items: cannot be empty")
+	ErrorTooMany  = fmt.Errorf("items: cannot have more than %d items", MaxItems)
+	ErrorMode     = fmt.Errorf("mode: must be one of %s, %s", ModeAtomic, ModePartial)
+	ErrorNoID     = errors.New("id: cannot be blank")
+	ErrorAborted  = errors.New("not applied, another item failed")
+	ErrorNoChange = errors.New("at least one field must be provided for update")
+)
+
+type Mode string
+
+const (
+	// ModeAtomic applies every item in one transaction, or none of them when
+	// an item fails.
+	ModeAtomic Mode = "atomic"
+	// ModePartial applies the items that succeed and reports the others.
+	ModePartial Mode = "partial"
+)
+
+// Outcome is what became of an item, Err is nil when it was applied.
+type Outcome struct {
+	ID   string
+	Data any
+	Err  error
+}
+
+// Abort marks the items of a failed atomic request. The failed items keep
+// their errors and the others were not applied. When no item failed, the
+// request failed as a whole and every item gets err.
+func Abort(outcomes []Outcome, err error) {
+	failed := false
+	for _, outcome := range outcomes {
+		failed = failed || outcome.Err != nil
+	}
+
+	for i := range outcomes {
+		outcomes[i].Data = nil
+		switch {
+		case !failed:
+			outcomes[i].Err = err
+		case outcomes[i].Err == nil:
+			outcomes[i].Err = ErrorAborted
+		}
+	}
+}
+
+// FirstError is the error of the first failed item, an item that was not
+// applied only because another one failed comes last.
+func FirstError(outcomes []Outcome) (err error) {
+	for i, outcome := range outcomes {
+		switch {
+		case outcome.Err == nil:
+		case errors.Is(outcome.Err, ErrorAborted):
+			if err == nil {
+				err = fmt.Errorf("items[%d]: %w", i, outcome.Err)
+			}
+		default:
+			return fmt.Errorf("items[%d]: %w", i, outcome.Err)
+		}
+	}
+	return
+}
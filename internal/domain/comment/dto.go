@@ -0,0 +1,118 @@
+package comment
+
+import (
+	"errors"
+	"strings"
+)
+
+// MaxBodyLength is the longest comment body in characters.
+const MaxBodyLength = 10000
+
+var (
+	ErrorUnknownParent = errors.New("parent_comment_id: comment does not exist")
+	ErrorOtherTask     = errors.New("parent_comment_id: comment belongs to another task")
+)
+
+type Request struct {
+	Body            *string `json:"body"`
+	ParentCommentID *string `json:"parent_comment_id"`
+}
+
+func (s *Request) Validate() error {
+	if s.Body == nil || strings.TrimSpace(*s.Body) == "" {
+		return errors.New("body: cannot be blank")
+	}
+
+	if len([]rune(*s.Body)) > MaxBodyLength {
+		return errors.New("body: must be at most 10000 characters")
+	}
+
+	if s.ParentCommentID != nil && *s.ParentCommentID == "" {
+		return errors.New("parent_comment_id: cannot be blank")
+	}
+
+	return nil
+}
+
+func (s *Request) ValidateUpdate() error {
+	if s.ParentCommentID != nil {
+		return errors.New("parent_comment_id: reply cannot be moved to another comment")
+	}
+
+	return s.Validate()
+}
+
+type Response struct {
+	ID              string   `json:"id"`
+	TaskID          string   `json:"task_id"`
+	AuthorID        string   `json:"author_id"`
+	ParentCommentID string   `json:"parent_comment_id,omitempty"`
+	Body            string   `json:"body"`
+	Mentions        []string `json:"mentions,omitempty"`
+	CreatedAt       string   `json:"created_at,omitempty"`
+	EditedAt        string   `json:"edited_at,omitempty"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:     data.ID,
+		TaskID: data.TaskID,
+	}
+	if data.AuthorID != nil {
+		res.AuthorID = *data.AuthorID
+	}
+	if data.ParentCommentID != nil {
+		res.ParentCommentID = *data.ParentCommentID
+	}
+	if data.Body != nil {
+		res.Body = *data.Body
+	}
+	if data.CreatedAt != nil {
+		res.CreatedAt = *data.CreatedAt
+	}
+	if data.EditedAt != nil {
+		res.EditedAt = *data.EditedAt
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
+
+type RevisionResponse struct {
+	ID        string `json:"id"`
+	CommentID string `json:"comment_id"`
+	EditorID  string `json:"editor_id"`
+	Body      string `json:"body"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+func ParseFromRevision(data Revision) (res RevisionResponse) {
+	res = RevisionResponse{
+		ID:        data.ID,
+		CommentID: data.CommentID,
+	}
+	if data.EditorID != nil {
+		res.EditorID = *data.EditorID
+	}
+	if data.Body != nil {
+		res.Body = *data.Body
+	}
+	if data.CreatedAt != nil {
+		res.CreatedAt = *data.CreatedAt
+	}
+	return
+}
+
+func ParseFromRevisions(data []Revision) (res []RevisionResponse) {
+	res = make([]RevisionResponse, 0)
+	for _, object := range data {
+		res = append(res, ParseFromRevision(object))
+	}
+	return
+}
@@ -0,0 +1,20 @@
+package comment
+
+type Entity struct {
+	ID              string  `db:"id"`
+	TaskID          string  `db:"task_id"`
+	AuthorID        *string `db:"author_id"`
+	ParentCommentID *string `db:"parent_comment_id"`
+	Body            *string `db:"body"`
+	CreatedAt       *string `db:"created_at"`
+	EditedAt        *string `db:"edited_at"`
+}
+
+// Revision is a previous body of an edited comment.
+type Revision struct {
+	ID        string  `db:"id"`
+	CommentID string  `db:"comment_id"`
+	EditorID  *string `db:"editor_id"`
+	Body      *string `db:"body"`
+	CreatedAt *string `db:"created_at"`
+}
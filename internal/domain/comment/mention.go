@@ -0,0 +1,31 @@
+package comment
+
+import (
+	"regexp"
+)
+
+var (
+	// mentionPattern matches @email that does not continue a word, so that
+	// the address itself is not taken for a mention.
+	mentionPattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9._%+@-])@([A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)+)`)
+	// codePattern matches fenced code blocks and inline code spans, mentions
+	// inside them are not resolved.
+	codePattern = regexp.MustCompile("(?s)```.*?```|`[^`\n]*`")
+)
+
+// Mentions returns the distinct emails mentioned in a Markdown body as
+// @email, in order of appearance.
+func Mentions(body string) (emails []string) {
+	body = codePattern.ReplaceAllString(body, " ")
+
+	seen := map[string]bool{}
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		email := match[1]
+		if !seen[email] {
+			seen[email] = true
+			emails = append(emails, email)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,29 @@
+package comment
+
+import (
+	"context"
+	"hard/pkg/store"
+)
+
+type Repository interface {
+	List(ctx context.Context, taskID string, page store.Page) (dest []Entity, cursor store.Cursor, err error)
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	Add(ctx context.Context, data Entity) (id string, err error)
+	Update(ctx context.Context, id string, data Entity) (err error)
+	Delete(ctx context.Context, id string) (err error)
+	ListRevisions(ctx context.Context, id string, page store.Page) (dest []Revision, cursor store.Cursor, err error)
+	AddRevision(ctx context.Context, data Revision) (err error)
+	ListMentions(ctx context.Context, ids []string) (dest map[string][]string, err error)
+	SetMentions(ctx context.Context, id string, userIDs []string) (err error)
+	ListMentioning(ctx context.Context, userID string, page store.Page) (dest []Entity, cursor store.Cursor, err error)
+}
+
+/*
+GET /tasks/{id}/comments: получить комментарии задачи.
+POST /tasks/{id}/comments: добавить комментарий или ответ на комментарий.
+GET /tasks/{id}/comments/{comment_id}: получить комментарий.
+PUT /tasks/{id}/comments/{comment_id}: изменить текст комментария.
+DELETE /tasks/{id}/comments/{comment_id}: удалить комментарий вместе с ответами.
+GET /tasks/{id}/comments/{comment_id}/history: получить предыдущие версии комментария.
+GET /users/{id}/mentions: получить комментарии, в которых упомянут пользователь.
+*/
@@ -0,0 +1,43 @@
+package dependency
+
+import (
+	"errors"
+)
+
+var (
+	ErrorSelf           = errors.New("blocker_id: task cannot block itself")
+	ErrorUnknownBlocker = errors.New("blocker_id: blocking task does not exist")
+	ErrorCrossProject   = errors.New("blocker_id: blocking task belongs to another project")
+	ErrorCycle          = errors.New("dependency cycle")
+	ErrorExists         = errors.New("task already depends on the blocking task")
+	ErrorBlocked        = errors.New("task is blocked by open tasks")
+)
+
+type Request struct {
+	BlockerID *string `json:"blocker_id"`
+}
+
+func (s *Request) Validate() error {
+	if s.BlockerID == nil || *s.BlockerID == "" {
+		return errors.New("blocker_id: cannot be blank")
+	}
+
+	return nil
+}
+
+type Response struct {
+	TaskID    string `json:"task_id"`
+	BlockerID string `json:"blocker_id"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		TaskID:    data.TaskID,
+		BlockerID: data.BlockerID,
+	}
+	if data.CreatedAt != nil {
+		res.CreatedAt = *data.CreatedAt
+	}
+	return
+}
@@ -0,0 +1,9 @@
+package dependency
+
+// Entity is a blocking relationship: the task cannot be closed while the
+// blocker is open.
+type Entity struct {
+	TaskID    string  `db:"task_id"`
+	BlockerID string  `db:"blocker_id"`
+	CreatedAt *string `db:"created_at"`
+}
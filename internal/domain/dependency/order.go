@@ -0,0 +1,58 @@
+package dependency
+
+import (
+	"hard/internal/domain/task"
+	"slices"
+)
+
+// Sort orders the tasks so that every task comes after its blockers. Tasks
+// that do not depend on each other keep their relative order, links to tasks
+// outside the list are ignored.
+func Sort(tasks []task.Entity, links []Entity) (res []task.Entity, err error) {
+	index := make(map[string]int, len(tasks))
+	for i, data := range tasks {
+		index[data.ID] = i
+	}
+
+	blockers := make([]int, len(tasks))
+	dependents := make([][]int, len(tasks))
+	for _, link := range links {
+		from, ok := index[link.BlockerID]
+		if !ok {
+			continue
+		}
+		to, ok := index[link.TaskID]
+		if !ok {
+			continue
+		}
+		dependents[from] = append(dependents[from], to)
+		blockers[to]++
+	}
+
+	ready := make([]int, 0)
+	for i := range tasks {
+		if blockers[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	res = make([]task.Entity, 0, len(tasks))
+	for len(ready) > 0 {
+		i := ready[0]
+		ready = ready[1:]
+		res = append(res, tasks[i])
+
+		for _, j := range dependents[i] {
+			if blockers[j]--; blockers[j] == 0 {
+				k, _ := slices.BinarySearch(ready, j)
+				ready = slices.Insert(ready, k, j)
+			}
+		}
+	}
+
+	if len(res) < len(tasks) {
+		return nil, ErrorCycle
+	}
+
+	return
+}
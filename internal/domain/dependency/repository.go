@@ -0,0 +1,26 @@
+package dependency
+
+import (
+	"context"
+	"hard/internal/domain/task"
+	"hard/pkg/store"
+)
+
+type Repository interface {
+	ListBlockers(ctx context.Context, taskID string, page store.Page) (dest []task.Entity, cursor store.Cursor, err error)
+	ListDependents(ctx context.Context, taskID string, page store.Page) (dest []task.Entity, cursor store.Cursor, err error)
+	ListByProject(ctx context.Context, projectID string) (dest []Entity, err error)
+	Get(ctx context.Context, taskID, blockerID string) (dest Entity, err error)
+	Add(ctx context.Context, data Entity) (err error)
+	Delete(ctx context.Context, taskID, blockerID string) (err error)
+	Blocks(ctx context.Context, blockerID, taskID string) (ok bool, err error)
+	OpenBlockers(ctx context.Context, taskIDs []string) (dest []string, err error)
+}
+
+/*
+GET /tasks/{id}/dependencies: получить задачи, блокирующие задачу.
+POST /tasks/{id}/dependencies: добавить блокирующую задачу.
+DELETE /tasks/{id}/dependencies/{blocker_id}: удалить блокирующую задачу.
+GET /tasks/{id}/dependents: получить задачи, заблокированные задачей.
+GET /projects/{id}/tasks/order: получить задачи проекта в топологическом порядке.
+*/
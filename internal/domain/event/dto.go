@@ -0,0 +1,71 @@
+package event
+
+import (
+	"errors"
+	"hard/internal/domain/audit"
+	"hard/pkg/pubsub"
+	"strconv"
+)
+
+var ErrorLastEventID = errors.New("Last-Event-ID: must be the id of an event")
+
+// TypeReset is sent instead of the missed events when they can no longer be
+// replayed, the client should reload what it shows.
+const TypeReset = "reset"
+
+// Filter narrows the stream down to a project or to the tasks of an assignee.
+type Filter struct {
+	ProjectID  string
+	AssigneeID string
+}
+
+// Match reports whether the event concerns the project or the assignee of the
+// filter, before or after the change. Project events never match an assignee.
+func (f Filter) Match(data Event) bool {
+	if f.ProjectID != "" && data.ProjectID != f.ProjectID && data.PreviousProjectID != f.ProjectID {
+		return false
+	}
+
+	if f.AssigneeID != "" {
+		if data.Entity != audit.EntityTask {
+			return false
+		}
+		if data.AssigneeID != f.AssigneeID && data.PreviousAssigneeID != f.AssigneeID {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ParseLastEventID reads the ID of the last event a reconnecting client got,
+// zero when it is empty.
+func ParseLastEventID(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, ErrorLastEventID
+	}
+
+	return id, nil
+}
+
+type Response struct {
+	ID string `json:"id"`
+	Event
+}
+
+func ParseFromMessage(data pubsub.Message[Event]) Response {
+	return Response{
+		ID:    strconv.FormatInt(data.ID, 10),
+		Event: data.Data,
+	}
+}
+
+// Reset is the response telling the client that it missed events.
+func Reset() Response {
+	return Response{Event: Event{Type: TypeReset, Changes: audit.Diff{}}}
+}
@@ -0,0 +1,21 @@
+package event
+
+import (
+	"hard/internal/domain/audit"
+)
+
+// Event is a change of a task or a project streamed to the clients.
+type Event struct {
+	Type       string     `json:"type"`
+	Entity     string     `json:"entity"`
+	EntityID   string     `json:"entity_id"`
+	ProjectID  string     `json:"project_id,omitempty"`
+	AssigneeID string     `json:"assignee_id,omitempty"`
+	ActorID    string     `json:"actor_id,omitempty"`
+	Changes    audit.Diff `json:"changes" swaggertype:"object"`
+	CreatedAt  string     `json:"created_at"`
+	// PreviousProjectID and PreviousAssigneeID are set when the change moves or
+	// reassigns a task, so that the subscribers of the previous ones see it go.
+	PreviousProjectID  string `json:"previous_project_id,omitempty"`
+	PreviousAssigneeID string `json:"previous_assignee_id,omitempty"`
+}
@@ -0,0 +1,22 @@
+package event
+
+import (
+	"context"
+)
+
+// Repository fans the events out to every replica of the service.
+type Repository interface {
+	// NextID takes the ID of an event from the sequence the replicas share.
+	NextID(ctx context.Context) (id int64, err error)
+	// Notify sends the event with its ID to the replicas listening, including
+	// this one.
+	Notify(ctx context.Context, id int64, data Event) (err error)
+	// Listen calls fn with every notified event until ctx is done, lost is
+	// called when events may have been missed while reconnecting.
+	Listen(ctx context.Context, fn func(id int64, data Event), lost func()) (err error)
+}
+
+/*
+GET /events?project_id={id}&assignee_id={userId}: получить поток изменений задач и проектов (Server-Sent Events).
+GET /ws?project_id={id}&assignee_id={userId}: получить поток изменений задач и проектов (WebSocket).
+*/
@@ -0,0 +1,96 @@
+package label
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrorExists       = errors.New("name: label already exists in the project")
+	ErrorUnknownLabel = errors.New("label_id: label does not exist")
+	ErrorOtherProject = errors.New("label_id: label belongs to another project")
+	ErrorAttached     = errors.New("label is already attached to the task")
+)
+
+var colorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+type Request struct {
+	Name  *string `json:"name"`
+	Color *string `json:"color"`
+}
+
+func (s *Request) Validate() error {
+	if s.Name == nil {
+		return errors.New("name: cannot be blank")
+	}
+
+	if s.Color == nil {
+		return errors.New("color: cannot be blank")
+	}
+
+	return s.ValidateUpdate()
+}
+
+func (s *Request) ValidateUpdate() error {
+	if s.Name != nil {
+		if name := strings.TrimSpace(*s.Name); name == "" {
+			return errors.New("name: cannot be blank")
+		} else if len(name) > 50 {
+			return errors.New("name: must be at most 50 characters")
+		} else if strings.Contains(name, ",") {
+			return errors.New("name: cannot contain commas")
+		}
+	}
+
+	if s.Color != nil && !colorPattern.MatchString(*s.Color) {
+		return errors.New("color: must be a hex color such as #ff0000")
+	}
+
+	return nil
+}
+
+func (s *Request) IsEmpty() bool {
+	return s.Name == nil && s.Color == nil
+}
+
+type Response struct {
+	ID        string `json:"id"`
+	ProjectID string `json:"project_id"`
+	Name      string `json:"name"`
+	Color     string `json:"color"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:        data.ID,
+		ProjectID: data.ProjectID,
+	}
+	if data.Name != nil {
+		res.Name = *data.Name
+	}
+	if data.Color != nil {
+		res.Color = *data.Color
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
+
+type AttachRequest struct {
+	LabelID *string `json:"label_id"`
+}
+
+func (s *AttachRequest) Validate() error {
+	if s.LabelID == nil || *s.LabelID == "" {
+		return errors.New("label_id: cannot be blank")
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package label
+
+type Entity struct {
+	ID        string  `db:"id"`
+	ProjectID string  `db:"project_id"`
+	Name      *string `db:"name"`
+	Color     *string `db:"color"`
+}
+
+// Assignment is a label attached to a task.
+type Assignment struct {
+	TaskID    string  `db:"task_id"`
+	LabelID   string  `db:"label_id"`
+	CreatedAt *string `db:"created_at"`
+}
@@ -0,0 +1,31 @@
+package label
+
+import (
+	"context"
+	"hard/pkg/store"
+)
+
+type Repository interface {
+	List(ctx context.Context, projectID string, page store.Page) (dest []Entity, cursor store.Cursor, err error)
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	GetByName(ctx context.Context, projectID, name string) (dest Entity, err error)
+	Add(ctx context.Context, data Entity) (id string, err error)
+	Update(ctx context.Context, id string, data Entity) (err error)
+	Delete(ctx context.Context, id string) (err error)
+	ListByTasks(ctx context.Context, taskIDs []string) (dest map[string][]Entity, err error)
+	GetAssignment(ctx context.Context, taskID, labelID string) (dest Assignment, err error)
+	Attach(ctx context.Context, data Assignment) (err error)
+	Detach(ctx context.Context, taskID, labelID string) (err error)
+	DetachOtherProjects(ctx context.Context, taskID string) (err error)
+}
+
+/*
+GET /projects/{id}/labels: получить метки проекта.
+POST /projects/{id}/labels: создать метку.
+GET /projects/{id}/labels/{label_id}: получить метку.
+PUT /projects/{id}/labels/{label_id}: изменить метку.
+DELETE /projects/{id}/labels/{label_id}: удалить метку.
+GET /tasks/{id}/labels: получить метки задачи.
+POST /tasks/{id}/labels: добавить метку задаче.
+DELETE /tasks/{id}/labels/{label_id}: снять метку с задачи.
+*/
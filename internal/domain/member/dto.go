@@ -0,0 +1,68 @@
+package member
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrorAlreadyMember = errors.New("user is already a member of the project")
+	ErrorUnknownUser   = errors.New("user does not exist")
+	ErrorNotMember     = errors.New("assignee is not a member of the project")
+)
+
+type Request struct {
+	UserID *string `json:"user_id"`
+	Role   *string `json:"role"`
+}
+
+func (s *Request) Validate() error {
+	if s.UserID == nil {
+		return errors.New("user_id: cannot be blank")
+	}
+
+	return s.ValidateRole()
+}
+
+func (s *Request) ValidateRole() error {
+	if s.Role == nil {
+		return errors.New("role: cannot be blank")
+	}
+
+	if Rank(*s.Role) == 0 {
+		return errors.New("role: must be one of " + strings.Join(Roles, ", "))
+	}
+
+	return nil
+}
+
+type Response struct {
+	ProjectID string `json:"project_id"`
+	UserID    string `json:"user_id"`
+	Role      string `json:"role"`
+	FullName  string `json:"full_name"`
+	Email     string `json:"email"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ProjectID: data.ProjectID,
+		UserID:    data.UserID,
+		Role:      *data.Role,
+	}
+	if data.FullName != nil {
+		res.FullName = *data.FullName
+	}
+	if data.Email != nil {
+		res.Email = *data.Email
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
@@ -0,0 +1,34 @@
+package member
+
+const (
+	RoleOwner      = "owner"
+	RoleMaintainer = "maintainer"
+	RoleMember     = "member"
+	RoleViewer     = "viewer"
+)
+
+// Roles lists the project roles from the most to the least privileged.
+var Roles = []string{RoleOwner, RoleMaintainer, RoleMember, RoleViewer}
+
+type Entity struct {
+	ProjectID string  `db:"project_id"`
+	UserID    string  `db:"user_id"`
+	Role      *string `db:"role"`
+	FullName  *string `db:"full_name"`
+	Email     *string `db:"email"`
+}
+
+// AtLeast reports whether the member's role grants the privileges of role.
+func (e Entity) AtLeast(role string) bool {
+	return e.Role != nil && Rank(*e.Role) > 0 && Rank(*e.Role) <= Rank(role)
+}
+
+// Rank returns the 1-based position of the role in Roles, 0 if unknown.
+func Rank(role string) int {
+	for i, r := range Roles {
+		if r == role {
+			return i + 1
+		}
+	}
+	return 0
+}
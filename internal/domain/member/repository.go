@@ -0,0 +1,22 @@
+package member
+
+import (
+	"context"
+	"hard/pkg/store"
+)
+
+type Repository interface {
+	List(ctx context.Context, projectID string, page store.Page) (dest []Entity, cursor store.Cursor, err error)
+	Get(ctx context.Context, projectID, userID string) (dest Entity, err error)
+	Add(ctx context.Context, data Entity) (err error)
+	Update(ctx context.Context, data Entity) (err error)
+	Delete(ctx context.Context, projectID, userID string) (err error)
+}
+
+/*
+GET /projects/{id}/members: получить участников проекта.
+POST /projects/{id}/members: добавить участника в проект.
+PUT /projects/{id}/members/{user_id}: изменить роль участника.
+DELETE /projects/{id}/members/{user_id}: удалить участника из проекта.
+GET /users/{id}/projects: получить проекты пользователя.
+*/
@@ -0,0 +1,167 @@
+package milestone
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+var (
+	ErrorExists           = errors.New("name: milestone already exists in the project")
+	ErrorEndDate          = errors.New("end_date: cannot be before start_date")
+	ErrorClosed           = errors.New("milestone is already closed")
+	ErrorUnknownMilestone = errors.New("milestone_id: milestone does not exist")
+	ErrorOtherProject     = errors.New("milestone_id: milestone belongs to another project")
+	ErrorClosedMilestone  = errors.New("milestone_id: milestone is closed")
+	ErrorNextMilestone    = errors.New("next_milestone_id: must be another open milestone of the project")
+)
+
+const dateLayout = "2006-01-02"
+
+type Request struct {
+	Name      *string `json:"name"`
+	Goal      *string `json:"goal"`
+	StartDate *string `json:"start_date"`
+	EndDate   *string `json:"end_date"`
+}
+
+func (s *Request) Validate() error {
+	if s.Name == nil {
+		return errors.New("name: cannot be blank")
+	}
+
+	return s.ValidateUpdate()
+}
+
+// ValidateUpdate checks only the fields present in a partial update, an empty
+// date clears it.
+func (s *Request) ValidateUpdate() error {
+	if s.Name != nil {
+		if name := strings.TrimSpace(*s.Name); name == "" {
+			return errors.New("name: cannot be blank")
+		} else if len(name) > 100 {
+			return errors.New("name: must be at most 100 characters")
+		}
+	}
+
+	var start, end time.Time
+	var err error
+
+	if s.StartDate != nil && *s.StartDate != "" {
+		if start, err = time.Parse(dateLayout, *s.StartDate); err != nil {
+			return errors.New("start_date: invalid format")
+		}
+	}
+
+	if s.EndDate != nil && *s.EndDate != "" {
+		if end, err = time.Parse(dateLayout, *s.EndDate); err != nil {
+			return errors.New("end_date: invalid format")
+		}
+	}
+
+	if !start.IsZero() && !end.IsZero() && end.Before(start) {
+		return ErrorEndDate
+	}
+
+	return nil
+}
+
+func (s *Request) IsEmpty() bool {
+	return s.Name == nil && s.Goal == nil && s.StartDate == nil && s.EndDate == nil
+}
+
+// CloseRequest names the milestone that receives the unfinished tasks, the
+// next open milestone of the project is used when it is empty.
+type CloseRequest struct {
+	NextMilestoneID *string `json:"next_milestone_id"`
+}
+
+type StatsResponse struct {
+	TotalTasks      int     `json:"total_tasks"`
+	CompletedTasks  int     `json:"completed_tasks"`
+	OpenTasks       int     `json:"open_tasks"`
+	PercentComplete float64 `json:"percent_complete"`
+	EstimateHours   float64 `json:"estimate_hours"`
+	CompletedHours  float64 `json:"completed_hours"`
+}
+
+type Response struct {
+	ID        string        `json:"id"`
+	ProjectID string        `json:"project_id"`
+	Name      string        `json:"name"`
+	Goal      string        `json:"goal,omitempty"`
+	StartDate string        `json:"start_date,omitempty"`
+	EndDate   string        `json:"end_date,omitempty"`
+	ClosedAt  string        `json:"closed_at,omitempty"`
+	Closed    bool          `json:"closed"`
+	Stats     StatsResponse `json:"stats"`
+}
+
+// CloseResponse is the closed milestone with the tasks moved out of it, the
+// next milestone is empty when the tasks were left without one.
+type CloseResponse struct {
+	Milestone       Response `json:"milestone"`
+	NextMilestoneID string   `json:"next_milestone_id,omitempty"`
+	MovedTaskIDs    []string `json:"moved_task_ids"`
+}
+
+func ParseFromEntity(data Entity, stats Stats) (res Response) {
+	res = Response{
+		ID:        data.ID,
+		ProjectID: data.ProjectID,
+		Closed:    data.ClosedAt != nil,
+		Stats:     ParseStats(stats),
+	}
+	if data.Name != nil {
+		res.Name = *data.Name
+	}
+	if data.Goal != nil {
+		res.Goal = *data.Goal
+	}
+	if data.StartDate != nil {
+		res.StartDate = dateOf(*data.StartDate)
+	}
+	if data.EndDate != nil {
+		res.EndDate = dateOf(*data.EndDate)
+	}
+	if data.ClosedAt != nil {
+		res.ClosedAt = dateOf(*data.ClosedAt)
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity, stats map[string]Stats) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object, stats[object.ID]))
+	}
+	return
+}
+
+// ParseStats rounds the percentage and hours to hundredths.
+func ParseStats(data Stats) (res StatsResponse) {
+	res = StatsResponse{
+		TotalTasks:     data.Total,
+		CompletedTasks: data.Completed,
+		OpenTasks:      data.Total - data.Completed,
+		EstimateHours:  round(data.EstimateHours),
+		CompletedHours: round(data.CompletedHours),
+	}
+	if data.Total > 0 {
+		res.PercentComplete = round(float64(data.Completed) * 100 / float64(data.Total))
+	}
+	return
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// dateOf drops the time the driver may add to a date column.
+func dateOf(value string) string {
+	if len(value) > len(dateLayout) {
+		return value[:len(dateLayout)]
+	}
+	return value
+}
@@ -0,0 +1,21 @@
+package milestone
+
+// Entity is a milestone or a sprint of a project, a closed one has ClosedAt.
+type Entity struct {
+	ID        string  `db:"id"`
+	ProjectID string  `db:"project_id"`
+	Name      *string `db:"name"`
+	Goal      *string `db:"goal"`
+	StartDate *string `db:"start_date"`
+	EndDate   *string `db:"end_date"`
+	ClosedAt  *string `db:"closed_at"`
+}
+
+// Stats counts the tasks of a milestone, soft deleted tasks are left out.
+type Stats struct {
+	MilestoneID    string  `db:"milestone_id"`
+	Total          int     `db:"total"`
+	Completed      int     `db:"completed"`
+	EstimateHours  float64 `db:"estimate_hours"`
+	CompletedHours float64 `db:"completed_hours"`
+}
@@ -0,0 +1,34 @@
+package milestone
+
+import (
+	"context"
+	"hard/pkg/store"
+)
+
+type Repository interface {
+	List(ctx context.Context, projectID string, page store.Page) (dest []Entity, cursor store.Cursor, err error)
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	GetByName(ctx context.Context, projectID, name string) (dest Entity, err error)
+	Add(ctx context.Context, data Entity) (id string, err error)
+	Update(ctx context.Context, id string, data Entity) (err error)
+	Delete(ctx context.Context, id string) (err error)
+	// Close sets closed_at to today, it fails with store.ErrorNotFound when
+	// the milestone is already closed.
+	Close(ctx context.Context, id string) (closedAt string, err error)
+	// Next returns the open milestone of the project that follows the given
+	// one: the earliest to start after it, milestones without dates last.
+	Next(ctx context.Context, data Entity) (dest Entity, err error)
+	// MoveOpenTasks moves the unfinished tasks of a milestone to another one,
+	// or out of any milestone when to is empty, and returns their IDs.
+	MoveOpenTasks(ctx context.Context, from, to string) (ids []string, err error)
+	Stats(ctx context.Context, ids []string) (dest map[string]Stats, err error)
+}
+
+/*
+GET /projects/{id}/milestones: получить вехи проекта.
+POST /projects/{id}/milestones: создать веху.
+GET /projects/{id}/milestones/{milestone_id}: получить веху.
+PUT /projects/{id}/milestones/{milestone_id}: изменить веху.
+DELETE /projects/{id}/milestones/{milestone_id}: удалить веху.
+POST /projects/{id}/milestones/{milestone_id}/close: закрыть веху и перенести незавершенные задачи.
+*/
@@ -63,6 +63,8 @@ type Response struct {
 	StartDate   string `json:"start_date"`
 	EndDate     string `json:"end_date"`
 	ManagerID   string `json:"manager_id"`
+	DeletedAt   string `json:"deleted_at,omitempty"`
+	Version     int    `json:"version,omitempty"`
 }
 
 func ParseFromEntity(data Entity) (res Response) {
@@ -78,6 +80,12 @@ func ParseFromEntity(data Entity) (res Response) {
 	if data.EndDate != nil {
 		res.EndDate = *data.EndDate
 	}
+	if data.DeletedAt != nil {
+		res.DeletedAt = *data.DeletedAt
+	}
+	if data.Version != nil {
+		res.Version = *data.Version
+	}
 	return
 }
 
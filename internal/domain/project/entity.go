@@ -7,4 +7,6 @@ type Entity struct {
 	StartDate   *string `db:"start_date"`
 	EndDate     *string `db:"end_date"`
 	ManagerID   *string `db:"manager_id"`
+	DeletedAt   *string `db:"deleted_at"`
+	Version     *int    `db:"version"`
 }
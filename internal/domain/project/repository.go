@@ -3,14 +3,20 @@ package project
 import (
 	"context"
 	"hard/internal/domain/task"
+	"hard/pkg/store"
+	"time"
 )
 
 type Repository interface {
-	List(ctx context.Context) (dest []Entity, err error)
+	List(ctx context.Context, page store.Page) (dest []Entity, cursor store.Cursor, err error)
 	Add(ctx context.Context, data Entity) (id string, err error)
+	AddMany(ctx context.Context, data []Entity) (ids []string, err error)
 	Get(ctx context.Context, id string) (dest Entity, err error)
+	GetMany(ctx context.Context, ids []string) (dest []Entity, err error)
 	Update(ctx context.Context, id string, dest Entity) (err error)
-	Delete(ctx context.Context, id string) (err error)
-	Search(ctx context.Context, data Entity) (dest []Entity, err error)
-	ListTasks(ctx context.Context, id string) (data []task.Entity, err error)
+	Delete(ctx context.Context, id string, version *int) (err error)
+	Restore(ctx context.Context, id string) (err error)
+	Purge(ctx context.Context, retention time.Duration) (n int64, err error)
+	Search(ctx context.Context, data Entity, page store.Page) (dest []Entity, cursor store.Cursor, err error)
+	ListTasks(ctx context.Context, id string, page store.Page) (data []task.Entity, cursor store.Cursor, err error)
 }
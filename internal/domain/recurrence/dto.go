@@ -0,0 +1,61 @@
+package recurrence
+
+import (
+	"errors"
+)
+
+type Request struct {
+	Rule    *string `json:"rule"`
+	Trigger *string `json:"trigger"`
+}
+
+func (s *Request) Validate() error {
+	if s.Rule == nil {
+		return errors.New("rule: cannot be blank")
+	}
+
+	if _, err := ParseRule(*s.Rule); err != nil {
+		return err
+	}
+
+	if s.Trigger != nil && *s.Trigger != TriggerCompletion && *s.Trigger != TriggerSchedule {
+		return errors.New("trigger: must be one of " + TriggerCompletion + ", " + TriggerSchedule)
+	}
+
+	return nil
+}
+
+type Response struct {
+	ID        string `json:"id"`
+	TaskID    string `json:"task_id"`
+	Rule      string `json:"rule"`
+	Trigger   string `json:"trigger"`
+	StartDate string `json:"start_date"`
+	LastDate  string `json:"last_date"`
+	NextDate  string `json:"next_date,omitempty"`
+	Generated int    `json:"generated"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:        data.ID,
+		TaskID:    data.TaskID,
+		Rule:      data.Rule,
+		Trigger:   data.Trigger,
+		StartDate: dateOf(data.StartDate),
+		LastDate:  dateOf(data.LastDate),
+		Generated: data.Generated,
+	}
+	if data.NextDate != nil {
+		res.NextDate = dateOf(*data.NextDate)
+	}
+	return
+}
+
+// dateOf drops the time the driver may add to a date column.
+func dateOf(value string) string {
+	if len(value) > len(dateLayout) {
+		return value[:len(dateLayout)]
+	}
+	return value
+}
@@ -0,0 +1,72 @@
+package recurrence
+
+import "time"
+
+// Triggers of a series: the next occurrence is created once the latest one is
+// completed, or on its date whatever the state of the latest one.
+const (
+	TriggerCompletion = "completion"
+	TriggerSchedule   = "schedule"
+)
+
+// Entity is a series of recurring tasks. TaskID is its latest occurrence, which
+// the next one is copied from, LastDate is the date of that occurrence and
+// NextDate the date the rule gives after it, nil once the series is over.
+type Entity struct {
+	ID        string  `db:"id"`
+	TaskID    string  `db:"task_id"`
+	Rule      string  `db:"rule"`
+	Trigger   string  `db:"trigger"`
+	StartDate string  `db:"start_date"`
+	LastDate  string  `db:"last_date"`
+	NextDate  *string `db:"next_date"`
+	Generated int     `db:"generated"`
+}
+
+// Occurrence is a task created for a date of a series.
+type Occurrence struct {
+	RecurrenceID string `db:"recurrence_id"`
+	Date         string `db:"occurrence"`
+	TaskID       string `db:"task_id"`
+}
+
+// Due picks the date of the occurrence to create today, false when none is
+// due. A scheduled series creates the latest occurrence that has arrived, a
+// series triggered by completion the first one that is not in the past, so
+// that occurrences missed while the latest one stayed open or the scheduler
+// was down are skipped rather than created in a burst.
+func Due(rule Rule, data Entity, completed bool, today time.Time) (date time.Time, ok bool) {
+	start, err := ParseDate(data.StartDate)
+	if err != nil {
+		return
+	}
+	last, err := ParseDate(data.LastDate)
+	if err != nil {
+		return
+	}
+
+	switch data.Trigger {
+	case TriggerCompletion:
+		if !completed {
+			return
+		}
+		after := last
+		if yesterday := today.AddDate(0, 0, -1); yesterday.After(after) {
+			after = yesterday
+		}
+		return rule.Next(start, after, data.Generated)
+	case TriggerSchedule:
+		if date, ok = rule.Next(start, last, data.Generated); !ok || date.After(today) {
+			return time.Time{}, false
+		}
+		for {
+			next, more := rule.Next(start, date, data.Generated)
+			if !more || next.After(today) {
+				return date, true
+			}
+			date = next
+		}
+	}
+
+	return
+}
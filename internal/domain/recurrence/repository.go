@@ -0,0 +1,29 @@
+package recurrence
+
+import (
+	"context"
+)
+
+type Repository interface {
+	// GetByTask returns the series the task is an occurrence of.
+	GetByTask(ctx context.Context, taskID string) (dest Entity, err error)
+	// Add creates the series with its task as the first occurrence.
+	Add(ctx context.Context, data Entity) (id string, err error)
+	Update(ctx context.Context, id string, data Entity) (err error)
+	Delete(ctx context.Context, id string) (err error)
+	// ListDue returns the series whose next occurrence may have to be created
+	// by today: scheduled ones whose next date has come and those waiting for
+	// the completion of a completed latest occurrence.
+	ListDue(ctx context.Context, today string) (dest []Entity, err error)
+	// Advance records the occurrence and makes its task the latest one of the
+	// series, generated is the count including it. It fails with
+	// store.ErrorConflict when the occurrence was already created or the series
+	// was advanced meanwhile.
+	Advance(ctx context.Context, data Occurrence, next *string, generated int) (err error)
+}
+
+/*
+GET /tasks/{id}/recurrence: получить правило повторения задачи.
+PUT /tasks/{id}/recurrence: задать правило повторения задачи.
+DELETE /tasks/{id}/recurrence: остановить повторение задачи.
+*/
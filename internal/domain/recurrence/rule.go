@@ -0,0 +1,294 @@
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequencies of the supported RRULE subset.
+const (
+	FrequencyDaily   = "DAILY"
+	FrequencyWeekly  = "WEEKLY"
+	FrequencyMonthly = "MONTHLY"
+)
+
+// maxInterval keeps the search for the next occurrence short.
+const maxInterval = 99
+
+const dateLayout = "2006-01-02"
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Weekday is a BYDAY entry. Ordinal picks the n-th such weekday of the month,
+// counted from the end when negative, zero matches every such weekday.
+type Weekday struct {
+	Day     time.Weekday
+	Ordinal int
+}
+
+// Rule is a recurrence rule in the subset of RFC 5545 RRULE supported for
+// tasks: FREQ of DAILY, WEEKLY or MONTHLY with INTERVAL, BYDAY, UNTIL and
+// COUNT. Occurrences are dates, weeks start on Monday.
+type Rule struct {
+	Frequency string
+	Interval  int
+	ByDay     []Weekday
+	// Until is the last date an occurrence may fall on, zero when unbounded.
+	Until time.Time
+	// Count is the number of occurrences of the series, zero when unbounded.
+	Count int
+}
+
+// ParseRule reads a rule such as FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH;COUNT=10,
+// an RRULE: prefix is allowed.
+func ParseRule(value string) (rule Rule, err error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return rule, errors.New("rule: cannot be blank")
+	}
+
+	rule.Interval = 1
+	seen := map[string]bool{}
+	for _, part := range strings.Split(value, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(strings.TrimSpace(name))
+		value = strings.ToUpper(strings.TrimSpace(value))
+		if !ok || value == "" {
+			return rule, fmt.Errorf("rule: %q must be NAME=VALUE", part)
+		}
+		if seen[name] {
+			return rule, fmt.Errorf("rule: %s is given twice", name)
+		}
+		seen[name] = true
+
+		switch name {
+		case "FREQ":
+			if value != FrequencyDaily && value != FrequencyWeekly && value != FrequencyMonthly {
+				return rule, errors.New("rule: FREQ must be one of DAILY, WEEKLY, MONTHLY")
+			}
+			rule.Frequency = value
+		case "INTERVAL":
+			if rule.Interval, err = strconv.Atoi(value); err != nil || rule.Interval < 1 || rule.Interval > maxInterval {
+				return rule, fmt.Errorf("rule: INTERVAL must be a number from 1 to %d", maxInterval)
+			}
+		case "BYDAY":
+			if rule.ByDay, err = parseByDay(value); err != nil {
+				return
+			}
+		case "UNTIL":
+			if rule.Until, err = parseUntil(value); err != nil {
+				return
+			}
+		case "COUNT":
+			if rule.Count, err = strconv.Atoi(value); err != nil || rule.Count < 1 {
+				return rule, errors.New("rule: COUNT must be a positive number")
+			}
+		default:
+			return rule, fmt.Errorf("rule: %s is not supported, use FREQ, INTERVAL, BYDAY, UNTIL or COUNT", name)
+		}
+	}
+
+	if rule.Frequency == "" {
+		return rule, errors.New("rule: FREQ is required")
+	}
+	if !rule.Until.IsZero() && rule.Count > 0 {
+		return rule, errors.New("rule: UNTIL and COUNT cannot be used together")
+	}
+	if rule.Frequency != FrequencyMonthly {
+		for _, day := range rule.ByDay {
+			if day.Ordinal != 0 {
+				return rule, errors.New("rule: BYDAY ordinals such as 1MO are only allowed with FREQ=MONTHLY")
+			}
+		}
+	}
+
+	return rule, nil
+}
+
+func parseByDay(value string) (res []Weekday, err error) {
+	for _, item := range strings.Split(value, ",") {
+		if len(item) < 2 {
+			return nil, fmt.Errorf("rule: BYDAY %q is not a weekday", item)
+		}
+
+		day, ok := weekdays[item[len(item)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("rule: BYDAY %q is not a weekday", item)
+		}
+
+		ordinal := 0
+		if prefix := item[:len(item)-2]; prefix != "" {
+			ordinal, err = strconv.Atoi(prefix)
+			if err != nil || ordinal == 0 || ordinal < -5 || ordinal > 5 {
+				return nil, fmt.Errorf("rule: BYDAY %q must have an ordinal from -5 to 5", item)
+			}
+		}
+
+		res = append(res, Weekday{Day: day, Ordinal: ordinal})
+	}
+
+	return res, nil
+}
+
+// parseUntil reads a DATE or a DATE-TIME, only the date is kept.
+func parseUntil(value string) (time.Time, error) {
+	if len(value) > 8 {
+		if _, err := time.Parse("20060102T150405", strings.TrimSuffix(value, "Z")); err != nil {
+			return time.Time{}, errors.New("rule: UNTIL must be a date such as 20241231")
+		}
+		value = value[:8]
+	}
+
+	until, err := time.Parse("20060102", value)
+	if err != nil {
+		return time.Time{}, errors.New("rule: UNTIL must be a date such as 20241231")
+	}
+
+	return until, nil
+}
+
+// String formats the rule in its canonical form, which is what is stored.
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + r.Frequency}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			name := strings.ToUpper(day.Day.String()[:2])
+			if day.Ordinal != 0 {
+				name = strconv.Itoa(day.Ordinal) + name
+			}
+			days = append(days, name)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next returns the first occurrence of the series started at start that falls
+// after the given date. generated is the number of occurrences created so far,
+// false means the series is over.
+func (r Rule) Next(start, after time.Time, generated int) (next time.Time, ok bool) {
+	if r.Count > 0 && generated >= r.Count {
+		return
+	}
+
+	day := after.AddDate(0, 0, 1)
+	if day.Before(start) {
+		day = start
+	}
+
+	// a few periods are enough unless the rule asks for a day that most
+	// periods lack, such as February 29 or a fifth Monday
+	limit := day.AddDate(8, 0, 0)
+	switch r.Frequency {
+	case FrequencyDaily:
+		limit = limit.AddDate(0, 0, 8*r.Interval)
+	case FrequencyWeekly:
+		limit = limit.AddDate(0, 0, 8*7*r.Interval)
+	case FrequencyMonthly:
+		limit = limit.AddDate(0, 8*r.Interval, 0)
+	}
+	if !r.Until.IsZero() && r.Until.Before(limit) {
+		limit = r.Until
+	}
+
+	for ; !day.After(limit); day = day.AddDate(0, 0, 1) {
+		if r.matches(start, day) {
+			return day, true
+		}
+	}
+
+	return
+}
+
+func (r Rule) matches(start, day time.Time) bool {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	switch r.Frequency {
+	case FrequencyDaily:
+		return days(start, day)%interval == 0 && (len(r.ByDay) == 0 || r.onWeekday(day))
+	case FrequencyWeekly:
+		if days(monday(start), monday(day))/7%interval != 0 {
+			return false
+		}
+		if len(r.ByDay) == 0 {
+			return day.Weekday() == start.Weekday()
+		}
+		return r.onWeekday(day)
+	case FrequencyMonthly:
+		months := (day.Year()-start.Year())*12 + int(day.Month()) - int(start.Month())
+		if months%interval != 0 {
+			return false
+		}
+		if len(r.ByDay) == 0 {
+			return day.Day() == start.Day()
+		}
+		return r.onWeekday(day)
+	}
+
+	return false
+}
+
+func (r Rule) onWeekday(day time.Time) bool {
+	last := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+
+	for _, weekday := range r.ByDay {
+		if weekday.Day != day.Weekday() {
+			continue
+		}
+		switch {
+		case weekday.Ordinal == 0:
+			return true
+		case weekday.Ordinal > 0 && (day.Day()-1)/7+1 == weekday.Ordinal:
+			return true
+		case weekday.Ordinal < 0 && -((last-day.Day())/7+1) == weekday.Ordinal:
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseDate reads a date column, the driver may return dates as timestamps.
+func ParseDate(value string) (time.Time, error) {
+	if len(value) > len(dateLayout) {
+		value = value[:len(dateLayout)]
+	}
+	return time.Parse(dateLayout, value)
+}
+
+func FormatDate(value time.Time) string {
+	return value.Format(dateLayout)
+}
+
+func days(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// monday is the first day of the week of the date.
+func monday(day time.Time) time.Time {
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
@@ -0,0 +1,253 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Periods of the velocity report.
+const (
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+)
+
+const (
+	// maxDays is the longest range of a daily report.
+	maxDays = 366
+	// maxPeriods is the longest range of the velocity report.
+	maxPeriods = 104
+	// defaultDays and defaultPeriods are the ranges used when from is empty.
+	defaultDays    = 30
+	defaultPeriods = 12
+)
+
+const dateLayout = "2006-01-02"
+
+var (
+	ErrorRange   = errors.New("to: cannot be before from")
+	ErrorDays    = fmt.Errorf("to: a daily report covers at most %d days", maxDays)
+	ErrorPeriods = fmt.Errorf("to: a velocity report covers at most %d periods", maxPeriods)
+)
+
+// Filter selects the tasks and the dates of a report. From and To are
+// inclusive, Terminal and Statuses come from the workflow of the project.
+type Filter struct {
+	ProjectID   string
+	MilestoneID string
+	From        string
+	To          string
+	Period      string
+	Terminal    []string
+	Statuses    []string
+}
+
+func (f *Filter) Validate() error {
+	var from, to time.Time
+	var err error
+
+	if f.From != "" {
+		if from, err = time.Parse(dateLayout, f.From); err != nil {
+			return errors.New("from: must be a date such as 2024-05-01")
+		}
+	}
+
+	if f.To != "" {
+		if to, err = time.Parse(dateLayout, f.To); err != nil {
+			return errors.New("to: must be a date such as 2024-05-01")
+		}
+	}
+
+	if f.From != "" && f.To != "" && to.Before(from) {
+		return ErrorRange
+	}
+
+	if f.Period != "" && f.Period != PeriodWeek && f.Period != PeriodMonth {
+		return errors.New("period: must be one of week, month")
+	}
+
+	return nil
+}
+
+// Days fills in the range of a daily report: it ends today and covers the
+// last 30 days unless given.
+func (f *Filter) Days(now time.Time) error {
+	to, from, err := f.dates(now, func(to time.Time) time.Time {
+		return to.AddDate(0, 0, 1-defaultDays)
+	})
+	if err != nil {
+		return err
+	}
+
+	if int(to.Sub(from).Hours()/24) >= maxDays {
+		return ErrorDays
+	}
+
+	return nil
+}
+
+// Periods fills in the range and the period of the velocity report: weeks
+// ending with the current one, the last 12 unless given.
+func (f *Filter) Periods(now time.Time) error {
+	if f.Period == "" {
+		f.Period = PeriodWeek
+	}
+
+	to, from, err := f.dates(now, func(to time.Time) time.Time {
+		if f.Period == PeriodMonth {
+			return to.AddDate(0, 1-defaultPeriods, 0)
+		}
+		return to.AddDate(0, 0, 7*(1-defaultPeriods))
+	})
+	if err != nil {
+		return err
+	}
+
+	periods := int(to.Sub(from).Hours()/24/7) + 1
+	if f.Period == PeriodMonth {
+		periods = (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month()) + 1
+	}
+	if periods > maxPeriods {
+		return ErrorPeriods
+	}
+
+	return nil
+}
+
+func (f *Filter) dates(now time.Time, start func(to time.Time) time.Time) (to, from time.Time, err error) {
+	if f.To == "" {
+		f.To = now.Format(dateLayout)
+	}
+	if to, err = time.Parse(dateLayout, f.To); err != nil {
+		return
+	}
+
+	if f.From == "" {
+		f.From = start(to).Format(dateLayout)
+	}
+	if from, err = time.Parse(dateLayout, f.From); err != nil {
+		return
+	}
+
+	if to.Before(from) {
+		err = ErrorRange
+	}
+
+	return
+}
+
+type BurndownDay struct {
+	Date           string  `json:"date"`
+	TotalTasks     int     `json:"total_tasks"`
+	CompletedTasks int     `json:"completed_tasks"`
+	RemainingTasks int     `json:"remaining_tasks"`
+	RemainingHours float64 `json:"remaining_hours"`
+}
+
+type BurndownResponse struct {
+	ProjectID   string        `json:"project_id"`
+	MilestoneID string        `json:"milestone_id,omitempty"`
+	From        string        `json:"from"`
+	To          string        `json:"to"`
+	Days        []BurndownDay `json:"days"`
+}
+
+type VelocityPeriod struct {
+	Start          string  `json:"start"`
+	CompletedTasks int     `json:"completed_tasks"`
+	CompletedHours float64 `json:"completed_hours"`
+}
+
+type VelocityResponse struct {
+	ProjectID   string           `json:"project_id"`
+	MilestoneID string           `json:"milestone_id,omitempty"`
+	Period      string           `json:"period"`
+	From        string           `json:"from"`
+	To          string           `json:"to"`
+	Periods     []VelocityPeriod `json:"periods"`
+}
+
+// FlowDay maps the statuses to the number of tasks in them.
+type FlowDay struct {
+	Date     string         `json:"date"`
+	Statuses map[string]int `json:"statuses"`
+}
+
+type FlowResponse struct {
+	ProjectID   string    `json:"project_id"`
+	MilestoneID string    `json:"milestone_id,omitempty"`
+	From        string    `json:"from"`
+	To          string    `json:"to"`
+	Statuses    []string  `json:"statuses"`
+	Days        []FlowDay `json:"days"`
+}
+
+func ParseBurndown(filter Filter, data []Burndown) (res BurndownResponse) {
+	res = BurndownResponse{
+		ProjectID:   filter.ProjectID,
+		MilestoneID: filter.MilestoneID,
+		From:        filter.From,
+		To:          filter.To,
+		Days:        make([]BurndownDay, 0, len(data)),
+	}
+	for _, object := range data {
+		res.Days = append(res.Days, BurndownDay{
+			Date:           object.Date,
+			TotalTasks:     object.TotalTasks,
+			CompletedTasks: object.CompletedTasks,
+			RemainingTasks: object.RemainingTasks,
+			RemainingHours: round(object.RemainingHours),
+		})
+	}
+	return
+}
+
+func ParseVelocity(filter Filter, data []Velocity) (res VelocityResponse) {
+	res = VelocityResponse{
+		ProjectID:   filter.ProjectID,
+		MilestoneID: filter.MilestoneID,
+		Period:      filter.Period,
+		From:        filter.From,
+		To:          filter.To,
+		Periods:     make([]VelocityPeriod, 0, len(data)),
+	}
+	for _, object := range data {
+		res.Periods = append(res.Periods, VelocityPeriod{
+			Start:          object.Start,
+			CompletedTasks: object.CompletedTasks,
+			CompletedHours: round(object.CompletedHours),
+		})
+	}
+	return
+}
+
+// ParseFlow groups the rows by day, the statuses keep the order of the rows.
+func ParseFlow(filter Filter, data []Flow) (res FlowResponse) {
+	res = FlowResponse{
+		ProjectID:   filter.ProjectID,
+		MilestoneID: filter.MilestoneID,
+		From:        filter.From,
+		To:          filter.To,
+		Statuses:    make([]string, 0),
+		Days:        make([]FlowDay, 0),
+	}
+
+	seen := map[string]bool{}
+	for _, object := range data {
+		if len(res.Days) == 0 || res.Days[len(res.Days)-1].Date != object.Date {
+			res.Days = append(res.Days, FlowDay{Date: object.Date, Statuses: map[string]int{}})
+		}
+		res.Days[len(res.Days)-1].Statuses[object.Status] = object.Tasks
+
+		if !seen[object.Status] {
+			seen[object.Status] = true
+			res.Statuses = append(res.Statuses, object.Status)
+		}
+	}
+	return
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
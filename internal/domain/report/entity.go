@@ -0,0 +1,24 @@
+package report
+
+// Burndown is the work of the project at the end of a day.
+type Burndown struct {
+	Date           string  `db:"date"`
+	TotalTasks     int     `db:"total_tasks"`
+	CompletedTasks int     `db:"completed_tasks"`
+	RemainingTasks int     `db:"remaining_tasks"`
+	RemainingHours float64 `db:"remaining_hours"`
+}
+
+// Velocity is the work completed in a period that starts on Start.
+type Velocity struct {
+	Start          string  `db:"start"`
+	CompletedTasks int     `db:"completed_tasks"`
+	CompletedHours float64 `db:"completed_hours"`
+}
+
+// Flow is the number of tasks in a status at the end of a day.
+type Flow struct {
+	Date   string `db:"date"`
+	Status string `db:"status"`
+	Tasks  int    `db:"tasks"`
+}
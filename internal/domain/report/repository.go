@@ -0,0 +1,24 @@
+package report
+
+import (
+	"context"
+)
+
+// Repository computes the reports from the status history of the tasks, a
+// task counts as completed while its latest status is one of Terminal.
+type Repository interface {
+	// Burndown returns a row for every day from From to To.
+	Burndown(ctx context.Context, filter Filter) (dest []Burndown, err error)
+	// Velocity returns a row for every period from From to To, a task
+	// completed several times in a period counts once.
+	Velocity(ctx context.Context, filter Filter) (dest []Velocity, err error)
+	// CumulativeFlow returns a row for every day and status, the statuses of
+	// the workflow come first in their order.
+	CumulativeFlow(ctx context.Context, filter Filter) (dest []Flow, err error)
+}
+
+/*
+GET /projects/{id}/reports/burndown?from=&to=&milestone_id=: получить оставшуюся работу по дням.
+GET /projects/{id}/reports/velocity?period=&from=&to=: получить выполненную работу по периодам.
+GET /projects/{id}/reports/cumulative-flow?from=&to=&milestone_id=: получить число задач в каждом статусе по дням.
+*/
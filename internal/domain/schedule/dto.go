@@ -0,0 +1,70 @@
+package schedule
+
+import (
+	"errors"
+)
+
+var (
+	ErrorSelf               = errors.New("predecessor_id: task cannot precede itself")
+	ErrorUnknownPredecessor = errors.New("predecessor_id: predecessor task does not exist")
+	ErrorOtherProject       = errors.New("predecessor_id: predecessor task belongs to another project")
+	ErrorCycle              = errors.New("predecessor cycle")
+	ErrorExists             = errors.New("task already follows the predecessor")
+	ErrorNoStartDate        = errors.New("start_date: project has no start date to schedule from")
+)
+
+type Request struct {
+	PredecessorID *string `json:"predecessor_id"`
+}
+
+func (s *Request) Validate() error {
+	if s.PredecessorID == nil || *s.PredecessorID == "" {
+		return errors.New("predecessor_id: cannot be blank")
+	}
+
+	return nil
+}
+
+type LinkResponse struct {
+	TaskID        string `json:"task_id"`
+	PredecessorID string `json:"predecessor_id"`
+	CreatedAt     string `json:"created_at,omitempty"`
+}
+
+func ParseFromLink(data Link) (res LinkResponse) {
+	res = LinkResponse{
+		TaskID:        data.TaskID,
+		PredecessorID: data.PredecessorID,
+	}
+	if data.CreatedAt != nil {
+		res.CreatedAt = *data.CreatedAt
+	}
+	return
+}
+
+// Response is the computed schedule of a project. Dates are inclusive, delay
+// is the number of days the projected finish is past the project end date.
+type Response struct {
+	ProjectID       string         `json:"project_id"`
+	StartDate       string         `json:"start_date"`
+	EndDate         string         `json:"end_date,omitempty"`
+	ProjectedFinish string         `json:"projected_finish"`
+	OnTime          bool           `json:"on_time"`
+	DelayDays       int            `json:"delay_days"`
+	CriticalPath    []string       `json:"critical_path"`
+	Tasks           []TaskResponse `json:"tasks"`
+}
+
+type TaskResponse struct {
+	ID             string `json:"id"`
+	Title          string `json:"title"`
+	DurationDays   int    `json:"duration_days"`
+	EarliestStart  string `json:"earliest_start"`
+	EarliestFinish string `json:"earliest_finish"`
+	LatestStart    string `json:"latest_start"`
+	LatestFinish   string `json:"latest_finish"`
+	SlackDays      int    `json:"slack_days"`
+	Critical       bool   `json:"critical"`
+	DueDate        string `json:"due_date,omitempty"`
+	Late           bool   `json:"late"`
+}
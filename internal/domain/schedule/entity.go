@@ -0,0 +1,9 @@
+package schedule
+
+// Link is a finish-to-start relationship: the task starts after the
+// predecessor finishes.
+type Link struct {
+	TaskID        string  `db:"task_id"`
+	PredecessorID string  `db:"predecessor_id"`
+	CreatedAt     *string `db:"created_at"`
+}
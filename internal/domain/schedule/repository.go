@@ -0,0 +1,23 @@
+package schedule
+
+import (
+	"context"
+	"hard/internal/domain/task"
+	"hard/pkg/store"
+)
+
+type Repository interface {
+	ListPredecessors(ctx context.Context, taskID string, page store.Page) (dest []task.Entity, cursor store.Cursor, err error)
+	ListByProject(ctx context.Context, projectID string) (dest []Link, err error)
+	Get(ctx context.Context, taskID, predecessorID string) (dest Link, err error)
+	Add(ctx context.Context, data Link) (err error)
+	Delete(ctx context.Context, taskID, predecessorID string) (err error)
+	Precedes(ctx context.Context, predecessorID, taskID string) (ok bool, err error)
+}
+
+/*
+GET /tasks/{id}/predecessors: получить предшественников задачи.
+POST /tasks/{id}/predecessors: добавить предшественника.
+DELETE /tasks/{id}/predecessors/{predecessor_id}: удалить предшественника.
+GET /projects/{id}/schedule: получить расписание проекта и критический путь.
+*/
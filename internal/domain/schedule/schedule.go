@@ -0,0 +1,170 @@
+package schedule
+
+import (
+	"hard/internal/domain/dependency"
+	"hard/internal/domain/project"
+	"hard/internal/domain/task"
+	"math"
+	"time"
+)
+
+// HoursPerDay converts estimates to days of work.
+const HoursPerDay = 8
+
+const dateLayout = "2006-01-02"
+
+// ParseDate reads a date column, the driver returns dates as timestamps.
+func ParseDate(value string) (time.Time, error) {
+	if len(value) > len(dateLayout) {
+		value = value[:len(dateLayout)]
+	}
+	return time.Parse(dateLayout, value)
+}
+
+// Duration is the planned length of the task in days: the estimate when it is
+// set, the span between start and due dates otherwise, 0 for a milestone.
+func Duration(data task.Entity) int {
+	if data.EstimateHours != nil {
+		return int(math.Ceil(*data.EstimateHours / HoursPerDay))
+	}
+
+	if data.StartDate != nil && data.DueDate != nil {
+		start, err := ParseDate(*data.StartDate)
+		if err != nil {
+			return 0
+		}
+		due, err := ParseDate(*data.DueDate)
+		if err != nil {
+			return 0
+		}
+		return days(start, due) + 1
+	}
+
+	return 0
+}
+
+// Compute runs the forward and backward pass of the critical path method over
+// the tasks of the project. Days are counted from the project start date, a
+// task starts no earlier than its own start date and than the finish of its
+// predecessors. Tasks without slack form the critical path.
+func Compute(data project.Entity, tasks []task.Entity, links []Link) (res Response, err error) {
+	if data.StartDate == nil {
+		return res, ErrorNoStartDate
+	}
+	start, err := ParseDate(*data.StartDate)
+	if err != nil {
+		return
+	}
+
+	edges := make([]dependency.Entity, 0, len(links))
+	for _, link := range links {
+		edges = append(edges, dependency.Entity{TaskID: link.TaskID, BlockerID: link.PredecessorID})
+	}
+	order, err := dependency.Sort(tasks, edges)
+	if err != nil {
+		return res, ErrorCycle
+	}
+
+	index := make(map[string]int, len(order))
+	for i, object := range order {
+		index[object.ID] = i
+	}
+
+	predecessors := make([][]int, len(order))
+	successors := make([][]int, len(order))
+	for _, link := range links {
+		from, ok := index[link.PredecessorID]
+		if !ok {
+			continue
+		}
+		to, ok := index[link.TaskID]
+		if !ok {
+			continue
+		}
+		predecessors[to] = append(predecessors[to], from)
+		successors[from] = append(successors[from], to)
+	}
+
+	// earliest and latest start and finish, finish is exclusive
+	duration := make([]int, len(order))
+	es, ef := make([]int, len(order)), make([]int, len(order))
+	ls, lf := make([]int, len(order)), make([]int, len(order))
+
+	finish := 0
+	for i, object := range order {
+		duration[i] = Duration(object)
+		if object.StartDate != nil {
+			if date, err := ParseDate(*object.StartDate); err == nil {
+				es[i] = max(es[i], days(start, date))
+			}
+		}
+		for _, p := range predecessors[i] {
+			es[i] = max(es[i], ef[p])
+		}
+		ef[i] = es[i] + duration[i]
+		finish = max(finish, ef[i])
+	}
+
+	for i := len(order) - 1; i >= 0; i-- {
+		lf[i] = finish
+		for _, s := range successors[i] {
+			lf[i] = min(lf[i], ls[s])
+		}
+		ls[i] = lf[i] - duration[i]
+	}
+
+	res = Response{
+		ProjectID:       data.ID,
+		StartDate:       start.Format(dateLayout),
+		ProjectedFinish: finishDate(start, 0, finish).Format(dateLayout),
+		OnTime:          true,
+		CriticalPath:    make([]string, 0),
+		Tasks:           make([]TaskResponse, 0, len(order)),
+	}
+
+	if data.EndDate != nil {
+		if end, err := ParseDate(*data.EndDate); err == nil {
+			res.EndDate = end.Format(dateLayout)
+			res.DelayDays = days(end, finishDate(start, 0, finish))
+			res.OnTime = res.DelayDays <= 0
+		}
+	}
+
+	for i, object := range order {
+		item := TaskResponse{
+			ID:             object.ID,
+			DurationDays:   duration[i],
+			EarliestStart:  start.AddDate(0, 0, es[i]).Format(dateLayout),
+			EarliestFinish: finishDate(start, es[i], ef[i]).Format(dateLayout),
+			LatestStart:    start.AddDate(0, 0, ls[i]).Format(dateLayout),
+			LatestFinish:   finishDate(start, ls[i], lf[i]).Format(dateLayout),
+			SlackDays:      ls[i] - es[i],
+		}
+		if object.Title != nil {
+			item.Title = *object.Title
+		}
+		item.Critical = item.SlackDays == 0
+		if item.Critical {
+			res.CriticalPath = append(res.CriticalPath, object.ID)
+		}
+		if object.DueDate != nil {
+			if due, err := ParseDate(*object.DueDate); err == nil {
+				item.DueDate = due.Format(dateLayout)
+				item.Late = finishDate(start, es[i], ef[i]).After(due)
+			}
+		}
+		res.Tasks = append(res.Tasks, item)
+	}
+
+	return
+}
+
+// finishDate is the last day of work between the start and the exclusive
+// finish offsets, the start day for a milestone.
+func finishDate(start time.Time, from, to int) time.Time {
+	return start.AddDate(0, 0, max(to-1, from))
+}
+
+func days(from, to time.Time) int {
+	return int(math.Round(to.Sub(from).Hours() / 24))
+}
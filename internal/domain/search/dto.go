@@ -0,0 +1,65 @@
+package search
+
+import (
+	"errors"
+	"strings"
+)
+
+type Request struct {
+	Query string
+	Types []string
+}
+
+func (s *Request) Validate() error {
+	if strings.TrimSpace(s.Query) == "" {
+		return errors.New("q: cannot be blank")
+	}
+
+	for _, t := range s.Types {
+		if !IsType(t) {
+			return errors.New("type: must be one of " + strings.Join(Types, ", "))
+		}
+	}
+
+	return nil
+}
+
+func IsType(value string) bool {
+	for _, t := range Types {
+		if t == value {
+			return true
+		}
+	}
+	return false
+}
+
+type Response struct {
+	Type     string  `json:"type"`
+	ID       string  `json:"id"`
+	Title    string  `json:"title"`
+	Headline string  `json:"headline"`
+	Rank     float64 `json:"rank"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		Type: data.Type,
+		ID:   data.ID,
+		Rank: data.Rank,
+	}
+	if data.Title != nil {
+		res.Title = *data.Title
+	}
+	if data.Headline != nil {
+		res.Headline = *data.Headline
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
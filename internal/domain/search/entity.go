@@ -0,0 +1,17 @@
+package search
+
+const (
+	TypeTask    = "task"
+	TypeProject = "project"
+	TypeUser    = "user"
+)
+
+var Types = []string{TypeTask, TypeProject, TypeUser}
+
+type Entity struct {
+	Type     string  `db:"type"`
+	ID       string  `db:"id"`
+	Title    *string `db:"title"`
+	Headline *string `db:"headline"`
+	Rank     float64 `db:"rank"`
+}
@@ -0,0 +1,10 @@
+package search
+
+import (
+	"context"
+	"hard/pkg/store"
+)
+
+type Repository interface {
+	Search(ctx context.Context, query string, types []string, page store.Page) (dest []Entity, cursor store.Cursor, err error)
+}
@@ -2,18 +2,36 @@ package task
 
 import (
 	"errors"
+	"hard/internal/domain/label"
+	"strings"
 	"time"
 )
 
+var (
+	ErrorProjectDeleted = errors.New("project of the task is deleted, restore the project instead")
+	ErrorParentDeleted  = errors.New("parent of the task is deleted, restore the parent instead")
+	ErrorUnknownParent  = errors.New("parent_id: parent task does not exist")
+	ErrorParentProject  = errors.New("parent_id: parent task belongs to another project")
+	ErrorParentCycle    = errors.New("parent_id: task cannot be nested under itself or its subtasks")
+	ErrorMoveSubtasks   = errors.New("project_id: task with subtasks cannot be moved to another project")
+	ErrorOpenSubtasks   = errors.New("task has open subtasks")
+	ErrorDueDate        = errors.New("due_date: cannot be before start_date")
+)
+
 type Request struct {
-	ID          string  `json:"id"`
-	Title       *string `json:"title"`
-	Description *string `json:"description"`
-	Priority    *string `json:"priority"`
-	Status      *string `json:"status"`
-	AssigneeID  *string `json:"assignee_id"`
-	ProjectID   *string `json:"project_id"`
-	CompletedAt *string `json:"completed_at"`
+	ID            string   `json:"id"`
+	Title         *string  `json:"title"`
+	Description   *string  `json:"description"`
+	Priority      *string  `json:"priority"`
+	Status        *string  `json:"status"`
+	AssigneeID    *string  `json:"assignee_id"`
+	ProjectID     *string  `json:"project_id"`
+	ParentID      *string  `json:"parent_id"`
+	StartDate     *string  `json:"start_date"`
+	DueDate       *string  `json:"due_date"`
+	EstimateHours *float64 `json:"estimate_hours"`
+	MilestoneID   *string  `json:"milestone_id"`
+	CompletedAt   *string  `json:"completed_at"`
 }
 
 func (s *Request) Validate() error {
@@ -29,6 +47,10 @@ func (s *Request) Validate() error {
 		return errors.New("priority: cannot be blank")
 	}
 
+	if PriorityRank(*s.Priority) == 0 {
+		return errors.New("priority: must be one of " + strings.Join(Priorities, ", "))
+	}
+
 	if s.Status == nil {
 		return errors.New("status: cannot be blank")
 	}
@@ -47,26 +69,77 @@ func (s *Request) Validate() error {
 		}
 	}
 
-	return nil
+	return s.validateSchedule()
 }
 
-func IsEmpty(data Request) bool {
-	return data.Title == nil &&
-		data.Priority == nil &&
-		data.Status == nil &&
-		data.AssigneeID == nil &&
-		data.ProjectID == nil
+// IsEmpty reports whether an update request sets none of the updatable fields.
+func (s *Request) IsEmpty() bool {
+	return s.Title == nil && s.Description == nil && s.Priority == nil &&
+		s.Status == nil && s.AssigneeID == nil && s.ProjectID == nil && s.ParentID == nil &&
+		s.StartDate == nil && s.DueDate == nil && s.EstimateHours == nil && s.MilestoneID == nil &&
+		s.CompletedAt == nil
+}
+
+// ValidateUpdate checks only the fields present in a partial update.
+func (s *Request) ValidateUpdate() error {
+	if s.Priority != nil && PriorityRank(*s.Priority) == 0 {
+		return errors.New("priority: must be one of " + strings.Join(Priorities, ", "))
+	}
+
+	if s.CompletedAt != nil && *s.CompletedAt != "" {
+		if _, err := time.Parse("2006-01-02", *s.CompletedAt); err != nil {
+			return errors.New("completed_at: invalid format")
+		}
+	}
+
+	return s.validateSchedule()
+}
+
+// validateSchedule checks the planning fields, an empty date clears it.
+func (s *Request) validateSchedule() error {
+	var start, due time.Time
+	var err error
+
+	if s.StartDate != nil && *s.StartDate != "" {
+		if start, err = time.Parse("2006-01-02", *s.StartDate); err != nil {
+			return errors.New("start_date: invalid format")
+		}
+	}
+
+	if s.DueDate != nil && *s.DueDate != "" {
+		if due, err = time.Parse("2006-01-02", *s.DueDate); err != nil {
+			return errors.New("due_date: invalid format")
+		}
+	}
+
+	if !start.IsZero() && !due.IsZero() && due.Before(start) {
+		return ErrorDueDate
+	}
+
+	if s.EstimateHours != nil && *s.EstimateHours < 0 {
+		return errors.New("estimate_hours: cannot be negative")
+	}
+
+	return nil
 }
 
 type Response struct {
-	ID          string `json:"id"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Priority    string `json:"priority"`
-	Status      string `json:"status"`
-	AssigneeID  string `json:"assignee_id"`
-	ProjectID   string `json:"project_id"`
-	CompletedAt string `json:"completed_at"`
+	ID            string           `json:"id"`
+	Title         string           `json:"title"`
+	Description   string           `json:"description"`
+	Priority      string           `json:"priority"`
+	Status        string           `json:"status"`
+	AssigneeID    string           `json:"assignee_id"`
+	ProjectID     string           `json:"project_id"`
+	ParentID      string           `json:"parent_id,omitempty"`
+	StartDate     string           `json:"start_date,omitempty"`
+	DueDate       string           `json:"due_date,omitempty"`
+	EstimateHours float64          `json:"estimate_hours,omitempty"`
+	MilestoneID   string           `json:"milestone_id,omitempty"`
+	CompletedAt   string           `json:"completed_at"`
+	DeletedAt     string           `json:"deleted_at,omitempty"`
+	Version       int              `json:"version,omitempty"`
+	Labels        []label.Response `json:"labels,omitempty"`
 }
 
 func ParseFromEntity(data Entity) (res Response) {
@@ -81,9 +154,30 @@ func ParseFromEntity(data Entity) (res Response) {
 	if data.AssigneeID != nil {
 		res.AssigneeID = *data.AssigneeID
 	}
+	if data.ParentID != nil {
+		res.ParentID = *data.ParentID
+	}
+	if data.StartDate != nil {
+		res.StartDate = *data.StartDate
+	}
+	if data.DueDate != nil {
+		res.DueDate = *data.DueDate
+	}
+	if data.EstimateHours != nil {
+		res.EstimateHours = *data.EstimateHours
+	}
+	if data.MilestoneID != nil {
+		res.MilestoneID = *data.MilestoneID
+	}
 	if data.CompletedAt != nil {
 		res.CompletedAt = *data.CompletedAt
 	}
+	if data.DeletedAt != nil {
+		res.DeletedAt = *data.DeletedAt
+	}
+	if data.Version != nil {
+		res.Version = *data.Version
+	}
 	return
 }
 
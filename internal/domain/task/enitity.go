@@ -1,12 +1,19 @@
 package task
 
 type Entity struct {
-	ID          string  `db:"id"`
-	Title       *string `db:"title"`
-	Description *string `db:"description"`
-	Priority    *string `db:"priority"`
-	Status      *string `db:"status"`
-	AssigneeID  *string `db:"assignee_id"`
-	ProjectID   *string `db:"project_id"`
-	CompletedAt *string `db:"completed_at"`
+	ID            string   `db:"id"`
+	Title         *string  `db:"title"`
+	Description   *string  `db:"description"`
+	Priority      *string  `db:"priority"`
+	Status        *string  `db:"status"`
+	AssigneeID    *string  `db:"assignee_id"`
+	ProjectID     *string  `db:"project_id"`
+	ParentID      *string  `db:"parent_id"`
+	StartDate     *string  `db:"start_date"`
+	DueDate       *string  `db:"due_date"`
+	EstimateHours *float64 `db:"estimate_hours"`
+	MilestoneID   *string  `db:"milestone_id"`
+	CompletedAt   *string  `db:"completed_at"`
+	DeletedAt     *string  `db:"deleted_at"`
+	Version       *int     `db:"version"`
 }
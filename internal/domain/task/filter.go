@@ -0,0 +1,266 @@
+package task
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Field string
+
+const (
+	FieldID          Field = "id"
+	FieldTitle       Field = "title"
+	FieldDescription Field = "description"
+	FieldPriority    Field = "priority"
+	FieldStatus      Field = "status"
+	FieldAssigneeID  Field = "assignee_id"
+	FieldProjectID   Field = "project_id"
+	FieldMilestoneID Field = "milestone_id"
+	FieldStartDate   Field = "start_date"
+	FieldDueDate     Field = "due_date"
+	FieldLabel       Field = "label"
+	FieldCompletedAt Field = "completed_at"
+	FieldCreatedAt   Field = "created_at"
+	FieldUpdatedAt   Field = "updated_at"
+)
+
+type Operator string
+
+const (
+	OperatorEqual        Operator = "="
+	OperatorNotEqual     Operator = "!="
+	OperatorGreater      Operator = ">"
+	OperatorGreaterEqual Operator = ">="
+	OperatorLess         Operator = "<"
+	OperatorLessEqual    Operator = "<="
+	OperatorContains     Operator = "~"
+	OperatorIn           Operator = "in"
+	OperatorAll          Operator = "all"
+)
+
+// Kind describes how values of a field are validated and compared.
+type Kind int
+
+const (
+	KindText Kind = iota
+	KindEnum
+	KindRank
+	KindNumber
+	KindDate
+	KindLabel
+)
+
+// Priorities lists the known priorities from lowest to highest, ordered
+// comparisons and sorting on priority follow this order.
+var Priorities = []string{"Low", "Medium", "High"}
+
+var fields = map[Field]Kind{
+	FieldID:          KindNumber,
+	FieldTitle:       KindText,
+	FieldDescription: KindText,
+	FieldPriority:    KindRank,
+	FieldStatus:      KindEnum,
+	FieldAssigneeID:  KindNumber,
+	FieldProjectID:   KindNumber,
+	FieldMilestoneID: KindNumber,
+	FieldStartDate:   KindDate,
+	FieldDueDate:     KindDate,
+	FieldLabel:       KindLabel,
+	FieldCompletedAt: KindDate,
+	FieldCreatedAt:   KindDate,
+	FieldUpdatedAt:   KindDate,
+}
+
+var operators = map[Kind][]Operator{
+	KindText:   {OperatorEqual, OperatorNotEqual, OperatorContains, OperatorIn},
+	KindEnum:   {OperatorEqual, OperatorNotEqual, OperatorIn},
+	KindRank:   {OperatorEqual, OperatorNotEqual, OperatorIn, OperatorGreater, OperatorGreaterEqual, OperatorLess, OperatorLessEqual},
+	KindNumber: {OperatorEqual, OperatorNotEqual, OperatorIn, OperatorGreater, OperatorGreaterEqual, OperatorLess, OperatorLessEqual},
+	KindDate:   {OperatorEqual, OperatorNotEqual, OperatorGreater, OperatorGreaterEqual, OperatorLess, OperatorLessEqual},
+	KindLabel:  {OperatorEqual, OperatorNotEqual, OperatorIn, OperatorAll},
+}
+
+// symbols are matched longest first.
+var symbols = []Operator{
+	OperatorNotEqual, OperatorGreaterEqual, OperatorLessEqual,
+	OperatorGreater, OperatorLess, OperatorContains, OperatorEqual,
+}
+
+// reserved query parameters are handled outside the filter.
+var reserved = map[string]bool{"limit": true, "after": true, "before": true}
+
+type Condition struct {
+	Field    Field
+	Operator Operator
+	Values   []string
+}
+
+type Order struct {
+	Field Field
+	Desc  bool
+}
+
+type Filter struct {
+	Conditions []Condition
+	Sort       []Order
+}
+
+type FilterError struct {
+	Field    string `json:"field"`
+	Operator string `json:"operator,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Reason   string `json:"reason"`
+}
+
+func (e *FilterError) Error() string {
+	return e.Field + ": " + e.Reason
+}
+
+func KindOf(field Field) Kind {
+	return fields[field]
+}
+
+// PriorityRank returns the 1-based position of the priority, 0 if unknown.
+func PriorityRank(priority string) int {
+	for i, p := range Priorities {
+		if p == priority {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// ParseFilter parses a raw query string such as
+// "status=in:Active,Review&priority!=Low&title~login&label=all:bug,ui&sort=-priority,created_at".
+func ParseFilter(rawQuery string) (filter Filter, err error) {
+	for _, raw := range strings.Split(rawQuery, "&") {
+		if raw == "" {
+			continue
+		}
+		term, unescapeErr := url.QueryUnescape(raw)
+		if unescapeErr != nil {
+			return filter, &FilterError{Field: raw, Reason: "malformed query"}
+		}
+
+		name := term
+		if i := strings.IndexAny(term, "!<>=~"); i >= 0 {
+			name = term[:i]
+		}
+		rest := term[len(name):]
+
+		if name == "sort" {
+			if filter.Sort, err = parseSort(strings.TrimPrefix(rest, "=")); err != nil {
+				return
+			}
+			continue
+		}
+		if reserved[name] {
+			continue
+		}
+
+		var cond Condition
+		if cond, err = parseCondition(name, rest); err != nil {
+			return
+		}
+		filter.Conditions = append(filter.Conditions, cond)
+	}
+
+	return
+}
+
+func parseCondition(name, rest string) (cond Condition, err error) {
+	kind, ok := fields[Field(name)]
+	if !ok {
+		return cond, &FilterError{Field: name, Reason: "unknown field"}
+	}
+	cond.Field = Field(name)
+
+	for _, op := range symbols {
+		if strings.HasPrefix(rest, string(op)) {
+			cond.Operator = op
+			break
+		}
+	}
+	if cond.Operator == "" {
+		return cond, &FilterError{Field: name, Operator: rest, Reason: "unknown operator"}
+	}
+
+	value := rest[len(cond.Operator):]
+	if cond.Operator == OperatorEqual && strings.HasPrefix(value, "in:") {
+		cond.Operator = OperatorIn
+		cond.Values = strings.Split(strings.TrimPrefix(value, "in:"), ",")
+	} else if cond.Operator == OperatorEqual && strings.HasPrefix(value, "all:") {
+		cond.Operator = OperatorAll
+		cond.Values = strings.Split(strings.TrimPrefix(value, "all:"), ",")
+	} else {
+		cond.Values = []string{value}
+	}
+
+	if !allowed(kind, cond.Operator) {
+		return cond, &FilterError{Field: name, Operator: string(cond.Operator), Reason: "operator not supported for field"}
+	}
+
+	for _, v := range cond.Values {
+		if reason := validate(kind, v); reason != "" {
+			return cond, &FilterError{Field: name, Operator: string(cond.Operator), Value: v, Reason: reason}
+		}
+	}
+
+	return
+}
+
+func parseSort(value string) (sort []Order, err error) {
+	for _, item := range strings.Split(value, ",") {
+		order := Order{}
+		if strings.HasPrefix(item, "-") {
+			order.Desc = true
+			item = item[1:]
+		}
+		order.Field = Field(item)
+
+		if _, ok := fields[order.Field]; !ok {
+			return nil, &FilterError{Field: "sort", Value: item, Reason: "unknown field"}
+		}
+		if order.Field == FieldDescription || order.Field == FieldLabel {
+			return nil, &FilterError{Field: "sort", Value: item, Reason: "field is not sortable"}
+		}
+
+		sort = append(sort, order)
+	}
+
+	return
+}
+
+func allowed(kind Kind, op Operator) bool {
+	for _, o := range operators[kind] {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+func validate(kind Kind, value string) string {
+	if value == "" {
+		return "value cannot be blank"
+	}
+
+	switch kind {
+	case KindNumber:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return "value must be a number"
+		}
+	case KindDate:
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return "value must be a date in 2006-01-02 format"
+		}
+	case KindRank:
+		if PriorityRank(value) == 0 {
+			return "value must be one of " + strings.Join(Priorities, ", ")
+		}
+	}
+
+	return ""
+}
@@ -1,12 +1,25 @@
 package task
 
-import "context"
+import (
+	"context"
+	"hard/pkg/store"
+	"time"
+)
 
 type Repository interface {
-	List(ctx context.Context) (dest []Entity, err error)
+	List(ctx context.Context, page store.Page) (dest []Entity, cursor store.Cursor, err error)
 	Add(ctx context.Context, data Entity) (id string, err error)
+	AddMany(ctx context.Context, data []Entity) (ids []string, err error)
 	Get(ctx context.Context, id string) (dest Entity, err error)
 	Update(ctx context.Context, id string, dest Entity) (err error)
-	Delete(ctx context.Context, id string) (err error)
-	Search(ctx context.Context, data Entity) (dest []Entity, err error)
+	Delete(ctx context.Context, id string, version *int) (err error)
+	Restore(ctx context.Context, id string) (err error)
+	Purge(ctx context.Context, retention time.Duration) (n int64, err error)
+	Search(ctx context.Context, filter Filter, page store.Page) (dest []Entity, cursor store.Cursor, err error)
+	ListSubtasks(ctx context.Context, id string, page store.Page) (dest []Entity, cursor store.Cursor, err error)
+	Descendants(ctx context.Context, id string) (dest []Entity, err error)
+	Ancestors(ctx context.Context, id string) (dest []string, err error)
+	ListByProject(ctx context.Context, projectID string) (dest []Entity, err error)
+	ListByProjects(ctx context.Context, projectIDs []string, limit int) (dest map[string][]Entity, err error)
+	ListByAssignees(ctx context.Context, assigneeIDs []string, limit int) (dest map[string][]Entity, err error)
 }
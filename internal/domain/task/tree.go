@@ -0,0 +1,82 @@
+package task
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+// ClosePolicy decides what happens to open subtasks when their parent moves to
+// a terminal status.
+type ClosePolicy string
+
+const (
+	// ClosePolicyBlock rejects closing a task while any of its subtasks is open.
+	ClosePolicyBlock ClosePolicy = "block"
+	// ClosePolicyCascade closes the open subtasks together with the parent.
+	ClosePolicyCascade ClosePolicy = "cascade"
+	// ClosePolicyAllow closes the parent and leaves the subtasks as they are.
+	ClosePolicyAllow ClosePolicy = "allow"
+)
+
+var closePolicies = []ClosePolicy{ClosePolicyBlock, ClosePolicyCascade, ClosePolicyAllow}
+
+func ParseClosePolicy(value string) (ClosePolicy, error) {
+	for _, policy := range closePolicies {
+		if string(policy) == value {
+			return policy, nil
+		}
+	}
+
+	names := make([]string, 0, len(closePolicies))
+	for _, policy := range closePolicies {
+		names = append(names, string(policy))
+	}
+	return "", errors.New("close policy: must be one of " + strings.Join(names, ", "))
+}
+
+// Node is a task with its subtasks. Progress is the share of completed work in
+// percent: 0 or 100 for a leaf, the mean progress of the subtasks otherwise.
+type Node struct {
+	Response
+	Progress int    `json:"progress"`
+	Subtasks []Node `json:"subtasks"`
+}
+
+// BuildTree nests the descendants under root. Descendants whose parent is not
+// in the list are dropped.
+func BuildTree(root Entity, descendants []Entity) (res Node) {
+	children := make(map[string][]Entity)
+	for _, data := range descendants {
+		if data.ParentID != nil {
+			children[*data.ParentID] = append(children[*data.ParentID], data)
+		}
+	}
+
+	res, _ = buildNode(root, children)
+
+	return
+}
+
+func buildNode(data Entity, children map[string][]Entity) (res Node, progress float64) {
+	res = Node{
+		Response: ParseFromEntity(data),
+		Subtasks: make([]Node, 0, len(children[data.ID])),
+	}
+
+	if len(children[data.ID]) == 0 {
+		if res.CompletedAt != "" {
+			progress = 100
+		}
+	} else {
+		for _, child := range children[data.ID] {
+			node, childProgress := buildNode(child, children)
+			res.Subtasks = append(res.Subtasks, node)
+			progress += childProgress
+		}
+		progress /= float64(len(res.Subtasks))
+	}
+	res.Progress = int(math.Round(progress))
+
+	return
+}
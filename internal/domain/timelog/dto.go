@@ -0,0 +1,170 @@
+package timelog
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrorRunning    = errors.New("a timer is already running, stop it first")
+	ErrorNotRunning = errors.New("no timer is running on the task")
+)
+
+// maxEntry is the longest time a single manual entry may log.
+const maxEntry = 24 * time.Hour
+
+const dateLayout = "2006-01-02"
+
+// Filter narrows the totals down to a task, a user or a project, From and To
+// are inclusive dates matched against the start of the entries.
+type Filter struct {
+	TaskID    string
+	UserID    string
+	ProjectID string
+	From      string
+	To        string
+}
+
+func (f *Filter) Validate() error {
+	var from, to time.Time
+	var err error
+
+	if f.From != "" {
+		if from, err = time.Parse(dateLayout, f.From); err != nil {
+			return errors.New("from: must be a date such as 2024-05-01")
+		}
+	}
+
+	if f.To != "" {
+		if to, err = time.Parse(dateLayout, f.To); err != nil {
+			return errors.New("to: must be a date such as 2024-05-01")
+		}
+	}
+
+	if f.From != "" && f.To != "" && to.Before(from) {
+		return errors.New("to: cannot be before from")
+	}
+
+	return nil
+}
+
+// TimerRequest starts or stops a timer, the note is optional.
+type TimerRequest struct {
+	Note *string `json:"note"`
+}
+
+func (s *TimerRequest) Validate() error {
+	if s.Note != nil && len(*s.Note) > 1000 {
+		return errors.New("note: must be at most 1000 characters")
+	}
+
+	return nil
+}
+
+// Request logs time manually, the end is given either as ended_at or as
+// duration_minutes from started_at.
+type Request struct {
+	StartedAt       *string `json:"started_at" example:"2024-05-01T09:00:00Z"`
+	EndedAt         *string `json:"ended_at" example:"2024-05-01T10:30:00Z"`
+	DurationMinutes *int    `json:"duration_minutes" example:"90"`
+	Note            *string `json:"note"`
+}
+
+func (s *Request) Validate() error {
+	if s.StartedAt == nil || *s.StartedAt == "" {
+		return errors.New("started_at: cannot be blank")
+	}
+
+	startedAt, err := time.Parse(time.RFC3339, *s.StartedAt)
+	if err != nil {
+		return errors.New("started_at: must be a time such as 2024-05-01T09:00:00Z")
+	}
+
+	if (s.EndedAt == nil) == (s.DurationMinutes == nil) {
+		return errors.New("ended_at: either ended_at or duration_minutes must be set")
+	}
+
+	if s.EndedAt != nil {
+		endedAt, err := time.Parse(time.RFC3339, *s.EndedAt)
+		if err != nil {
+			return errors.New("ended_at: must be a time such as 2024-05-01T10:30:00Z")
+		}
+		if endedAt.Before(startedAt) {
+			return errors.New("ended_at: cannot be before started_at")
+		}
+	}
+
+	if s.DurationMinutes != nil && *s.DurationMinutes <= 0 {
+		return errors.New("duration_minutes: must be positive")
+	}
+
+	startedAt, endedAt := s.Interval()
+	if endedAt.Sub(startedAt) > maxEntry {
+		return errors.New("ended_at: an entry can log at most 24 hours")
+	}
+	if endedAt.After(time.Now()) {
+		return errors.New("ended_at: cannot be in the future")
+	}
+
+	if s.Note != nil && len(*s.Note) > 1000 {
+		return errors.New("note: must be at most 1000 characters")
+	}
+
+	return nil
+}
+
+// Interval returns the start and the end of a validated request in UTC.
+func (s *Request) Interval() (startedAt, endedAt time.Time) {
+	startedAt, _ = time.Parse(time.RFC3339, *s.StartedAt)
+	startedAt = startedAt.UTC()
+
+	if s.EndedAt != nil {
+		endedAt, _ = time.Parse(time.RFC3339, *s.EndedAt)
+		endedAt = endedAt.UTC()
+	} else {
+		endedAt = startedAt.Add(time.Duration(*s.DurationMinutes) * time.Minute)
+	}
+
+	return
+}
+
+type Response struct {
+	ID              string `json:"id"`
+	UserID          string `json:"user_id"`
+	TaskID          string `json:"task_id"`
+	StartedAt       string `json:"started_at"`
+	EndedAt         string `json:"ended_at,omitempty"`
+	DurationSeconds int64  `json:"duration_seconds"`
+	Note            string `json:"note,omitempty"`
+	Running         bool   `json:"running"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:      data.ID,
+		UserID:  data.UserID,
+		TaskID:  data.TaskID,
+		Running: data.EndedAt == nil,
+	}
+	if data.StartedAt != nil {
+		res.StartedAt = *data.StartedAt
+	}
+	if data.EndedAt != nil {
+		res.EndedAt = *data.EndedAt
+	}
+	if data.DurationSeconds != nil {
+		res.DurationSeconds = *data.DurationSeconds
+	}
+	if data.Note != nil {
+		res.Note = *data.Note
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
@@ -0,0 +1,23 @@
+package timelog
+
+// Entity is time a user spent on a task. A running timer has no EndedAt and
+// DurationSeconds yet, both are set when it is stopped.
+type Entity struct {
+	ID              string  `db:"id"`
+	UserID          string  `db:"user_id"`
+	TaskID          string  `db:"task_id"`
+	StartedAt       *string `db:"started_at"`
+	EndedAt         *string `db:"ended_at"`
+	DurationSeconds *int64  `db:"duration_seconds"`
+	Note            *string `db:"note"`
+}
+
+// Total is the time a user logged on a task, running timers count up to now.
+type Total struct {
+	UserID    string  `db:"user_id"`
+	TaskID    string  `db:"task_id"`
+	ProjectID *string `db:"project_id"`
+	Title     *string `db:"title"`
+	Seconds   int64   `db:"seconds"`
+	Running   bool    `db:"running"`
+}
@@ -0,0 +1,31 @@
+package timelog
+
+import (
+	"context"
+	"hard/pkg/store"
+)
+
+type Repository interface {
+	List(ctx context.Context, taskID string, page store.Page) (dest []Entity, cursor store.Cursor, err error)
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	// GetRunning returns the running timer of the user.
+	GetRunning(ctx context.Context, userID string) (dest Entity, err error)
+	Add(ctx context.Context, data Entity) (id string, err error)
+	// Start adds a running timer started now.
+	Start(ctx context.Context, data Entity) (id string, err error)
+	// Stop ends the running timer now, a non-nil note replaces its note.
+	Stop(ctx context.Context, id string, note *string) (err error)
+	Delete(ctx context.Context, id string) (err error)
+	Totals(ctx context.Context, filter Filter) (dest []Total, err error)
+}
+
+/*
+POST /tasks/{id}/timer/start: запустить таймер.
+POST /tasks/{id}/timer/stop: остановить таймер.
+GET /tasks/{id}/time: получить итоги по задаче.
+GET /tasks/{id}/time/entries: получить записи времени задачи.
+POST /tasks/{id}/time/entries: записать время вручную.
+DELETE /tasks/{id}/time/entries/{entry_id}: удалить запись времени.
+GET /users/{id}/time?from=&to=: получить итоги пользователя за период.
+GET /projects/{id}/time: получить итоги по проекту.
+*/
@@ -0,0 +1,227 @@
+package timelog
+
+import (
+	"hard/internal/domain/task"
+	"math"
+	"sort"
+)
+
+// UserHours is the time a user logged.
+type UserHours struct {
+	UserID string  `json:"user_id"`
+	Hours  float64 `json:"hours"`
+}
+
+// TaskHours is the time logged on a task, compared against its estimate when
+// the task has one.
+type TaskHours struct {
+	TaskID        string   `json:"task_id"`
+	Title         string   `json:"title,omitempty"`
+	ProjectID     string   `json:"project_id,omitempty"`
+	EstimateHours *float64 `json:"estimate_hours,omitempty"`
+	LoggedHours   float64  `json:"logged_hours"`
+	OverEstimate  bool     `json:"over_estimate"`
+}
+
+// ProjectHours is the time a user logged on the tasks of a project.
+type ProjectHours struct {
+	ProjectID string  `json:"project_id"`
+	Hours     float64 `json:"hours"`
+}
+
+// Estimate compares logged time with an estimate. Remaining is what is left
+// of the estimate, overrun is the time logged past it.
+type Estimate struct {
+	EstimateHours  *float64 `json:"estimate_hours,omitempty"`
+	LoggedHours    float64  `json:"logged_hours"`
+	RemainingHours *float64 `json:"remaining_hours,omitempty"`
+	OverrunHours   float64  `json:"overrun_hours"`
+	OverEstimate   bool     `json:"over_estimate"`
+}
+
+type TaskSummary struct {
+	TaskID string `json:"task_id"`
+	Estimate
+	// Running is set while someone's timer runs on the task, its time so far
+	// is included.
+	Running bool        `json:"running"`
+	Users   []UserHours `json:"users"`
+}
+
+type ProjectSummary struct {
+	ProjectID string `json:"project_id"`
+	// Estimate compares the sum of the task estimates with all time logged
+	// on the project.
+	Estimate
+	Tasks []TaskHours `json:"tasks"`
+	Users []UserHours `json:"users"`
+}
+
+type UserSummary struct {
+	UserID      string         `json:"user_id"`
+	From        string         `json:"from,omitempty"`
+	To          string         `json:"to,omitempty"`
+	LoggedHours float64        `json:"logged_hours"`
+	Running     bool           `json:"running"`
+	Tasks       []TaskHours    `json:"tasks"`
+	Projects    []ProjectHours `json:"projects"`
+}
+
+// SummarizeTask totals the time logged on the task per user.
+func SummarizeTask(data task.Entity, totals []Total) (res TaskSummary) {
+	res = TaskSummary{
+		TaskID: data.ID,
+		Users:  make([]UserHours, 0),
+	}
+
+	var seconds int64
+	users := make(map[string]int64)
+	for _, total := range totals {
+		seconds += total.Seconds
+		users[total.UserID] += total.Seconds
+		res.Running = res.Running || total.Running
+	}
+
+	res.Estimate = compare(data.EstimateHours, seconds)
+	res.Users = userHours(users)
+
+	return
+}
+
+// SummarizeProject totals the time logged on the tasks of the project, tasks
+// without logged time are listed when they have an estimate.
+func SummarizeProject(projectID string, tasks []task.Entity, totals []Total) (res ProjectSummary) {
+	res = ProjectSummary{
+		ProjectID: projectID,
+		Tasks:     make([]TaskHours, 0),
+		Users:     make([]UserHours, 0),
+	}
+
+	var seconds int64
+	perTask := make(map[string]int64)
+	users := make(map[string]int64)
+	for _, total := range totals {
+		seconds += total.Seconds
+		perTask[total.TaskID] += total.Seconds
+		users[total.UserID] += total.Seconds
+	}
+
+	var estimate *float64
+	for _, data := range tasks {
+		logged, ok := perTask[data.ID]
+		if !ok && data.EstimateHours == nil {
+			continue
+		}
+		if data.EstimateHours != nil {
+			sum := *data.EstimateHours
+			if estimate != nil {
+				sum += *estimate
+			}
+			estimate = &sum
+		}
+
+		object := TaskHours{
+			TaskID:        data.ID,
+			EstimateHours: data.EstimateHours,
+			LoggedHours:   hours(logged),
+		}
+		if data.Title != nil {
+			object.Title = *data.Title
+		}
+		object.OverEstimate = compare(data.EstimateHours, logged).OverEstimate
+		res.Tasks = append(res.Tasks, object)
+	}
+
+	res.Estimate = compare(estimate, seconds)
+	res.Users = userHours(users)
+
+	return
+}
+
+// SummarizeUser totals the time the user logged per task and per project.
+func SummarizeUser(userID string, filter Filter, totals []Total) (res UserSummary) {
+	res = UserSummary{
+		UserID:   userID,
+		From:     filter.From,
+		To:       filter.To,
+		Tasks:    make([]TaskHours, 0),
+		Projects: make([]ProjectHours, 0),
+	}
+
+	var seconds int64
+	projects := make(map[string]int64)
+	for _, total := range totals {
+		seconds += total.Seconds
+		res.Running = res.Running || total.Running
+
+		object := TaskHours{
+			TaskID:      total.TaskID,
+			LoggedHours: hours(total.Seconds),
+		}
+		if total.Title != nil {
+			object.Title = *total.Title
+		}
+		if total.ProjectID != nil {
+			object.ProjectID = *total.ProjectID
+			projects[*total.ProjectID] += total.Seconds
+		}
+		res.Tasks = append(res.Tasks, object)
+	}
+	res.LoggedHours = hours(seconds)
+
+	sort.SliceStable(res.Tasks, func(i, j int) bool {
+		return res.Tasks[i].LoggedHours > res.Tasks[j].LoggedHours
+	})
+
+	for projectID, seconds := range projects {
+		res.Projects = append(res.Projects, ProjectHours{ProjectID: projectID, Hours: hours(seconds)})
+	}
+	sort.Slice(res.Projects, func(i, j int) bool {
+		if res.Projects[i].Hours != res.Projects[j].Hours {
+			return res.Projects[i].Hours > res.Projects[j].Hours
+		}
+		return res.Projects[i].ProjectID < res.Projects[j].ProjectID
+	})
+
+	return
+}
+
+func compare(estimate *float64, seconds int64) (res Estimate) {
+	res = Estimate{
+		EstimateHours: estimate,
+		LoggedHours:   hours(seconds),
+	}
+	if estimate == nil {
+		return
+	}
+
+	remaining := math.Max(round(*estimate-res.LoggedHours), 0)
+	res.RemainingHours = &remaining
+	res.OverrunHours = math.Max(round(res.LoggedHours-*estimate), 0)
+	res.OverEstimate = res.OverrunHours > 0
+
+	return
+}
+
+func userHours(users map[string]int64) (res []UserHours) {
+	res = make([]UserHours, 0, len(users))
+	for userID, seconds := range users {
+		res = append(res, UserHours{UserID: userID, Hours: hours(seconds)})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Hours != res[j].Hours {
+			return res[i].Hours > res[j].Hours
+		}
+		return res[i].UserID < res[j].UserID
+	})
+	return
+}
+
+// hours converts seconds to hours rounded to hundredths.
+func hours(seconds int64) float64 {
+	return round(float64(seconds) / 3600)
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
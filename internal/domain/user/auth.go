@@ -0,0 +1,44 @@
+package user
+
+import (
+	"errors"
+	"time"
+)
+
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+func (s *LoginRequest) Validate() error {
+	if s.Email == "" {
+		return errors.New("email: cannot be blank")
+	}
+
+	return nil
+}
+
+// PasswordRequest sets the login password of a user. bcrypt only reads the
+// first 72 bytes, longer passwords are refused instead of being cut.
+type PasswordRequest struct {
+	Password string `json:"password"`
+}
+
+func (s *PasswordRequest) Validate() error {
+	if len(s.Password) < 8 {
+		return errors.New("password: must be at least 8 characters")
+	}
+
+	if len(s.Password) > 72 {
+		return errors.New("password: must be at most 72 bytes")
+	}
+
+	return nil
+}
+
+type TokenResponse struct {
+	AccessToken string    `json:"access_token"`
+	TokenType   string    `json:"token_type"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	User        Response  `json:"user"`
+}
@@ -27,11 +27,17 @@ func (s *Request) Validate() error {
 	return nil
 }
 
+func (s *Request) IsEmpty() bool {
+	return s.FullName == nil && s.Email == nil && s.Role == nil
+}
+
 type Response struct {
-	ID       string `json:"id"`
-	FullName string `json:"full_name"`
-	Email    string `json:"email"`
-	Role     string `json:"role"`
+	ID        string `json:"id"`
+	FullName  string `json:"full_name"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	DeletedAt string `json:"deleted_at,omitempty"`
+	Version   int    `json:"version,omitempty"`
 }
 
 func ParseFromEntity(data Entity) (res Response) {
@@ -41,6 +47,12 @@ func ParseFromEntity(data Entity) (res Response) {
 		Email:    *data.Email,
 		Role:     *data.Role,
 	}
+	if data.DeletedAt != nil {
+		res.DeletedAt = *data.DeletedAt
+	}
+	if data.Version != nil {
+		res.Version = *data.Version
+	}
 	return
 }
 
@@ -1,8 +1,10 @@
 package user
 
 type Entity struct {
-	ID       string  `db:"id"`
-	FullName *string `db:"full_name"`
-	Email    *string `db:"email"`
-	Role     *string `db:"role"`
+	ID        string  `db:"id"`
+	FullName  *string `db:"full_name"`
+	Email     *string `db:"email"`
+	Role      *string `db:"role"`
+	DeletedAt *string `db:"deleted_at"`
+	Version   *int    `db:"version"`
 }
@@ -2,17 +2,28 @@ package user
 
 import (
 	"context"
+	"hard/internal/domain/project"
 	"hard/internal/domain/task"
+	"hard/pkg/store"
+	"time"
 )
 
 type Repository interface {
-	List(ctx context.Context) (dest []Entity, err error)
+	List(ctx context.Context, page store.Page) (dest []Entity, cursor store.Cursor, err error)
 	Add(ctx context.Context, data Entity) (id string, err error)
+	AddMany(ctx context.Context, data []Entity) (ids []string, err error)
 	Get(ctx context.Context, id string) (data Entity, err error)
+	GetMany(ctx context.Context, ids []string) (dest []Entity, err error)
+	GetByEmail(ctx context.Context, email string) (data Entity, err error)
+	GetPasswordHash(ctx context.Context, id string) (hash string, err error)
+	SetPasswordHash(ctx context.Context, id string, hash string) (err error)
 	Update(ctx context.Context, id string, data Entity) (err error)
-	Delete(ctx context.Context, id string) (err error)
-	Search(ctx context.Context, name string, email string) (data []Entity, err error)
-	ListTasks(ctx context.Context, id string) (data []task.Entity, err error)
+	Delete(ctx context.Context, id string, version *int) (err error)
+	Restore(ctx context.Context, id string) (err error)
+	Purge(ctx context.Context, retention time.Duration) (n int64, err error)
+	Search(ctx context.Context, name string, email string, page store.Page) (data []Entity, cursor store.Cursor, err error)
+	ListTasks(ctx context.Context, id string, page store.Page) (data []task.Entity, cursor store.Cursor, err error)
+	ListProjects(ctx context.Context, id string, page store.Page) (data []project.Entity, cursor store.Cursor, err error)
 }
 
 /*
@@ -20,8 +31,11 @@ GET /users: получить список всех пользователей.
 POST /users: создать нового пользователя.
 GET /users/{id}: получить данные конкретного пользователя.
 PUT /users/{id}: обновить данные конкретного пользователя.
+PUT /users/{id}/password: задать пароль конкретного пользователя.
 DELETE /users/{id}: удалить конкретного пользователя.
+POST /users/{id}/restore: восстановить удаленного пользователя.
 GET /users/{id}/tasks: получить список задач конкретного пользователя.
+GET /users/{id}/projects: получить список проектов, в которых участвует пользователь.
 GET /users/search?name={name}: найти пользователей по имени.
 GET /users/search?email={email}: найти пользователей по электронной почте.
 */
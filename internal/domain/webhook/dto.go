@@ -0,0 +1,354 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hard/internal/domain/audit"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var ErrorPending = errors.New("delivery is still pending")
+
+const (
+	StatusPending   = "pending"
+	StatusDelivered = "delivered"
+	StatusDead      = "dead"
+)
+
+var Statuses = []string{StatusPending, StatusDelivered, StatusDead}
+
+// EventTaskStatusChanged is raised next to task.updated when the status of a
+// task changes.
+const EventTaskStatusChanged = "task.status_changed"
+
+// actions name the event of each audit operation, such as task.created.
+var actions = map[audit.Operation]string{
+	audit.OperationCreate:  "created",
+	audit.OperationUpdate:  "updated",
+	audit.OperationDelete:  "deleted",
+	audit.OperationRestore: "restored",
+}
+
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Limits are how long a receiver has to answer and how many times a delivery
+// is attempted before it is dead.
+type Limits struct {
+	Timeout     time.Duration
+	MaxAttempts int
+}
+
+var DefaultLimits = Limits{Timeout: 10 * time.Second, MaxAttempts: 8}
+
+const (
+	backoffBase = 30 * time.Second
+	backoffMax  = time.Hour
+)
+
+// EventName is the event raised by an audited change, such as task.created.
+func EventName(entity string, operation audit.Operation) string {
+	return entity + "." + actions[operation]
+}
+
+// Patterns are the subscriptions that match an event: *, the entity
+// wildcard and the event itself.
+func Patterns(event string) []string {
+	entity, _, _ := strings.Cut(event, ".")
+	return []string{"*", entity + ".*", event}
+}
+
+func isPattern(pattern string) bool {
+	if pattern == "*" || pattern == EventTaskStatusChanged {
+		return true
+	}
+
+	entity, action, ok := strings.Cut(pattern, ".")
+	if !ok || !isEntity(entity) {
+		return false
+	}
+	if action == "*" {
+		return true
+	}
+	for _, a := range actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+func isEntity(entity string) bool {
+	for _, e := range audit.Entities {
+		if e == entity {
+			return true
+		}
+	}
+	return false
+}
+
+// Sign is the signature of a delivery body sent in the X-Webhook-Signature
+// header: sha256= followed by the hex HMAC-SHA256 of the body with the
+// secret of the webhook.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff is how long to wait after the given failed attempt, doubling from
+// 30 seconds up to an hour.
+func Backoff(attempt int) time.Duration {
+	delay := backoffBase
+	for i := 1; i < attempt && delay < backoffMax; i++ {
+		delay *= 2
+	}
+	if delay > backoffMax {
+		delay = backoffMax
+	}
+	return delay
+}
+
+// Failed is the outcome of a failed attempt of the job, the delivery is dead
+// once it has been attempted maxAttempts times.
+func (j Job) Failed(maxAttempts int, statusCode *int, err error) (res Attempt) {
+	message := err.Error()
+	res = Attempt{Status: StatusPending, StatusCode: statusCode, Error: &message}
+
+	attempt := j.Attempts + 1
+	if attempt >= maxAttempts {
+		res.Status = StatusDead
+		return
+	}
+	res.RetryIn = Backoff(attempt)
+
+	return
+}
+
+// GenerateSecret returns a random secret for a webhook created without one.
+func GenerateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Redacted is the webhook as recorded in the audit log, the secret is
+// replaced by a short fingerprint so that a rotation still shows.
+func (e Entity) Redacted() Entity {
+	if e.Secret != nil {
+		sum := sha256.Sum256([]byte(*e.Secret))
+		fingerprint := "sha256:" + hex.EncodeToString(sum[:4])
+		e.Secret = &fingerprint
+	}
+	return e
+}
+
+type Request struct {
+	URL    *string  `json:"url"`
+	Events []string `json:"events"`
+	Secret *string  `json:"secret"`
+	Active *bool    `json:"active"`
+}
+
+func (s *Request) Validate() error {
+	if s.URL == nil {
+		return errors.New("url: cannot be blank")
+	}
+
+	if s.Secret != nil && strings.TrimSpace(*s.Secret) == "" {
+		s.Secret = nil
+	}
+
+	return s.ValidateUpdate()
+}
+
+// ValidateUpdate checks only the fields present in a partial update.
+func (s *Request) ValidateUpdate() error {
+	if s.URL != nil {
+		if len(*s.URL) > 2048 {
+			return errors.New("url: must be at most 2048 characters")
+		}
+		u, err := url.Parse(*s.URL)
+		if err != nil || u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+			return errors.New("url: must be an absolute http or https URL")
+		}
+	}
+
+	if s.Events != nil && len(s.Events) == 0 {
+		return errors.New("events: cannot be empty")
+	}
+	for _, pattern := range s.Events {
+		if !isPattern(pattern) {
+			return fmt.Errorf("events: unknown event %q", pattern)
+		}
+	}
+
+	if s.Secret != nil {
+		if secret := strings.TrimSpace(*s.Secret); secret == "" {
+			return errors.New("secret: cannot be blank")
+		} else if len(secret) < 16 {
+			return errors.New("secret: must be at least 16 characters")
+		} else if len(secret) > 255 {
+			return errors.New("secret: must be at most 255 characters")
+		}
+	}
+
+	return nil
+}
+
+func (s *Request) IsEmpty() bool {
+	return s.URL == nil && s.Events == nil && s.Secret == nil && s.Active == nil
+}
+
+type Response struct {
+	ID     string   `json:"id"`
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Active bool     `json:"active"`
+	// Secret is only returned when the webhook is created.
+	Secret    string `json:"secret,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:     data.ID,
+		Events: data.Events,
+	}
+	if data.URL != nil {
+		res.URL = *data.URL
+	}
+	if data.Active != nil {
+		res.Active = *data.Active
+	}
+	if data.CreatedAt != nil {
+		res.CreatedAt = *data.CreatedAt
+	}
+	if data.UpdatedAt != nil {
+		res.UpdatedAt = *data.UpdatedAt
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
+
+// DeliveryFilter narrows the delivery log of a webhook down to a status.
+type DeliveryFilter struct {
+	Status string
+}
+
+func (f *DeliveryFilter) Validate() error {
+	if f.Status == "" {
+		return nil
+	}
+	for _, status := range Statuses {
+		if status == f.Status {
+			return nil
+		}
+	}
+	return errors.New("status: must be one of " + strings.Join(Statuses, ", "))
+}
+
+type DeliveryResponse struct {
+	ID             string `json:"id"`
+	WebhookID      string `json:"webhook_id"`
+	EventID        string `json:"event_id"`
+	Event          string `json:"event"`
+	Entity         string `json:"entity"`
+	EntityID       string `json:"entity_id"`
+	Status         string `json:"status"`
+	Attempts       int    `json:"attempts"`
+	NextAttemptAt  string `json:"next_attempt_at,omitempty"`
+	LastAttemptAt  string `json:"last_attempt_at,omitempty"`
+	LastStatusCode *int   `json:"last_status_code"`
+	LastError      string `json:"last_error,omitempty"`
+	DeliveredAt    string `json:"delivered_at,omitempty"`
+	CreatedAt      string `json:"created_at,omitempty"`
+}
+
+func ParseFromDelivery(data Delivery) (res DeliveryResponse) {
+	res = DeliveryResponse{
+		ID:             data.ID,
+		WebhookID:      data.WebhookID,
+		EventID:        data.EventID,
+		Event:          data.Event,
+		Entity:         data.Entity,
+		EntityID:       data.EntityID,
+		Status:         data.Status,
+		Attempts:       data.Attempts,
+		LastStatusCode: data.LastStatusCode,
+	}
+	if data.NextAttemptAt != nil && data.Status == StatusPending {
+		res.NextAttemptAt = *data.NextAttemptAt
+	}
+	if data.LastAttemptAt != nil {
+		res.LastAttemptAt = *data.LastAttemptAt
+	}
+	if data.LastError != nil {
+		res.LastError = *data.LastError
+	}
+	if data.DeliveredAt != nil {
+		res.DeliveredAt = *data.DeliveredAt
+	}
+	if data.CreatedAt != nil {
+		res.CreatedAt = *data.CreatedAt
+	}
+	return
+}
+
+func ParseFromDeliveries(data []Delivery) (res []DeliveryResponse) {
+	res = make([]DeliveryResponse, 0)
+	for _, object := range data {
+		res = append(res, ParseFromDelivery(object))
+	}
+	return
+}
+
+// Payload is the JSON body of a delivery.
+type Payload struct {
+	ID        string     `json:"id"`
+	Event     string     `json:"event"`
+	Entity    string     `json:"entity"`
+	EntityID  string     `json:"entity_id"`
+	ActorID   string     `json:"actor_id,omitempty"`
+	Changes   audit.Diff `json:"changes" swaggertype:"object"`
+	CreatedAt string     `json:"created_at,omitempty"`
+}
+
+func ParsePayload(data Event) (res Payload) {
+	res = Payload{
+		ID:       data.ID,
+		Event:    data.Event,
+		Entity:   data.Entity,
+		EntityID: data.EntityID,
+		Changes:  data.Changes,
+	}
+	if data.ActorID != nil {
+		res.ActorID = *data.ActorID
+	}
+	if data.CreatedAt != nil {
+		res.CreatedAt = *data.CreatedAt
+	}
+	if res.Changes == nil {
+		res.Changes = audit.Diff{}
+	}
+	return
+}
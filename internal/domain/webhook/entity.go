@@ -0,0 +1,85 @@
+package webhook
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"hard/internal/domain/audit"
+	"time"
+)
+
+type Entity struct {
+	ID        string  `db:"id"`
+	URL       *string `db:"url"`
+	Events    Events  `db:"events"`
+	Secret    *string `db:"secret"`
+	Active    *bool   `db:"active"`
+	CreatedAt *string `db:"created_at"`
+	UpdatedAt *string `db:"updated_at"`
+}
+
+// Events are the event patterns a webhook is subscribed to.
+type Events []string
+
+func (e Events) Value() (driver.Value, error) {
+	return json.Marshal(e)
+}
+
+func (e *Events) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, e)
+	case string:
+		return json.Unmarshal([]byte(v), e)
+	default:
+		return errors.New("webhook: unsupported events type")
+	}
+}
+
+// Event is a change raised to the subscribed webhooks, kept in the outbox.
+type Event struct {
+	ID        string     `db:"id"`
+	Event     string     `db:"event"`
+	Entity    string     `db:"entity"`
+	EntityID  string     `db:"entity_id"`
+	ActorID   *string    `db:"actor_id"`
+	Changes   audit.Diff `db:"changes"`
+	CreatedAt *string    `db:"created_at"`
+}
+
+// Delivery is an event sent, or to be sent, to one webhook.
+type Delivery struct {
+	ID             string  `db:"id"`
+	WebhookID      string  `db:"webhook_id"`
+	EventID        string  `db:"event_id"`
+	Event          string  `db:"event"`
+	Entity         string  `db:"entity"`
+	EntityID       string  `db:"entity_id"`
+	Status         string  `db:"status"`
+	Attempts       int     `db:"attempts"`
+	NextAttemptAt  *string `db:"next_attempt_at"`
+	LastAttemptAt  *string `db:"last_attempt_at"`
+	LastStatusCode *int    `db:"last_status_code"`
+	LastError      *string `db:"last_error"`
+	DeliveredAt    *string `db:"delivered_at"`
+	CreatedAt      *string `db:"created_at"`
+}
+
+// Job is a claimed delivery with what is needed to send it.
+type Job struct {
+	DeliveryID string `db:"delivery_id"`
+	WebhookID  string `db:"webhook_id"`
+	Attempts   int    `db:"attempts"`
+	URL        string `db:"url"`
+	Secret     string `db:"secret"`
+	Event      Event  `db:"event"`
+}
+
+// Attempt is the outcome of sending a delivery once.
+type Attempt struct {
+	Status     string
+	StatusCode *int
+	Error      *string
+	// RetryIn is when a pending delivery is sent again.
+	RetryIn time.Duration
+}
@@ -0,0 +1,38 @@
+package webhook
+
+import (
+	"context"
+	"hard/pkg/store"
+	"time"
+)
+
+type Repository interface {
+	List(ctx context.Context, page store.Page) (dest []Entity, cursor store.Cursor, err error)
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	Add(ctx context.Context, data Entity) (id string, err error)
+	Update(ctx context.Context, id string, data Entity) (err error)
+	Delete(ctx context.Context, id string) (err error)
+	// Enqueue writes the event to the outbox with a pending delivery for
+	// every active webhook subscribed to it, nothing when there is none.
+	Enqueue(ctx context.Context, data Event) (err error)
+	// Claim returns up to limit due deliveries and postpones them by lease, so
+	// that other dispatchers skip them until the attempt is completed.
+	Claim(ctx context.Context, limit int, lease time.Duration) (dest []Job, err error)
+	// Complete records an attempt of the delivery.
+	Complete(ctx context.Context, id string, data Attempt) (err error)
+	// ListDeliveries returns the deliveries of the webhook, newest first.
+	ListDeliveries(ctx context.Context, webhookID string, filter DeliveryFilter, page store.Page) (dest []Delivery, cursor store.Cursor, err error)
+	GetDelivery(ctx context.Context, id string) (dest Delivery, err error)
+	// Redeliver makes the delivery pending again with all of its attempts.
+	Redeliver(ctx context.Context, id string) (err error)
+}
+
+/*
+GET /webhooks: получить вебхуки.
+POST /webhooks: создать вебхук.
+GET /webhooks/{id}: получить вебхук.
+PUT /webhooks/{id}: изменить вебхук.
+DELETE /webhooks/{id}: удалить вебхук.
+GET /webhooks/{id}/deliveries: получить журнал доставок вебхука.
+POST /webhooks/{id}/deliveries/{delivery_id}/retry: повторить доставку.
+*/
@@ -0,0 +1,94 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrorUnknownStatus        = errors.New("status is not defined by the project workflow")
+	ErrorTransitionNotAllowed = errors.New("status transition is not allowed by the project workflow")
+)
+
+type Request struct {
+	Statuses    []string            `json:"statuses"`
+	Transitions map[string][]string `json:"transitions"`
+	Terminal    []string            `json:"terminal"`
+}
+
+func (s *Request) Validate() error {
+	if len(s.Statuses) == 0 {
+		return errors.New("statuses: cannot be blank")
+	}
+
+	seen := map[string]bool{}
+	for _, status := range s.Statuses {
+		if strings.TrimSpace(status) == "" {
+			return errors.New("statuses: cannot contain blank values")
+		}
+		if seen[status] {
+			return fmt.Errorf("statuses: duplicate status %q", status)
+		}
+		seen[status] = true
+	}
+
+	for from, targets := range s.Transitions {
+		if !seen[from] {
+			return fmt.Errorf("transitions: unknown status %q", from)
+		}
+		for _, to := range targets {
+			if !seen[to] {
+				return fmt.Errorf("transitions: unknown status %q", to)
+			}
+		}
+	}
+
+	for _, status := range s.Terminal {
+		if !seen[status] {
+			return fmt.Errorf("terminal: unknown status %q", status)
+		}
+	}
+
+	return nil
+}
+
+func (s *Request) Definition() Definition {
+	d := Definition{
+		Statuses:    s.Statuses,
+		Transitions: s.Transitions,
+		Terminal:    s.Terminal,
+	}
+	if d.Transitions == nil {
+		d.Transitions = map[string][]string{}
+	}
+	if d.Terminal == nil {
+		d.Terminal = []string{}
+	}
+	return d
+}
+
+type Response struct {
+	ProjectID   string              `json:"project_id"`
+	Statuses    []string            `json:"statuses"`
+	Transitions map[string][]string `json:"transitions"`
+	Terminal    []string            `json:"terminal"`
+	Default     bool                `json:"default"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ProjectID:   data.ProjectID,
+		Statuses:    data.Definition.Statuses,
+		Transitions: data.Definition.Transitions,
+		Terminal:    data.Definition.Terminal,
+	}
+	return
+}
+
+// ParseFromDefault describes the default workflow of a project.
+func ParseFromDefault(projectID string) (res Response) {
+	res = ParseFromEntity(Entity{ProjectID: projectID, Definition: Default})
+	res.Default = true
+	return
+}
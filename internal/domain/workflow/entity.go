@@ -0,0 +1,69 @@
+package workflow
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+type Entity struct {
+	ProjectID  string     `db:"project_id"`
+	Definition Definition `db:"definition"`
+}
+
+// Definition lists the statuses a task of the project may have, the allowed
+// transitions between them and the statuses that complete a task.
+type Definition struct {
+	Statuses    []string            `json:"statuses"`
+	Transitions map[string][]string `json:"transitions"`
+	Terminal    []string            `json:"terminal"`
+}
+
+// Default is used by projects that have not defined their own workflow.
+var Default = Definition{
+	Statuses: []string{"Active", "Review", "Done"},
+	Transitions: map[string][]string{
+		"Active": {"Review", "Done"},
+		"Review": {"Active", "Done"},
+		"Done":   {"Active"},
+	},
+	Terminal: []string{"Done"},
+}
+
+func (d Definition) HasStatus(status string) bool {
+	return contains(d.Statuses, status)
+}
+
+func (d Definition) IsTerminal(status string) bool {
+	return contains(d.Terminal, status)
+}
+
+// CanTransition reports whether a task may move from one status to another.
+// Keeping the current status is always allowed.
+func (d Definition) CanTransition(from, to string) bool {
+	return from == to || contains(d.Transitions[from], to)
+}
+
+func (d Definition) Value() (driver.Value, error) {
+	return json.Marshal(d)
+}
+
+func (d *Definition) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, d)
+	case string:
+		return json.Unmarshal([]byte(v), d)
+	default:
+		return errors.New("workflow: unsupported definition type")
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
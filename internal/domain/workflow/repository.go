@@ -0,0 +1,9 @@
+package workflow
+
+import "context"
+
+type Repository interface {
+	Get(ctx context.Context, projectID string) (dest Entity, err error)
+	Save(ctx context.Context, data Entity) (err error)
+	Delete(ctx context.Context, projectID string) (err error)
+}
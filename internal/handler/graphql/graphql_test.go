@@ -0,0 +1,303 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"hard/internal/domain/project"
+	"hard/internal/domain/task"
+	"hard/internal/domain/user"
+	"hard/internal/service/tasker"
+	"hard/pkg/helpers"
+	"hard/pkg/store"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockUserRepository mocks the methods the tests call, the others panic.
+type MockUserRepository struct {
+	mock.Mock
+	user.Repository
+}
+
+func (m *MockUserRepository) GetMany(ctx context.Context, ids []string) (dest []user.Entity, err error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]user.Entity), args.Error(1)
+}
+
+type MockProjectRepository struct {
+	mock.Mock
+	project.Repository
+}
+
+func (m *MockProjectRepository) List(ctx context.Context, page store.Page) (dest []project.Entity, cursor store.Cursor, err error) {
+	args := m.Called(ctx, page)
+	return args.Get(0).([]project.Entity), args.Get(1).(store.Cursor), args.Error(2)
+}
+
+func (m *MockProjectRepository) GetMany(ctx context.Context, ids []string) (dest []project.Entity, err error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]project.Entity), args.Error(1)
+}
+
+type MockTaskRepository struct {
+	mock.Mock
+	task.Repository
+}
+
+func (m *MockTaskRepository) List(ctx context.Context, page store.Page) (dest []task.Entity, cursor store.Cursor, err error) {
+	args := m.Called(ctx, page)
+	return args.Get(0).([]task.Entity), args.Get(1).(store.Cursor), args.Error(2)
+}
+
+func (m *MockTaskRepository) ListByProjects(ctx context.Context, projectIDs []string, limit int) (dest map[string][]task.Entity, err error) {
+	args := m.Called(ctx, projectIDs, limit)
+	return args.Get(0).(map[string][]task.Entity), args.Error(1)
+}
+
+func newUser(id, name string) user.Entity {
+	return user.Entity{ID: id, FullName: helpers.GetStringPtr(name), Email: helpers.GetStringPtr(id + "@example.com"), Role: helpers.GetStringPtr("user")}
+}
+
+func newProject(id, title, managerID string) project.Entity {
+	description := ""
+	return project.Entity{ID: id, Title: helpers.GetStringPtr(title), Description: &description, ManagerID: helpers.GetStringPtr(managerID)}
+}
+
+func newTask(id, projectID, assigneeID string) task.Entity {
+	description := ""
+	return task.Entity{ID: id, Title: helpers.GetStringPtr("Task " + id), Description: &description, Priority: helpers.GetStringPtr("Low"), Status: helpers.GetStringPtr("Active"), ProjectID: helpers.GetStringPtr(projectID), AssigneeID: helpers.GetStringPtr(assigneeID)}
+}
+
+// serve runs the query against the endpoint and returns the status and body.
+func serve(t *testing.T, s *tasker.Service, limits Limits, body string) (int, map[string]any) {
+	h, err := NewHandler(s, limits)
+	assert.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	h.Routes(r.Group(""))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewBufferString(body)))
+
+	var res map[string]any
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	return w.Code, res
+}
+
+func TestQueryBatchesRelations(t *testing.T) {
+	mockTaskRepo := new(MockTaskRepository)
+	mockTaskRepo.On("List", mock.Anything, mock.Anything).Return([]task.Entity{
+		newTask("1", "10", "1"),
+		newTask("2", "10", "2"),
+		newTask("3", "11", "1"),
+		newTask("4", "11", "9"),
+	}, store.Cursor{Next: "next"}, nil)
+
+	mockUserRepo := new(MockUserRepository)
+	mockUserRepo.On("GetMany", mock.Anything, []string{"1", "2", "9"}).Return([]user.Entity{newUser("1", "John"), newUser("2", "Jane")}, nil).Once()
+	mockUserRepo.On("GetMany", mock.Anything, []string{"3"}).Return([]user.Entity{newUser("3", "Jack")}, nil).Once()
+
+	mockProjectRepo := new(MockProjectRepository)
+	mockProjectRepo.On("GetMany", mock.Anything, []string{"10", "11"}).Return([]project.Entity{newProject("10", "Alpha", "1"), newProject("11", "Beta", "3")}, nil).Once()
+
+	taskService, _ := tasker.New(
+		tasker.WithUserRepository(mockUserRepo),
+		tasker.WithProjectRepository(mockProjectRepo),
+		tasker.WithTaskRepository(mockTaskRepo),
+	)
+
+	code, res := serve(t, taskService, Limits{}, `{"query": "{ tasks { items { id assignee { fullName } project { title manager { fullName } } } nextCursor } }"}`)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Nil(t, res["errors"])
+
+	page := res["data"].(map[string]any)["tasks"].(map[string]any)
+	assert.Equal(t, "next", page["nextCursor"])
+	items := page["items"].([]any)
+	assert.Len(t, items, 4)
+	assert.Equal(t, map[string]any{"fullName": "Jane"}, items[1].(map[string]any)["assignee"])
+	assert.Nil(t, items[3].(map[string]any)["assignee"])
+	assert.Equal(t, map[string]any{"title": "Beta", "manager": map[string]any{"fullName": "Jack"}}, items[2].(map[string]any)["project"])
+
+	// one query per level, the manager already loaded as an assignee is reused
+	mockUserRepo.AssertNumberOfCalls(t, "GetMany", 2)
+	mockProjectRepo.AssertNumberOfCalls(t, "GetMany", 1)
+}
+
+func TestQueryProjectTasks(t *testing.T) {
+	mockProjectRepo := new(MockProjectRepository)
+	mockProjectRepo.On("List", mock.Anything, mock.MatchedBy(func(page store.Page) bool { return page.Limit == 2 })).Return([]project.Entity{newProject("10", "Alpha", "1"), newProject("11", "Beta", "1")}, store.Cursor{}, nil)
+
+	mockTaskRepo := new(MockTaskRepository)
+	mockTaskRepo.On("ListByProjects", mock.Anything, []string{"10", "11"}, 5).Return(map[string][]task.Entity{
+		"10": {newTask("1", "10", "1"), newTask("2", "10", "1")},
+	}, nil).Once()
+
+	taskService, _ := tasker.New(
+		tasker.WithProjectRepository(mockProjectRepo),
+		tasker.WithTaskRepository(mockTaskRepo),
+	)
+
+	code, res := serve(t, taskService, Limits{}, `{"query": "query($n: Int) { projects(first: 2) { items { id tasks(first: $n) { id } } nextCursor } }", "variables": {"n": 5}}`)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Nil(t, res["errors"])
+
+	page := res["data"].(map[string]any)["projects"].(map[string]any)
+	assert.Nil(t, page["nextCursor"])
+	items := page["items"].([]any)
+	assert.Equal(t, []any{map[string]any{"id": "1"}, map[string]any{"id": "2"}}, items[0].(map[string]any)["tasks"])
+	assert.Equal(t, []any{}, items[1].(map[string]any)["tasks"])
+	mockTaskRepo.AssertNumberOfCalls(t, "ListByProjects", 1)
+}
+
+func TestQueryLimits(t *testing.T) {
+	limits := Limits{MaxDepth: 4, MaxComplexity: 100}
+
+	tests := []struct {
+		name         string
+		query        string
+		variables    map[string]any
+		expectedCode int
+	}{
+		{
+			name:         "Within Limits",
+			query:        `{ tasks(first: 10) { items { id title } } }`,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Too Deep",
+			query:        `{ tasks { items { project { manager { id } } } } }`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Too Deep Through Fragment",
+			query:        `{ tasks { items { ...relations } } } fragment relations on Task { project { manager { id } } }`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Too Complex",
+			query:        `{ tasks(first: 50) { items { id title } } }`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Too Complex Through Variable",
+			query:        `query($n: Int) { tasks(first: $n) { items { id title } } }`,
+			variables:    map[string]any{"n": 50},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Too Complex Through Variable Default",
+			query:        `query($n: Int = 100) { tasks(first: $n) { items { id } } }`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Within Limits Through Variable Default",
+			query:        `query($n: Int = 2) { tasks(first: $n) { items { id title } } }`,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Unresolved Variable",
+			query:        `query($n: Int) { tasks(first: $n) { items { id title } } }`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Introspection",
+			query:        `{ __schema { types { name fields { name type { name ofType { name ofType { name } } } } } } }`,
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockTaskRepo.On("List", mock.Anything, mock.Anything).Return([]task.Entity{}, store.Cursor{}, nil)
+
+			taskService, _ := tasker.New(tasker.WithTaskRepository(mockTaskRepo))
+
+			body, _ := json.Marshal(Request{Query: tt.query, Variables: tt.variables})
+			code, res := serve(t, taskService, limits, string(body))
+
+			assert.Equal(t, tt.expectedCode, code)
+			if tt.expectedCode != http.StatusOK {
+				assert.NotEmpty(t, res["errors"])
+				mockTaskRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestQueryErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		expectedCode int
+	}{
+		{
+			name:         "Blank Query",
+			body:         `{"query": ""}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Syntax Error",
+			body:         `{"query": "{ tasks { items { id }"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Unknown Field",
+			body:         `{"query": "{ tasks { items { secret } } }"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Invalid First",
+			body:         `{"query": "{ tasks(first: 500) { items { id } } }"}`,
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskService, _ := tasker.New(tasker.WithTaskRepository(new(MockTaskRepository)))
+
+			code, res := serve(t, taskService, Limits{}, tt.body)
+
+			assert.Equal(t, tt.expectedCode, code)
+			assert.NotEmpty(t, res["errors"])
+		})
+	}
+}
+
+func TestQueryOverGet(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	// the top-level fields resolve in any order
+	mockUserRepo.On("GetMany", mock.Anything, mock.MatchedBy(func(ids []string) bool {
+		return len(ids) == 2 && slices.Contains(ids, "1") && slices.Contains(ids, "2")
+	})).Return([]user.Entity{newUser("1", "John")}, nil).Once()
+
+	taskService, _ := tasker.New(tasker.WithUserRepository(mockUserRepo))
+	h, _ := NewHandler(taskService, Limits{})
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	h.Routes(r.Group(""))
+
+	query := url.Values{
+		"query":     {`query($id: ID!) { user(id: $id) { fullName } missing: user(id: "2") { id } }`},
+		"variables": {`{"id": "1"}`},
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/graphql?"+query.Encode(), nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"data": {"user": {"fullName": "John"}, "missing": null}}`, w.Body.String())
+	mockUserRepo.AssertExpectations(t)
+}
@@ -0,0 +1,114 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"hard/internal/service/tasker"
+	"net/http"
+)
+
+type Handler struct {
+	taskerService *tasker.Service
+	schema        graphql.Schema
+	limits        Limits
+}
+
+func NewHandler(s *tasker.Service, limits Limits) (*Handler, error) {
+	schema, err := newSchema(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Handler{taskerService: s, schema: schema, limits: limits}, nil
+}
+
+// Routes sets up the GraphQL endpoint
+func (h *Handler) Routes(r *gin.RouterGroup) {
+	r.GET("/graphql", h.query)
+	r.POST("/graphql", h.query)
+}
+
+// Request is a GraphQL query with its variables.
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// queryGraphQL godoc
+//
+//	@Summary		Query users, projects and tasks
+//	@Description	Run a GraphQL query over users, projects and tasks and their relations: project.manager, project.tasks, task.assignee, task.project and user.tasks. The relations of every row of a level are loaded with one query. Queries nested too deeply or selecting too many fields are rejected before they run. A GET request passes the query, operationName and variables in the query string
+//	@Tags			graphql
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		Request	true	"Query"
+//	@Success		200		{object}	graphql.Result
+//	@Failure		400		{object}	graphql.Result
+//	@Failure		401		{object}	response.Object
+//	@Router			/graphql [post]
+func (h *Handler) query(c *gin.Context) {
+	req, err := parseRequest(c)
+	if err != nil {
+		reject(c, err)
+		return
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{Source: req.Query})
+	if err != nil {
+		reject(c, err)
+		return
+	}
+
+	if res := graphql.ValidateDocument(&h.schema, doc, nil); !res.IsValid {
+		c.JSON(http.StatusBadRequest, graphql.Result{Errors: res.Errors})
+		return
+	}
+
+	if err = h.limits.check(&h.schema, doc, req.Variables); err != nil {
+		reject(c, err)
+		return
+	}
+
+	res := graphql.Execute(graphql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       withLoaders(c.Request.Context(), h.taskerService),
+	})
+
+	c.JSON(http.StatusOK, res)
+}
+
+// parseRequest reads the query from the JSON body, or from the query string
+// of a GET request.
+func parseRequest(c *gin.Context) (req Request, err error) {
+	if c.Request.Method == http.MethodGet {
+		req.Query = c.Query("query")
+		req.OperationName = c.Query("operationName")
+		if variables := c.Query("variables"); variables != "" {
+			if err = json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				err = errors.New("variables: must be a JSON object")
+				return
+			}
+		}
+	} else if err = c.ShouldBindJSON(&req); err != nil {
+		return
+	}
+
+	if req.Query == "" {
+		err = errors.New("query: cannot be blank")
+	}
+
+	return
+}
+
+// reject answers a query that cannot run in the GraphQL response format.
+func reject(c *gin.Context, err error) {
+	c.JSON(http.StatusBadRequest, graphql.Result{Errors: gqlerrors.FormatErrors(err)})
+}
@@ -0,0 +1,171 @@
+package graphql
+
+import (
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"hard/pkg/store"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Limits bound the cost of a query before it runs, zero disables a limit.
+type Limits struct {
+	// MaxDepth is how deeply the fields of a query may be nested.
+	MaxDepth int
+	// MaxComplexity is how many fields a query may resolve, the selections of a
+	// list count once per row it may return.
+	MaxComplexity int
+}
+
+// check measures every operation of a validated document. Introspection
+// fields are not counted, so that tools can always read the schema.
+func (l Limits) check(schema *graphql.Schema, doc *ast.Document, variables map[string]any) error {
+	m := measure{
+		schema:    schema,
+		variables: variables,
+		fragments: map[string]*ast.FragmentDefinition{},
+	}
+
+	var operations []*ast.OperationDefinition
+	for _, definition := range doc.Definitions {
+		switch d := definition.(type) {
+		case *ast.FragmentDefinition:
+			m.fragments[d.Name.Value] = d
+		case *ast.OperationDefinition:
+			operations = append(operations, d)
+		}
+	}
+
+	for _, operation := range operations {
+		m.defaults = map[string]ast.Value{}
+		for _, definition := range operation.VariableDefinitions {
+			if definition.DefaultValue != nil {
+				m.defaults[definition.Variable.Name.Value] = definition.DefaultValue
+			}
+		}
+
+		depth, complexity := m.selections(schema.QueryType(), operation.SelectionSet)
+		if l.MaxDepth > 0 && depth > l.MaxDepth {
+			return fmt.Errorf("query depth %d exceeds the limit of %d", depth, l.MaxDepth)
+		}
+		if l.MaxComplexity > 0 && complexity > l.MaxComplexity {
+			return fmt.Errorf("query complexity %d exceeds the limit of %d", complexity, l.MaxComplexity)
+		}
+	}
+
+	return nil
+}
+
+type measure struct {
+	schema    *graphql.Schema
+	variables map[string]any
+	// defaults are the default values of the variables of the operation
+	defaults  map[string]ast.Value
+	fragments map[string]*ast.FragmentDefinition
+}
+
+// selections returns the depth and the complexity of the selections made on a
+// value of type parent. Validation has ruled out unknown fields and fragment
+// cycles.
+func (m measure) selections(parent graphql.Type, set *ast.SelectionSet) (depth, complexity int) {
+	if set == nil {
+		return
+	}
+
+	for _, selection := range set.Selections {
+		var d, c int
+		switch s := selection.(type) {
+		case *ast.Field:
+			object, ok := parent.(*graphql.Object)
+			if !ok || strings.HasPrefix(s.Name.Value, "__") {
+				continue
+			}
+			definition, ok := object.Fields()[s.Name.Value]
+			if !ok {
+				continue
+			}
+			d, c = m.selections(unwrap(definition.Type), s.SelectionSet)
+			d, c = d+1, 1+m.rows(definition, s)*c
+		case *ast.InlineFragment:
+			t := parent
+			if s.TypeCondition != nil {
+				t = m.schema.Type(s.TypeCondition.Name.Value)
+			}
+			d, c = m.selections(t, s.SelectionSet)
+		case *ast.FragmentSpread:
+			if f, ok := m.fragments[s.Name.Value]; ok {
+				d, c = m.selections(m.schema.Type(f.TypeCondition.Name.Value), f.SelectionSet)
+			}
+		}
+		depth = max(depth, d)
+		complexity += c
+	}
+
+	return
+}
+
+// rows is how many rows a field taking the first argument may return, one for
+// the other fields.
+func (m measure) rows(definition *graphql.FieldDefinition, field *ast.Field) int {
+	paged := false
+	for _, arg := range definition.Args {
+		paged = paged || arg.Name() == "first"
+	}
+	if !paged {
+		return 1
+	}
+
+	for _, arg := range field.Arguments {
+		if arg.Name.Value == "first" {
+			return m.limit(arg.Value)
+		}
+	}
+
+	return store.DefaultLimit
+}
+
+// limit is the page size a first argument asks for, a variable is read from
+// the request or else from its default like the executor does. A value that
+// is not a known integer counts as the largest page.
+func (m measure) limit(value ast.Value) int {
+	if v, ok := value.(*ast.Variable); ok {
+		if provided, ok := m.variables[v.Name.Value]; ok {
+			switch n := provided.(type) {
+			case float64:
+				if n == math.Trunc(n) {
+					return store.Page{Limit: int(n)}.Size()
+				}
+			case int:
+				return store.Page{Limit: n}.Size()
+			}
+			return store.MaxLimit
+		}
+		if value, ok = m.defaults[v.Name.Value]; !ok {
+			return store.MaxLimit
+		}
+	}
+
+	if v, ok := value.(*ast.IntValue); ok {
+		if n, err := strconv.Atoi(v.Value); err == nil {
+			return store.Page{Limit: n}.Size()
+		}
+	}
+
+	return store.MaxLimit
+}
+
+// unwrap returns the named type of a list or non-null type.
+func unwrap(t graphql.Type) graphql.Type {
+	for {
+		switch wrapper := t.(type) {
+		case *graphql.List:
+			t = wrapper.OfType
+		case *graphql.NonNull:
+			t = wrapper.OfType
+		default:
+			return t
+		}
+	}
+}
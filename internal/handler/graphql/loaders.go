@@ -0,0 +1,60 @@
+package graphql
+
+import (
+	"context"
+	"hard/internal/domain/project"
+	"hard/internal/domain/task"
+	"hard/internal/domain/user"
+	"hard/internal/service/tasker"
+	"hard/pkg/loader"
+)
+
+type loadersKey struct{}
+
+// loaders batch the relation lookups of a request. The task lists are loaded
+// per limit, since one query applies the same limit to every row.
+type loaders struct {
+	ctx           context.Context
+	taskerService *tasker.Service
+
+	users    *loader.Loader[string, user.Response]
+	projects *loader.Loader[string, project.Response]
+
+	byProject map[int]*loader.Loader[string, []task.Response]
+	byUser    map[int]*loader.Loader[string, []task.Response]
+}
+
+// withLoaders returns a context carrying new loaders for a request.
+func withLoaders(ctx context.Context, s *tasker.Service) context.Context {
+	l := &loaders{
+		ctx:           ctx,
+		taskerService: s,
+		users:         loader.New(ctx, s.GetUsers),
+		projects:      loader.New(ctx, s.GetProjects),
+		byProject:     map[int]*loader.Loader[string, []task.Response]{},
+		byUser:        map[int]*loader.Loader[string, []task.Response]{},
+	}
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+func (l *loaders) projectTasks(limit int) *loader.Loader[string, []task.Response] {
+	if _, ok := l.byProject[limit]; !ok {
+		l.byProject[limit] = loader.New(l.ctx, func(ctx context.Context, ids []string) (map[string][]task.Response, error) {
+			return l.taskerService.GetTasksByProjects(ctx, ids, limit)
+		})
+	}
+	return l.byProject[limit]
+}
+
+func (l *loaders) userTasks(limit int) *loader.Loader[string, []task.Response] {
+	if _, ok := l.byUser[limit]; !ok {
+		l.byUser[limit] = loader.New(l.ctx, func(ctx context.Context, ids []string) (map[string][]task.Response, error) {
+			return l.taskerService.GetTasksByUsers(ctx, ids, limit)
+		})
+	}
+	return l.byUser[limit]
+}
@@ -0,0 +1,294 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/graphql-go/graphql"
+	"hard/internal/domain/project"
+	"hard/internal/domain/task"
+	"hard/internal/domain/user"
+	"hard/internal/service/tasker"
+	"hard/pkg/loader"
+	"hard/pkg/store"
+)
+
+// newSchema describes the users, projects and tasks with their relations. The
+// relations are resolved through the loaders of the request, so that a list
+// costs one query per relation instead of one per row.
+func newSchema(s *tasker.Service) (graphql.Schema, error) {
+	var userType, projectType, taskType *graphql.Object
+
+	first := graphql.FieldConfigArgument{
+		"first": &graphql.ArgumentConfig{
+			Type:        graphql.Int,
+			Description: fmt.Sprintf("Number of rows, %d by default and %d at most", store.DefaultLimit, store.MaxLimit),
+		},
+	}
+	page := graphql.FieldConfigArgument{
+		"first": first["first"],
+		"after": &graphql.ArgumentConfig{
+			Type:        graphql.String,
+			Description: "Cursor of the next page",
+		},
+	}
+
+	userType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":        field(graphql.NewNonNull(graphql.ID), func(data user.Response) any { return data.ID }),
+				"fullName":  field(graphql.NewNonNull(graphql.String), func(data user.Response) any { return data.FullName }),
+				"email":     field(graphql.NewNonNull(graphql.String), func(data user.Response) any { return data.Email }),
+				"role":      field(graphql.NewNonNull(graphql.String), func(data user.Response) any { return data.Role }),
+				"deletedAt": field(graphql.String, func(data user.Response) any { return optional(data.DeletedAt) }),
+				"version":   field(graphql.Int, func(data user.Response) any { return data.Version }),
+				"tasks": &graphql.Field{
+					Type:        tasksType(taskType),
+					Description: "Tasks assigned to the user",
+					Args:        first,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						limit, err := limitArgument(p)
+						if err != nil {
+							return nil, err
+						}
+						return loadTasks(loadersFrom(p.Context).userTasks(limit), p.Source.(user.Response).ID), nil
+					},
+				},
+			}
+		}),
+	})
+
+	projectType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Project",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":          field(graphql.NewNonNull(graphql.ID), func(data project.Response) any { return data.ID }),
+				"title":       field(graphql.NewNonNull(graphql.String), func(data project.Response) any { return data.Title }),
+				"description": field(graphql.NewNonNull(graphql.String), func(data project.Response) any { return data.Description }),
+				"startDate":   field(graphql.String, func(data project.Response) any { return optional(data.StartDate) }),
+				"endDate":     field(graphql.String, func(data project.Response) any { return optional(data.EndDate) }),
+				"managerId":   field(graphql.NewNonNull(graphql.ID), func(data project.Response) any { return data.ManagerID }),
+				"deletedAt":   field(graphql.String, func(data project.Response) any { return optional(data.DeletedAt) }),
+				"version":     field(graphql.Int, func(data project.Response) any { return data.Version }),
+				"manager": &graphql.Field{
+					Type: userType,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return loadOne(loadersFrom(p.Context).users, p.Source.(project.Response).ManagerID), nil
+					},
+				},
+				"tasks": &graphql.Field{
+					Type:        tasksType(taskType),
+					Description: "Tasks of the project",
+					Args:        first,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						limit, err := limitArgument(p)
+						if err != nil {
+							return nil, err
+						}
+						return loadTasks(loadersFrom(p.Context).projectTasks(limit), p.Source.(project.Response).ID), nil
+					},
+				},
+			}
+		}),
+	})
+
+	taskType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Task",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":            field(graphql.NewNonNull(graphql.ID), func(data task.Response) any { return data.ID }),
+				"title":         field(graphql.NewNonNull(graphql.String), func(data task.Response) any { return data.Title }),
+				"description":   field(graphql.NewNonNull(graphql.String), func(data task.Response) any { return data.Description }),
+				"priority":      field(graphql.NewNonNull(graphql.String), func(data task.Response) any { return data.Priority }),
+				"status":        field(graphql.NewNonNull(graphql.String), func(data task.Response) any { return data.Status }),
+				"assigneeId":    field(graphql.ID, func(data task.Response) any { return optional(data.AssigneeID) }),
+				"projectId":     field(graphql.NewNonNull(graphql.ID), func(data task.Response) any { return data.ProjectID }),
+				"parentId":      field(graphql.ID, func(data task.Response) any { return optional(data.ParentID) }),
+				"milestoneId":   field(graphql.ID, func(data task.Response) any { return optional(data.MilestoneID) }),
+				"startDate":     field(graphql.String, func(data task.Response) any { return optional(data.StartDate) }),
+				"dueDate":       field(graphql.String, func(data task.Response) any { return optional(data.DueDate) }),
+				"estimateHours": field(graphql.Float, func(data task.Response) any { return data.EstimateHours }),
+				"completedAt":   field(graphql.String, func(data task.Response) any { return optional(data.CompletedAt) }),
+				"deletedAt":     field(graphql.String, func(data task.Response) any { return optional(data.DeletedAt) }),
+				"version":       field(graphql.Int, func(data task.Response) any { return data.Version }),
+				"assignee": &graphql.Field{
+					Type: userType,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return loadOne(loadersFrom(p.Context).users, p.Source.(task.Response).AssigneeID), nil
+					},
+				},
+				"project": &graphql.Field{
+					Type: projectType,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						return loadOne(loadersFrom(p.Context).projects, p.Source.(task.Response).ProjectID), nil
+					},
+				},
+			}
+		}),
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"user": &graphql.Field{
+				Type: userType,
+				Args: graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return loadOne(loadersFrom(p.Context).users, p.Args["id"].(string)), nil
+				},
+			},
+			"users": &graphql.Field{
+				Type: pageType("UserPage", userType),
+				Args: page,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return listPage(p, s.ListUsers)
+				},
+			},
+			"project": &graphql.Field{
+				Type: projectType,
+				Args: graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return loadOne(loadersFrom(p.Context).projects, p.Args["id"].(string)), nil
+				},
+			},
+			"projects": &graphql.Field{
+				Type: pageType("ProjectPage", projectType),
+				Args: page,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return listPage(p, s.ListProjects)
+				},
+			},
+			"task": &graphql.Field{
+				Type: taskType,
+				Args: graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					res, err := s.GetTask(p.Context, p.Args["id"].(string))
+					if errors.Is(err, store.ErrorNotFound) {
+						return nil, nil
+					}
+					if err != nil {
+						return nil, err
+					}
+					return res, nil
+				},
+			},
+			"tasks": &graphql.Field{
+				Type: pageType("TaskPage", taskType),
+				Args: page,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return listPage(p, s.ListTasks)
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+// field resolves a field of the source of type T with get.
+func field[T any](t graphql.Output, get func(data T) any) *graphql.Field {
+	return &graphql.Field{
+		Type: t,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return get(p.Source.(T)), nil
+		},
+	}
+}
+
+// optional turns the empty strings the responses use for missing values into null.
+func optional(value string) any {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+func tasksType(taskType *graphql.Object) graphql.Output {
+	return graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(taskType)))
+}
+
+func pageType(name string, itemType *graphql.Object) graphql.Output {
+	return graphql.NewNonNull(graphql.NewObject(graphql.ObjectConfig{
+		Name: name,
+		Fields: graphql.Fields{
+			"items": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(itemType))),
+			},
+			"nextCursor": &graphql.Field{
+				Type:        graphql.String,
+				Description: "Cursor of the next page, null on the last one",
+			},
+		},
+	}))
+}
+
+// result is a page of a top-level list, its fields resolve by name.
+type result[T any] struct {
+	Items      []T
+	NextCursor *string
+}
+
+func listPage[T any](p graphql.ResolveParams, list func(ctx context.Context, page store.Page) ([]T, store.Cursor, error)) (any, error) {
+	limit, err := limitArgument(p)
+	if err != nil {
+		return nil, err
+	}
+	after, _ := p.Args["after"].(string)
+
+	items, cursor, err := list(p.Context, store.Page{Limit: limit, After: after})
+	if err != nil {
+		return nil, err
+	}
+
+	res := result[T]{Items: items}
+	if cursor.Next != "" {
+		res.NextCursor = &cursor.Next
+	}
+	return res, nil
+}
+
+// limitArgument reads the first argument the way the REST API reads limit.
+func limitArgument(p graphql.ResolveParams) (int, error) {
+	limit, ok := p.Args["first"].(int)
+	if !ok {
+		return store.DefaultLimit, nil
+	}
+	if limit < 1 || limit > store.MaxLimit {
+		return 0, fmt.Errorf("first: must be between 1 and %d", store.MaxLimit)
+	}
+	return limit, nil
+}
+
+// loadOne returns the thunk of the row with the given id, null when the id is
+// empty or the row does not exist.
+func loadOne[T any](l *loader.Loader[string, T], id string) any {
+	if id == "" {
+		return nil
+	}
+
+	thunk := l.Load(id)
+	return func() (any, error) {
+		value, ok, err := thunk()
+		if err != nil || !ok {
+			return nil, err
+		}
+		return value, nil
+	}
+}
+
+// loadTasks returns the thunk of the tasks of the given id, a row without
+// tasks has an empty list.
+func loadTasks(l *loader.Loader[string, []task.Response], id string) any {
+	thunk := l.Load(id)
+	return func() (any, error) {
+		value, _, err := thunk()
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			value = []task.Response{}
+		}
+		return value, nil
+	}
+}
@@ -0,0 +1,61 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"hard/internal/domain/access"
+	"hard/internal/domain/dependency"
+	"hard/internal/domain/member"
+	"hard/internal/domain/milestone"
+	"hard/internal/domain/task"
+	"hard/internal/domain/workflow"
+	"hard/pkg/store"
+)
+
+// toStatus maps the errors of the service to the status codes closest to the
+// HTTP statuses the HTTP API responds with.
+func toStatus(err error) error {
+	code := codes.Internal
+
+	switch {
+	case errors.Is(err, store.ErrorNotFound):
+		code = codes.NotFound
+	case errors.Is(err, access.ErrorForbidden):
+		code = codes.PermissionDenied
+	case errors.Is(err, store.ErrorConflict):
+		code = codes.Aborted
+	case errors.Is(err, store.ErrorInvalidCursor), isInvalid(err):
+		code = codes.InvalidArgument
+	case errors.Is(err, store.ErrorNotDeleted),
+		errors.Is(err, task.ErrorProjectDeleted), errors.Is(err, task.ErrorParentDeleted),
+		errors.Is(err, workflow.ErrorTransitionNotAllowed), errors.Is(err, task.ErrorOpenSubtasks), errors.Is(err, dependency.ErrorBlocked):
+		code = codes.FailedPrecondition
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	}
+
+	return status.Error(code, err.Error())
+}
+
+func isInvalid(err error) bool {
+	return errors.Is(err, store.ErrorInvalidInput) ||
+		errors.Is(err, workflow.ErrorUnknownStatus) ||
+		errors.Is(err, member.ErrorNotMember) ||
+		errors.Is(err, task.ErrorUnknownParent) ||
+		errors.Is(err, task.ErrorParentProject) ||
+		errors.Is(err, task.ErrorParentCycle) ||
+		errors.Is(err, task.ErrorMoveSubtasks) ||
+		errors.Is(err, task.ErrorDueDate) ||
+		errors.Is(err, milestone.ErrorUnknownMilestone) ||
+		errors.Is(err, milestone.ErrorOtherProject) ||
+		errors.Is(err, milestone.ErrorClosedMilestone)
+}
+
+// invalid is the status of a request the service was not called with.
+func invalid(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
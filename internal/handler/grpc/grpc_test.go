@@ -0,0 +1,283 @@
+package grpc
+
+import (
+	"context"
+	"hard/internal/domain/task"
+	"hard/internal/domain/user"
+	"hard/internal/service/tasker"
+	taskerv1 "hard/pkg/api/tasker/v1"
+	"hard/pkg/auth"
+	"hard/pkg/helpers"
+	"hard/pkg/server/interceptor"
+	"hard/pkg/store"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// MockUserRepository mocks the methods the tests call, the others panic.
+type MockUserRepository struct {
+	mock.Mock
+	user.Repository
+}
+
+func (m *MockUserRepository) Get(ctx context.Context, id string) (data user.Entity, err error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(user.Entity), args.Error(1)
+}
+
+func (m *MockUserRepository) Update(ctx context.Context, id string, data user.Entity) (err error) {
+	args := m.Called(ctx, id, data)
+	return args.Error(0)
+}
+
+type MockTaskRepository struct {
+	mock.Mock
+	task.Repository
+}
+
+func (m *MockTaskRepository) List(ctx context.Context, page store.Page) (dest []task.Entity, cursor store.Cursor, err error) {
+	args := m.Called(ctx, page)
+	return args.Get(0).([]task.Entity), args.Get(1).(store.Cursor), args.Error(2)
+}
+
+// dial serves the services of s the way the app does and returns a client
+// connection to them, with the token of userID unless it is empty.
+func dial(t *testing.T, s *tasker.Service, userID string) *grpc.ClientConn {
+	tokens, _ := auth.New(auth.Config{Secret: "test-secret", Issuer: "hard"})
+
+	unary, stream := interceptor.Authenticate(tokens, healthpb.Health_ServiceDesc.ServiceName)
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(unary), grpc.ChainStreamInterceptor(stream))
+	NewUserServer(s).Register(server)
+	NewProjectServer(s).Register(server)
+	NewTaskServer(s).Register(server)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+
+	listener := bufconn.Listen(1 << 20)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	options := []grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}
+	if userID != "" {
+		token, _, _ := tokens.Issue(userID)
+		options = append(options, grpc.WithUnaryInterceptor(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			return invoker(metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token), method, req, reply, cc, opts...)
+		}), grpc.WithStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return streamer(metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token), desc, cc, method, opts...)
+		}))
+	}
+
+	conn, err := grpc.NewClient("passthrough:///bufnet", options...)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func TestGetUser(t *testing.T) {
+	tests := []struct {
+		name         string
+		id           string
+		userID       string
+		mockUser     user.Entity
+		mockError    error
+		expectedCode codes.Code
+		expectedName string
+	}{
+		{
+			name:         "OK",
+			id:           "1",
+			userID:       "1",
+			mockUser:     user.Entity{ID: "1", FullName: helpers.GetStringPtr("John Doe"), Email: helpers.GetStringPtr("john@example.com"), Role: helpers.GetStringPtr("user")},
+			expectedCode: codes.OK,
+			expectedName: "John Doe",
+		},
+		{
+			name:         "Not Found",
+			id:           "2",
+			userID:       "1",
+			mockError:    store.ErrorNotFound,
+			expectedCode: codes.NotFound,
+		},
+		{
+			name:         "Unauthenticated",
+			id:           "1",
+			expectedCode: codes.Unauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			mockRepo.On("Get", mock.Anything, tt.id).Return(tt.mockUser, tt.mockError)
+
+			taskService, _ := tasker.New(tasker.WithUserRepository(mockRepo))
+			client := taskerv1.NewUserServiceClient(dial(t, taskService, tt.userID))
+
+			res, err := client.GetUser(context.Background(), &taskerv1.GetRequest{Id: tt.id})
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			if tt.expectedCode == codes.OK {
+				assert.Equal(t, tt.expectedName, res.FullName)
+				assert.Equal(t, "john@example.com", res.Email)
+			}
+		})
+	}
+}
+
+func TestUpdateUser(t *testing.T) {
+	version := int32(2)
+
+	tests := []struct {
+		name         string
+		request      *taskerv1.UpdateUserRequest
+		expectedCode codes.Code
+	}{
+		{
+			name:         "OK",
+			request:      &taskerv1.UpdateUserRequest{Id: "1", FullName: helpers.GetStringPtr("Jane Doe")},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "Version Conflict",
+			request:      &taskerv1.UpdateUserRequest{Id: "1", FullName: helpers.GetStringPtr("Jane Doe"), Version: &version},
+			expectedCode: codes.Aborted,
+		},
+		{
+			name:         "Forbidden",
+			request:      &taskerv1.UpdateUserRequest{Id: "1", Role: helpers.GetStringPtr("admin")},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "Empty",
+			request:      &taskerv1.UpdateUserRequest{Id: "1"},
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := 1
+			mockRepo := new(MockUserRepository)
+			mockRepo.On("Get", mock.Anything, "1").Return(user.Entity{ID: "1", FullName: helpers.GetStringPtr("John Doe"), Role: helpers.GetStringPtr("user"), Version: &current}, nil)
+			mockRepo.On("Update", mock.Anything, "1", mock.Anything).Return(nil)
+
+			taskService, _ := tasker.New(tasker.WithUserRepository(mockRepo))
+			client := taskerv1.NewUserServiceClient(dial(t, taskService, "1"))
+
+			_, err := client.UpdateUser(context.Background(), tt.request)
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+		})
+	}
+}
+
+func TestListTasksStream(t *testing.T) {
+	description := ""
+	first := []task.Entity{
+		{ID: "1", Title: helpers.GetStringPtr("One"), Description: &description, Priority: helpers.GetStringPtr("Low"), Status: helpers.GetStringPtr("Active"), ProjectID: helpers.GetStringPtr("1")},
+		{ID: "2", Title: helpers.GetStringPtr("Two"), Description: &description, Priority: helpers.GetStringPtr("Low"), Status: helpers.GetStringPtr("Active"), ProjectID: helpers.GetStringPtr("1")},
+	}
+	second := []task.Entity{
+		{ID: "3", Title: helpers.GetStringPtr("Three"), Description: &description, Priority: helpers.GetStringPtr("High"), Status: helpers.GetStringPtr("Done"), ProjectID: helpers.GetStringPtr("1")},
+	}
+
+	tests := []struct {
+		name          string
+		request       *taskerv1.ListRequest
+		expectedIDs   []string
+		expectedCode  codes.Code
+		expectedPages int
+	}{
+		{
+			name:          "All Pages",
+			request:       &taskerv1.ListRequest{},
+			expectedIDs:   []string{"1", "2", "3"},
+			expectedPages: 2,
+		},
+		{
+			name:          "Limit",
+			request:       &taskerv1.ListRequest{Limit: 2},
+			expectedIDs:   []string{"1", "2"},
+			expectedPages: 1,
+		},
+		{
+			name:          "From Cursor",
+			request:       &taskerv1.ListRequest{After: "page-2"},
+			expectedIDs:   []string{"3"},
+			expectedPages: 1,
+		},
+		{
+			name:         "Invalid Limit",
+			request:      &taskerv1.ListRequest{Limit: -1},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "Deleted Rows Forbidden",
+			request:      &taskerv1.ListRequest{IncludeDeleted: true},
+			expectedCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockTaskRepo.On("List", mock.Anything, mock.MatchedBy(func(page store.Page) bool { return page.After == "" })).Return(first, store.Cursor{Next: "page-2"}, nil)
+			mockTaskRepo.On("List", mock.Anything, mock.MatchedBy(func(page store.Page) bool { return page.After == "page-2" })).Return(second, store.Cursor{}, nil)
+
+			mockUserRepo := new(MockUserRepository)
+			mockUserRepo.On("Get", mock.Anything, "1").Return(user.Entity{ID: "1", Role: helpers.GetStringPtr("user")}, nil)
+
+			taskService, _ := tasker.New(
+				tasker.WithUserRepository(mockUserRepo),
+				tasker.WithTaskRepository(mockTaskRepo),
+			)
+			client := taskerv1.NewTaskServiceClient(dial(t, taskService, "1"))
+
+			stream, err := client.ListTasks(context.Background(), tt.request)
+			assert.NoError(t, err)
+
+			var ids []string
+			for {
+				res, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					assert.Equal(t, tt.expectedCode, status.Code(err))
+					break
+				}
+				ids = append(ids, res.Id)
+			}
+
+			assert.Equal(t, tt.expectedIDs, ids)
+			mockTaskRepo.AssertNumberOfCalls(t, "List", tt.expectedPages)
+		})
+	}
+}
+
+func TestHealthIsPublic(t *testing.T) {
+	taskService, _ := tasker.New()
+	conn := dial(t, taskService, "")
+
+	res, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, res.Status)
+}
@@ -0,0 +1,64 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"hard/internal/service/tasker"
+	"hard/pkg/store"
+)
+
+// streamPages sends every item of the list from the after cursor on, limit of
+// them at most when it is set, fetching the pages one after another.
+func streamPages[T, M any](ctx context.Context, limit int32, after string, list func(ctx context.Context, page store.Page) ([]T, store.Cursor, error), parse func(T) M, send func(M) error) error {
+	if limit < 0 {
+		return invalid(errors.New("limit: cannot be negative"))
+	}
+
+	sent := 0
+	for {
+		page := store.Page{Limit: store.MaxLimit, After: after}
+		if limit > 0 && int(limit)-sent < page.Limit {
+			page.Limit = int(limit) - sent
+		}
+
+		data, cursor, err := list(ctx, page)
+		if err != nil {
+			return toStatus(err)
+		}
+		for _, object := range data {
+			if err = send(parse(object)); err != nil {
+				return err
+			}
+		}
+
+		sent += len(data)
+		if cursor.Next == "" || limit > 0 && sent >= int(limit) {
+			return nil
+		}
+		after = cursor.Next
+	}
+}
+
+// includeDeleted returns a context in which soft deleted rows are returned
+// when the request asks for them and the caller may see them.
+func includeDeleted(ctx context.Context, s *tasker.Service, include bool) (context.Context, error) {
+	if !include {
+		return ctx, nil
+	}
+
+	ctx, err := s.IncludeDeleted(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return ctx, nil
+}
+
+// version is the optional version of a conditional change.
+func version(value *int32) *int {
+	if value == nil {
+		return nil
+	}
+	v := int(*value)
+	return &v
+}
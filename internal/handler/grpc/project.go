@@ -0,0 +1,153 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/grpc"
+	"hard/internal/domain/project"
+	"hard/internal/domain/task"
+	"hard/internal/service/tasker"
+	taskerv1 "hard/pkg/api/tasker/v1"
+	"hard/pkg/helpers"
+	"hard/pkg/store"
+)
+
+type ProjectServer struct {
+	taskerv1.UnimplementedProjectServiceServer
+	taskerService *tasker.Service
+}
+
+func NewProjectServer(s *tasker.Service) *ProjectServer {
+	return &ProjectServer{taskerService: s}
+}
+
+// Register sets up the project service
+func (h *ProjectServer) Register(r *grpc.Server) {
+	taskerv1.RegisterProjectServiceServer(r, h)
+}
+
+func (h *ProjectServer) ListProjects(req *taskerv1.ListRequest, stream taskerv1.ProjectService_ListProjectsServer) error {
+	ctx, err := includeDeleted(stream.Context(), h.taskerService, req.IncludeDeleted)
+	if err != nil {
+		return err
+	}
+
+	return streamPages(ctx, req.Limit, req.After, h.taskerService.ListProjects, parseProject, stream.Send)
+}
+
+func (h *ProjectServer) CreateProject(ctx context.Context, req *taskerv1.CreateProjectRequest) (*taskerv1.Project, error) {
+	data := project.Request{
+		Title:       helpers.GetStringPtr(req.Title),
+		Description: &req.Description,
+		StartDate:   helpers.GetStringPtr(req.StartDate),
+		EndDate:     req.EndDate,
+		ManagerID:   helpers.GetStringPtr(req.ManagerId),
+	}
+	if err := data.Validate(); err != nil {
+		return nil, invalid(err)
+	}
+
+	res, err := h.taskerService.CreateProject(ctx, data)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return parseProject(res), nil
+}
+
+func (h *ProjectServer) GetProject(ctx context.Context, req *taskerv1.GetRequest) (*taskerv1.Project, error) {
+	ctx, err := includeDeleted(ctx, h.taskerService, req.IncludeDeleted)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := h.taskerService.GetProject(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return parseProject(res), nil
+}
+
+func (h *ProjectServer) UpdateProject(ctx context.Context, req *taskerv1.UpdateProjectRequest) (*taskerv1.UpdateResponse, error) {
+	data := project.Request{
+		Title:       req.Title,
+		Description: req.Description,
+		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
+		ManagerID:   req.ManagerId,
+	}
+	if data.IsEmpty() {
+		return nil, invalid(errors.New("at least one field must be provided for update"))
+	}
+
+	if err := h.taskerService.UpdateProject(ctx, req.Id, data, version(req.Version)); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &taskerv1.UpdateResponse{}, nil
+}
+
+func (h *ProjectServer) DeleteProject(ctx context.Context, req *taskerv1.DeleteRequest) (*taskerv1.DeleteResponse, error) {
+	if err := h.taskerService.DeleteProject(ctx, req.Id, version(req.Version)); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &taskerv1.DeleteResponse{Id: req.Id}, nil
+}
+
+func (h *ProjectServer) RestoreProject(ctx context.Context, req *taskerv1.RestoreRequest) (*taskerv1.Project, error) {
+	res, err := h.taskerService.RestoreProject(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return parseProject(res), nil
+}
+
+func (h *ProjectServer) SearchProjects(req *taskerv1.SearchProjectsRequest, stream taskerv1.ProjectService_SearchProjectsServer) error {
+	data := project.Request{
+		Title:     helpers.GetStringPtr(req.Title),
+		ManagerID: helpers.GetStringPtr(req.ManagerId),
+	}
+	if data.IsEmpty() {
+		return invalid(errors.New("title or manager_id is required"))
+	}
+
+	ctx, err := includeDeleted(stream.Context(), h.taskerService, req.IncludeDeleted)
+	if err != nil {
+		return err
+	}
+
+	list := func(ctx context.Context, page store.Page) ([]project.Response, store.Cursor, error) {
+		return h.taskerService.SearchProjects(ctx, data, page)
+	}
+
+	return streamPages(ctx, req.Limit, req.After, list, parseProject, stream.Send)
+}
+
+func (h *ProjectServer) ListProjectTasks(req *taskerv1.ListByIDRequest, stream taskerv1.ProjectService_ListProjectTasksServer) error {
+	ctx, err := includeDeleted(stream.Context(), h.taskerService, req.IncludeDeleted)
+	if err != nil {
+		return err
+	}
+
+	list := func(ctx context.Context, page store.Page) ([]task.Response, store.Cursor, error) {
+		return h.taskerService.GetTasksByProject(ctx, req.Id, page)
+	}
+
+	return streamPages(ctx, req.Limit, req.After, list, parseTask, stream.Send)
+}
+
+func parseProject(data project.Response) *taskerv1.Project {
+	return &taskerv1.Project{
+		Id:          data.ID,
+		Title:       data.Title,
+		Description: data.Description,
+		StartDate:   data.StartDate,
+		EndDate:     data.EndDate,
+		ManagerId:   data.ManagerID,
+		DeletedAt:   data.DeletedAt,
+		Version:     int32(data.Version),
+	}
+}
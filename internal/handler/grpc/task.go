@@ -0,0 +1,172 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/grpc"
+	"hard/internal/domain/task"
+	"hard/internal/service/tasker"
+	taskerv1 "hard/pkg/api/tasker/v1"
+	"hard/pkg/helpers"
+	"hard/pkg/store"
+)
+
+type TaskServer struct {
+	taskerv1.UnimplementedTaskServiceServer
+	taskerService *tasker.Service
+}
+
+func NewTaskServer(s *tasker.Service) *TaskServer {
+	return &TaskServer{taskerService: s}
+}
+
+// Register sets up the task service
+func (h *TaskServer) Register(r *grpc.Server) {
+	taskerv1.RegisterTaskServiceServer(r, h)
+}
+
+func (h *TaskServer) ListTasks(req *taskerv1.ListRequest, stream taskerv1.TaskService_ListTasksServer) error {
+	ctx, err := includeDeleted(stream.Context(), h.taskerService, req.IncludeDeleted)
+	if err != nil {
+		return err
+	}
+
+	return streamPages(ctx, req.Limit, req.After, h.taskerService.ListTasks, parseTask, stream.Send)
+}
+
+func (h *TaskServer) CreateTask(ctx context.Context, req *taskerv1.CreateTaskRequest) (*taskerv1.Task, error) {
+	data := task.Request{
+		Title:         helpers.GetStringPtr(req.Title),
+		Description:   &req.Description,
+		Priority:      helpers.GetStringPtr(req.Priority),
+		Status:        helpers.GetStringPtr(req.Status),
+		AssigneeID:    helpers.GetStringPtr(req.AssigneeId),
+		ProjectID:     helpers.GetStringPtr(req.ProjectId),
+		ParentID:      req.ParentId,
+		StartDate:     req.StartDate,
+		DueDate:       req.DueDate,
+		EstimateHours: req.EstimateHours,
+		MilestoneID:   req.MilestoneId,
+		CompletedAt:   req.CompletedAt,
+	}
+	if err := data.Validate(); err != nil {
+		return nil, invalid(err)
+	}
+
+	res, err := h.taskerService.CreateTask(ctx, data)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return parseTask(res), nil
+}
+
+func (h *TaskServer) GetTask(ctx context.Context, req *taskerv1.GetRequest) (*taskerv1.Task, error) {
+	ctx, err := includeDeleted(ctx, h.taskerService, req.IncludeDeleted)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := h.taskerService.GetTask(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return parseTask(res), nil
+}
+
+func (h *TaskServer) UpdateTask(ctx context.Context, req *taskerv1.UpdateTaskRequest) (*taskerv1.UpdateResponse, error) {
+	data := task.Request{
+		Title:         req.Title,
+		Description:   req.Description,
+		Priority:      req.Priority,
+		Status:        req.Status,
+		AssigneeID:    req.AssigneeId,
+		ProjectID:     req.ProjectId,
+		ParentID:      req.ParentId,
+		StartDate:     req.StartDate,
+		DueDate:       req.DueDate,
+		EstimateHours: req.EstimateHours,
+		MilestoneID:   req.MilestoneId,
+		CompletedAt:   req.CompletedAt,
+	}
+	if data.IsEmpty() {
+		return nil, invalid(errors.New("at least one field must be provided for update"))
+	}
+	if err := data.ValidateUpdate(); err != nil {
+		return nil, invalid(err)
+	}
+
+	if err := h.taskerService.UpdateTask(ctx, req.Id, data, version(req.Version)); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &taskerv1.UpdateResponse{}, nil
+}
+
+func (h *TaskServer) DeleteTask(ctx context.Context, req *taskerv1.DeleteRequest) (*taskerv1.DeleteResponse, error) {
+	if err := h.taskerService.DeleteTask(ctx, req.Id, version(req.Version)); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &taskerv1.DeleteResponse{Id: req.Id}, nil
+}
+
+func (h *TaskServer) RestoreTask(ctx context.Context, req *taskerv1.RestoreRequest) (*taskerv1.Task, error) {
+	res, err := h.taskerService.RestoreTask(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return parseTask(res), nil
+}
+
+func (h *TaskServer) SearchTasks(req *taskerv1.SearchTasksRequest, stream taskerv1.TaskService_SearchTasksServer) error {
+	filter, err := task.ParseFilter(req.Filter)
+	if err != nil {
+		return invalid(err)
+	}
+	if len(filter.Conditions) == 0 {
+		return invalid(errors.New("filter: at least one condition is required"))
+	}
+
+	ctx, err := includeDeleted(stream.Context(), h.taskerService, req.IncludeDeleted)
+	if err != nil {
+		return err
+	}
+
+	list := func(ctx context.Context, page store.Page) ([]task.Response, store.Cursor, error) {
+		return h.taskerService.SearchTasks(ctx, filter, page)
+	}
+
+	return streamPages(ctx, req.Limit, req.After, list, parseTask, stream.Send)
+}
+
+func parseTask(data task.Response) *taskerv1.Task {
+	res := &taskerv1.Task{
+		Id:            data.ID,
+		Title:         data.Title,
+		Description:   data.Description,
+		Priority:      data.Priority,
+		Status:        data.Status,
+		AssigneeId:    data.AssigneeID,
+		ProjectId:     data.ProjectID,
+		ParentId:      data.ParentID,
+		StartDate:     data.StartDate,
+		DueDate:       data.DueDate,
+		EstimateHours: data.EstimateHours,
+		MilestoneId:   data.MilestoneID,
+		CompletedAt:   data.CompletedAt,
+		DeletedAt:     data.DeletedAt,
+		Version:       int32(data.Version),
+	}
+	for _, object := range data.Labels {
+		res.Labels = append(res.Labels, &taskerv1.Label{
+			Id:        object.ID,
+			ProjectId: object.ProjectID,
+			Name:      object.Name,
+			Color:     object.Color,
+		})
+	}
+	return res
+}
@@ -0,0 +1,143 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/grpc"
+	"hard/internal/domain/task"
+	"hard/internal/domain/user"
+	"hard/internal/service/tasker"
+	taskerv1 "hard/pkg/api/tasker/v1"
+	"hard/pkg/helpers"
+	"hard/pkg/store"
+)
+
+type UserServer struct {
+	taskerv1.UnimplementedUserServiceServer
+	taskerService *tasker.Service
+}
+
+func NewUserServer(s *tasker.Service) *UserServer {
+	return &UserServer{taskerService: s}
+}
+
+// Register sets up the user service
+func (h *UserServer) Register(r *grpc.Server) {
+	taskerv1.RegisterUserServiceServer(r, h)
+}
+
+func (h *UserServer) ListUsers(req *taskerv1.ListRequest, stream taskerv1.UserService_ListUsersServer) error {
+	ctx, err := includeDeleted(stream.Context(), h.taskerService, req.IncludeDeleted)
+	if err != nil {
+		return err
+	}
+
+	return streamPages(ctx, req.Limit, req.After, h.taskerService.ListUsers, parseUser, stream.Send)
+}
+
+func (h *UserServer) CreateUser(ctx context.Context, req *taskerv1.CreateUserRequest) (*taskerv1.User, error) {
+	data := user.Request{
+		FullName: helpers.GetStringPtr(req.FullName),
+		Email:    helpers.GetStringPtr(req.Email),
+		Role:     helpers.GetStringPtr(req.Role),
+	}
+	if err := data.Validate(); err != nil {
+		return nil, invalid(err)
+	}
+
+	res, err := h.taskerService.CreateUser(ctx, data)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return parseUser(res), nil
+}
+
+func (h *UserServer) GetUser(ctx context.Context, req *taskerv1.GetRequest) (*taskerv1.User, error) {
+	ctx, err := includeDeleted(ctx, h.taskerService, req.IncludeDeleted)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := h.taskerService.GetUser(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return parseUser(res), nil
+}
+
+func (h *UserServer) UpdateUser(ctx context.Context, req *taskerv1.UpdateUserRequest) (*taskerv1.UpdateResponse, error) {
+	data := user.Request{
+		FullName: req.FullName,
+		Email:    req.Email,
+		Role:     req.Role,
+	}
+	if data.FullName == nil && data.Email == nil && data.Role == nil {
+		return nil, invalid(errors.New("at least one field must be provided for update"))
+	}
+
+	if err := h.taskerService.UpdateUser(ctx, req.Id, data, version(req.Version)); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &taskerv1.UpdateResponse{}, nil
+}
+
+func (h *UserServer) DeleteUser(ctx context.Context, req *taskerv1.DeleteRequest) (*taskerv1.DeleteResponse, error) {
+	if err := h.taskerService.DeleteUser(ctx, req.Id, version(req.Version)); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &taskerv1.DeleteResponse{Id: req.Id}, nil
+}
+
+func (h *UserServer) RestoreUser(ctx context.Context, req *taskerv1.RestoreRequest) (*taskerv1.User, error) {
+	res, err := h.taskerService.RestoreUser(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return parseUser(res), nil
+}
+
+func (h *UserServer) SearchUsers(req *taskerv1.SearchUsersRequest, stream taskerv1.UserService_SearchUsersServer) error {
+	if req.Name == "" && req.Email == "" {
+		return invalid(errors.New("name or email is required"))
+	}
+
+	ctx, err := includeDeleted(stream.Context(), h.taskerService, req.IncludeDeleted)
+	if err != nil {
+		return err
+	}
+
+	list := func(ctx context.Context, page store.Page) ([]user.Response, store.Cursor, error) {
+		return h.taskerService.SearchUser(ctx, req.Name, req.Email, page)
+	}
+
+	return streamPages(ctx, req.Limit, req.After, list, parseUser, stream.Send)
+}
+
+func (h *UserServer) ListUserTasks(req *taskerv1.ListByIDRequest, stream taskerv1.UserService_ListUserTasksServer) error {
+	ctx, err := includeDeleted(stream.Context(), h.taskerService, req.IncludeDeleted)
+	if err != nil {
+		return err
+	}
+
+	list := func(ctx context.Context, page store.Page) ([]task.Response, store.Cursor, error) {
+		return h.taskerService.GetTasksByUser(ctx, req.Id, page)
+	}
+
+	return streamPages(ctx, req.Limit, req.After, list, parseTask, stream.Send)
+}
+
+func parseUser(data user.Response) *taskerv1.User {
+	return &taskerv1.User{
+		Id:        data.ID,
+		FullName:  data.FullName,
+		Email:     data.Email,
+		Role:      data.Role,
+		DeletedAt: data.DeletedAt,
+		Version:   int32(data.Version),
+	}
+}
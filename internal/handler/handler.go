@@ -4,20 +4,33 @@ import (
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 	"hard/docs"
 	"hard/internal/config"
+	gql "hard/internal/handler/graphql"
+	rpc "hard/internal/handler/grpc"
 	"hard/internal/handler/http"
 	"hard/internal/service/tasker"
+	"hard/pkg/auth"
+	"hard/pkg/server/interceptor"
 	"hard/pkg/server/router"
 )
 
 type Dependencies struct {
 	Configs       config.Configs
 	TaskerService *tasker.Service
+	Tokens        *auth.Tokens
 }
 type Handler struct {
 	dependencies Dependencies
 	HTTP         *gin.Engine
+	GRPC         *grpc.Server
+	// Health reports the state of the gRPC services, Shutdown marks them as
+	// not serving before the server stops.
+	Health *health.Server
 }
 type Configuration func(h *Handler) error
 
@@ -46,15 +59,62 @@ func WithHTTPHandler() Configuration {
 		userHandler := http.NewUserHandler(h.dependencies.TaskerService)
 		taskHandler := http.NewTaskHandler(h.dependencies.TaskerService)
 		projectHandler := http.NewProjectHandler(h.dependencies.TaskerService)
+		searchHandler := http.NewSearchHandler(h.dependencies.TaskerService)
+		authHandler := http.NewAuthHandler(h.dependencies.TaskerService)
+		auditHandler := http.NewAuditHandler(h.dependencies.TaskerService)
+		webhookHandler := http.NewWebhookHandler(h.dependencies.TaskerService)
+		eventHandler := http.NewEventHandler(h.dependencies.TaskerService)
 		heathCheck := http.NewHealthHandler()
+		graphqlHandler, err := gql.NewHandler(h.dependencies.TaskerService, gql.Limits{
+			MaxDepth:      h.dependencies.Configs.GRAPHQL.MaxDepth,
+			MaxComplexity: h.dependencies.Configs.GRAPHQL.MaxComplexity,
+		})
+		if err != nil {
+			return
+		}
 		api := h.HTTP.Group("/api/v1/")
 		{
-			userHandler.Routes(api)
-			taskHandler.Routes(api)
-			projectHandler.Routes(api)
-
 			heathCheck.Routes(api)
+			authHandler.Routes(api)
+
+			private := api.Group("", router.Authenticate(h.dependencies.Tokens))
+			userHandler.Routes(private)
+			taskHandler.Routes(private)
+			projectHandler.Routes(private)
+			searchHandler.Routes(private)
+			auditHandler.Routes(private)
+			webhookHandler.Routes(private)
+			graphqlHandler.Routes(private)
+
+			stream := api.Group("", router.AuthenticateStream(h.dependencies.Tokens))
+			eventHandler.Routes(stream)
 		}
 		return
 	}
 }
+
+func WithGRPCHandler() Configuration {
+	return func(h *Handler) (err error) {
+		unary, stream := interceptor.Authenticate(h.dependencies.Tokens,
+			healthpb.Health_ServiceDesc.ServiceName,
+			"grpc.reflection.v1.ServerReflection",
+			"grpc.reflection.v1alpha.ServerReflection")
+
+		h.GRPC = grpc.NewServer(
+			grpc.ChainUnaryInterceptor(unary),
+			grpc.ChainStreamInterceptor(stream))
+
+		rpc.NewUserServer(h.dependencies.TaskerService).Register(h.GRPC)
+		rpc.NewProjectServer(h.dependencies.TaskerService).Register(h.GRPC)
+		rpc.NewTaskServer(h.dependencies.TaskerService).Register(h.GRPC)
+
+		h.Health = health.NewServer()
+		healthpb.RegisterHealthServer(h.GRPC, h.Health)
+		for service := range h.GRPC.GetServiceInfo() {
+			h.Health.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+		}
+
+		reflection.Register(h.GRPC)
+		return
+	}
+}
@@ -0,0 +1,335 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"hard/internal/domain/access"
+	"hard/internal/domain/member"
+	"hard/internal/domain/project"
+	"hard/internal/domain/task"
+	"hard/internal/domain/user"
+	"hard/internal/domain/workflow"
+	"hard/pkg/helpers"
+	"hard/pkg/store"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"hard/internal/service/tasker"
+)
+
+type MockProjectRepository struct {
+	mock.Mock
+}
+
+func (m *MockProjectRepository) List(ctx context.Context, page store.Page) (dest []project.Entity, cursor store.Cursor, err error) {
+	args := m.Called(ctx, page)
+	return args.Get(0).([]project.Entity), args.Get(1).(store.Cursor), args.Error(2)
+}
+
+func (m *MockProjectRepository) Add(ctx context.Context, data project.Entity) (id string, err error) {
+	args := m.Called(ctx, data)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockProjectRepository) AddMany(ctx context.Context, data []project.Entity) (ids []string, err error) {
+	args := m.Called(ctx, data)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockProjectRepository) Get(ctx context.Context, id string) (dest project.Entity, err error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(project.Entity), args.Error(1)
+}
+
+func (m *MockProjectRepository) GetMany(ctx context.Context, ids []string) (dest []project.Entity, err error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]project.Entity), args.Error(1)
+}
+
+func (m *MockProjectRepository) Update(ctx context.Context, id string, data project.Entity) (err error) {
+	args := m.Called(ctx, id, data)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) Delete(ctx context.Context, id string, version *int) (err error) {
+	args := m.Called(ctx, id, version)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) Restore(ctx context.Context, id string) (err error) {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) Purge(ctx context.Context, retention time.Duration) (n int64, err error) {
+	args := m.Called(ctx, retention)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockProjectRepository) Search(ctx context.Context, data project.Entity, page store.Page) (dest []project.Entity, cursor store.Cursor, err error) {
+	args := m.Called(ctx, data, page)
+	return args.Get(0).([]project.Entity), args.Get(1).(store.Cursor), args.Error(2)
+}
+
+func (m *MockProjectRepository) ListTasks(ctx context.Context, id string, page store.Page) (dest []task.Entity, cursor store.Cursor, err error) {
+	args := m.Called(ctx, id, page)
+	return args.Get(0).([]task.Entity), args.Get(1).(store.Cursor), args.Error(2)
+}
+
+func TestAccessControl(t *testing.T) {
+	users := map[string]string{"1": "admin", "2": "scientist", "3": "developer", "4": "developer", "5": "developer"}
+	// project 1 is managed by user 2 and has user 5 as a member, task 7 belongs
+	// to it and is assigned to user 3
+	current := task.Entity{ID: "7", Status: helpers.GetStringPtr("Active"), AssigneeID: helpers.GetStringPtr("3"), ProjectID: helpers.GetStringPtr("1")}
+
+	tests := []struct {
+		name           string
+		policy         access.Policy
+		callerID       string
+		method         string
+		target         string
+		inputBody      string
+		expectedStatus int
+	}{
+		{
+			name:           "Admin Deletes User",
+			callerID:       "1",
+			method:         "DELETE",
+			target:         "/users/4",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Developer Cannot Delete User",
+			callerID:       "3",
+			method:         "DELETE",
+			target:         "/users/4",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "User Updates Own Profile",
+			callerID:       "3",
+			method:         "PUT",
+			target:         "/users/3",
+			inputBody:      `{"full_name":"Morty Smith"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "User Cannot Change Own Role",
+			callerID:       "3",
+			method:         "PUT",
+			target:         "/users/3",
+			inputBody:      `{"role":"admin"}`,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "User Sets Own Password",
+			callerID:       "3",
+			method:         "PUT",
+			target:         "/users/3/password",
+			inputBody:      `{"password":"wubba-lubba"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "User Cannot Set Others Password",
+			callerID:       "3",
+			method:         "PUT",
+			target:         "/users/4/password",
+			inputBody:      `{"password":"wubba-lubba"}`,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Short Password",
+			callerID:       "1",
+			method:         "PUT",
+			target:         "/users/4/password",
+			inputBody:      `{"password":"short"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Manager Updates Project",
+			callerID:       "2",
+			method:         "PUT",
+			target:         "/projects/1",
+			inputBody:      `{"title":"Alpha"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Non Manager Cannot Delete Project",
+			callerID:       "3",
+			method:         "DELETE",
+			target:         "/projects/1",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Assignee Changes Status",
+			callerID:       "3",
+			method:         "PUT",
+			target:         "/tasks/7",
+			inputBody:      `{"status":"Review"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Others Cannot Change Status",
+			callerID:       "4",
+			method:         "PUT",
+			target:         "/tasks/7",
+			inputBody:      `{"status":"Review"}`,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Member Edits Title",
+			callerID:       "5",
+			method:         "PUT",
+			target:         "/tasks/7",
+			inputBody:      `{"title":"Design Homepage"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Others Cannot Edit Title",
+			callerID:       "4",
+			method:         "PUT",
+			target:         "/tasks/7",
+			inputBody:      `{"title":"Design Homepage"}`,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Member Adds Task",
+			callerID:       "5",
+			method:         "POST",
+			target:         "/tasks/",
+			inputBody:      `{"title":"Portal","description":"","priority":"Low","status":"Active","assignee_id":"5","project_id":"1"}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Others Cannot Add Task",
+			callerID:       "4",
+			method:         "POST",
+			target:         "/tasks/",
+			inputBody:      `{"title":"Portal","description":"","priority":"Low","status":"Active","assignee_id":"5","project_id":"1"}`,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Unknown Caller",
+			callerID:       "99",
+			method:         "DELETE",
+			target:         "/tasks/7",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Configured Policy",
+			policy:         access.Policy{access.ActionDeleteUser: {Roles: []string{"developer"}}},
+			callerID:       "3",
+			method:         "DELETE",
+			target:         "/users/4",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := new(MockUserRepository)
+			for id, role := range users {
+				mockUserRepo.On("Get", mock.Anything, id).Return(user.Entity{ID: id, Role: helpers.GetStringPtr(role)}, nil)
+			}
+			mockUserRepo.On("Get", mock.Anything, mock.Anything).Return(user.Entity{}, store.ErrorNotFound)
+			mockUserRepo.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockUserRepo.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockUserRepo.On("SetPasswordHash", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			mockProjectRepo := new(MockProjectRepository)
+			mockProjectRepo.On("Get", mock.Anything, "1").Return(project.Entity{ID: "1", ManagerID: helpers.GetStringPtr("2")}, nil)
+			mockProjectRepo.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockProjectRepo.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			mockTaskRepo := new(MockTaskRepository)
+			mockTaskRepo.On("Get", mock.Anything, "7").Return(current, nil)
+			mockTaskRepo.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockTaskRepo.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockTaskRepo.On("Add", mock.Anything, mock.Anything).Return("8", nil)
+
+			mockWorkflowRepo := new(MockWorkflowRepository)
+			mockWorkflowRepo.On("Get", mock.Anything, mock.Anything).Return(workflow.Entity{}, store.ErrorNotFound)
+
+			mockMemberRepo := new(MockMemberRepository)
+			mockMemberRepo.On("Get", mock.Anything, "1", "5").Return(member.Entity{ProjectID: "1", UserID: "5", Role: helpers.GetStringPtr(member.RoleMember)}, nil)
+			mockMemberRepo.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(member.Entity{}, store.ErrorNotFound)
+
+			configs := []tasker.Configuration{
+				tasker.WithMemberRepository(mockMemberRepo),
+				tasker.WithUserRepository(mockUserRepo),
+				tasker.WithProjectRepository(mockProjectRepo),
+				tasker.WithTaskRepository(mockTaskRepo),
+				tasker.WithWorkflowRepository(mockWorkflowRepo),
+			}
+			if tt.policy != nil {
+				configs = append(configs, tasker.WithPolicy(tt.policy))
+			}
+			taskService, _ := tasker.New(configs...)
+
+			gin.SetMode(gin.TestMode)
+			r := authenticated(tt.callerID)
+			api := r.Group("/")
+			NewUserHandler(taskService).Routes(api)
+			NewProjectHandler(taskService).Routes(api)
+			NewTaskHandler(taskService).Routes(api)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.target, bytes.NewBufferString(tt.inputBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		expectedRule  access.Rule
+		expectedError string
+	}{
+		{
+			name:         "Overrides Action",
+			content:      `{"user:delete": {"roles": ["developer"]}}`,
+			expectedRule: access.Rule{Roles: []string{"developer"}},
+		},
+		{
+			name:          "Unknown Action",
+			content:       `{"user:remove": {"roles": ["developer"]}}`,
+			expectedError: `unknown action "user:remove"`,
+		},
+		{
+			name:          "Unknown Relation",
+			content:       `{"user:delete": {"relations": ["friend"]}}`,
+			expectedError: `unknown relation "friend"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "policy.json")
+			assert.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			policy, err := access.LoadPolicy(path)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				assert.Nil(t, policy)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedRule, policy[access.ActionDeleteUser])
+			assert.Equal(t, access.DefaultPolicy[access.ActionUpdateUser], policy[access.ActionUpdateUser])
+		})
+	}
+}
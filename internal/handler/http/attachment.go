@@ -0,0 +1,236 @@
+package http
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"hard/internal/domain/access"
+	"hard/internal/domain/attachment"
+	"hard/pkg/server/response"
+	"hard/pkg/store"
+	"mime"
+	"net/http"
+	"time"
+)
+
+// multipartOverhead is allowed on top of the size limit for the boundaries and
+// part headers of the upload form.
+const multipartOverhead = 1 << 20
+
+// listAttachments godoc
+//
+//	@Summary		List task attachments
+//	@Description	Get the metadata of the files attached to a task
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string	true	"Task ID"
+//	@Param			limit			query		int		false	"Page size"
+//	@Param			after			query		string	false	"Cursor of the next page"
+//	@Param			before			query		string	false	"Cursor of the previous page"
+//	@Param			include_deleted	query		bool	false	"Include soft deleted rows, admin only"
+//	@Success		200				{array}		attachment.Response
+//	@Failure		400				{object}	response.Object
+//	@Failure		403				{object}	response.Object
+//	@Failure		404				{object}	response.Object
+//	@Failure		500				{object}	response.Object
+//	@Router			/tasks/{id}/attachments [get]
+func (h *TaskHandler) listAttachments(c *gin.Context) {
+	id := c.Param("id")
+	page, err := parsePage(c)
+	if err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	res, cursor, err := h.taskerService.ListAttachments(c, id, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, store.ErrorInvalidCursor):
+			response.BadRequest(c, err, nil)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OKPage(c, res, cursor.Next, cursor.Prev)
+}
+
+// uploadAttachment godoc
+//
+//	@Summary		Upload an attachment
+//	@Description	Attach a file to a task with a multipart form. The content type is detected from the content and must be one of the allowed types.
+//	@Tags			tasks
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			id		path		string	true	"Task ID"
+//	@Param			file	formData	file	true	"File"
+//	@Success		201		{object}	attachment.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		413		{object}	response.Object
+//	@Failure		415		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/tasks/{id}/attachments [post]
+func (h *TaskHandler) uploadAttachment(c *gin.Context) {
+	id := c.Param("id")
+
+	limits := h.taskerService.AttachmentLimits()
+	if limits.MaxSize > 0 {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limits.MaxSize+multipartOverhead)
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			response.RequestEntityTooLarge(c, attachment.TooLarge(limits.MaxSize))
+			return
+		}
+		response.BadRequest(c, errors.New("file: cannot be blank"), nil)
+		return
+	}
+
+	req := attachment.Request{
+		FileName: header.Filename,
+		Size:     header.Size,
+	}
+
+	if err = req.Validate(); err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		response.InternalServerError(c, err)
+		return
+	}
+	defer file.Close()
+
+	res, err := h.taskerService.UploadAttachment(c, id, req, file)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		case errors.Is(err, attachment.ErrorTooLarge):
+			response.RequestEntityTooLarge(c, err)
+		case errors.Is(err, attachment.ErrorType):
+			response.UnsupportedMediaType(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.Created(c, res)
+}
+
+// getAttachment godoc
+//
+//	@Summary		Get an attachment
+//	@Description	Get the metadata of a file attached to a task
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string	true	"Task ID"
+//	@Param			attachment_id	path		string	true	"Attachment ID"
+//	@Param			include_deleted	query		bool	false	"Include soft deleted rows, admin only"
+//	@Success		200				{object}	attachment.Response
+//	@Failure		403				{object}	response.Object
+//	@Failure		404				{object}	response.Object
+//	@Failure		500				{object}	response.Object
+//	@Router			/tasks/{id}/attachments/{attachment_id} [get]
+func (h *TaskHandler) getAttachment(c *gin.Context) {
+	id, attachmentID := c.Param("id"), c.Param("attachment_id")
+
+	res, err := h.taskerService.GetAttachment(c, id, attachmentID)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, res)
+}
+
+// downloadAttachment godoc
+//
+//	@Summary		Download an attachment
+//	@Description	Get the content of a file attached to a task, a Range header requests part of it
+//	@Tags			tasks
+//	@Produce		octet-stream
+//	@Param			id				path		string	true	"Task ID"
+//	@Param			attachment_id	path		string	true	"Attachment ID"
+//	@Param			Range			header		string	false	"Byte range such as bytes=0-1023"
+//	@Param			include_deleted	query		bool	false	"Include soft deleted rows, admin only"
+//	@Success		200				{file}		file
+//	@Success		206				{file}		file
+//	@Failure		403				{object}	response.Object
+//	@Failure		404				{object}	response.Object
+//	@Failure		416				{string}	string
+//	@Failure		500				{object}	response.Object
+//	@Router			/tasks/{id}/attachments/{attachment_id}/content [get]
+func (h *TaskHandler) downloadAttachment(c *gin.Context) {
+	id, attachmentID := c.Param("id"), c.Param("attachment_id")
+
+	res, content, err := h.taskerService.OpenAttachment(c, id, attachmentID)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+	defer content.Close()
+
+	c.Header("Content-Type", res.ContentType)
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": res.FileName}))
+	c.Header("X-Content-Type-Options", "nosniff")
+
+	// ServeContent answers Range and If-Range requests with 206 and 416
+	http.ServeContent(c.Writer, c.Request, res.FileName, time.Time{}, content)
+}
+
+// deleteAttachment godoc
+//
+//	@Summary		Delete an attachment
+//	@Description	Delete a file attached to a task together with its content
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string	true	"Task ID"
+//	@Param			attachment_id	path		string	true	"Attachment ID"
+//	@Success		200				{string}	string	"Deleted Attachment ID"
+//	@Failure		403				{object}	response.Object
+//	@Failure		404				{object}	response.Object
+//	@Failure		500				{object}	response.Object
+//	@Router			/tasks/{id}/attachments/{attachment_id} [delete]
+func (h *TaskHandler) deleteAttachment(c *gin.Context) {
+	id, attachmentID := c.Param("id"), c.Param("attachment_id")
+
+	if err := h.taskerService.DeleteAttachment(c, id, attachmentID); err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, attachmentID)
+}
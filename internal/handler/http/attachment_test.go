@@ -0,0 +1,508 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"hard/internal/domain/attachment"
+	"hard/internal/domain/member"
+	"hard/internal/domain/project"
+	"hard/internal/domain/task"
+	"hard/internal/domain/user"
+	"hard/pkg/helpers"
+	"hard/pkg/store"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"hard/internal/service/tasker"
+)
+
+type MockAttachmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentRepository) List(ctx context.Context, taskID string, page store.Page) (dest []attachment.Entity, cursor store.Cursor, err error) {
+	args := m.Called(ctx, taskID, page)
+	return args.Get(0).([]attachment.Entity), args.Get(1).(store.Cursor), args.Error(2)
+}
+
+func (m *MockAttachmentRepository) Get(ctx context.Context, id string) (dest attachment.Entity, err error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(attachment.Entity), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) Add(ctx context.Context, data attachment.Entity) (id string, err error) {
+	args := m.Called(ctx, data)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) Delete(ctx context.Context, id string) (err error) {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockAttachmentRepository) ListOrphans(ctx context.Context, limit int) (dest []attachment.Entity, err error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]attachment.Entity), args.Error(1)
+}
+
+// fakeS3 is an in-memory stand-in for an S3-compatible service, it accepts
+// path-style PUT, HEAD, GET and DELETE requests signed with Signature Version 4.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func newFakeS3(t *testing.T) *httptest.Server {
+	fake := &fakeS3{objects: map[string][]byte{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	authorization := r.Header.Get("Authorization")
+	signed := map[string]bool{}
+	if _, list, ok := strings.Cut(authorization, "SignedHeaders="); ok {
+		list, _, _ = strings.Cut(list, ",")
+		for _, name := range strings.Split(list, ";") {
+			signed[name] = true
+		}
+	}
+	if !strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 Credential=access/") ||
+		!strings.Contains(authorization, "/us-east-1/s3/aws4_request") ||
+		!signed["host"] || !signed["x-amz-date"] || !signed["x-amz-content-sha256"] ||
+		r.Header.Get("Range") != "" && !signed["range"] ||
+		r.Header.Get("X-Amz-Date") == "" || r.Header.Get("X-Amz-Content-Sha256") == "" {
+		http.Error(w, "AccessDenied", http.StatusForbidden)
+		return
+	}
+
+	key, ok := strings.CutPrefix(r.URL.Path, "/attachments/")
+	if !ok {
+		http.Error(w, "NoSuchBucket", http.StatusNotFound)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		content, err := io.ReadAll(r.Body)
+		if err != nil || int64(len(content)) != r.ContentLength {
+			http.Error(w, "IncompleteBody", http.StatusBadRequest)
+			return
+		}
+		f.objects[key] = content
+	case http.MethodHead, http.MethodGet:
+		content, ok := f.objects[key]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		http.ServeContent(w, r, key, time.Time{}, bytes.NewReader(content))
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func multipartFile(name, content string) (body *bytes.Buffer, contentType string) {
+	body = new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	if name != "" {
+		part, _ := writer.CreateFormFile("file", name)
+		part.Write([]byte(content))
+	}
+	writer.Close()
+	return body, writer.FormDataContentType()
+}
+
+func TestAttachments(t *testing.T) {
+	createdAt := "2024-05-01T10:00:00Z"
+	stored := "hello, attachments"
+	newAttachment := func(id, taskID, uploaderID, name, contentType string, size int64, key string) attachment.Entity {
+		return attachment.Entity{
+			ID:          id,
+			TaskID:      helpers.GetStringPtr(taskID),
+			UploaderID:  helpers.GetStringPtr(uploaderID),
+			FileName:    helpers.GetStringPtr(name),
+			ContentType: helpers.GetStringPtr(contentType),
+			Size:        &size,
+			BlobKey:     key,
+			CreatedAt:   &createdAt,
+		}
+	}
+	attachments := map[string]attachment.Entity{
+		"4": newAttachment("4", "1", "user-id", "notes.txt", "text/plain", int64(len(stored)), "tasks/1/4"),
+		"5": newAttachment("5", "1", "admin-id", "report.txt", "text/plain", 11, "tasks/1/5"),
+		"6": newAttachment("6", "2", "user-id", "other.txt", "text/plain", 5, "tasks/2/6"),
+	}
+
+	tests := []struct {
+		name            string
+		caller          string
+		method          string
+		target          string
+		fileName        string
+		fileContent     string
+		rangeHeader     string
+		expectedStatus  int
+		expectedBody    string
+		expectedContent string
+		expectedHeaders map[string]string
+		expectedAdded   bool
+		expectedDeleted bool
+	}{
+		{
+			name:           "List Attachments",
+			method:         "GET",
+			target:         "/tasks/1/attachments",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[{"id":"4","task_id":"1","uploader_id":"user-id","file_name":"notes.txt","content_type":"text/plain","size":18,"created_at":"2024-05-01T10:00:00Z"}],"success":true}`,
+		},
+		{
+			name:           "Get Attachment",
+			method:         "GET",
+			target:         "/tasks/1/attachments/4",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"id":"4","task_id":"1","uploader_id":"user-id","file_name":"notes.txt","content_type":"text/plain","size":18,"created_at":"2024-05-01T10:00:00Z"},"success":true}`,
+		},
+		{
+			name:           "Attachment Of Another Task",
+			method:         "GET",
+			target:         "/tasks/1/attachments/6",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"error not found","success":false}`,
+		},
+		{
+			name:            "Download Attachment",
+			method:          "GET",
+			target:          "/tasks/1/attachments/4/content",
+			expectedStatus:  http.StatusOK,
+			expectedContent: stored,
+			expectedHeaders: map[string]string{
+				"Content-Type":           "text/plain",
+				"Content-Disposition":    "attachment; filename=notes.txt",
+				"Content-Length":         "18",
+				"Accept-Ranges":          "bytes",
+				"X-Content-Type-Options": "nosniff",
+			},
+		},
+		{
+			name:            "Download Range",
+			method:          "GET",
+			target:          "/tasks/1/attachments/4/content",
+			rangeHeader:     "bytes=7-17",
+			expectedStatus:  http.StatusPartialContent,
+			expectedContent: "attachments",
+			expectedHeaders: map[string]string{
+				"Content-Range":  "bytes 7-17/18",
+				"Content-Length": "11",
+			},
+		},
+		{
+			name:            "Download Suffix Range",
+			method:          "GET",
+			target:          "/tasks/1/attachments/4/content",
+			rangeHeader:     "bytes=-5",
+			expectedStatus:  http.StatusPartialContent,
+			expectedContent: "ments",
+			expectedHeaders: map[string]string{
+				"Content-Range": "bytes 13-17/18",
+			},
+		},
+		{
+			name:           "Unsatisfiable Range",
+			method:         "GET",
+			target:         "/tasks/1/attachments/4/content",
+			rangeHeader:    "bytes=100-",
+			expectedStatus: http.StatusRequestedRangeNotSatisfiable,
+			expectedHeaders: map[string]string{
+				"Content-Range": "bytes */18",
+			},
+		},
+		{
+			name:           "Missing Blob",
+			method:         "GET",
+			target:         "/tasks/1/attachments/5/content",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"error not found","success":false}`,
+		},
+		{
+			name:           "Upload Attachment",
+			method:         "POST",
+			target:         "/tasks/1/attachments",
+			fileName:       "report.txt",
+			fileContent:    "all is well",
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"data":{"id":"5","task_id":"1","uploader_id":"admin-id","file_name":"report.txt","content_type":"text/plain","size":11,"created_at":"2024-05-01T10:00:00Z"},"success":true}`,
+			expectedAdded:  true,
+		},
+		{
+			name:           "Upload Strips Directories",
+			method:         "POST",
+			target:         "/tasks/1/attachments",
+			fileName:       `C:\Users\jane\report.txt`,
+			fileContent:    "all is well",
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"data":{"id":"5","task_id":"1","uploader_id":"admin-id","file_name":"report.txt","content_type":"text/plain","size":11,"created_at":"2024-05-01T10:00:00Z"},"success":true}`,
+			expectedAdded:  true,
+		},
+		{
+			name:           "Upload Too Large",
+			method:         "POST",
+			target:         "/tasks/1/attachments",
+			fileName:       "big.txt",
+			fileContent:    strings.Repeat("a", 65),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedBody:   `{"message":"file: too large, the limit is 64 bytes","success":false}`,
+		},
+		{
+			name:           "Upload Disallowed Type",
+			method:         "POST",
+			target:         "/tasks/1/attachments",
+			fileName:       "notes.txt",
+			fileContent:    "%PDF-1.4 pretending to be text",
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedBody:   `{"message":"file: content type is not allowed","success":false}`,
+		},
+		{
+			name:           "Upload Without File",
+			method:         "POST",
+			target:         "/tasks/1/attachments",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"file: cannot be blank","success":false}`,
+		},
+		{
+			name:           "Upload Empty File",
+			method:         "POST",
+			target:         "/tasks/1/attachments",
+			fileName:       "empty.txt",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"file: cannot be empty","success":false}`,
+		},
+		{
+			name:           "Upload To Missing Task",
+			method:         "POST",
+			target:         "/tasks/9/attachments",
+			fileName:       "report.txt",
+			fileContent:    "all is well",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"error not found","success":false}`,
+		},
+		{
+			name:           "Delete By Another User",
+			caller:         "other-id",
+			method:         "DELETE",
+			target:         "/tasks/1/attachments/4",
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"message":"permission denied","success":false}`,
+		},
+		{
+			name:            "Delete By Uploader",
+			caller:          "user-id",
+			method:          "DELETE",
+			target:          "/tasks/1/attachments/4",
+			expectedStatus:  http.StatusOK,
+			expectedBody:    `{"data":"4","success":true}`,
+			expectedDeleted: true,
+		},
+	}
+
+	stores := map[string]func(t *testing.T) store.BlobStore{
+		"Local": func(t *testing.T) store.BlobStore {
+			blobs, err := store.NewLocalBlobStore(t.TempDir())
+			assert.NoError(t, err)
+			return blobs
+		},
+		"S3": func(t *testing.T) store.BlobStore {
+			blobs, err := store.NewS3BlobStore(store.S3Config{
+				Endpoint:  newFakeS3(t).URL,
+				Bucket:    "attachments",
+				AccessKey: "access",
+				SecretKey: "secret",
+			})
+			assert.NoError(t, err)
+			return blobs
+		},
+	}
+
+	for storeName, newStore := range stores {
+		for _, tt := range tests {
+			t.Run(storeName+"/"+tt.name, func(t *testing.T) {
+				blobs := newStore(t)
+				assert.NoError(t, blobs.Put(context.Background(), "tasks/1/4", strings.NewReader(stored), int64(len(stored)), "text/plain"))
+
+				mockUserRepo := mockAdmin()
+				mockUserRepo.On("Get", mock.Anything, "user-id").Return(user.Entity{ID: "user-id", Role: helpers.GetStringPtr("user")}, nil)
+				mockUserRepo.On("Get", mock.Anything, "other-id").Return(user.Entity{ID: "other-id", Role: helpers.GetStringPtr("user")}, nil)
+
+				mockTaskRepo := new(MockTaskRepository)
+				mockTaskRepo.On("Get", mock.Anything, "9").Return(nil, store.ErrorNotFound)
+				mockTaskRepo.On("Get", mock.Anything, "1").Return(task.Entity{ID: "1", ProjectID: helpers.GetStringPtr("2")}, nil)
+
+				mockProjectRepo := new(MockProjectRepository)
+				mockProjectRepo.On("Get", mock.Anything, "2").Return(project.Entity{ID: "2", ManagerID: helpers.GetStringPtr("manager-id")}, nil)
+
+				mockMemberRepo := new(MockMemberRepository)
+				mockMemberRepo.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(member.Entity{}, store.ErrorNotFound)
+
+				mockAttachmentRepo := new(MockAttachmentRepository)
+				mockAttachmentRepo.On("List", mock.Anything, "1", mock.Anything).Return([]attachment.Entity{attachments["4"]}, store.Cursor{}, nil)
+				for id, data := range attachments {
+					mockAttachmentRepo.On("Get", mock.Anything, id).Return(data, nil)
+				}
+				mockAttachmentRepo.On("Get", mock.Anything, mock.Anything).Return(attachment.Entity{}, store.ErrorNotFound)
+				mockAttachmentRepo.On("Add", mock.Anything, mock.Anything).Return("5", nil)
+				mockAttachmentRepo.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+				mockAuditRepo := new(MockAuditRepository)
+				mockAuditRepo.On("Add", mock.Anything, mock.Anything).Return(nil)
+
+				taskService, _ := tasker.New(
+					tasker.WithUserRepository(mockUserRepo),
+					tasker.WithTaskRepository(mockTaskRepo),
+					tasker.WithProjectRepository(mockProjectRepo),
+					tasker.WithMemberRepository(mockMemberRepo),
+					tasker.WithAttachmentRepository(mockAttachmentRepo),
+					tasker.WithAuditRepository(mockAuditRepo),
+					tasker.WithBlobStore(blobs),
+					tasker.WithAttachmentLimits(attachment.Limits{MaxSize: 64, Types: []string{"text/plain"}}),
+				)
+
+				caller := tt.caller
+				if caller == "" {
+					caller = "admin-id"
+				}
+
+				gin.SetMode(gin.TestMode)
+				r := authenticated(caller)
+				NewTaskHandler(taskService).Routes(r.Group("/"))
+
+				body, contentType := multipartFile(tt.fileName, tt.fileContent)
+				w := httptest.NewRecorder()
+				req := httptest.NewRequest(tt.method, tt.target, body)
+				req.Header.Set("Content-Type", contentType)
+				if tt.rangeHeader != "" {
+					req.Header.Set("Range", tt.rangeHeader)
+				}
+
+				r.ServeHTTP(w, req)
+
+				assert.Equal(t, tt.expectedStatus, w.Code)
+				if tt.expectedBody != "" {
+					assert.JSONEq(t, tt.expectedBody, w.Body.String())
+				}
+				if tt.expectedContent != "" {
+					assert.Equal(t, tt.expectedContent, w.Body.String())
+				}
+				for name, value := range tt.expectedHeaders {
+					assert.Equal(t, value, w.Header().Get(name), name)
+				}
+
+				if tt.expectedAdded {
+					var added attachment.Entity
+					if !mockAttachmentRepo.AssertCalled(t, "Add", mock.Anything, mock.MatchedBy(func(data attachment.Entity) bool {
+						added = data
+						return true
+					})) {
+						return
+					}
+					assert.Equal(t, "report.txt", *added.FileName)
+					assert.Equal(t, "text/plain", *added.ContentType)
+					assert.Equal(t, int64(len(tt.fileContent)), *added.Size)
+					assert.True(t, strings.HasPrefix(added.BlobKey, "tasks/1/"))
+
+					content, err := blobs.Open(context.Background(), added.BlobKey)
+					assert.NoError(t, err)
+					uploaded, _ := io.ReadAll(content)
+					content.Close()
+					assert.Equal(t, tt.fileContent, string(uploaded))
+				} else {
+					mockAttachmentRepo.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+				}
+
+				content, err := blobs.Open(context.Background(), "tasks/1/4")
+				if err == nil {
+					content.Close()
+				}
+				if tt.expectedDeleted {
+					mockAttachmentRepo.AssertCalled(t, "Delete", mock.Anything, "4")
+					assert.ErrorIs(t, err, store.ErrorNotFound)
+				} else {
+					mockAttachmentRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+					assert.NoError(t, err)
+				}
+			})
+		}
+	}
+}
+
+func TestBlobKeys(t *testing.T) {
+	blobs, err := store.NewLocalBlobStore(t.TempDir())
+	assert.NoError(t, err)
+
+	for _, key := range []string{"", "/etc/passwd", "../outside", "tasks/../../outside", "tasks//1"} {
+		err := blobs.Put(context.Background(), key, strings.NewReader("x"), 1, "text/plain")
+		assert.ErrorIs(t, err, store.ErrorInvalidKey, key)
+	}
+}
+
+func TestPurgeAttachments(t *testing.T) {
+	retention := 30 * 24 * time.Hour
+
+	blobs, err := store.NewLocalBlobStore(t.TempDir())
+	assert.NoError(t, err)
+	for _, key := range []string{"tasks/3/a", "tasks/3/b", "tasks/1/c"} {
+		assert.NoError(t, blobs.Put(context.Background(), key, strings.NewReader("x"), 1, "text/plain"))
+	}
+
+	mockTaskRepo := new(MockTaskRepository)
+	mockTaskRepo.On("Purge", mock.Anything, retention).Return(int64(1), nil)
+	mockProjectRepo := new(MockProjectRepository)
+	mockProjectRepo.On("Purge", mock.Anything, retention).Return(int64(0), nil)
+	mockUserRepo := new(MockUserRepository)
+	mockUserRepo.On("Purge", mock.Anything, retention).Return(int64(0), nil)
+	mockAttachmentRepo := new(MockAttachmentRepository)
+	mockAttachmentRepo.On("ListOrphans", mock.Anything, mock.Anything).Return([]attachment.Entity{
+		{ID: "7", BlobKey: "tasks/3/a"},
+		{ID: "8", BlobKey: "tasks/3/b"},
+	}, nil)
+	mockAttachmentRepo.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+	taskService, _ := tasker.New(
+		tasker.WithUserRepository(mockUserRepo),
+		tasker.WithTaskRepository(mockTaskRepo),
+		tasker.WithProjectRepository(mockProjectRepo),
+		tasker.WithAttachmentRepository(mockAttachmentRepo),
+		tasker.WithBlobStore(blobs),
+	)
+
+	n, err := taskService.Purge(context.Background(), retention)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	mockAttachmentRepo.AssertCalled(t, "Delete", mock.Anything, "7")
+	mockAttachmentRepo.AssertCalled(t, "Delete", mock.Anything, "8")
+	for key, exists := range map[string]bool{"tasks/3/a": false, "tasks/3/b": false, "tasks/1/c": true} {
+		content, err := blobs.Open(context.Background(), key)
+		if exists {
+			content.Close()
+			assert.NoError(t, err, key)
+		} else {
+			assert.ErrorIs(t, err, store.ErrorNotFound, key)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package http
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"hard/internal/domain/access"
+	"hard/internal/domain/audit"
+	"hard/internal/service/tasker"
+	"hard/pkg/server/response"
+	"hard/pkg/store"
+)
+
+type AuditHandler struct {
+	taskerService *tasker.Service
+}
+
+func NewAuditHandler(s *tasker.Service) *AuditHandler {
+	return &AuditHandler{taskerService: s}
+}
+
+// Routes sets up the routes for the audit log
+func (h *AuditHandler) Routes(r *gin.RouterGroup) {
+	api := r.Group("/audit")
+	{
+		api.GET("/", h.list)
+	}
+}
+
+// listAudit godoc
+//
+//	@Summary		List audit log
+//	@Description	Get the recorded changes, newest first
+//	@Tags			audit
+//	@Accept			json
+//	@Produce		json
+//	@Param			entity		query		string	false	"Entity type: user, task, project, workflow or member"
+//	@Param			id			query		string	false	"Entity ID"
+//	@Param			actor_id	query		string	false	"ID of the user who made the change"
+//	@Param			limit		query		int		false	"Page size"
+//	@Param			after		query		string	false	"Cursor of the next page"
+//	@Param			before		query		string	false	"Cursor of the previous page"
+//	@Success		200			{array}		audit.Response
+//	@Failure		400			{object}	response.Object
+//	@Failure		403			{object}	response.Object
+//	@Failure		500			{object}	response.Object
+//	@Router			/audit [get]
+func (h *AuditHandler) list(c *gin.Context) {
+	filter := audit.Filter{
+		EntityType: c.Query("entity"),
+		EntityID:   c.Query("id"),
+		ActorID:    c.Query("actor_id"),
+	}
+	if err := filter.Validate(); err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	page, err := parsePage(c)
+	if err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	res, cursor, err := h.taskerService.ListAudit(c, filter, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorInvalidCursor):
+			response.BadRequest(c, err, nil)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OKPage(c, res, cursor.Next, cursor.Prev)
+}
@@ -0,0 +1,230 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"hard/internal/domain/audit"
+	"hard/internal/domain/member"
+	"hard/internal/domain/project"
+	"hard/internal/domain/task"
+	"hard/internal/domain/user"
+	"hard/pkg/helpers"
+	"hard/pkg/store"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"hard/internal/service/tasker"
+)
+
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) Add(ctx context.Context, data audit.Entity) (err error) {
+	args := m.Called(ctx, data)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) List(ctx context.Context, filter audit.Filter, page store.Page) (dest []audit.Entity, cursor store.Cursor, err error) {
+	args := m.Called(ctx, filter, page)
+	return args.Get(0).([]audit.Entity), args.Get(1).(store.Cursor), args.Error(2)
+}
+
+// MockTransactor counts transactions and whether they were committed.
+type MockTransactor struct {
+	started, committed int
+}
+
+func (m *MockTransactor) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.started++
+	if err := fn(ctx); err != nil {
+		return err
+	}
+	m.committed++
+	return nil
+}
+
+func TestAuditLog(t *testing.T) {
+	current := task.Entity{
+		ID:         "5",
+		Title:      helpers.GetStringPtr("Rescue"),
+		Priority:   helpers.GetStringPtr("High"),
+		AssigneeID: helpers.GetStringPtr("2"),
+		ProjectID:  helpers.GetStringPtr("1"),
+	}
+	beta := project.Entity{ID: "2", Title: helpers.GetStringPtr("Beta"), ManagerID: helpers.GetStringPtr("2")}
+	recorded := audit.Entity{
+		ID:         "10",
+		ActorID:    helpers.GetStringPtr("admin-id"),
+		EntityType: audit.EntityTask,
+		EntityID:   "5",
+		Operation:  audit.OperationUpdate,
+		Diff:       audit.Diff{"assignee_id": {Old: "2", New: "3"}},
+		CreatedAt:  time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name              string
+		callerID          string
+		method            string
+		target            string
+		inputBody         string
+		expectedRecord    *audit.Entity
+		mockAuditError    error
+		expectedStatus    int
+		expectedBody      string
+		expectedCommitted int
+	}{
+		{
+			name:      "Assignee Change Is Recorded",
+			callerID:  "admin-id",
+			method:    "PUT",
+			target:    "/tasks/5",
+			inputBody: `{"assignee_id":"3","priority":"High"}`,
+			expectedRecord: &audit.Entity{
+				ActorID:    helpers.GetStringPtr("admin-id"),
+				EntityType: audit.EntityTask,
+				EntityID:   "5",
+				Operation:  audit.OperationUpdate,
+				Diff:       audit.Diff{"assignee_id": {Old: "2", New: "3"}},
+			},
+			expectedStatus:    http.StatusOK,
+			expectedBody:      `{"data":"ok","success":true}`,
+			expectedCommitted: 1,
+		},
+		{
+			name:     "Project Deletion Is Recorded",
+			callerID: "admin-id",
+			method:   "DELETE",
+			target:   "/projects/2",
+			expectedRecord: &audit.Entity{
+				ActorID:    helpers.GetStringPtr("admin-id"),
+				EntityType: audit.EntityProject,
+				EntityID:   "2",
+				Operation:  audit.OperationDelete,
+				Diff: audit.Diff{
+					"id":         {Old: "2"},
+					"title":      {Old: "Beta"},
+					"manager_id": {Old: "2"},
+				},
+			},
+			expectedStatus:    http.StatusOK,
+			expectedBody:      `{"data":"2","success":true}`,
+			expectedCommitted: 1,
+		},
+		{
+			name:              "Failed Audit Rolls Back",
+			callerID:          "admin-id",
+			method:            "DELETE",
+			target:            "/projects/2",
+			mockAuditError:    errors.New("audit error"),
+			expectedStatus:    http.StatusInternalServerError,
+			expectedBody:      `{"message":"audit error","success":false}`,
+			expectedCommitted: 0,
+		},
+		{
+			name:           "Query Audit Log",
+			callerID:       "admin-id",
+			method:         "GET",
+			target:         "/audit/?entity=task&id=5",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[{"id":"10","actor_id":"admin-id","entity":"task","entity_id":"5","operation":"update","diff":{"assignee_id":{"old":"2","new":"3"}},"created_at":"2024-05-01T10:00:00Z"}],"success":true}`,
+		},
+		{
+			name:           "Unknown Entity",
+			callerID:       "admin-id",
+			method:         "GET",
+			target:         "/audit/?entity=invoice",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"entity: must be one of user, task, project, workflow, member, dependency, predecessor, label, task_label, comment, attachment, time_entry, recurrence, milestone, webhook","success":false}`,
+		},
+		{
+			name:           "Audit Log Is Admin Only",
+			callerID:       "2",
+			method:         "GET",
+			target:         "/audit/?entity=task&id=5",
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"message":"permission denied","success":false}`,
+		},
+		{
+			name:           "Task History",
+			callerID:       "2",
+			method:         "GET",
+			target:         "/tasks/5/history",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[{"id":"10","actor_id":"admin-id","entity":"task","entity_id":"5","operation":"update","diff":{"assignee_id":{"old":"2","new":"3"}},"created_at":"2024-05-01T10:00:00Z"}],"success":true}`,
+		},
+		{
+			name:           "Task History Of Unrelated User",
+			callerID:       "4",
+			method:         "GET",
+			target:         "/tasks/5/history",
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"message":"permission denied","success":false}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := mockAdmin()
+			mockUserRepo.On("Get", mock.Anything, "2").Return(user.Entity{ID: "2", Role: helpers.GetStringPtr("scientist")}, nil)
+			mockUserRepo.On("Get", mock.Anything, "4").Return(user.Entity{ID: "4", Role: helpers.GetStringPtr("scientist")}, nil)
+
+			mockTaskRepo := new(MockTaskRepository)
+			mockTaskRepo.On("Get", mock.Anything, "5").Return(current, nil)
+			mockTaskRepo.On("Update", mock.Anything, "5", mock.Anything).Return(nil)
+
+			mockProjectRepo := new(MockProjectRepository)
+			mockProjectRepo.On("Get", mock.Anything, "1").Return(project.Entity{ID: "1", ManagerID: helpers.GetStringPtr("1")}, nil)
+			mockProjectRepo.On("Get", mock.Anything, "2").Return(beta, nil)
+			mockProjectRepo.On("Delete", mock.Anything, "2", mock.Anything).Return(nil)
+
+			mockMemberRepo := new(MockMemberRepository)
+			mockMemberRepo.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(member.Entity{}, nil)
+
+			mockAuditRepo := new(MockAuditRepository)
+			if tt.expectedRecord != nil {
+				mockAuditRepo.On("Add", mock.Anything, *tt.expectedRecord).Return(nil).Once()
+			} else {
+				mockAuditRepo.On("Add", mock.Anything, mock.Anything).Return(tt.mockAuditError)
+			}
+			mockAuditRepo.On("List", mock.Anything, audit.Filter{EntityType: "task", EntityID: "5"}, mock.Anything).Return([]audit.Entity{recorded}, store.Cursor{}, nil)
+
+			transactor := new(MockTransactor)
+			taskService, _ := tasker.New(
+				tasker.WithUserRepository(mockUserRepo),
+				tasker.WithTaskRepository(mockTaskRepo),
+				tasker.WithProjectRepository(mockProjectRepo),
+				tasker.WithMemberRepository(mockMemberRepo),
+				tasker.WithAuditRepository(mockAuditRepo),
+				tasker.WithTransactor(transactor),
+			)
+
+			gin.SetMode(gin.TestMode)
+			r := authenticated(tt.callerID)
+			api := r.Group("/")
+			NewTaskHandler(taskService).Routes(api)
+			NewProjectHandler(taskService).Routes(api)
+			NewAuditHandler(taskService).Routes(api)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.target, bytes.NewBufferString(tt.inputBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			assert.Equal(t, tt.expectedCommitted, transactor.committed)
+			if tt.expectedRecord != nil {
+				mockAuditRepo.AssertCalled(t, "Add", mock.Anything, *tt.expectedRecord)
+			}
+		})
+	}
+}
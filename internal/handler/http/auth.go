@@ -0,0 +1,66 @@
+package http
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"hard/internal/domain/user"
+	"hard/internal/service/tasker"
+	"hard/pkg/auth"
+	"hard/pkg/server/response"
+)
+
+type AuthHandler struct {
+	taskerService *tasker.Service
+}
+
+func NewAuthHandler(s *tasker.Service) *AuthHandler {
+	return &AuthHandler{taskerService: s}
+}
+
+// Routes sets up the routes for authentication
+func (h *AuthHandler) Routes(r *gin.RouterGroup) {
+	api := r.Group("/auth")
+	{
+		api.POST("/login", h.login)
+	}
+}
+
+// login godoc
+//
+//	@Summary		Log in
+//	@Description	Issue a bearer token for an existing user
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			credentials	body		user.LoginRequest	true	"Login Request"
+//	@Success		200			{object}	user.TokenResponse
+//	@Failure		400			{object}	response.Object
+//	@Failure		401			{object}	response.Object
+//	@Failure		500			{object}	response.Object
+//	@Router			/auth/login [post]
+func (h *AuthHandler) login(c *gin.Context) {
+	req := user.LoginRequest{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	res, err := h.taskerService.Login(c, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrorInvalidCredentials):
+			response.Unauthorized(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, res)
+}
@@ -0,0 +1,344 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"hard/internal/domain/project"
+	"hard/internal/domain/task"
+	"hard/internal/domain/user"
+	"hard/pkg/auth"
+	"hard/pkg/helpers"
+	"hard/pkg/server/router"
+	"hard/pkg/store"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+	"hard/internal/service/tasker"
+)
+
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) List(ctx context.Context, page store.Page) (dest []user.Entity, cursor store.Cursor, err error) {
+	args := m.Called(ctx, page)
+	return args.Get(0).([]user.Entity), args.Get(1).(store.Cursor), args.Error(2)
+}
+
+func (m *MockUserRepository) Add(ctx context.Context, data user.Entity) (id string, err error) {
+	args := m.Called(ctx, data)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockUserRepository) AddMany(ctx context.Context, data []user.Entity) (ids []string, err error) {
+	args := m.Called(ctx, data)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockUserRepository) Get(ctx context.Context, id string) (dest user.Entity, err error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(user.Entity), args.Error(1)
+}
+
+func (m *MockUserRepository) GetMany(ctx context.Context, ids []string) (dest []user.Entity, err error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).([]user.Entity), args.Error(1)
+}
+
+func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (dest user.Entity, err error) {
+	args := m.Called(ctx, email)
+	return args.Get(0).(user.Entity), args.Error(1)
+}
+
+func (m *MockUserRepository) GetPasswordHash(ctx context.Context, id string) (hash string, err error) {
+	args := m.Called(ctx, id)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockUserRepository) SetPasswordHash(ctx context.Context, id string, hash string) (err error) {
+	args := m.Called(ctx, id, hash)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) Update(ctx context.Context, id string, data user.Entity) (err error) {
+	args := m.Called(ctx, id, data)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) Delete(ctx context.Context, id string, version *int) (err error) {
+	args := m.Called(ctx, id, version)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) Restore(ctx context.Context, id string) (err error) {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) Purge(ctx context.Context, retention time.Duration) (n int64, err error) {
+	args := m.Called(ctx, retention)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockUserRepository) Search(ctx context.Context, name string, email string, page store.Page) (dest []user.Entity, cursor store.Cursor, err error) {
+	args := m.Called(ctx, name, email, page)
+	return args.Get(0).([]user.Entity), args.Get(1).(store.Cursor), args.Error(2)
+}
+
+func (m *MockUserRepository) ListTasks(ctx context.Context, id string, page store.Page) (dest []task.Entity, cursor store.Cursor, err error) {
+	args := m.Called(ctx, id, page)
+	return args.Get(0).([]task.Entity), args.Get(1).(store.Cursor), args.Error(2)
+}
+
+func (m *MockUserRepository) ListProjects(ctx context.Context, id string, page store.Page) (dest []project.Entity, cursor store.Cursor, err error) {
+	args := m.Called(ctx, id, page)
+	return args.Get(0).([]project.Entity), args.Get(1).(store.Cursor), args.Error(2)
+}
+
+func TestLogin(t *testing.T) {
+	rick := user.Entity{
+		ID:       "1",
+		FullName: helpers.GetStringPtr("Rick Sanchez"),
+		Email:    helpers.GetStringPtr("rick@example.com"),
+		Role:     helpers.GetStringPtr("scientist"),
+	}
+
+	tests := []struct {
+		name           string
+		inputBody      string
+		mockUser       user.Entity
+		mockRepoError  error
+		noPassword     bool
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Successful Login",
+			inputBody:      `{"email":"rick@example.com","password":"secret"}`,
+			mockUser:       rick,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Wrong Password",
+			inputBody:      `{"email":"rick@example.com","password":"guess"}`,
+			mockUser:       rick,
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"message":"invalid credentials","success":false}`,
+		},
+		{
+			name:           "No Password Set",
+			inputBody:      `{"email":"rick@example.com","password":""}`,
+			mockUser:       rick,
+			noPassword:     true,
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"message":"invalid credentials","success":false}`,
+		},
+		{
+			name:           "Unknown Email",
+			inputBody:      `{"email":"morty@example.com","password":"secret"}`,
+			mockRepoError:  store.ErrorNotFound,
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"message":"invalid credentials","success":false}`,
+		},
+		{
+			name:           "Missing Email",
+			inputBody:      `{"password":"secret"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"email: cannot be blank","success":false}`,
+		},
+		{
+			name:           "Internal Server Error",
+			inputBody:      `{"email":"rick@example.com","password":"secret"}`,
+			mockRepoError:  errors.New("repository error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"repository error","success":false}`,
+		},
+	}
+
+	tokens, _ := auth.New(auth.Config{Secret: "test-secret", Issuer: "hard"})
+	hash, _ := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			mockRepo.On("GetByEmail", mock.Anything, mock.Anything).Return(tt.mockUser, tt.mockRepoError)
+			mockRepo.On("Get", mock.Anything, tt.mockUser.ID).Return(tt.mockUser, nil)
+			if tt.noPassword {
+				mockRepo.On("GetPasswordHash", mock.Anything, tt.mockUser.ID).Return("", nil)
+			} else {
+				mockRepo.On("GetPasswordHash", mock.Anything, tt.mockUser.ID).Return(string(hash), nil)
+			}
+
+			taskService, _ := tasker.New(
+				tasker.WithUserRepository(mockRepo),
+				tasker.WithIdentityProvider(tasker.NewDirectoryProvider(mockRepo)),
+				tasker.WithTokens(tokens),
+			)
+
+			authHandler := NewAuthHandler(taskService)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/auth/login", authHandler.login)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/auth/login", bytes.NewBufferString(tt.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, w.Body.String())
+				return
+			}
+
+			body := struct {
+				Data user.TokenResponse `json:"data"`
+			}{}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, "Bearer", body.Data.TokenType)
+			assert.Equal(t, "rick@example.com", body.Data.User.Email)
+
+			userID, err := tokens.Verify(body.Data.AccessToken)
+			assert.NoError(t, err)
+			assert.Equal(t, "1", userID)
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	jwks, _ := json.Marshal(map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "test",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	})
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	assert.NoError(t, os.WriteFile(jwksFile, jwks, 0o600))
+
+	tokens, err := auth.New(auth.Config{Secret: "test-secret", JWKSFile: jwksFile, Issuer: "hard"})
+	assert.NoError(t, err)
+
+	hs256, _, _ := tokens.Issue("1")
+
+	sign := func(method jwt.SigningMethod, key any, kid string, claims jwt.RegisteredClaims) string {
+		token := jwt.NewWithClaims(method, claims)
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+		signed, _ := token.SignedString(key)
+		return signed
+	}
+	valid := jwt.RegisteredClaims{
+		Subject:   "2",
+		Issuer:    "hard",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+	expired := valid
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+	foreign := valid
+	foreign.Issuer = "someone-else"
+
+	tests := []struct {
+		name           string
+		header         string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "HS256 Token",
+			header:         "Bearer " + hs256,
+			expectedStatus: http.StatusOK,
+			expectedBody:   "1",
+		},
+		{
+			name:           "RS256 Token From JWKS",
+			header:         "Bearer " + sign(jwt.SigningMethodRS256, key, "test", valid),
+			expectedStatus: http.StatusOK,
+			expectedBody:   "2",
+		},
+		{
+			name:           "Missing Token",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"message":"invalid or expired token","success":false}`,
+		},
+		{
+			name:           "Expired Token",
+			header:         "Bearer " + sign(jwt.SigningMethodHS256, []byte("test-secret"), "", expired),
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"message":"invalid or expired token","success":false}`,
+		},
+		{
+			name:           "Wrong Issuer",
+			header:         "Bearer " + sign(jwt.SigningMethodHS256, []byte("test-secret"), "", foreign),
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"message":"invalid or expired token","success":false}`,
+		},
+		{
+			name:           "Wrong Secret",
+			header:         "Bearer " + sign(jwt.SigningMethodHS256, []byte("other-secret"), "", valid),
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"message":"invalid or expired token","success":false}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			r := router.New()
+			r.GET("/me", router.Authenticate(tokens), func(c *gin.Context) {
+				userID, _ := auth.UserID(c)
+				c.String(http.StatusOK, userID)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/me", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
+
+// authenticated returns a test engine that serves requests on behalf of the user.
+func authenticated(userID string) *gin.Engine {
+	r := gin.New()
+	r.ContextWithFallback = true
+	r.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(auth.WithUserID(c.Request.Context(), userID))
+		c.Next()
+	})
+	return r
+}
+
+func mockAdmin() *MockUserRepository {
+	mockRepo := new(MockUserRepository)
+	mockRepo.On("Get", mock.Anything, "admin-id").Return(user.Entity{ID: "admin-id", Role: helpers.GetStringPtr("admin")}, nil)
+	return mockRepo
+}
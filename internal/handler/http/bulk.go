@@ -0,0 +1,320 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"hard/internal/domain/access"
+	"hard/internal/domain/bulk"
+	"hard/internal/domain/dependency"
+	"hard/internal/domain/member"
+	"hard/internal/domain/project"
+	"hard/internal/domain/task"
+	"hard/internal/domain/user"
+	"hard/internal/domain/workflow"
+	"hard/pkg/server/response"
+	"hard/pkg/store"
+	"net/http"
+)
+
+// invalidItem is an item rejected before it reached the service.
+type invalidItem struct {
+	err error
+}
+
+func (e invalidItem) Error() string { return e.err.Error() }
+
+func (e invalidItem) Unwrap() error { return e.err }
+
+// serveBulk validates the items of a bulk request and passes the valid ones
+// to run. In atomic mode an invalid item fails the request before run is
+// called. created is the status of an applied item.
+func serveBulk[T any](c *gin.Context, created int, validate func(item *T) error,
+	run func(ctx context.Context, items []T, mode bulk.Mode) ([]bulk.Outcome, error)) {
+	req := bulk.Request[T]{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	status := func(err error) int {
+		if err == nil {
+			return created
+		}
+		return bulkStatus(err)
+	}
+
+	outcomes := make([]bulk.Outcome, len(req.Items))
+	items, indexes := make([]T, 0, len(req.Items)), make([]int, 0, len(req.Items))
+	for i := range req.Items {
+		if err := validate(&req.Items[i]); err != nil {
+			outcomes[i].Err = invalidItem{err}
+			continue
+		}
+		items, indexes = append(items, req.Items[i]), append(indexes, i)
+	}
+
+	if req.Mode == bulk.ModeAtomic && len(items) < len(req.Items) {
+		bulk.Abort(outcomes, nil)
+		response.BadRequest(c, bulk.FirstError(outcomes), bulk.ParseFromOutcomes(req.Mode, outcomes, status))
+		return
+	}
+
+	var err error
+	if len(items) > 0 {
+		var applied []bulk.Outcome
+		if applied, err = run(c, items, req.Mode); applied == nil && err != nil {
+			response.Error(c, bulkStatus(err), err, nil)
+			return
+		}
+		for j, i := range indexes {
+			outcomes[i] = applied[j]
+		}
+	}
+
+	res := bulk.ParseFromOutcomes(req.Mode, outcomes, status)
+
+	switch {
+	case req.Mode == bulk.ModePartial:
+		response.OK(c, res)
+	case err != nil:
+		response.Error(c, bulkStatus(err), err, res)
+	case created == http.StatusCreated:
+		response.Created(c, res)
+	default:
+		response.OK(c, res)
+	}
+}
+
+// bulkStatus is the status an item would have been answered with on its own.
+func bulkStatus(err error) int {
+	var invalid invalidItem
+
+	switch {
+	case errors.As(err, &invalid), errors.Is(err, store.ErrorInvalidInput):
+		return http.StatusBadRequest
+	case errors.Is(err, bulk.ErrorAborted):
+		return http.StatusFailedDependency
+	case errors.Is(err, store.ErrorNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, access.ErrorForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, store.ErrorConflict):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, workflow.ErrorTransitionNotAllowed), errors.Is(err, task.ErrorOpenSubtasks), errors.Is(err, dependency.ErrorBlocked):
+		return http.StatusConflict
+	case errors.Is(err, workflow.ErrorUnknownStatus), errors.Is(err, member.ErrorNotMember), isParentError(err), isMilestoneError(err),
+		errors.Is(err, task.ErrorDueDate):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func validateKey(key *bulk.Key) error {
+	return key.Validate()
+}
+
+func validateTaskUpdate(item *bulk.Update[task.Request]) error {
+	if err := item.Validate(); err != nil {
+		return err
+	}
+	if item.Data.IsEmpty() {
+		return bulk.ErrorNoChange
+	}
+	return item.Data.ValidateUpdate()
+}
+
+// addTasks godoc
+//
+//	@Summary		Add tasks in bulk
+//	@Description	Create up to 1000 tasks with one multi-row insert. In atomic mode, the default, every task is created or none of them: the response has the status of the first failed task and the others are reported with 424. In partial mode the valid tasks are created and the response is 200 with the status and error of each task
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			tasks	body		bulk.Request[task.Request]	true	"Tasks"
+//	@Success		200		{object}	bulk.Response
+//	@Success		201		{object}	bulk.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		422		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/tasks/bulk [post]
+func (h *TaskHandler) addBulk(c *gin.Context) {
+	serveBulk(c, http.StatusCreated, (*task.Request).Validate, h.taskerService.CreateTasks)
+}
+
+// updateTasks godoc
+//
+//	@Summary		Update tasks in bulk
+//	@Description	Update up to 1000 tasks, each item has the id of the task, an optional version and the changed fields. In atomic mode, the default, the tasks are updated in one transaction that stops at the first failure. In partial mode each task is updated on its own
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			tasks	body		bulk.Request[bulk.Update[task.Request]]	true	"Tasks"
+//	@Success		200		{object}	bulk.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		409		{object}	response.Object
+//	@Failure		412		{object}	response.Object
+//	@Failure		422		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/tasks/bulk [patch]
+func (h *TaskHandler) updateBulk(c *gin.Context) {
+	serveBulk(c, http.StatusOK, validateTaskUpdate, h.taskerService.UpdateTasks)
+}
+
+// deleteTasks godoc
+//
+//	@Summary		Delete tasks in bulk
+//	@Description	Delete up to 1000 tasks, each item has the id of the task and an optional version. In atomic mode, the default, the tasks are deleted in one transaction that stops at the first failure. In partial mode each task is deleted on its own
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			tasks	body		bulk.Request[bulk.Key]	true	"Tasks"
+//	@Success		200		{object}	bulk.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		412		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/tasks/bulk [delete]
+func (h *TaskHandler) deleteBulk(c *gin.Context) {
+	serveBulk(c, http.StatusOK, validateKey, h.taskerService.DeleteTasks)
+}
+
+func validateUserUpdate(item *bulk.Update[user.Request]) error {
+	if err := item.Validate(); err != nil {
+		return err
+	}
+	if item.Data.IsEmpty() {
+		return bulk.ErrorNoChange
+	}
+	return nil
+}
+
+// addUsers godoc
+//
+//	@Summary		Add users in bulk
+//	@Description	Create up to 1000 users with one multi-row insert, admin only. In atomic mode, the default, every user is created or none of them. In partial mode the valid users are created and the response is 200 with the status and error of each user
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			users	body		bulk.Request[user.Request]	true	"Users"
+//	@Success		200		{object}	bulk.Response
+//	@Success		201		{object}	bulk.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/users/bulk [post]
+func (h *UserHandler) addBulk(c *gin.Context) {
+	serveBulk(c, http.StatusCreated, (*user.Request).Validate, h.taskerService.CreateUsers)
+}
+
+// updateUsers godoc
+//
+//	@Summary		Update users in bulk
+//	@Description	Update up to 1000 users, each item has the id of the user, an optional version and the changed fields. In atomic mode, the default, the users are updated in one transaction that stops at the first failure. In partial mode each user is updated on its own
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			users	body		bulk.Request[bulk.Update[user.Request]]	true	"Users"
+//	@Success		200		{object}	bulk.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		412		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/users/bulk [patch]
+func (h *UserHandler) updateBulk(c *gin.Context) {
+	serveBulk(c, http.StatusOK, validateUserUpdate, h.taskerService.UpdateUsers)
+}
+
+// deleteUsers godoc
+//
+//	@Summary		Delete users in bulk
+//	@Description	Delete up to 1000 users, each item has the id of the user and an optional version. In atomic mode, the default, the users are deleted in one transaction that stops at the first failure. In partial mode each user is deleted on its own
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			users	body		bulk.Request[bulk.Key]	true	"Users"
+//	@Success		200		{object}	bulk.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		412		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/users/bulk [delete]
+func (h *UserHandler) deleteBulk(c *gin.Context) {
+	serveBulk(c, http.StatusOK, validateKey, h.taskerService.DeleteUsers)
+}
+
+func validateProjectUpdate(item *bulk.Update[project.Request]) error {
+	if err := item.Validate(); err != nil {
+		return err
+	}
+	if item.Data.IsEmpty() {
+		return bulk.ErrorNoChange
+	}
+	return nil
+}
+
+// addProjects godoc
+//
+//	@Summary		Add projects in bulk
+//	@Description	Create up to 1000 projects with one multi-row insert, the manager of each project becomes its owner. In atomic mode, the default, every project is created or none of them. In partial mode the valid projects are created and the response is 200 with the status and error of each project
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			projects	body		bulk.Request[project.Request]	true	"Projects"
+//	@Success		200			{object}	bulk.Response
+//	@Success		201			{object}	bulk.Response
+//	@Failure		400			{object}	response.Object
+//	@Failure		500			{object}	response.Object
+//	@Router			/projects/bulk [post]
+func (h *ProjectHandler) addBulk(c *gin.Context) {
+	serveBulk(c, http.StatusCreated, (*project.Request).Validate, h.taskerService.CreateProjects)
+}
+
+// updateProjects godoc
+//
+//	@Summary		Update projects in bulk
+//	@Description	Update up to 1000 projects, each item has the id of the project, an optional version and the changed fields. In atomic mode, the default, the projects are updated in one transaction that stops at the first failure. In partial mode each project is updated on its own
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			projects	body		bulk.Request[bulk.Update[project.Request]]	true	"Projects"
+//	@Success		200			{object}	bulk.Response
+//	@Failure		400			{object}	response.Object
+//	@Failure		403			{object}	response.Object
+//	@Failure		404			{object}	response.Object
+//	@Failure		412			{object}	response.Object
+//	@Failure		500			{object}	response.Object
+//	@Router			/projects/bulk [patch]
+func (h *ProjectHandler) updateBulk(c *gin.Context) {
+	serveBulk(c, http.StatusOK, validateProjectUpdate, h.taskerService.UpdateProjects)
+}
+
+// deleteProjects godoc
+//
+//	@Summary		Delete projects in bulk
+//	@Description	Delete up to 1000 projects, each item has the id of the project and an optional version. In atomic mode, the default, the projects are deleted in one transaction that stops at the first failure. In partial mode each project is deleted on its own
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			projects	body		bulk.Request[bulk.Key]	true	"Projects"
+//	@Success		200			{object}	bulk.Response
+//	@Failure		400			{object}	response.Object
+//	@Failure		403			{object}	response.Object
+//	@Failure		404			{object}	response.Object
+//	@Failure		412			{object}	response.Object
+//	@Failure		500			{object}	response.Object
+//	@Router			/projects/bulk [delete]
+func (h *ProjectHandler) deleteBulk(c *gin.Context) {
+	serveBulk(c, http.StatusOK, validateKey, h.taskerService.DeleteProjects)
+}
@@ -0,0 +1,329 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hard/internal/domain/bulk"
+	"hard/internal/domain/member"
+	"hard/internal/domain/task"
+	"hard/internal/domain/user"
+	"hard/internal/domain/workflow"
+	"hard/internal/service/tasker"
+	"hard/pkg/helpers"
+	"hard/pkg/store"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// serveBulkRequest sends the body to the bulk endpoint of the task handler
+// and returns the status, the message and the per-item statuses.
+func serveBulkRequest(t *testing.T, taskService *tasker.Service, method, body string) (int, string, []int) {
+	gin.SetMode(gin.TestMode)
+	r := authenticated("admin-id")
+	NewTaskHandler(taskService).Routes(r.Group("/"))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, "/tasks/bulk", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	var res struct {
+		Message string        `json:"message"`
+		Data    bulk.Response `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+
+	statuses := make([]int, 0, len(res.Data.Results))
+	for _, result := range res.Data.Results {
+		statuses = append(statuses, result.Status)
+	}
+	return w.Code, res.Message, statuses
+}
+
+func withTitles(titles ...string) any {
+	return mock.MatchedBy(func(data []task.Entity) bool {
+		if len(data) != len(titles) {
+			return false
+		}
+		for i := range data {
+			if data[i].Title == nil || *data[i].Title != titles[i] {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func TestAddTasksBulk(t *testing.T) {
+	tests := []struct {
+		name              string
+		inputBody         string
+		mockRepo          func(m *MockTaskRepository)
+		expectedStatus    int
+		expectedMessage   string
+		expectedStatuses  []int
+		expectedCommitted int
+	}{
+		{
+			name:      "Atomic",
+			inputBody: `{"items":[{"title":"a","description":"","priority":"High","status":"Active","assignee_id":"1","project_id":"2"},{"title":"b","description":"","priority":"High","status":"Active","assignee_id":"1","project_id":"2"}]}`,
+			mockRepo: func(m *MockTaskRepository) {
+				m.On("AddMany", mock.Anything, withTitles("a", "b")).Return([]string{"7", "8"}, nil).Once()
+			},
+			expectedStatus:    http.StatusCreated,
+			expectedStatuses:  []int{http.StatusCreated, http.StatusCreated},
+			expectedCommitted: 1,
+		},
+		{
+			name:              "Atomic Invalid Item",
+			inputBody:         `{"mode":"atomic","items":[{"title":"a","description":"","priority":"High","status":"Active","assignee_id":"1","project_id":"2"},{"project_id":"2"}]}`,
+			mockRepo:          func(m *MockTaskRepository) {},
+			expectedStatus:    http.StatusBadRequest,
+			expectedMessage:   "items[1]: title: cannot be blank",
+			expectedStatuses:  []int{http.StatusFailedDependency, http.StatusBadRequest},
+			expectedCommitted: 0,
+		},
+		{
+			name:      "Atomic Insert Fails",
+			inputBody: `{"items":[{"title":"a","description":"","priority":"High","status":"Active","assignee_id":"1","project_id":"2"},{"title":"b","description":"","priority":"High","status":"Active","assignee_id":"1","project_id":"2"}]}`,
+			mockRepo: func(m *MockTaskRepository) {
+				m.On("AddMany", mock.Anything, withTitles("a", "b")).Return([]string(nil), errors.New("repository error")).Once()
+			},
+			expectedStatus:    http.StatusInternalServerError,
+			expectedMessage:   "items[0]: repository error",
+			expectedStatuses:  []int{http.StatusInternalServerError, http.StatusInternalServerError},
+			expectedCommitted: 0,
+		},
+		{
+			name:      "Partial Invalid Item",
+			inputBody: `{"mode":"partial","items":[{"project_id":"2"},{"title":"b","description":"","priority":"High","status":"Active","assignee_id":"1","project_id":"2"}]}`,
+			mockRepo: func(m *MockTaskRepository) {
+				m.On("AddMany", mock.Anything, withTitles("b")).Return([]string{"8"}, nil).Once()
+			},
+			expectedStatus:    http.StatusOK,
+			expectedStatuses:  []int{http.StatusBadRequest, http.StatusCreated},
+			expectedCommitted: 1,
+		},
+		{
+			name:      "Partial Insert Retried Per Item",
+			inputBody: `{"mode":"partial","items":[{"title":"a","description":"","priority":"High","status":"Active","assignee_id":"1","project_id":"2"},{"title":"b","description":"","priority":"High","status":"Active","assignee_id":"1","project_id":"2"}]}`,
+			mockRepo: func(m *MockTaskRepository) {
+				m.On("AddMany", mock.Anything, withTitles("a", "b")).Return([]string(nil), errors.New("repository error")).Once()
+				m.On("AddMany", mock.Anything, withTitles("a")).Return([]string{"7"}, nil).Once()
+				m.On("AddMany", mock.Anything, withTitles("b")).Return([]string(nil), errors.New("repository error")).Once()
+			},
+			expectedStatus:    http.StatusOK,
+			expectedStatuses:  []int{http.StatusCreated, http.StatusInternalServerError},
+			expectedCommitted: 1,
+		},		{
+			name:      "Partial Malformed Item",
+			inputBody: `{"mode":"partial","items":[{"title":"a","description":"","priority":"High","status":"Active","assignee_id":"1","project_id":"2"},{"title":"b","description":"","priority":"High","status":"Active","assignee_id":"x","project_id":"2"}]}`,
+			mockRepo: func(m *MockTaskRepository) {
+				malformed := fmt.Errorf("%w: invalid input syntax for type integer: \"x\"", store.ErrorInvalidInput)
+				m.On("AddMany", mock.Anything, withTitles("a", "b")).Return([]string(nil), malformed).Once()
+				m.On("AddMany", mock.Anything, withTitles("a")).Return([]string{"7"}, nil).Once()
+				m.On("AddMany", mock.Anything, withTitles("b")).Return([]string(nil), malformed).Once()
+			},
+			expectedStatus:    http.StatusOK,
+			expectedStatuses:  []int{http.StatusCreated, http.StatusBadRequest},
+			expectedCommitted: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			tt.mockRepo(mockRepo)
+
+			mockWorkflowRepo := new(MockWorkflowRepository)
+			mockWorkflowRepo.On("Get", mock.Anything, mock.Anything).Return(workflow.Entity{}, store.ErrorNotFound)
+
+			mockMemberRepo := new(MockMemberRepository)
+			mockMemberRepo.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(member.Entity{}, nil)
+
+			transactor := new(MockTransactor)
+			taskService, _ := tasker.New(
+				tasker.WithTaskRepository(mockRepo),
+				tasker.WithUserRepository(mockAdmin()),
+				tasker.WithWorkflowRepository(mockWorkflowRepo),
+				tasker.WithMemberRepository(mockMemberRepo),
+				tasker.WithTransactor(transactor),
+			)
+
+			code, message, statuses := serveBulkRequest(t, taskService, http.MethodPost, tt.inputBody)
+
+			assert.Equal(t, tt.expectedStatus, code)
+			assert.Equal(t, tt.expectedMessage, message)
+			assert.Equal(t, tt.expectedStatuses, statuses)
+			assert.Equal(t, tt.expectedCommitted, transactor.committed)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteTasksBulk(t *testing.T) {
+	tests := []struct {
+		name             string
+		inputBody        string
+		expectedStatus   int
+		expectedStatuses []int
+		expectedDeleted  []string
+	}{
+		{
+			name:             "Atomic Stops At First Failure",
+			inputBody:        `{"items":[{"id":"1"},{"id":"99"},{"id":"2"}]}`,
+			expectedStatus:   http.StatusNotFound,
+			expectedStatuses: []int{http.StatusFailedDependency, http.StatusNotFound, http.StatusFailedDependency},
+			expectedDeleted:  []string{"1"},
+		},
+		{
+			name:             "Partial",
+			inputBody:        `{"mode":"partial","items":[{"id":"1"},{"id":"99"},{"id":"2","version":3}]}`,
+			expectedStatus:   http.StatusOK,
+			expectedStatuses: []int{http.StatusOK, http.StatusNotFound, http.StatusPreconditionFailed},
+			expectedDeleted:  []string{"1"},
+		},
+		{
+			name:             "Missing ID",
+			inputBody:        `{"mode":"partial","items":[{"id":"1"},{"version":1}]}`,
+			expectedStatus:   http.StatusOK,
+			expectedStatuses: []int{http.StatusOK, http.StatusBadRequest},
+			expectedDeleted:  []string{"1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version := 1
+			mockRepo := new(MockTaskRepository)
+			mockRepo.On("Get", mock.Anything, "1").Return(task.Entity{ID: "1", Version: &version}, nil)
+			mockRepo.On("Get", mock.Anything, "2").Return(task.Entity{ID: "2", Version: &version}, nil)
+			mockRepo.On("Get", mock.Anything, "99").Return(task.Entity{}, store.ErrorNotFound)
+			mockRepo.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			taskService, _ := tasker.New(tasker.WithTaskRepository(mockRepo), tasker.WithUserRepository(mockAdmin()))
+
+			code, _, statuses := serveBulkRequest(t, taskService, http.MethodDelete, tt.inputBody)
+
+			assert.Equal(t, tt.expectedStatus, code)
+			assert.Equal(t, tt.expectedStatuses, statuses)
+			mockRepo.AssertNumberOfCalls(t, "Delete", len(tt.expectedDeleted))
+			for _, id := range tt.expectedDeleted {
+				mockRepo.AssertCalled(t, "Delete", mock.Anything, id, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestBulkRequestErrors(t *testing.T) {
+	items := strings.TrimSuffix(strings.Repeat(`{"id":"1"},`, bulk.MaxItems+1), ",")
+
+	tests := []struct {
+		name            string
+		method          string
+		inputBody       string
+		expectedStatus  int
+		expectedMessage string
+	}{
+		{
+			name:            "No Items",
+			method:          http.MethodDelete,
+			inputBody:       `{"items":[]}`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: bulk.ErrorNoItems.Error(),
+		},
+		{
+			name:            "Too Many Items",
+			method:          http.MethodDelete,
+			inputBody:       fmt.Sprintf(`{"items":[%s]}`, items),
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: bulk.ErrorTooMany.Error(),
+		},
+		{
+			name:            "Unknown Mode",
+			method:          http.MethodDelete,
+			inputBody:       `{"mode":"eventual","items":[{"id":"1"}]}`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: bulk.ErrorMode.Error(),
+		},
+		{
+			name:            "Update Without Fields",
+			method:          http.MethodPatch,
+			inputBody:       `{"items":[{"id":"1","version":2}]}`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: "items[0]: " + bulk.ErrorNoChange.Error(),
+		},
+		{
+			name:            "Update Invalid Priority",
+			method:          http.MethodPatch,
+			inputBody:       `{"items":[{"id":"1","priority":"Urgent"}]}`,
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: "items[0]: priority: must be one of " + strings.Join(task.Priorities, ", "),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTaskRepository)
+			taskService, _ := tasker.New(tasker.WithTaskRepository(mockRepo))
+
+			code, message, _ := serveBulkRequest(t, taskService, tt.method, tt.inputBody)
+
+			assert.Equal(t, tt.expectedStatus, code)
+			assert.Equal(t, tt.expectedMessage, message)
+			mockRepo.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAddUsersBulk(t *testing.T) {
+	tests := []struct {
+		name           string
+		callerID       string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Admin",
+			callerID:       "admin-id",
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"data":{"mode":"atomic","succeeded":1,"failed":0,"results":[{"index":0,"id":"5","status":201,"data":{"id":"5","full_name":"John","email":"john@example.com","role":"user"}}]},"success":true}`,
+		},
+		{
+			name:           "Not Admin",
+			callerID:       "user-id",
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"message":"permission denied","success":false}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mockAdmin()
+			mockRepo.On("Get", mock.Anything, "user-id").Return(user.Entity{ID: "user-id", Role: helpers.GetStringPtr("user")}, nil)
+			mockRepo.On("AddMany", mock.Anything, mock.Anything).Return([]string{"5"}, nil)
+
+			taskService, _ := tasker.New(tasker.WithUserRepository(mockRepo))
+
+			gin.SetMode(gin.TestMode)
+			r := authenticated(tt.callerID)
+			NewUserHandler(taskService).Routes(r.Group("/"))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/users/bulk",
+				bytes.NewBufferString(`{"items":[{"full_name":"John","email":"john@example.com","role":"user"}]}`))
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,294 @@
+package http
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"hard/internal/domain/access"
+	"hard/internal/domain/comment"
+	"hard/pkg/server/response"
+	"hard/pkg/store"
+)
+
+// listComments godoc
+//
+//	@Summary		List task comments
+//	@Description	Get the comments of a task in the order they were written, replies refer to their thread with parent_comment_id
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string	true	"Task ID"
+//	@Param			limit			query		int		false	"Page size"
+//	@Param			after			query		string	false	"Cursor of the next page"
+//	@Param			before			query		string	false	"Cursor of the previous page"
+//	@Param			include_deleted	query		bool	false	"Include soft deleted rows, admin only"
+//	@Success		200				{array}		comment.Response
+//	@Failure		400				{object}	response.Object
+//	@Failure		403				{object}	response.Object
+//	@Failure		404				{object}	response.Object
+//	@Failure		500				{object}	response.Object
+//	@Router			/tasks/{id}/comments [get]
+func (h *TaskHandler) listComments(c *gin.Context) {
+	id := c.Param("id")
+	page, err := parsePage(c)
+	if err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	res, cursor, err := h.taskerService.ListComments(c, id, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, store.ErrorInvalidCursor):
+			response.BadRequest(c, err, nil)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OKPage(c, res, cursor.Next, cursor.Prev)
+}
+
+// addComment godoc
+//
+//	@Summary		Add a comment
+//	@Description	Comment on a task or reply to a comment with parent_comment_id. The body is Markdown, @email mentions the user with that email.
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string			true	"Task ID"
+//	@Param			comment	body		comment.Request	true	"Comment Request"
+//	@Success		201		{object}	comment.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		422		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/tasks/{id}/comments [post]
+func (h *TaskHandler) addComment(c *gin.Context) {
+	id := c.Param("id")
+	req := comment.Request{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	res, err := h.taskerService.CreateComment(c, id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		case errors.Is(err, comment.ErrorUnknownParent), errors.Is(err, comment.ErrorOtherTask):
+			response.UnprocessableEntity(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.Created(c, res)
+}
+
+// getComment godoc
+//
+//	@Summary		Get a comment
+//	@Description	Get a comment of a task by ID
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string	true	"Task ID"
+//	@Param			comment_id		path		string	true	"Comment ID"
+//	@Param			include_deleted	query		bool	false	"Include soft deleted rows, admin only"
+//	@Success		200				{object}	comment.Response
+//	@Failure		403				{object}	response.Object
+//	@Failure		404				{object}	response.Object
+//	@Failure		500				{object}	response.Object
+//	@Router			/tasks/{id}/comments/{comment_id} [get]
+func (h *TaskHandler) getComment(c *gin.Context) {
+	id, commentID := c.Param("id"), c.Param("comment_id")
+
+	res, err := h.taskerService.GetComment(c, id, commentID)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, res)
+}
+
+// updateComment godoc
+//
+//	@Summary		Edit a comment
+//	@Description	Replace the body of a comment, the previous body is kept in its history
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string			true	"Task ID"
+//	@Param			comment_id	path		string			true	"Comment ID"
+//	@Param			comment		body		comment.Request	true	"Comment Request"
+//	@Success		200			{string}	string			"ok"
+//	@Failure		400			{object}	response.Object
+//	@Failure		403			{object}	response.Object
+//	@Failure		404			{object}	response.Object
+//	@Failure		500			{object}	response.Object
+//	@Router			/tasks/{id}/comments/{comment_id} [put]
+func (h *TaskHandler) updateComment(c *gin.Context) {
+	id, commentID := c.Param("id"), c.Param("comment_id")
+	req := comment.Request{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	if err := req.ValidateUpdate(); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	if err := h.taskerService.UpdateComment(c, id, commentID, req); err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, "ok")
+}
+
+// deleteComment godoc
+//
+//	@Summary		Delete a comment
+//	@Description	Delete a comment of a task together with its replies
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string	true	"Task ID"
+//	@Param			comment_id	path		string	true	"Comment ID"
+//	@Success		200			{string}	string	"Deleted Comment ID"
+//	@Failure		403			{object}	response.Object
+//	@Failure		404			{object}	response.Object
+//	@Failure		500			{object}	response.Object
+//	@Router			/tasks/{id}/comments/{comment_id} [delete]
+func (h *TaskHandler) deleteComment(c *gin.Context) {
+	id, commentID := c.Param("id"), c.Param("comment_id")
+
+	if err := h.taskerService.DeleteComment(c, id, commentID); err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, commentID)
+}
+
+// commentHistory godoc
+//
+//	@Summary		Comment edit history
+//	@Description	Get the previous bodies of a comment, oldest first
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string	true	"Task ID"
+//	@Param			comment_id		path		string	true	"Comment ID"
+//	@Param			limit			query		int		false	"Page size"
+//	@Param			after			query		string	false	"Cursor of the next page"
+//	@Param			before			query		string	false	"Cursor of the previous page"
+//	@Param			include_deleted	query		bool	false	"Include soft deleted rows, admin only"
+//	@Success		200				{array}		comment.RevisionResponse
+//	@Failure		400				{object}	response.Object
+//	@Failure		403				{object}	response.Object
+//	@Failure		404				{object}	response.Object
+//	@Failure		500				{object}	response.Object
+//	@Router			/tasks/{id}/comments/{comment_id}/history [get]
+func (h *TaskHandler) commentHistory(c *gin.Context) {
+	id, commentID := c.Param("id"), c.Param("comment_id")
+	page, err := parsePage(c)
+	if err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	res, cursor, err := h.taskerService.ListCommentHistory(c, id, commentID, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, store.ErrorInvalidCursor):
+			response.BadRequest(c, err, nil)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OKPage(c, res, cursor.Next, cursor.Prev)
+}
+
+// listMentions godoc
+//
+//	@Summary		List user mentions
+//	@Description	Get the comments in which the user was mentioned with @email
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string	true	"User ID"
+//	@Param			limit			query		int		false	"Page size"
+//	@Param			after			query		string	false	"Cursor of the next page"
+//	@Param			before			query		string	false	"Cursor of the previous page"
+//	@Param			include_deleted	query		bool	false	"Include soft deleted rows, admin only"
+//	@Success		200				{array}		comment.Response
+//	@Failure		400				{object}	response.Object
+//	@Failure		403				{object}	response.Object
+//	@Failure		404				{object}	response.Object
+//	@Failure		500				{object}	response.Object
+//	@Router			/users/{id}/mentions [get]
+func (h *UserHandler) listMentions(c *gin.Context) {
+	id := c.Param("id")
+	page, err := parsePage(c)
+	if err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	res, cursor, err := h.taskerService.ListMentions(c, id, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, store.ErrorInvalidCursor):
+			response.BadRequest(c, err, nil)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OKPage(c, res, cursor.Next, cursor.Prev)
+}
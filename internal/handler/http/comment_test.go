@@ -0,0 +1,346 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"hard/internal/domain/audit"
+	"hard/internal/domain/comment"
+	"hard/internal/domain/member"
+	"hard/internal/domain/project"
+	"hard/internal/domain/task"
+	"hard/internal/domain/user"
+	"hard/pkg/helpers"
+	"hard/pkg/store"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"hard/internal/service/tasker"
+)
+
+type MockCommentRepository struct {
+	mock.Mock
+}
+
+func (m *MockCommentRepository) List(ctx context.Context, taskID string, page store.Page) (dest []comment.Entity, cursor store.Cursor, err error) {
+	args := m.Called(ctx, taskID, page)
+	return args.Get(0).([]comment.Entity), args.Get(1).(store.Cursor), args.Error(2)
+}
+
+func (m *MockCommentRepository) Get(ctx context.Context, id string) (dest comment.Entity, err error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(comment.Entity), args.Error(1)
+}
+
+func (m *MockCommentRepository) Add(ctx context.Context, data comment.Entity) (id string, err error) {
+	args := m.Called(ctx, data)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockCommentRepository) Update(ctx context.Context, id string, data comment.Entity) (err error) {
+	args := m.Called(ctx, id, data)
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) Delete(ctx context.Context, id string) (err error) {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) ListRevisions(ctx context.Context, id string, page store.Page) (dest []comment.Revision, cursor store.Cursor, err error) {
+	args := m.Called(ctx, id, page)
+	return args.Get(0).([]comment.Revision), args.Get(1).(store.Cursor), args.Error(2)
+}
+
+func (m *MockCommentRepository) AddRevision(ctx context.Context, data comment.Revision) (err error) {
+	args := m.Called(ctx, data)
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) ListMentions(ctx context.Context, ids []string) (dest map[string][]string, err error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).(map[string][]string), args.Error(1)
+}
+
+func (m *MockCommentRepository) SetMentions(ctx context.Context, id string, userIDs []string) (err error) {
+	args := m.Called(ctx, id, userIDs)
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) ListMentioning(ctx context.Context, userID string, page store.Page) (dest []comment.Entity, cursor store.Cursor, err error) {
+	args := m.Called(ctx, userID, page)
+	return args.Get(0).([]comment.Entity), args.Get(1).(store.Cursor), args.Error(2)
+}
+
+func TestComments(t *testing.T) {
+	createdAt := "2024-05-01T10:00:00Z"
+	newComment := func(id, taskID, authorID, parentID, body string) comment.Entity {
+		data := comment.Entity{ID: id, TaskID: taskID, AuthorID: helpers.GetStringPtr(authorID), Body: helpers.GetStringPtr(body), CreatedAt: &createdAt}
+		if parentID != "" {
+			data.ParentCommentID = &parentID
+		}
+		return data
+	}
+	// 1 and its reply 2 are on task 1, 3 is on task 4
+	comments := map[string]comment.Entity{
+		"1": newComment("1", "1", "user-id", "", "Looks good to @jane@example.com"),
+		"2": newComment("2", "1", "admin-id", "1", "Agreed"),
+		"3": newComment("3", "4", "admin-id", "", "Other task"),
+	}
+	first := `{"id":"1","task_id":"1","author_id":"user-id","body":"Looks good to @jane@example.com","mentions":["3"],"created_at":"2024-05-01T10:00:00Z"}`
+	reply := `{"id":"2","task_id":"1","author_id":"admin-id","parent_comment_id":"1","body":"Agreed","created_at":"2024-05-01T10:00:00Z"}`
+
+	tests := []struct {
+		name             string
+		caller           string
+		method           string
+		target           string
+		inputBody        string
+		expectedStatus   int
+		expectedBody     string
+		expectedAdded    *comment.Entity
+		expectedMentions []string
+		expectedRevision *comment.Revision
+		expectedRecord   *audit.Entity
+	}{
+		{
+			name:           "List Task Comments",
+			method:         "GET",
+			target:         "/tasks/1/comments",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[` + first + `,` + reply + `],"success":true}`,
+		},
+		{
+			name:           "Comments Of Unknown Task",
+			method:         "GET",
+			target:         "/tasks/9/comments",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"error not found","success":false}`,
+		},
+		{
+			name:             "Add Comment With Mentions",
+			caller:           "user-id",
+			method:           "POST",
+			target:           "/tasks/1/comments",
+			inputBody:        `{"body":"**Ping** @jane@example.com and @ghost@example.com, not ` + "`@bob@example.com`" + `. Thanks @JANE@example.com!"}`,
+			expectedStatus:   http.StatusCreated,
+			expectedBody:     `{"data":{"id":"5","task_id":"1","author_id":"user-id","body":"new","mentions":["3"],"created_at":"2024-05-01T10:00:00Z"},"success":true}`,
+			expectedAdded:    &comment.Entity{TaskID: "1", AuthorID: helpers.GetStringPtr("user-id"), Body: helpers.GetStringPtr("**Ping** @jane@example.com and @ghost@example.com, not `@bob@example.com`. Thanks @JANE@example.com!")},
+			expectedMentions: []string{"3"},
+			expectedRecord:   &audit.Entity{EntityType: audit.EntityComment, EntityID: "5", Operation: audit.OperationCreate},
+		},
+		{
+			name:           "Reply To Comment",
+			method:         "POST",
+			target:         "/tasks/1/comments",
+			inputBody:      `{"body":"new","parent_comment_id":"2"}`,
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"data":{"id":"5","task_id":"1","author_id":"user-id","body":"new","created_at":"2024-05-01T10:00:00Z"},"success":true}`,
+			expectedAdded:  &comment.Entity{TaskID: "1", AuthorID: helpers.GetStringPtr("admin-id"), ParentCommentID: helpers.GetStringPtr("2"), Body: helpers.GetStringPtr("new")},
+		},
+		{
+			name:           "Reply To Comment Of Another Task",
+			method:         "POST",
+			target:         "/tasks/1/comments",
+			inputBody:      `{"body":"new","parent_comment_id":"3"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"message":"parent_comment_id: comment belongs to another task","success":false}`,
+		},
+		{
+			name:           "Reply To Unknown Comment",
+			method:         "POST",
+			target:         "/tasks/1/comments",
+			inputBody:      `{"body":"new","parent_comment_id":"9"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"message":"parent_comment_id: comment does not exist","success":false}`,
+		},
+		{
+			name:           "Blank Comment",
+			method:         "POST",
+			target:         "/tasks/1/comments",
+			inputBody:      `{"body":"  "}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"data":{"body":"  ","parent_comment_id":null},"message":"body: cannot be blank","success":false}`,
+		},
+		{
+			name:           "Get Comment",
+			method:         "GET",
+			target:         "/tasks/1/comments/1",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":` + first + `,"success":true}`,
+		},
+		{
+			name:           "Comment Of Another Task",
+			method:         "GET",
+			target:         "/tasks/1/comments/3",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"error not found","success":false}`,
+		},
+		{
+			name:             "Author Edits Comment",
+			caller:           "user-id",
+			method:           "PUT",
+			target:           "/tasks/1/comments/1",
+			inputBody:        `{"body":"Looks good"}`,
+			expectedStatus:   http.StatusOK,
+			expectedBody:     `{"data":"ok","success":true}`,
+			expectedMentions: []string(nil),
+			expectedRevision: &comment.Revision{CommentID: "1", EditorID: helpers.GetStringPtr("user-id"), Body: helpers.GetStringPtr("Looks good to @jane@example.com")},
+			expectedRecord:   &audit.Entity{EntityType: audit.EntityComment, EntityID: "1", Operation: audit.OperationUpdate},
+		},
+		{
+			name:           "Edit Comment Of Another User",
+			caller:         "user-id",
+			method:         "PUT",
+			target:         "/tasks/1/comments/2",
+			inputBody:      `{"body":"Disagreed"}`,
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"message":"permission denied","success":false}`,
+		},
+		{
+			name:           "Move Reply",
+			method:         "PUT",
+			target:         "/tasks/1/comments/2",
+			inputBody:      `{"body":"Agreed","parent_comment_id":"3"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"data":{"body":"Agreed","parent_comment_id":"3"},"message":"parent_comment_id: reply cannot be moved to another comment","success":false}`,
+		},
+		{
+			name:           "Delete Comment Of Another User",
+			caller:         "user-id",
+			method:         "DELETE",
+			target:         "/tasks/1/comments/2",
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"message":"permission denied","success":false}`,
+		},
+		{
+			name:           "Manager Deletes Comment",
+			caller:         "manager-id",
+			method:         "DELETE",
+			target:         "/tasks/1/comments/1",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":"1","success":true}`,
+			expectedRecord: &audit.Entity{EntityType: audit.EntityComment, EntityID: "1", Operation: audit.OperationDelete},
+		},
+		{
+			name:           "Comment History",
+			method:         "GET",
+			target:         "/tasks/1/comments/1/history",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[{"id":"7","comment_id":"1","editor_id":"user-id","body":"Looks god","created_at":"2024-05-01T09:00:00Z"}],"success":true}`,
+		},
+		{
+			name:           "User Mentions",
+			method:         "GET",
+			target:         "/users/3/mentions",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[` + first + `],"success":true}`,
+		},
+		{
+			name:           "Mentions Of Unknown User",
+			method:         "GET",
+			target:         "/users/9/mentions",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"error not found","success":false}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := mockAdmin()
+			mockUserRepo.On("Get", mock.Anything, "user-id").Return(user.Entity{ID: "user-id", Role: helpers.GetStringPtr("user")}, nil)
+			mockUserRepo.On("Get", mock.Anything, "manager-id").Return(user.Entity{ID: "manager-id", Role: helpers.GetStringPtr("user")}, nil)
+			mockUserRepo.On("Get", mock.Anything, "3").Return(user.Entity{ID: "3", Email: helpers.GetStringPtr("jane@example.com")}, nil)
+			mockUserRepo.On("Get", mock.Anything, "9").Return(user.Entity{}, store.ErrorNotFound)
+			mockUserRepo.On("GetByEmail", mock.Anything, "jane@example.com").Return(user.Entity{ID: "3"}, nil)
+			mockUserRepo.On("GetByEmail", mock.Anything, "JANE@example.com").Return(user.Entity{ID: "3"}, nil)
+			mockUserRepo.On("GetByEmail", mock.Anything, mock.Anything).Return(user.Entity{}, store.ErrorNotFound)
+
+			mockTaskRepo := new(MockTaskRepository)
+			mockTaskRepo.On("Get", mock.Anything, "9").Return(nil, store.ErrorNotFound)
+			mockTaskRepo.On("Get", mock.Anything, "1").Return(task.Entity{ID: "1", ProjectID: helpers.GetStringPtr("2")}, nil)
+
+			mockProjectRepo := new(MockProjectRepository)
+			mockProjectRepo.On("Get", mock.Anything, "2").Return(project.Entity{ID: "2", ManagerID: helpers.GetStringPtr("manager-id")}, nil)
+
+			mockMemberRepo := new(MockMemberRepository)
+			mockMemberRepo.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(member.Entity{}, store.ErrorNotFound)
+
+			mockCommentRepo := new(MockCommentRepository)
+			mockCommentRepo.On("List", mock.Anything, "1", mock.Anything).Return([]comment.Entity{comments["1"], comments["2"]}, store.Cursor{}, nil)
+			for id, data := range comments {
+				mockCommentRepo.On("Get", mock.Anything, id).Return(data, nil)
+			}
+			mockCommentRepo.On("Get", mock.Anything, "5").Return(newComment("5", "1", "user-id", "", "new"), nil)
+			mockCommentRepo.On("Get", mock.Anything, mock.Anything).Return(comment.Entity{}, store.ErrorNotFound)
+			mockCommentRepo.On("Add", mock.Anything, mock.Anything).Return("5", nil)
+			mockCommentRepo.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockCommentRepo.On("Delete", mock.Anything, mock.Anything).Return(nil)
+			mockCommentRepo.On("ListRevisions", mock.Anything, "1", mock.Anything).Return([]comment.Revision{
+				{ID: "7", CommentID: "1", EditorID: helpers.GetStringPtr("user-id"), Body: helpers.GetStringPtr("Looks god"), CreatedAt: helpers.GetStringPtr("2024-05-01T09:00:00Z")},
+			}, store.Cursor{}, nil)
+			mockCommentRepo.On("AddRevision", mock.Anything, mock.Anything).Return(nil)
+			mockCommentRepo.On("ListMentions", mock.Anything, mock.Anything).Return(map[string][]string{"1": {"3"}}, nil)
+			mockCommentRepo.On("SetMentions", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockCommentRepo.On("ListMentioning", mock.Anything, "3", mock.Anything).Return([]comment.Entity{comments["1"]}, store.Cursor{}, nil)
+
+			mockAuditRepo := new(MockAuditRepository)
+			mockAuditRepo.On("Add", mock.Anything, mock.Anything).Return(nil)
+
+			taskService, _ := tasker.New(
+				tasker.WithUserRepository(mockUserRepo),
+				tasker.WithTaskRepository(mockTaskRepo),
+				tasker.WithProjectRepository(mockProjectRepo),
+				tasker.WithMemberRepository(mockMemberRepo),
+				tasker.WithCommentRepository(mockCommentRepo),
+				tasker.WithAuditRepository(mockAuditRepo),
+			)
+
+			caller := tt.caller
+			if caller == "" {
+				caller = "admin-id"
+			}
+
+			gin.SetMode(gin.TestMode)
+			r := authenticated(caller)
+			NewTaskHandler(taskService).Routes(r.Group("/"))
+			NewUserHandler(taskService).Routes(r.Group("/"))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.target, bytes.NewBufferString(tt.inputBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+
+			if tt.expectedAdded != nil {
+				mockCommentRepo.AssertCalled(t, "Add", mock.Anything, *tt.expectedAdded)
+			} else {
+				mockCommentRepo.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+			}
+			if tt.expectedMentions != nil || tt.expectedRevision != nil {
+				mockCommentRepo.AssertCalled(t, "SetMentions", mock.Anything, mock.Anything, tt.expectedMentions)
+			}
+			if tt.expectedRevision != nil {
+				mockCommentRepo.AssertCalled(t, "AddRevision", mock.Anything, *tt.expectedRevision)
+			} else {
+				mockCommentRepo.AssertNotCalled(t, "AddRevision", mock.Anything, mock.Anything)
+			}
+			if tt.method == "DELETE" && tt.expectedStatus != http.StatusOK {
+				mockCommentRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+			}
+			if tt.expectedRecord != nil {
+				mockAuditRepo.AssertCalled(t, "Add", mock.Anything, mock.MatchedBy(func(data audit.Entity) bool {
+					return data.EntityType == tt.expectedRecord.EntityType &&
+						data.EntityID == tt.expectedRecord.EntityID &&
+						data.Operation == tt.expectedRecord.Operation
+				}))
+			}
+		})
+	}
+}
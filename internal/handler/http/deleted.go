@@ -0,0 +1,48 @@
+package http
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"hard/internal/domain/access"
+	"hard/internal/service/tasker"
+	"hard/pkg/server/response"
+	"strconv"
+)
+
+// includeDeleted lets the routes it guards return soft deleted rows when the
+// request has ?include_deleted=true and the caller may see them.
+func includeDeleted(s *tasker.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		raw := c.Query("include_deleted")
+		if raw == "" {
+			c.Next()
+			return
+		}
+
+		include, err := strconv.ParseBool(raw)
+		if err != nil {
+			response.BadRequest(c, errors.New("include_deleted: must be true or false"), nil)
+			c.Abort()
+			return
+		}
+		if !include {
+			c.Next()
+			return
+		}
+
+		ctx, err := s.IncludeDeleted(c.Request.Context())
+		if err != nil {
+			switch {
+			case errors.Is(err, access.ErrorForbidden):
+				response.Forbidden(c, err)
+			default:
+				response.InternalServerError(c, err)
+			}
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,241 @@
+package http
+
+import (
+	"context"
+	"hard/internal/domain/audit"
+	"hard/internal/domain/member"
+	"hard/internal/domain/project"
+	"hard/internal/domain/task"
+	"hard/internal/domain/user"
+	"hard/pkg/helpers"
+	"hard/pkg/store"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"hard/internal/service/tasker"
+)
+
+// withDeleted matches the contexts that include soft deleted rows.
+func withDeleted(include bool) any {
+	return mock.MatchedBy(func(ctx context.Context) bool {
+		return (store.NotDeleted(ctx, "deleted_at") == "") == include
+	})
+}
+
+func TestSoftDelete(t *testing.T) {
+	deletedAt := "2024-05-01T10:00:00Z"
+	deleted := task.Entity{
+		ID:          "5",
+		Title:       helpers.GetStringPtr("Rescue"),
+		Description: helpers.GetStringPtr("Rescue"),
+		Priority:    helpers.GetStringPtr("High"),
+		Status:      helpers.GetStringPtr("Active"),
+		AssigneeID:  helpers.GetStringPtr("2"),
+		ProjectID:   helpers.GetStringPtr("2"),
+		DeletedAt:   &deletedAt,
+	}
+	orphan := deleted
+	orphan.ID = "6"
+	orphan.ProjectID = helpers.GetStringPtr("3")
+	live := deleted
+	live.ID = "7"
+	live.DeletedAt = nil
+	beta := project.Entity{
+		ID:          "2",
+		Title:       helpers.GetStringPtr("Beta"),
+		Description: helpers.GetStringPtr("Bla-bla-bla"),
+		ManagerID:   helpers.GetStringPtr("2"),
+		DeletedAt:   &deletedAt,
+	}
+
+	tests := []struct {
+		name           string
+		callerID       string
+		method         string
+		target         string
+		expectedStatus int
+		expectedBody   string
+		expectedRecord *audit.Entity
+	}{
+		{
+			name:           "Deleted Tasks Are Hidden",
+			callerID:       "admin-id",
+			method:         "GET",
+			target:         "/tasks/",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[{"id":"7","title":"Rescue","description":"Rescue","priority":"High","status":"Active","assignee_id":"2","project_id":"2","completed_at":""}],"success":true}`,
+		},
+		{
+			name:           "Admin Includes Deleted Tasks",
+			callerID:       "admin-id",
+			method:         "GET",
+			target:         "/tasks/?include_deleted=true",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[{"id":"5","title":"Rescue","description":"Rescue","priority":"High","status":"Active","assignee_id":"2","project_id":"2","completed_at":"","deleted_at":"2024-05-01T10:00:00Z"},{"id":"7","title":"Rescue","description":"Rescue","priority":"High","status":"Active","assignee_id":"2","project_id":"2","completed_at":""}],"success":true}`,
+		},
+		{
+			name:           "Include Deleted Is Admin Only",
+			callerID:       "2",
+			method:         "GET",
+			target:         "/tasks/?include_deleted=true",
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"message":"permission denied","success":false}`,
+		},
+		{
+			name:           "Invalid Include Deleted",
+			callerID:       "admin-id",
+			method:         "GET",
+			target:         "/tasks/?include_deleted=maybe",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"include_deleted: must be true or false","success":false}`,
+		},
+		{
+			name:           "Deleted Task Is Not Found",
+			callerID:       "admin-id",
+			method:         "GET",
+			target:         "/tasks/5",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"error not found","success":false}`,
+		},
+		{
+			name:           "Restore Task",
+			callerID:       "admin-id",
+			method:         "POST",
+			target:         "/tasks/5/restore",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"id":"5","title":"Rescue","description":"Rescue","priority":"High","status":"Active","assignee_id":"2","project_id":"2","completed_at":""},"success":true}`,
+			expectedRecord: &audit.Entity{
+				ActorID:    helpers.GetStringPtr("admin-id"),
+				EntityType: audit.EntityTask,
+				EntityID:   "5",
+				Operation:  audit.OperationRestore,
+				Diff:       audit.Diff{"deleted_at": {Old: deletedAt}},
+			},
+		},
+		{
+			name:           "Restore Live Task",
+			callerID:       "admin-id",
+			method:         "POST",
+			target:         "/tasks/7/restore",
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"error not deleted","success":false}`,
+		},
+		{
+			name:           "Restore Task Of Deleted Project",
+			callerID:       "admin-id",
+			method:         "POST",
+			target:         "/tasks/6/restore",
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"project of the task is deleted, restore the project instead","success":false}`,
+		},
+		{
+			name:           "Manager Restores Project",
+			callerID:       "2",
+			method:         "POST",
+			target:         "/projects/2/restore",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"id":"2","title":"Beta","description":"Bla-bla-bla","start_date":"","end_date":"","manager_id":"2"},"success":true}`,
+			expectedRecord: &audit.Entity{
+				ActorID:    helpers.GetStringPtr("2"),
+				EntityType: audit.EntityProject,
+				EntityID:   "2",
+				Operation:  audit.OperationRestore,
+				Diff:       audit.Diff{"deleted_at": {Old: deletedAt}},
+			},
+		},
+		{
+			name:           "Restore User Is Admin Only",
+			callerID:       "2",
+			method:         "POST",
+			target:         "/users/3/restore",
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"message":"permission denied","success":false}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := mockAdmin()
+			mockUserRepo.On("Get", mock.Anything, "2").Return(user.Entity{ID: "2", Role: helpers.GetStringPtr("scientist")}, nil)
+
+			mockTaskRepo := new(MockTaskRepository)
+			mockTaskRepo.On("List", withDeleted(false), mock.Anything).Return([]task.Entity{live}, store.Cursor{}, nil)
+			mockTaskRepo.On("List", withDeleted(true), mock.Anything).Return([]task.Entity{deleted, live}, store.Cursor{}, nil)
+			mockTaskRepo.On("Get", withDeleted(false), "5").Return(task.Entity{}, store.ErrorNotFound)
+			mockTaskRepo.On("Get", withDeleted(true), "5").Return(deleted, nil)
+			mockTaskRepo.On("Get", withDeleted(true), "6").Return(orphan, nil)
+			mockTaskRepo.On("Get", withDeleted(true), "7").Return(live, nil)
+			mockTaskRepo.On("Restore", mock.Anything, "5").Return(nil)
+
+			mockProjectRepo := new(MockProjectRepository)
+			mockProjectRepo.On("Get", withDeleted(false), "2").Return(project.Entity{ID: "2"}, nil)
+			mockProjectRepo.On("Get", withDeleted(true), "2").Return(beta, nil)
+			mockProjectRepo.On("Get", mock.Anything, "3").Return(project.Entity{}, store.ErrorNotFound)
+			mockProjectRepo.On("Restore", mock.Anything, "2").Return(nil)
+
+			mockMemberRepo := new(MockMemberRepository)
+			mockMemberRepo.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(member.Entity{}, store.ErrorNotFound)
+
+			mockAuditRepo := new(MockAuditRepository)
+			mockAuditRepo.On("Add", mock.Anything, mock.Anything).Return(nil)
+
+			taskService, _ := tasker.New(
+				tasker.WithUserRepository(mockUserRepo),
+				tasker.WithTaskRepository(mockTaskRepo),
+				tasker.WithProjectRepository(mockProjectRepo),
+				tasker.WithMemberRepository(mockMemberRepo),
+				tasker.WithAuditRepository(mockAuditRepo),
+			)
+
+			gin.SetMode(gin.TestMode)
+			r := authenticated(tt.callerID)
+			api := r.Group("/")
+			NewTaskHandler(taskService).Routes(api)
+			NewProjectHandler(taskService).Routes(api)
+			NewUserHandler(taskService).Routes(api)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			if tt.expectedRecord != nil {
+				mockAuditRepo.AssertCalled(t, "Add", mock.Anything, *tt.expectedRecord)
+			} else {
+				mockAuditRepo.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestPurge(t *testing.T) {
+	retention := 30 * 24 * time.Hour
+
+	mockTaskRepo := new(MockTaskRepository)
+	mockTaskRepo.On("Purge", mock.Anything, retention).Return(int64(3), nil)
+	mockProjectRepo := new(MockProjectRepository)
+	mockProjectRepo.On("Purge", mock.Anything, retention).Return(int64(1), nil)
+	mockUserRepo := new(MockUserRepository)
+	mockUserRepo.On("Purge", mock.Anything, retention).Return(int64(2), nil)
+	transactor := new(MockTransactor)
+
+	taskService, _ := tasker.New(
+		tasker.WithUserRepository(mockUserRepo),
+		tasker.WithTaskRepository(mockTaskRepo),
+		tasker.WithProjectRepository(mockProjectRepo),
+		tasker.WithTransactor(transactor),
+	)
+
+	n, err := taskService.Purge(context.Background(), retention)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), n)
+	assert.Equal(t, 1, transactor.committed)
+}
@@ -0,0 +1,210 @@
+package http
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"hard/internal/domain/access"
+	"hard/internal/domain/dependency"
+	"hard/pkg/server/response"
+	"hard/pkg/store"
+)
+
+// listDependencies godoc
+//
+//	@Summary		List blocking tasks
+//	@Description	Get the tasks that block a task, the task cannot be closed while any of them is open
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Task ID"
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			after	query		string	false	"Cursor of the next page"
+//	@Param			before	query		string	false	"Cursor of the previous page"
+//	@Param			include_deleted	query		bool	false	"Include soft deleted rows, admin only"
+//	@Success		200		{array}		task.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/tasks/{id}/dependencies [get]
+func (h *TaskHandler) listDependencies(c *gin.Context) {
+	id := c.Param("id")
+	page, err := parsePage(c)
+	if err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	res, cursor, err := h.taskerService.ListDependencies(c, id, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, store.ErrorInvalidCursor):
+			response.BadRequest(c, err, nil)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OKPage(c, res, cursor.Next, cursor.Prev)
+}
+
+// addDependency godoc
+//
+//	@Summary		Add a blocking task
+//	@Description	Make a task blocked by another task. Links that close a cycle are rejected, links to a task of
+//	@Description	another project are rejected unless cross project dependencies are enabled.
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string				true	"Task ID"
+//	@Param			dependency	body		dependency.Request	true	"Dependency Request"
+//	@Success		201			{object}	dependency.Response
+//	@Failure		400			{object}	response.Object
+//	@Failure		403			{object}	response.Object
+//	@Failure		404			{object}	response.Object
+//	@Failure		409			{object}	response.Object
+//	@Failure		422			{object}	response.Object
+//	@Failure		500			{object}	response.Object
+//	@Router			/tasks/{id}/dependencies [post]
+func (h *TaskHandler) addDependency(c *gin.Context) {
+	id := c.Param("id")
+	req := dependency.Request{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	res, err := h.taskerService.AddDependency(c, id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		case errors.Is(err, dependency.ErrorExists), errors.Is(err, dependency.ErrorCycle):
+			response.Conflict(c, err)
+		case errors.Is(err, dependency.ErrorSelf), errors.Is(err, dependency.ErrorUnknownBlocker), errors.Is(err, dependency.ErrorCrossProject):
+			response.UnprocessableEntity(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.Created(c, res)
+}
+
+// deleteDependency godoc
+//
+//	@Summary		Remove a blocking task
+//	@Description	Remove the link between a task and a task blocking it
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string	true	"Task ID"
+//	@Param			blocker_id	path		string	true	"Blocking Task ID"
+//	@Success		200			{string}	string	"Removed Blocking Task ID"
+//	@Failure		403			{object}	response.Object
+//	@Failure		404			{object}	response.Object
+//	@Failure		500			{object}	response.Object
+//	@Router			/tasks/{id}/dependencies/{blocker_id} [delete]
+func (h *TaskHandler) deleteDependency(c *gin.Context) {
+	id, blockerID := c.Param("id"), c.Param("blocker_id")
+
+	if err := h.taskerService.DeleteDependency(c, id, blockerID); err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, blockerID)
+}
+
+// listDependents godoc
+//
+//	@Summary		List blocked tasks
+//	@Description	Get the tasks blocked by a task
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Task ID"
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			after	query		string	false	"Cursor of the next page"
+//	@Param			before	query		string	false	"Cursor of the previous page"
+//	@Param			include_deleted	query		bool	false	"Include soft deleted rows, admin only"
+//	@Success		200		{array}		task.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/tasks/{id}/dependents [get]
+func (h *TaskHandler) listDependents(c *gin.Context) {
+	id := c.Param("id")
+	page, err := parsePage(c)
+	if err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	res, cursor, err := h.taskerService.ListDependents(c, id, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, store.ErrorInvalidCursor):
+			response.BadRequest(c, err, nil)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OKPage(c, res, cursor.Next, cursor.Prev)
+}
+
+// taskOrder godoc
+//
+//	@Summary		List project tasks in dependency order
+//	@Description	Get the tasks of a project in topological order, every task comes after the tasks blocking it
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Project ID"
+//	@Success		200	{array}		task.Response
+//	@Failure		404	{object}	response.Object
+//	@Failure		409	{object}	response.Object
+//	@Failure		500	{object}	response.Object
+//	@Router			/projects/{id}/tasks/order [get]
+func (h *ProjectHandler) taskOrder(c *gin.Context) {
+	id := c.Param("id")
+
+	res, err := h.taskerService.GetTaskOrder(c, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, dependency.ErrorCycle):
+			response.Conflict(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, res)
+}
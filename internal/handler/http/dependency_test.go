@@ -0,0 +1,326 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"hard/internal/domain/audit"
+	"hard/internal/domain/dependency"
+	"hard/internal/domain/project"
+	"hard/internal/domain/task"
+	"hard/internal/domain/workflow"
+	"hard/pkg/helpers"
+	"hard/pkg/store"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"hard/internal/service/tasker"
+)
+
+type MockDependencyRepository struct {
+	mock.Mock
+}
+
+func (m *MockDependencyRepository) ListBlockers(ctx context.Context, taskID string, page store.Page) (dest []task.Entity, cursor store.Cursor, err error) {
+	args := m.Called(ctx, taskID, page)
+	return args.Get(0).([]task.Entity), args.Get(1).(store.Cursor), args.Error(2)
+}
+
+func (m *MockDependencyRepository) ListDependents(ctx context.Context, taskID string, page store.Page) (dest []task.Entity, cursor store.Cursor, err error) {
+	args := m.Called(ctx, taskID, page)
+	return args.Get(0).([]task.Entity), args.Get(1).(store.Cursor), args.Error(2)
+}
+
+func (m *MockDependencyRepository) ListByProject(ctx context.Context, projectID string) (dest []dependency.Entity, err error) {
+	args := m.Called(ctx, projectID)
+	return args.Get(0).([]dependency.Entity), args.Error(1)
+}
+
+func (m *MockDependencyRepository) Get(ctx context.Context, taskID, blockerID string) (dest dependency.Entity, err error) {
+	args := m.Called(ctx, taskID, blockerID)
+	return args.Get(0).(dependency.Entity), args.Error(1)
+}
+
+func (m *MockDependencyRepository) Add(ctx context.Context, data dependency.Entity) (err error) {
+	args := m.Called(ctx, data)
+	return args.Error(0)
+}
+
+func (m *MockDependencyRepository) Delete(ctx context.Context, taskID, blockerID string) (err error) {
+	args := m.Called(ctx, taskID, blockerID)
+	return args.Error(0)
+}
+
+func (m *MockDependencyRepository) Blocks(ctx context.Context, blockerID, taskID string) (ok bool, err error) {
+	args := m.Called(ctx, blockerID, taskID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockDependencyRepository) OpenBlockers(ctx context.Context, taskIDs []string) (dest []string, err error) {
+	args := m.Called(ctx, taskIDs)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func TestDependencies(t *testing.T) {
+	newTask := func(id, projectID string) task.Entity {
+		return task.Entity{
+			ID:          id,
+			Title:       helpers.GetStringPtr("Task " + id),
+			Description: helpers.GetStringPtr("Task " + id),
+			Priority:    helpers.GetStringPtr("High"),
+			Status:      helpers.GetStringPtr("Active"),
+			AssigneeID:  helpers.GetStringPtr("2"),
+			ProjectID:   helpers.GetStringPtr(projectID),
+		}
+	}
+	response := func(id string) string {
+		return `{"id":"` + id + `","title":"Task ` + id + `","description":"Task ` + id + `","priority":"High","status":"Active","assignee_id":"2","project_id":"2","completed_at":""}`
+	}
+
+	// 1 blocks 2, 2 blocks 3, 4 is in another project
+	tasks := map[string]task.Entity{"1": newTask("1", "2"), "2": newTask("2", "2"), "3": newTask("3", "2"), "4": newTask("4", "3")}
+	createdAt := "2024-05-01T10:00:00Z"
+	link := dependency.Entity{TaskID: "2", BlockerID: "1", CreatedAt: &createdAt}
+
+	tests := []struct {
+		name           string
+		crossProject   bool
+		method         string
+		target         string
+		inputBody      string
+		mockLinks      []dependency.Entity
+		expectedStatus int
+		expectedBody   string
+		expectedAdded  *dependency.Entity
+		expectedRecord *audit.Entity
+	}{
+		{
+			name:           "List Blocking Tasks",
+			method:         "GET",
+			target:         "/tasks/3/dependencies",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[` + response("2") + `],"success":true}`,
+		},
+		{
+			name:           "List Blocked Tasks",
+			method:         "GET",
+			target:         "/tasks/1/dependents",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[` + response("2") + `],"success":true}`,
+		},
+		{
+			name:           "Dependencies Of Unknown Task",
+			method:         "GET",
+			target:         "/tasks/9/dependencies",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"error not found","success":false}`,
+		},
+		{
+			name:           "Add Dependency",
+			method:         "POST",
+			target:         "/tasks/3/dependencies",
+			inputBody:      `{"blocker_id":"1"}`,
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"data":{"task_id":"3","blocker_id":"1","created_at":"2024-05-01T10:00:00Z"},"success":true}`,
+			expectedAdded:  &dependency.Entity{TaskID: "3", BlockerID: "1"},
+			expectedRecord: &audit.Entity{EntityType: audit.EntityDependency, EntityID: "3/1", Operation: audit.OperationCreate},
+		},
+		{
+			name:           "Missing Blocker",
+			method:         "POST",
+			target:         "/tasks/3/dependencies",
+			inputBody:      `{}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"data":{"blocker_id":null},"message":"blocker_id: cannot be blank","success":false}`,
+		},
+		{
+			name:           "Task Blocks Itself",
+			method:         "POST",
+			target:         "/tasks/3/dependencies",
+			inputBody:      `{"blocker_id":"3"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"message":"blocker_id: task cannot block itself","success":false}`,
+		},
+		{
+			name:           "Unknown Blocker",
+			method:         "POST",
+			target:         "/tasks/3/dependencies",
+			inputBody:      `{"blocker_id":"9"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"message":"blocker_id: blocking task does not exist","success":false}`,
+		},
+		{
+			name:           "Cross Project Dependency",
+			method:         "POST",
+			target:         "/tasks/3/dependencies",
+			inputBody:      `{"blocker_id":"4"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"message":"blocker_id: blocking task belongs to another project","success":false}`,
+		},
+		{
+			name:           "Cross Project Dependency Allowed",
+			crossProject:   true,
+			method:         "POST",
+			target:         "/tasks/3/dependencies",
+			inputBody:      `{"blocker_id":"4"}`,
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"data":{"task_id":"3","blocker_id":"4","created_at":"2024-05-01T10:00:00Z"},"success":true}`,
+			expectedAdded:  &dependency.Entity{TaskID: "3", BlockerID: "4"},
+		},
+		{
+			name:           "Duplicate Dependency",
+			method:         "POST",
+			target:         "/tasks/2/dependencies",
+			inputBody:      `{"blocker_id":"1"}`,
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"task already depends on the blocking task","success":false}`,
+		},
+		{
+			name:           "Dependency Cycle",
+			method:         "POST",
+			target:         "/tasks/1/dependencies",
+			inputBody:      `{"blocker_id":"3"}`,
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"dependency cycle: task 1 already blocks task 3","success":false}`,
+		},
+		{
+			name:           "Delete Dependency",
+			method:         "DELETE",
+			target:         "/tasks/2/dependencies/1",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":"1","success":true}`,
+			expectedRecord: &audit.Entity{EntityType: audit.EntityDependency, EntityID: "2/1", Operation: audit.OperationDelete},
+		},
+		{
+			name:           "Delete Missing Dependency",
+			method:         "DELETE",
+			target:         "/tasks/3/dependencies/1",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"error not found","success":false}`,
+		},
+		{
+			name:           "Close Blocked Task",
+			method:         "PUT",
+			target:         "/tasks/2",
+			inputBody:      `{"status":"Done"}`,
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"task is blocked by open tasks: 1","success":false}`,
+		},
+		{
+			name:           "Close Unblocked Task",
+			method:         "PUT",
+			target:         "/tasks/1",
+			inputBody:      `{"status":"Done"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":"ok","success":true}`,
+		},
+		{
+			name:           "Topological Order",
+			method:         "GET",
+			target:         "/projects/2/tasks/order",
+			mockLinks:      []dependency.Entity{{TaskID: "1", BlockerID: "3"}, {TaskID: "3", BlockerID: "2"}},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[` + response("2") + `,` + response("3") + `,` + response("1") + `],"success":true}`,
+		},
+		{
+			name:           "Independent Tasks Keep Their Order",
+			method:         "GET",
+			target:         "/projects/2/tasks/order",
+			mockLinks:      []dependency.Entity{{TaskID: "1", BlockerID: "3"}},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[` + response("2") + `,` + response("3") + `,` + response("1") + `],"success":true}`,
+		},
+		{
+			name:           "Order With Cycle",
+			method:         "GET",
+			target:         "/projects/2/tasks/order",
+			mockLinks:      []dependency.Entity{{TaskID: "1", BlockerID: "2"}, {TaskID: "2", BlockerID: "1"}},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"dependency cycle","success":false}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockTaskRepo.On("Get", mock.Anything, "9").Return(nil, store.ErrorNotFound)
+			for id, data := range tasks {
+				mockTaskRepo.On("Get", mock.Anything, id).Return(data, nil)
+			}
+			mockTaskRepo.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockTaskRepo.On("Descendants", mock.Anything, mock.Anything).Return([]task.Entity{}, nil)
+			mockTaskRepo.On("ListByProject", mock.Anything, "2").Return([]task.Entity{tasks["1"], tasks["2"], tasks["3"]}, nil)
+
+			mockProjectRepo := new(MockProjectRepository)
+			mockProjectRepo.On("Get", mock.Anything, "2").Return(project.Entity{ID: "2"}, nil)
+
+			mockWorkflowRepo := new(MockWorkflowRepository)
+			mockWorkflowRepo.On("Get", mock.Anything, mock.Anything).Return(workflow.Entity{}, store.ErrorNotFound)
+
+			mockDependencyRepo := new(MockDependencyRepository)
+			mockDependencyRepo.On("ListBlockers", mock.Anything, "3", mock.Anything).Return([]task.Entity{tasks["2"]}, store.Cursor{}, nil)
+			mockDependencyRepo.On("ListDependents", mock.Anything, "1", mock.Anything).Return([]task.Entity{tasks["2"]}, store.Cursor{}, nil)
+			mockDependencyRepo.On("ListByProject", mock.Anything, "2").Return(tt.mockLinks, nil)
+			mockDependencyRepo.On("Get", mock.Anything, "2", "1").Return(link, nil)
+			if tt.expectedAdded != nil {
+				added := *tt.expectedAdded
+				added.CreatedAt = &createdAt
+				mockDependencyRepo.On("Get", mock.Anything, added.TaskID, added.BlockerID).Return(dependency.Entity{}, store.ErrorNotFound).Once()
+				mockDependencyRepo.On("Get", mock.Anything, added.TaskID, added.BlockerID).Return(added, nil)
+			}
+			mockDependencyRepo.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(dependency.Entity{}, store.ErrorNotFound)
+			mockDependencyRepo.On("Add", mock.Anything, mock.Anything).Return(nil)
+			mockDependencyRepo.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockDependencyRepo.On("Blocks", mock.Anything, "1", "3").Return(true, nil)
+			mockDependencyRepo.On("Blocks", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+			mockDependencyRepo.On("OpenBlockers", mock.Anything, []string{"2"}).Return([]string{"1"}, nil)
+			mockDependencyRepo.On("OpenBlockers", mock.Anything, mock.Anything).Return([]string{}, nil)
+
+			mockAuditRepo := new(MockAuditRepository)
+			mockAuditRepo.On("Add", mock.Anything, mock.Anything).Return(nil)
+
+			taskService, _ := tasker.New(
+				tasker.WithUserRepository(mockAdmin()),
+				tasker.WithTaskRepository(mockTaskRepo),
+				tasker.WithProjectRepository(mockProjectRepo),
+				tasker.WithWorkflowRepository(mockWorkflowRepo),
+				tasker.WithDependencyRepository(mockDependencyRepo),
+				tasker.WithAuditRepository(mockAuditRepo),
+				tasker.WithCrossProjectDependencies(tt.crossProject),
+			)
+
+			gin.SetMode(gin.TestMode)
+			r := authenticated("admin-id")
+			NewTaskHandler(taskService).Routes(r.Group("/"))
+			NewProjectHandler(taskService).Routes(r.Group("/"))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.target, bytes.NewBufferString(tt.inputBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+
+			if tt.expectedAdded != nil {
+				mockDependencyRepo.AssertCalled(t, "Add", mock.Anything, *tt.expectedAdded)
+			} else {
+				mockDependencyRepo.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+			}
+			if tt.expectedRecord != nil {
+				mockAuditRepo.AssertCalled(t, "Add", mock.Anything, mock.MatchedBy(func(data audit.Entity) bool {
+					return data.EntityType == tt.expectedRecord.EntityType &&
+						data.EntityID == tt.expectedRecord.EntityID &&
+						data.Operation == tt.expectedRecord.Operation
+				}))
+			}
+			if tt.method == "PUT" && tt.expectedStatus != http.StatusOK {
+				mockTaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"hard/internal/domain/access"
+	"hard/pkg/server/response"
+	"hard/pkg/store"
+	"strconv"
+	"strings"
+)
+
+// setETag tags the response with the version of the entity it carries.
+func setETag(c *gin.Context, version int) {
+	if version > 0 {
+		c.Header("ETag", `"`+strconv.Itoa(version)+`"`)
+	}
+}
+
+// ifMatch returns the version required by the If-Match header, nil when the
+// header is missing or "*". A list of tags matches when one of them is the
+// version current looks up, that version is then required so that the write
+// still fails if the entity changes meanwhile. Weak and malformed tags never
+// match. When nothing matches the error response is written and ok is false.
+func ifMatch(c *gin.Context, current func() (int, error)) (version *int, ok bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil, true
+	}
+
+	var tags []int
+	for _, tag := range strings.Split(header, ",") {
+		tag, quoted := strings.CutPrefix(strings.TrimSpace(tag), `"`)
+		tag, closed := strings.CutSuffix(tag, `"`)
+		if v, err := strconv.Atoi(tag); quoted && closed && err == nil {
+			tags = append(tags, v)
+		}
+	}
+
+	switch len(tags) {
+	case 0:
+		response.PreconditionFailed(c, store.ErrorConflict)
+		return
+	case 1:
+		return &tags[0], true
+	}
+
+	v, err := current()
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+	for _, tag := range tags {
+		if tag == v {
+			return &v, true
+		}
+	}
+
+	response.PreconditionFailed(c, store.ErrorConflict)
+	return
+}
+
+// version looks up the current version of the task for ifMatch.
+func (h *TaskHandler) version(c *gin.Context, id string) func() (int, error) {
+	return func() (int, error) {
+		res, err := h.taskerService.GetTask(c, id)
+		return res.Version, err
+	}
+}
+
+// version looks up the current version of the user for ifMatch.
+func (h *UserHandler) version(c *gin.Context, id string) func() (int, error) {
+	return func() (int, error) {
+		res, err := h.taskerService.GetUser(c, id)
+		return res.Version, err
+	}
+}
+
+// version looks up the current version of the project for ifMatch.
+func (h *ProjectHandler) version(c *gin.Context, id string) func() (int, error) {
+	return func() (int, error) {
+		res, err := h.taskerService.GetProject(c, id)
+		return res.Version, err
+	}
+}
@@ -0,0 +1,192 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+	"hard/internal/domain/event"
+	"hard/internal/service/tasker"
+	"hard/pkg/pubsub"
+	"hard/pkg/server/response"
+	"hard/pkg/store"
+	"time"
+)
+
+// heartbeat keeps idle streams open through proxies that drop silent
+// connections.
+const heartbeat = 15 * time.Second
+
+type EventHandler struct {
+	taskerService *tasker.Service
+}
+
+func NewEventHandler(s *tasker.Service) *EventHandler {
+	return &EventHandler{taskerService: s}
+}
+
+// Routes sets up the routes for the event streams
+func (h *EventHandler) Routes(r *gin.RouterGroup) {
+	r.GET("/events", h.stream)
+	r.GET("/ws", h.socket)
+}
+
+// streamEvents godoc
+//
+//	@Summary		Stream events
+//	@Description	Stream the create, update and delete events of tasks and projects as server-sent events, such as task.created or project.deleted. A client reconnecting with Last-Event-ID gets the events it missed, or a reset event when they are lost and it should reload. The token may be passed in access_token since EventSource cannot set headers
+//	@Tags			events
+//	@Produce		text/event-stream
+//	@Param			project_id		query		string	false	"Only the events of a project and of its tasks"
+//	@Param			assignee_id		query		string	false	"Only the events of the tasks of an assignee"
+//	@Param			Last-Event-ID	header		string	false	"ID of the last event received"
+//	@Param			last_event_id	query		string	false	"ID of the last event received, when the header cannot be set"
+//	@Param			access_token	query		string	false	"Bearer token, when the header cannot be set"
+//	@Success		200				{object}	event.Response
+//	@Failure		400				{object}	response.Object
+//	@Failure		401				{object}	response.Object
+//	@Failure		404				{object}	response.Object
+//	@Failure		500				{object}	response.Object
+//	@Router			/events [get]
+func (h *EventHandler) stream(c *gin.Context) {
+	sub, replay, complete, ok := h.subscribe(c)
+	if !ok {
+		return
+	}
+	defer sub.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(200)
+
+	if !complete {
+		writeEvent(c, event.Reset())
+	}
+	for _, msg := range replay {
+		writeEvent(c, event.ParseFromMessage(msg))
+	}
+	c.Writer.Flush()
+
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case msg, open := <-sub.C:
+			if !open {
+				return
+			}
+			writeEvent(c, event.ParseFromMessage(msg))
+		case <-ticker.C:
+			fmt.Fprint(c.Writer, ": ping\n\n")
+		}
+		c.Writer.Flush()
+	}
+}
+
+// socketEvents godoc
+//
+//	@Summary		Stream events over WebSocket
+//	@Description	Stream the events of /events as JSON messages over a WebSocket, resuming from last_event_id
+//	@Tags			events
+//	@Param			project_id		query		string	false	"Only the events of a project and of its tasks"
+//	@Param			assignee_id		query		string	false	"Only the events of the tasks of an assignee"
+//	@Param			last_event_id	query		string	false	"ID of the last event received"
+//	@Param			access_token	query		string	false	"Bearer token, when the header cannot be set"
+//	@Success		101				{object}	event.Response
+//	@Failure		400				{object}	response.Object
+//	@Failure		401				{object}	response.Object
+//	@Failure		404				{object}	response.Object
+//	@Failure		500				{object}	response.Object
+//	@Router			/ws [get]
+func (h *EventHandler) socket(c *gin.Context) {
+	sub, replay, complete, ok := h.subscribe(c)
+	if !ok {
+		return
+	}
+	defer sub.Close()
+
+	server := websocket.Server{Handler: func(conn *websocket.Conn) {
+		// the client sends nothing, reading only notices that it left
+		left := make(chan struct{})
+		go func() {
+			defer close(left)
+			var discard []byte
+			for websocket.Message.Receive(conn, &discard) == nil {
+			}
+		}()
+
+		if !complete {
+			if websocket.JSON.Send(conn, event.Reset()) != nil {
+				return
+			}
+		}
+		for _, msg := range replay {
+			if websocket.JSON.Send(conn, event.ParseFromMessage(msg)) != nil {
+				return
+			}
+		}
+
+		for {
+			select {
+			case <-left:
+				return
+			case msg, open := <-sub.C:
+				if !open {
+					return
+				}
+				if websocket.JSON.Send(conn, event.ParseFromMessage(msg)) != nil {
+					return
+				}
+			}
+		}
+	}}
+	server.ServeHTTP(c.Writer, c.Request)
+}
+
+// subscribe parses the filter and the last event ID of the request, it
+// responds with the error when there is one.
+func (h *EventHandler) subscribe(c *gin.Context) (sub *pubsub.Subscription[event.Event], replay []pubsub.Message[event.Event], complete, ok bool) {
+	filter := event.Filter{
+		ProjectID:  c.Query("project_id"),
+		AssigneeID: c.Query("assignee_id"),
+	}
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+	after, err := event.ParseLastEventID(lastEventID)
+	if err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	sub, replay, complete, err = h.taskerService.SubscribeEvents(c, filter, after)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	return sub, replay, complete, true
+}
+
+// writeEvent writes the event as a server-sent event, the reset event has no
+// ID so that the client keeps resuming from the last one it got.
+func writeEvent(c *gin.Context, data event.Response) {
+	payload, _ := json.Marshal(data)
+	if data.Type != event.TypeReset {
+		fmt.Fprintf(c.Writer, "id: %s\n", data.ID)
+	}
+	fmt.Fprintf(c.Writer, "event: %s\ndata: %s\n\n", data.Type, payload)
+}
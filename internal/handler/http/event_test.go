@@ -0,0 +1,387 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"hard/internal/domain/audit"
+	"hard/internal/domain/event"
+	"hard/internal/domain/member"
+	"hard/internal/domain/project"
+	"hard/internal/domain/task"
+	"hard/internal/domain/workflow"
+	"hard/pkg/helpers"
+	"hard/pkg/pubsub"
+	"hard/pkg/store"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/net/websocket"
+	"hard/internal/service/tasker"
+)
+
+// MockEventRepository takes the event IDs from a counter, as the database
+// sequence would, and keeps the events to this replica.
+type MockEventRepository struct {
+	mu   sync.Mutex
+	last int64
+}
+
+func (m *MockEventRepository) NextID(ctx context.Context) (id int64, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.last++
+	return m.last, nil
+}
+
+func (m *MockEventRepository) Notify(ctx context.Context, id int64, data event.Event) (err error) {
+	return errors.New("not listening")
+}
+
+func (m *MockEventRepository) Listen(ctx context.Context, fn func(id int64, data event.Event), lost func()) (err error) {
+	<-ctx.Done()
+	return nil
+}
+
+// eventServer serves the task, project and event routes of a service whose
+// events go to hub.
+func eventServer(hub *pubsub.Hub[event.Event]) *httptest.Server {
+	current := task.Entity{
+		ID:         "5",
+		Title:      helpers.GetStringPtr("Rescue"),
+		Status:     helpers.GetStringPtr("Active"),
+		ProjectID:  helpers.GetStringPtr("1"),
+		AssigneeID: helpers.GetStringPtr("admin-id"),
+	}
+
+	mockTaskRepo := new(MockTaskRepository)
+	mockTaskRepo.On("Get", mock.Anything, "5").Return(current, nil)
+	mockTaskRepo.On("Update", mock.Anything, "5", mock.Anything).Return(nil)
+
+	mockProjectRepo := new(MockProjectRepository)
+	mockProjectRepo.On("Get", mock.Anything, "1").Return(project.Entity{ID: "1", Title: helpers.GetStringPtr("Alpha")}, nil)
+	mockProjectRepo.On("Get", mock.Anything, "2").Return(project.Entity{ID: "2", Title: helpers.GetStringPtr("Beta")}, nil)
+	mockProjectRepo.On("Get", mock.Anything, "3").Return(project.Entity{}, store.ErrorNotFound)
+	mockProjectRepo.On("Delete", mock.Anything, "2", mock.Anything).Return(nil)
+
+	mockMemberRepo := new(MockMemberRepository)
+	mockMemberRepo.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(member.Entity{}, nil)
+
+	mockWorkflowRepo := new(MockWorkflowRepository)
+	mockWorkflowRepo.On("Get", mock.Anything, mock.Anything).Return(workflow.Entity{}, store.ErrorNotFound)
+
+	taskService, _ := tasker.New(
+		tasker.WithUserRepository(mockAdmin()),
+		tasker.WithTaskRepository(mockTaskRepo),
+		tasker.WithProjectRepository(mockProjectRepo),
+		tasker.WithMemberRepository(mockMemberRepo),
+		tasker.WithWorkflowRepository(mockWorkflowRepo),
+		tasker.WithTransactor(new(MockTransactor)),
+		tasker.WithEventRepository(new(MockEventRepository)),
+		tasker.WithEventHub(hub),
+	)
+
+	gin.SetMode(gin.TestMode)
+	r := authenticated("admin-id")
+	api := r.Group("/")
+	NewTaskHandler(taskService).Routes(api)
+	NewProjectHandler(taskService).Routes(api)
+	NewEventHandler(taskService).Routes(api)
+
+	return httptest.NewServer(r)
+}
+
+// sseEvent is a server-sent event as the client reads it.
+type sseEvent struct {
+	ID   string
+	Type string
+	Data event.Response
+}
+
+// readEvents reads n server-sent events from the stream, skipping comments.
+func readEvents(t *testing.T, body io.Reader, n int) (res []sseEvent) {
+	t.Helper()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scanner := bufio.NewScanner(body)
+		current := sseEvent{}
+		for len(res) < n && scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "":
+				if current.Type != "" {
+					res = append(res, current)
+				}
+				current = sseEvent{}
+			case strings.HasPrefix(line, "id: "):
+				current.ID = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				current.Type = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &current.Data)
+			}
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for %d events", n)
+	}
+
+	return
+}
+
+func TestEventStream(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		method         string
+		target         string
+		inputBody      string
+		expectedType   string
+		expectedEntity string
+		expectedID     string
+		expectedChange audit.Diff
+	}{
+		{
+			name:           "Task Update",
+			method:         "PUT",
+			target:         "/tasks/5",
+			inputBody:      `{"title":"Rescue the cat"}`,
+			expectedType:   "task.updated",
+			expectedEntity: "task",
+			expectedID:     "5",
+			expectedChange: audit.Diff{"title": {Old: "Rescue", New: "Rescue the cat"}},
+		},
+		{
+			name:           "Project Filter",
+			query:          "?project_id=1",
+			method:         "PUT",
+			target:         "/tasks/5",
+			inputBody:      `{"title":"Rescue the cat"}`,
+			expectedType:   "task.updated",
+			expectedEntity: "task",
+			expectedID:     "5",
+			expectedChange: audit.Diff{"title": {Old: "Rescue", New: "Rescue the cat"}},
+		},
+		{
+			name:           "Assignee Filter",
+			query:          "?assignee_id=admin-id",
+			method:         "PUT",
+			target:         "/tasks/5",
+			inputBody:      `{"title":"Rescue the cat"}`,
+			expectedType:   "task.updated",
+			expectedEntity: "task",
+			expectedID:     "5",
+			expectedChange: audit.Diff{"title": {Old: "Rescue", New: "Rescue the cat"}},
+		},
+		{
+			name:           "Project Deletion",
+			query:          "?project_id=2",
+			method:         "DELETE",
+			target:         "/projects/2",
+			expectedType:   "project.deleted",
+			expectedEntity: "project",
+			expectedID:     "2",
+			expectedChange: audit.Diff{"id": {Old: "2"}, "title": {Old: "Beta"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := eventServer(pubsub.NewHub[event.Event](10))
+			defer server.Close()
+
+			res, err := http.Get(server.URL + "/events" + tt.query)
+			assert.NoError(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+			assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+			req, _ := http.NewRequest(tt.method, server.URL+tt.target, bytes.NewBufferString(tt.inputBody))
+			req.Header.Set("Content-Type", "application/json")
+			change, err := http.DefaultClient.Do(req)
+			assert.NoError(t, err)
+			change.Body.Close()
+			assert.Equal(t, http.StatusOK, change.StatusCode)
+
+			events := readEvents(t, res.Body, 1)
+			assert.Equal(t, tt.expectedType, events[0].Type)
+			// the ID is the first one of the event sequence
+			assert.Equal(t, "1", events[0].ID)
+			assert.Equal(t, events[0].ID, events[0].Data.ID)
+			assert.Equal(t, tt.expectedEntity, events[0].Data.Entity)
+			assert.Equal(t, tt.expectedID, events[0].Data.EntityID)
+			assert.Equal(t, "admin-id", events[0].Data.ActorID)
+			assert.Equal(t, tt.expectedChange, events[0].Data.Changes)
+		})
+	}
+}
+
+func TestEventStreamFilter(t *testing.T) {
+	server := eventServer(pubsub.NewHub[event.Event](10))
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/events?project_id=2")
+	assert.NoError(t, err)
+	defer res.Body.Close()
+
+	// the task is in another project, only the deletion reaches the stream
+	req, _ := http.NewRequest("PUT", server.URL+"/tasks/5", bytes.NewBufferString(`{"title":"Rescue the cat"}`))
+	req.Header.Set("Content-Type", "application/json")
+	change, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	change.Body.Close()
+
+	req, _ = http.NewRequest("DELETE", server.URL+"/projects/2", nil)
+	change, err = http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	change.Body.Close()
+
+	events := readEvents(t, res.Body, 1)
+	assert.Equal(t, "project.deleted", events[0].Type)
+	assert.Equal(t, "2", events[0].Data.ProjectID)
+}
+
+func TestEventStreamResume(t *testing.T) {
+	tests := []struct {
+		name          string
+		size          int
+		lastEventID   string
+		expectedTypes []string
+	}{
+		{
+			name:          "Missed Events",
+			size:          10,
+			expectedTypes: []string{"project.updated", "project.deleted"},
+		},
+		{
+			name:          "Lost Events",
+			size:          1,
+			expectedTypes: []string{event.TypeReset, "project.deleted"},
+		},
+		{
+			name:          "Unknown Event",
+			size:          10,
+			lastEventID:   "1",
+			expectedTypes: []string{event.TypeReset},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := pubsub.NewHub[event.Event](tt.size)
+			server := eventServer(hub)
+			defer server.Close()
+
+			// the client got the first event before it was disconnected
+			first := hub.Publish(101, event.Event{Type: "project.created", Entity: "project", EntityID: "1", ProjectID: "1"})
+			hub.Publish(102, event.Event{Type: "project.updated", Entity: "project", EntityID: "1", ProjectID: "1"})
+			hub.Publish(103, event.Event{Type: "project.deleted", Entity: "project", EntityID: "1", ProjectID: "1"})
+
+			lastEventID := tt.lastEventID
+			if lastEventID == "" {
+				lastEventID = event.ParseFromMessage(first).ID
+			}
+
+			req, _ := http.NewRequest("GET", server.URL+"/events", nil)
+			req.Header.Set("Last-Event-ID", lastEventID)
+			res, err := http.DefaultClient.Do(req)
+			assert.NoError(t, err)
+			defer res.Body.Close()
+
+			var types []string
+			for _, e := range readEvents(t, res.Body, len(tt.expectedTypes)) {
+				types = append(types, e.Type)
+				if e.Type == event.TypeReset {
+					assert.Empty(t, e.ID)
+				}
+			}
+			assert.Equal(t, tt.expectedTypes, types)
+		})
+	}
+}
+
+func TestEventStreamErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		target         string
+		lastEventID    string
+		expectedStatus int
+	}{
+		{
+			name:           "Invalid Last Event ID",
+			target:         "/events",
+			lastEventID:    "yesterday",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid Last Event ID Query",
+			target:         "/ws?last_event_id=-1",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Unknown Project",
+			target:         "/events?project_id=3",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := eventServer(pubsub.NewHub[event.Event](10))
+			defer server.Close()
+
+			req, _ := http.NewRequest("GET", server.URL+tt.target, nil)
+			if tt.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", tt.lastEventID)
+			}
+			res, err := http.DefaultClient.Do(req)
+			assert.NoError(t, err)
+			res.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestEventSocket(t *testing.T) {
+	hub := pubsub.NewHub[event.Event](10)
+	server := eventServer(hub)
+	defer server.Close()
+
+	missed := hub.Publish(1, event.Event{Type: "project.created", Entity: "project", EntityID: "1", ProjectID: "1"})
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws?project_id=1&last_event_id=" + event.ParseFromMessage(missed).ID
+	conn, err := websocket.Dial(url, "", server.URL)
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	hub.Publish(2, event.Event{Type: "project.updated", Entity: "project", EntityID: "2", ProjectID: "2"})
+	hub.Publish(3, event.Event{Type: "project.updated", Entity: "project", EntityID: "1", ProjectID: "1"})
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var res event.Response
+	assert.NoError(t, websocket.JSON.Receive(conn, &res))
+	assert.Equal(t, "project.updated", res.Type)
+	assert.Equal(t, "1", res.EntityID)
+	assert.NotEmpty(t, res.ID)
+
+	hub.Close()
+	assert.Error(t, websocket.JSON.Receive(conn, &res))
+}
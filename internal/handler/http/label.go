@@ -0,0 +1,327 @@
+package http
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"hard/internal/domain/access"
+	"hard/internal/domain/label"
+	"hard/pkg/server/response"
+	"hard/pkg/store"
+)
+
+// listLabels godoc
+//
+//	@Summary		List project labels
+//	@Description	Get the labels of a project
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Project ID"
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			after	query		string	false	"Cursor of the next page"
+//	@Param			before	query		string	false	"Cursor of the previous page"
+//	@Success		200		{array}		label.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/projects/{id}/labels [get]
+func (h *ProjectHandler) listLabels(c *gin.Context) {
+	id := c.Param("id")
+	page, err := parsePage(c)
+	if err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	res, cursor, err := h.taskerService.ListLabels(c, id, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, store.ErrorInvalidCursor):
+			response.BadRequest(c, err, nil)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OKPage(c, res, cursor.Next, cursor.Prev)
+}
+
+// addLabel godoc
+//
+//	@Summary		Create a label
+//	@Description	Create a label of a project with a name and a hex color such as #d73a4a
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string			true	"Project ID"
+//	@Param			label	body		label.Request	true	"Label Request"
+//	@Success		201		{object}	label.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		409		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/projects/{id}/labels [post]
+func (h *ProjectHandler) addLabel(c *gin.Context) {
+	id := c.Param("id")
+	req := label.Request{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	res, err := h.taskerService.CreateLabel(c, id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		case errors.Is(err, label.ErrorExists):
+			response.Conflict(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.Created(c, res)
+}
+
+// getLabel godoc
+//
+//	@Summary		Get a label
+//	@Description	Get a label of a project by ID
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string	true	"Project ID"
+//	@Param			label_id	path		string	true	"Label ID"
+//	@Success		200			{object}	label.Response
+//	@Failure		404			{object}	response.Object
+//	@Failure		500			{object}	response.Object
+//	@Router			/projects/{id}/labels/{label_id} [get]
+func (h *ProjectHandler) getLabel(c *gin.Context) {
+	id, labelID := c.Param("id"), c.Param("label_id")
+
+	res, err := h.taskerService.GetLabel(c, id, labelID)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, res)
+}
+
+// updateLabel godoc
+//
+//	@Summary		Update a label
+//	@Description	Rename a label or change its color
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string			true	"Project ID"
+//	@Param			label_id	path		string			true	"Label ID"
+//	@Param			label		body		label.Request	true	"Label Request"
+//	@Success		200			{string}	string			"ok"
+//	@Failure		400			{object}	response.Object
+//	@Failure		403			{object}	response.Object
+//	@Failure		404			{object}	response.Object
+//	@Failure		409			{object}	response.Object
+//	@Failure		500			{object}	response.Object
+//	@Router			/projects/{id}/labels/{label_id} [put]
+func (h *ProjectHandler) updateLabel(c *gin.Context) {
+	id, labelID := c.Param("id"), c.Param("label_id")
+	req := label.Request{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	if req.IsEmpty() {
+		response.BadRequest(c, errors.New("at least one field must be provided for update"), req)
+		return
+	}
+
+	if err := req.ValidateUpdate(); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	if err := h.taskerService.UpdateLabel(c, id, labelID, req); err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		case errors.Is(err, label.ErrorExists):
+			response.Conflict(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, "ok")
+}
+
+// deleteLabel godoc
+//
+//	@Summary		Delete a label
+//	@Description	Delete a label of a project and remove it from all tasks
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string	true	"Project ID"
+//	@Param			label_id	path		string	true	"Label ID"
+//	@Success		200			{string}	string	"Deleted Label ID"
+//	@Failure		403			{object}	response.Object
+//	@Failure		404			{object}	response.Object
+//	@Failure		500			{object}	response.Object
+//	@Router			/projects/{id}/labels/{label_id} [delete]
+func (h *ProjectHandler) deleteLabel(c *gin.Context) {
+	id, labelID := c.Param("id"), c.Param("label_id")
+
+	if err := h.taskerService.DeleteLabel(c, id, labelID); err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, labelID)
+}
+
+// listTaskLabels godoc
+//
+//	@Summary		List task labels
+//	@Description	Get the labels attached to a task
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string	true	"Task ID"
+//	@Param			include_deleted	query		bool	false	"Include soft deleted rows, admin only"
+//	@Success		200				{array}		label.Response
+//	@Failure		403				{object}	response.Object
+//	@Failure		404				{object}	response.Object
+//	@Failure		500				{object}	response.Object
+//	@Router			/tasks/{id}/labels [get]
+func (h *TaskHandler) listTaskLabels(c *gin.Context) {
+	id := c.Param("id")
+
+	res, err := h.taskerService.ListTaskLabels(c, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, res)
+}
+
+// attachLabel godoc
+//
+//	@Summary		Attach a label
+//	@Description	Attach a label of the task's project to the task
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string				true	"Task ID"
+//	@Param			label	body		label.AttachRequest	true	"Attach Request"
+//	@Success		201		{object}	label.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		409		{object}	response.Object
+//	@Failure		422		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/tasks/{id}/labels [post]
+func (h *TaskHandler) attachLabel(c *gin.Context) {
+	id := c.Param("id")
+	req := label.AttachRequest{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	res, err := h.taskerService.AttachLabel(c, id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		case errors.Is(err, label.ErrorAttached):
+			response.Conflict(c, err)
+		case errors.Is(err, label.ErrorUnknownLabel), errors.Is(err, label.ErrorOtherProject):
+			response.UnprocessableEntity(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.Created(c, res)
+}
+
+// detachLabel godoc
+//
+//	@Summary		Detach a label
+//	@Description	Remove a label from a task
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string	true	"Task ID"
+//	@Param			label_id	path		string	true	"Label ID"
+//	@Success		200			{string}	string	"Detached Label ID"
+//	@Failure		403			{object}	response.Object
+//	@Failure		404			{object}	response.Object
+//	@Failure		500			{object}	response.Object
+//	@Router			/tasks/{id}/labels/{label_id} [delete]
+func (h *TaskHandler) detachLabel(c *gin.Context) {
+	id, labelID := c.Param("id"), c.Param("label_id")
+
+	if err := h.taskerService.DetachLabel(c, id, labelID); err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, labelID)
+}
@@ -0,0 +1,375 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"hard/internal/domain/audit"
+	"hard/internal/domain/label"
+	"hard/internal/domain/member"
+	"hard/internal/domain/project"
+	"hard/internal/domain/task"
+	"hard/internal/domain/user"
+	"hard/pkg/helpers"
+	"hard/pkg/store"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"hard/internal/service/tasker"
+)
+
+type MockLabelRepository struct {
+	mock.Mock
+}
+
+func (m *MockLabelRepository) List(ctx context.Context, projectID string, page store.Page) (dest []label.Entity, cursor store.Cursor, err error) {
+	args := m.Called(ctx, projectID, page)
+	return args.Get(0).([]label.Entity), args.Get(1).(store.Cursor), args.Error(2)
+}
+
+func (m *MockLabelRepository) Get(ctx context.Context, id string) (dest label.Entity, err error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(label.Entity), args.Error(1)
+}
+
+func (m *MockLabelRepository) GetByName(ctx context.Context, projectID, name string) (dest label.Entity, err error) {
+	args := m.Called(ctx, projectID, name)
+	return args.Get(0).(label.Entity), args.Error(1)
+}
+
+func (m *MockLabelRepository) Add(ctx context.Context, data label.Entity) (id string, err error) {
+	args := m.Called(ctx, data)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockLabelRepository) Update(ctx context.Context, id string, data label.Entity) (err error) {
+	args := m.Called(ctx, id, data)
+	return args.Error(0)
+}
+
+func (m *MockLabelRepository) Delete(ctx context.Context, id string) (err error) {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockLabelRepository) ListByTasks(ctx context.Context, taskIDs []string) (dest map[string][]label.Entity, err error) {
+	args := m.Called(ctx, taskIDs)
+	return args.Get(0).(map[string][]label.Entity), args.Error(1)
+}
+
+func (m *MockLabelRepository) GetAssignment(ctx context.Context, taskID, labelID string) (dest label.Assignment, err error) {
+	args := m.Called(ctx, taskID, labelID)
+	return args.Get(0).(label.Assignment), args.Error(1)
+}
+
+func (m *MockLabelRepository) Attach(ctx context.Context, data label.Assignment) (err error) {
+	args := m.Called(ctx, data)
+	return args.Error(0)
+}
+
+func (m *MockLabelRepository) Detach(ctx context.Context, taskID, labelID string) (err error) {
+	args := m.Called(ctx, taskID, labelID)
+	return args.Error(0)
+}
+
+func (m *MockLabelRepository) DetachOtherProjects(ctx context.Context, taskID string) (err error) {
+	args := m.Called(ctx, taskID)
+	return args.Error(0)
+}
+
+func TestLabels(t *testing.T) {
+	newLabel := func(id, projectID, name, color string) label.Entity {
+		return label.Entity{ID: id, ProjectID: projectID, Name: helpers.GetStringPtr(name), Color: helpers.GetStringPtr(color)}
+	}
+	bug := newLabel("1", "2", "bug", "#d73a4a")
+	ui := newLabel("3", "2", "ui", "#0075ca")
+	foreign := newLabel("2", "3", "bug", "#d73a4a")
+
+	newTask := func(id string) task.Entity {
+		return task.Entity{
+			ID:          id,
+			Title:       helpers.GetStringPtr("Task " + id),
+			Description: helpers.GetStringPtr("Task " + id),
+			Priority:    helpers.GetStringPtr("High"),
+			Status:      helpers.GetStringPtr("Active"),
+			AssigneeID:  helpers.GetStringPtr("2"),
+			ProjectID:   helpers.GetStringPtr("2"),
+		}
+	}
+	bugJSON := `{"id":"1","project_id":"2","name":"bug","color":"#d73a4a"}`
+	uiJSON := `{"id":"3","project_id":"2","name":"ui","color":"#0075ca"}`
+
+	tests := []struct {
+		name             string
+		caller           string
+		method           string
+		target           string
+		inputBody        string
+		expectedStatus   int
+		expectedBody     string
+		expectedAdded    *label.Entity
+		expectedUpdate   *label.Entity
+		expectedAttached *label.Assignment
+		expectedRecord   *audit.Entity
+	}{
+		{
+			name:           "List Project Labels",
+			method:         "GET",
+			target:         "/projects/2/labels",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[` + bugJSON + `,` + uiJSON + `],"success":true}`,
+		},
+		{
+			name:           "Labels Of Unknown Project",
+			method:         "GET",
+			target:         "/projects/9/labels",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"error not found","success":false}`,
+		},
+		{
+			name:           "Create Label",
+			method:         "POST",
+			target:         "/projects/2/labels",
+			inputBody:      `{"name":" backend ","color":"#a2eeef"}`,
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"data":{"id":"4","project_id":"2","name":"backend","color":"#a2eeef"},"success":true}`,
+			expectedAdded:  &label.Entity{ProjectID: "2", Name: helpers.GetStringPtr("backend"), Color: helpers.GetStringPtr("#a2eeef")},
+			expectedRecord: &audit.Entity{EntityType: audit.EntityLabel, EntityID: "4", Operation: audit.OperationCreate},
+		},
+		{
+			name:           "Invalid Color",
+			method:         "POST",
+			target:         "/projects/2/labels",
+			inputBody:      `{"name":"backend","color":"red"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"data":{"name":"backend","color":"red"},"message":"color: must be a hex color such as #ff0000","success":false}`,
+		},
+		{
+			name:           "Missing Name",
+			method:         "POST",
+			target:         "/projects/2/labels",
+			inputBody:      `{"color":"#a2eeef"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"data":{"name":null,"color":"#a2eeef"},"message":"name: cannot be blank","success":false}`,
+		},
+		{
+			name:           "Duplicate Name",
+			method:         "POST",
+			target:         "/projects/2/labels",
+			inputBody:      `{"name":"bug","color":"#a2eeef"}`,
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"name: label already exists in the project","success":false}`,
+		},
+		{
+			name:           "Create Label Without Permission",
+			caller:         "user-id",
+			method:         "POST",
+			target:         "/projects/2/labels",
+			inputBody:      `{"name":"backend","color":"#a2eeef"}`,
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"message":"permission denied","success":false}`,
+		},
+		{
+			name:           "Get Label",
+			method:         "GET",
+			target:         "/projects/2/labels/1",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":` + bugJSON + `,"success":true}`,
+		},
+		{
+			name:           "Label Of Another Project",
+			method:         "GET",
+			target:         "/projects/2/labels/2",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"error not found","success":false}`,
+		},
+		{
+			name:           "Change Label Color",
+			method:         "PUT",
+			target:         "/projects/2/labels/1",
+			inputBody:      `{"color":"#b60205"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":"ok","success":true}`,
+			expectedUpdate: &label.Entity{Color: helpers.GetStringPtr("#b60205")},
+			expectedRecord: &audit.Entity{EntityType: audit.EntityLabel, EntityID: "1", Operation: audit.OperationUpdate},
+		},
+		{
+			name:           "Rename To Existing Label",
+			method:         "PUT",
+			target:         "/projects/2/labels/3",
+			inputBody:      `{"name":"bug"}`,
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"name: label already exists in the project","success":false}`,
+		},
+		{
+			name:           "Empty Label Update",
+			method:         "PUT",
+			target:         "/projects/2/labels/1",
+			inputBody:      `{}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"data":{"name":null,"color":null},"message":"at least one field must be provided for update","success":false}`,
+		},
+		{
+			name:           "Delete Label",
+			method:         "DELETE",
+			target:         "/projects/2/labels/1",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":"1","success":true}`,
+			expectedRecord: &audit.Entity{EntityType: audit.EntityLabel, EntityID: "1", Operation: audit.OperationDelete},
+		},
+		{
+			name:           "List Task Labels",
+			method:         "GET",
+			target:         "/tasks/1/labels",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[` + bugJSON + `],"success":true}`,
+		},
+		{
+			name:           "Task Includes Its Labels",
+			method:         "GET",
+			target:         "/tasks/1",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"id":"1","title":"Task 1","description":"Task 1","priority":"High","status":"Active","assignee_id":"2","project_id":"2","completed_at":"","labels":[` + bugJSON + `]},"success":true}`,
+		},
+		{
+			name:             "Attach Label",
+			method:           "POST",
+			target:           "/tasks/1/labels",
+			inputBody:        `{"label_id":"3"}`,
+			expectedStatus:   http.StatusCreated,
+			expectedBody:     `{"data":` + uiJSON + `,"success":true}`,
+			expectedAttached: &label.Assignment{TaskID: "1", LabelID: "3"},
+			expectedRecord:   &audit.Entity{EntityType: audit.EntityTaskLabel, EntityID: "1/3", Operation: audit.OperationCreate},
+		},
+		{
+			name:           "Attach Label Twice",
+			method:         "POST",
+			target:         "/tasks/1/labels",
+			inputBody:      `{"label_id":"1"}`,
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"label is already attached to the task","success":false}`,
+		},
+		{
+			name:           "Attach Label Of Another Project",
+			method:         "POST",
+			target:         "/tasks/1/labels",
+			inputBody:      `{"label_id":"2"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"message":"label_id: label belongs to another project","success":false}`,
+		},
+		{
+			name:           "Attach Unknown Label",
+			method:         "POST",
+			target:         "/tasks/1/labels",
+			inputBody:      `{"label_id":"9"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"message":"label_id: label does not exist","success":false}`,
+		},
+		{
+			name:           "Detach Label",
+			method:         "DELETE",
+			target:         "/tasks/1/labels/1",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":"1","success":true}`,
+			expectedRecord: &audit.Entity{EntityType: audit.EntityTaskLabel, EntityID: "1/1", Operation: audit.OperationDelete},
+		},
+		{
+			name:           "Detach Missing Label",
+			method:         "DELETE",
+			target:         "/tasks/1/labels/3",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"error not found","success":false}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := mockAdmin()
+			mockUserRepo.On("Get", mock.Anything, "user-id").Return(user.Entity{ID: "user-id", Role: helpers.GetStringPtr("user")}, nil)
+
+			mockTaskRepo := new(MockTaskRepository)
+			mockTaskRepo.On("Get", mock.Anything, "1").Return(newTask("1"), nil)
+
+			mockProjectRepo := new(MockProjectRepository)
+			mockProjectRepo.On("Get", mock.Anything, "2").Return(project.Entity{ID: "2", ManagerID: helpers.GetStringPtr("admin-id")}, nil)
+			mockProjectRepo.On("Get", mock.Anything, "9").Return(project.Entity{}, store.ErrorNotFound)
+
+			mockMemberRepo := new(MockMemberRepository)
+			mockMemberRepo.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(member.Entity{}, store.ErrorNotFound)
+
+			mockLabelRepo := new(MockLabelRepository)
+			mockLabelRepo.On("List", mock.Anything, "2", mock.Anything).Return([]label.Entity{bug, ui}, store.Cursor{}, nil)
+			mockLabelRepo.On("Get", mock.Anything, "1").Return(bug, nil)
+			mockLabelRepo.On("Get", mock.Anything, "2").Return(foreign, nil)
+			mockLabelRepo.On("Get", mock.Anything, "3").Return(ui, nil)
+			mockLabelRepo.On("Get", mock.Anything, mock.Anything).Return(label.Entity{}, store.ErrorNotFound)
+			mockLabelRepo.On("GetByName", mock.Anything, "2", "bug").Return(bug, nil)
+			mockLabelRepo.On("GetByName", mock.Anything, mock.Anything, mock.Anything).Return(label.Entity{}, store.ErrorNotFound)
+			mockLabelRepo.On("Add", mock.Anything, mock.Anything).Return("4", nil)
+			mockLabelRepo.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockLabelRepo.On("Delete", mock.Anything, mock.Anything).Return(nil)
+			mockLabelRepo.On("ListByTasks", mock.Anything, []string{"1"}).Return(map[string][]label.Entity{"1": {bug}}, nil)
+			mockLabelRepo.On("GetAssignment", mock.Anything, "1", "1").Return(label.Assignment{TaskID: "1", LabelID: "1"}, nil)
+			mockLabelRepo.On("GetAssignment", mock.Anything, mock.Anything, mock.Anything).Return(label.Assignment{}, store.ErrorNotFound)
+			mockLabelRepo.On("Attach", mock.Anything, mock.Anything).Return(nil)
+			mockLabelRepo.On("Detach", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			mockAuditRepo := new(MockAuditRepository)
+			mockAuditRepo.On("Add", mock.Anything, mock.Anything).Return(nil)
+
+			taskService, _ := tasker.New(
+				tasker.WithUserRepository(mockUserRepo),
+				tasker.WithTaskRepository(mockTaskRepo),
+				tasker.WithProjectRepository(mockProjectRepo),
+				tasker.WithMemberRepository(mockMemberRepo),
+				tasker.WithLabelRepository(mockLabelRepo),
+				tasker.WithAuditRepository(mockAuditRepo),
+			)
+
+			caller := tt.caller
+			if caller == "" {
+				caller = "admin-id"
+			}
+
+			gin.SetMode(gin.TestMode)
+			r := authenticated(caller)
+			NewTaskHandler(taskService).Routes(r.Group("/"))
+			NewProjectHandler(taskService).Routes(r.Group("/"))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.target, bytes.NewBufferString(tt.inputBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+
+			if tt.expectedAdded != nil {
+				mockLabelRepo.AssertCalled(t, "Add", mock.Anything, *tt.expectedAdded)
+			} else {
+				mockLabelRepo.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+			}
+			if tt.expectedUpdate != nil {
+				mockLabelRepo.AssertCalled(t, "Update", mock.Anything, mock.Anything, *tt.expectedUpdate)
+			} else {
+				mockLabelRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+			}
+			if tt.expectedAttached != nil {
+				mockLabelRepo.AssertCalled(t, "Attach", mock.Anything, *tt.expectedAttached)
+			} else {
+				mockLabelRepo.AssertNotCalled(t, "Attach", mock.Anything, mock.Anything)
+			}
+			if tt.expectedRecord != nil {
+				mockAuditRepo.AssertCalled(t, "Add", mock.Anything, mock.MatchedBy(func(data audit.Entity) bool {
+					return data.EntityType == tt.expectedRecord.EntityType &&
+						data.EntityID == tt.expectedRecord.EntityID &&
+						data.Operation == tt.expectedRecord.Operation
+				}))
+			}
+		})
+	}
+}
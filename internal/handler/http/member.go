@@ -0,0 +1,178 @@
+package http
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"hard/internal/domain/access"
+	"hard/internal/domain/member"
+	"hard/pkg/server/response"
+	"hard/pkg/store"
+)
+
+// listMembers godoc
+//
+//	@Summary		List project members
+//	@Description	Get the members of a project with their roles
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Project ID"
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			after	query		string	false	"Cursor of the next page"
+//	@Param			before	query		string	false	"Cursor of the previous page"
+//	@Success		200		{array}		member.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/projects/{id}/members [get]
+func (h *ProjectHandler) listMembers(c *gin.Context) {
+	id := c.Param("id")
+	page, err := parsePage(c)
+	if err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	res, cursor, err := h.taskerService.ListMembers(c, id, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, store.ErrorInvalidCursor):
+			response.BadRequest(c, err, nil)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OKPage(c, res, cursor.Next, cursor.Prev)
+}
+
+// addMember godoc
+//
+//	@Summary		Add a project member
+//	@Description	Add a user to a project with a role: owner, maintainer, member or viewer
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string			true	"Project ID"
+//	@Param			member	body		member.Request	true	"Member Request"
+//	@Success		201		{object}	member.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		409		{object}	response.Object
+//	@Failure		422		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/projects/{id}/members [post]
+func (h *ProjectHandler) addMember(c *gin.Context) {
+	id := c.Param("id")
+	req := member.Request{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	res, err := h.taskerService.AddMember(c, id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		case errors.Is(err, member.ErrorAlreadyMember):
+			response.Conflict(c, err)
+		case errors.Is(err, member.ErrorUnknownUser):
+			response.UnprocessableEntity(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.Created(c, res)
+}
+
+// updateMember godoc
+//
+//	@Summary		Change a member role
+//	@Description	Change the role of a project member
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string			true	"Project ID"
+//	@Param			user_id	path		string			true	"User ID"
+//	@Param			member	body		member.Request	true	"Member Request"
+//	@Success		200		{string}	string			"ok"
+//	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/projects/{id}/members/{user_id} [put]
+func (h *ProjectHandler) updateMember(c *gin.Context) {
+	id, userID := c.Param("id"), c.Param("user_id")
+	req := member.Request{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	if err := req.ValidateRole(); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	if err := h.taskerService.UpdateMember(c, id, userID, req); err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, "ok")
+}
+
+// deleteMember godoc
+//
+//	@Summary		Remove a project member
+//	@Description	Remove a user from a project
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Project ID"
+//	@Param			user_id	path		string	true	"User ID"
+//	@Success		200		{string}	string	"Removed User ID"
+//	@Failure		403		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/projects/{id}/members/{user_id} [delete]
+func (h *ProjectHandler) deleteMember(c *gin.Context) {
+	id, userID := c.Param("id"), c.Param("user_id")
+
+	if err := h.taskerService.DeleteMember(c, id, userID); err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, userID)
+}
@@ -0,0 +1,220 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"hard/internal/domain/member"
+	"hard/internal/domain/project"
+	"hard/internal/domain/task"
+	"hard/internal/domain/user"
+	"hard/internal/domain/workflow"
+	"hard/pkg/helpers"
+	"hard/pkg/store"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"hard/internal/service/tasker"
+)
+
+type MockMemberRepository struct {
+	mock.Mock
+}
+
+func (m *MockMemberRepository) List(ctx context.Context, projectID string, page store.Page) (dest []member.Entity, cursor store.Cursor, err error) {
+	args := m.Called(ctx, projectID, page)
+	return args.Get(0).([]member.Entity), args.Get(1).(store.Cursor), args.Error(2)
+}
+
+func (m *MockMemberRepository) Get(ctx context.Context, projectID, userID string) (dest member.Entity, err error) {
+	args := m.Called(ctx, projectID, userID)
+	return args.Get(0).(member.Entity), args.Error(1)
+}
+
+func (m *MockMemberRepository) Add(ctx context.Context, data member.Entity) (err error) {
+	args := m.Called(ctx, data)
+	return args.Error(0)
+}
+
+func (m *MockMemberRepository) Update(ctx context.Context, data member.Entity) (err error) {
+	args := m.Called(ctx, data)
+	return args.Error(0)
+}
+
+func (m *MockMemberRepository) Delete(ctx context.Context, projectID, userID string) (err error) {
+	args := m.Called(ctx, projectID, userID)
+	return args.Error(0)
+}
+
+func TestMembers(t *testing.T) {
+	// user 2 manages project 1, user 3 maintains it and user 4 is a viewer
+	memberships := map[string]member.Entity{
+		"2": {ProjectID: "1", UserID: "2", Role: helpers.GetStringPtr(member.RoleOwner)},
+		"3": {ProjectID: "1", UserID: "3", Role: helpers.GetStringPtr(member.RoleMaintainer), FullName: helpers.GetStringPtr("Morty Smith"), Email: helpers.GetStringPtr("morty@example.com")},
+		"4": {ProjectID: "1", UserID: "4", Role: helpers.GetStringPtr(member.RoleViewer)},
+	}
+
+	tests := []struct {
+		name           string
+		callerID       string
+		method         string
+		target         string
+		inputBody      string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "List Members",
+			callerID:       "4",
+			method:         "GET",
+			target:         "/projects/1/members?limit=1",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[{"project_id":"1","user_id":"3","role":"maintainer","full_name":"Morty Smith","email":"morty@example.com"}],"success":true}`,
+		},
+		{
+			name:           "Maintainer Adds Member",
+			callerID:       "3",
+			method:         "POST",
+			target:         "/projects/1/members",
+			inputBody:      `{"user_id":"5","role":"member"}`,
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"data":{"project_id":"1","user_id":"5","role":"member","full_name":"","email":""},"success":true}`,
+		},
+		{
+			name:           "Viewer Cannot Add Member",
+			callerID:       "4",
+			method:         "POST",
+			target:         "/projects/1/members",
+			inputBody:      `{"user_id":"5","role":"member"}`,
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"message":"permission denied","success":false}`,
+		},
+		{
+			name:           "Already A Member",
+			callerID:       "2",
+			method:         "POST",
+			target:         "/projects/1/members",
+			inputBody:      `{"user_id":"3","role":"member"}`,
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"user is already a member of the project","success":false}`,
+		},
+		{
+			name:           "Unknown User",
+			callerID:       "2",
+			method:         "POST",
+			target:         "/projects/1/members",
+			inputBody:      `{"user_id":"99","role":"member"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"message":"user does not exist","success":false}`,
+		},
+		{
+			name:           "Invalid Role",
+			callerID:       "2",
+			method:         "PUT",
+			target:         "/projects/1/members/3",
+			inputBody:      `{"role":"boss"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"data":{"user_id":null,"role":"boss"},"message":"role: must be one of owner, maintainer, member, viewer","success":false}`,
+		},
+		{
+			name:           "Owner Removes Member",
+			callerID:       "2",
+			method:         "DELETE",
+			target:         "/projects/1/members/4",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":"4","success":true}`,
+		},
+		{
+			name:           "Assignee Must Be A Member",
+			callerID:       "2",
+			method:         "POST",
+			target:         "/tasks/",
+			inputBody:      `{"title":"Rescue","description":"Rescue","priority":"High","status":"Active","assignee_id":"5","project_id":"1"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"message":"assignee is not a member of the project","success":false}`,
+		},
+		{
+			name:           "Reassigning To A Non Member",
+			callerID:       "2",
+			method:         "PUT",
+			target:         "/tasks/7",
+			inputBody:      `{"assignee_id":"5"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"message":"assignee is not a member of the project","success":false}`,
+		},
+		{
+			name:           "User Projects",
+			callerID:       "4",
+			method:         "GET",
+			target:         "/users/3/projects",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[{"id":"1","title":"Alpha","description":"Save Morty","start_date":"2023-01-01","end_date":"","manager_id":"2"}],"success":true}`,
+		},
+	}
+
+	alpha := project.Entity{
+		ID:          "1",
+		Title:       helpers.GetStringPtr("Alpha"),
+		Description: helpers.GetStringPtr("Save Morty"),
+		StartDate:   helpers.GetStringPtr("2023-01-01"),
+		ManagerID:   helpers.GetStringPtr("2"),
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := new(MockUserRepository)
+			for _, id := range []string{"2", "3", "4", "5"} {
+				mockUserRepo.On("Get", mock.Anything, id).Return(user.Entity{ID: id, Role: helpers.GetStringPtr("developer")}, nil)
+			}
+			mockUserRepo.On("Get", mock.Anything, mock.Anything).Return(user.Entity{}, store.ErrorNotFound)
+			mockUserRepo.On("ListProjects", mock.Anything, "3", mock.Anything).Return([]project.Entity{alpha}, store.Cursor{}, nil)
+
+			mockProjectRepo := new(MockProjectRepository)
+			mockProjectRepo.On("Get", mock.Anything, "1").Return(alpha, nil)
+
+			mockMemberRepo := new(MockMemberRepository)
+			for id, data := range memberships {
+				mockMemberRepo.On("Get", mock.Anything, "1", id).Return(data, nil)
+			}
+			mockMemberRepo.On("Get", mock.Anything, "1", "5").Return(member.Entity{}, store.ErrorNotFound).Once()
+			mockMemberRepo.On("Get", mock.Anything, "1", "5").Return(member.Entity{ProjectID: "1", UserID: "5", Role: helpers.GetStringPtr(member.RoleMember)}, nil)
+			mockMemberRepo.On("List", mock.Anything, "1", mock.Anything).Return([]member.Entity{memberships["3"]}, store.Cursor{}, nil)
+			mockMemberRepo.On("Add", mock.Anything, mock.Anything).Return(nil)
+			mockMemberRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+			mockMemberRepo.On("Delete", mock.Anything, "1", mock.Anything).Return(nil)
+
+			mockTaskRepo := new(MockTaskRepository)
+			mockTaskRepo.On("Get", mock.Anything, "7").Return(task.Entity{ID: "7", Status: helpers.GetStringPtr("Active"), AssigneeID: helpers.GetStringPtr("3"), ProjectID: helpers.GetStringPtr("1")}, nil)
+
+			mockWorkflowRepo := new(MockWorkflowRepository)
+			mockWorkflowRepo.On("Get", mock.Anything, mock.Anything).Return(workflow.Entity{}, store.ErrorNotFound)
+
+			taskService, _ := tasker.New(
+				tasker.WithUserRepository(mockUserRepo),
+				tasker.WithProjectRepository(mockProjectRepo),
+				tasker.WithMemberRepository(mockMemberRepo),
+				tasker.WithTaskRepository(mockTaskRepo),
+				tasker.WithWorkflowRepository(mockWorkflowRepo),
+			)
+
+			gin.SetMode(gin.TestMode)
+			r := authenticated(tt.callerID)
+			api := r.Group("/")
+			NewUserHandler(taskService).Routes(api)
+			NewProjectHandler(taskService).Routes(api)
+			NewTaskHandler(taskService).Routes(api)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.target, bytes.NewBufferString(tt.inputBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package http
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"hard/internal/domain/access"
+	"hard/internal/domain/milestone"
+	"hard/pkg/server/response"
+	"hard/pkg/store"
+	"io"
+)
+
+// listMilestones godoc
+//
+//	@Summary		List project milestones
+//	@Description	Get the milestones or sprints of a project with their completion stats
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Project ID"
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			after	query		string	false	"Cursor of the next page"
+//	@Param			before	query		string	false	"Cursor of the previous page"
+//	@Success		200		{array}		milestone.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/projects/{id}/milestones [get]
+func (h *ProjectHandler) listMilestones(c *gin.Context) {
+	id := c.Param("id")
+	page, err := parsePage(c)
+	if err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	res, cursor, err := h.taskerService.ListMilestones(c, id, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, store.ErrorInvalidCursor):
+			response.BadRequest(c, err, nil)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OKPage(c, res, cursor.Next, cursor.Prev)
+}
+
+// addMilestone godoc
+//
+//	@Summary		Create a milestone
+//	@Description	Create a milestone or a sprint of a project with a name, a goal and dates
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string				true	"Project ID"
+//	@Param			milestone	body		milestone.Request	true	"Milestone Request"
+//	@Success		201			{object}	milestone.Response
+//	@Failure		400			{object}	response.Object
+//	@Failure		403			{object}	response.Object
+//	@Failure		404			{object}	response.Object
+//	@Failure		409			{object}	response.Object
+//	@Failure		500			{object}	response.Object
+//	@Router			/projects/{id}/milestones [post]
+func (h *ProjectHandler) addMilestone(c *gin.Context) {
+	id := c.Param("id")
+	req := milestone.Request{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	res, err := h.taskerService.CreateMilestone(c, id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		case errors.Is(err, milestone.ErrorExists):
+			response.Conflict(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.Created(c, res)
+}
+
+// getMilestone godoc
+//
+//	@Summary		Get a milestone
+//	@Description	Get a milestone of a project by ID with its completion stats
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string	true	"Project ID"
+//	@Param			milestone_id	path		string	true	"Milestone ID"
+//	@Success		200				{object}	milestone.Response
+//	@Failure		404				{object}	response.Object
+//	@Failure		500				{object}	response.Object
+//	@Router			/projects/{id}/milestones/{milestone_id} [get]
+func (h *ProjectHandler) getMilestone(c *gin.Context) {
+	id, milestoneID := c.Param("id"), c.Param("milestone_id")
+
+	res, err := h.taskerService.GetMilestone(c, id, milestoneID)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, res)
+}
+
+// updateMilestone godoc
+//
+//	@Summary		Update a milestone
+//	@Description	Rename a milestone or change its goal and dates, an empty goal or date clears it
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string				true	"Project ID"
+//	@Param			milestone_id	path		string				true	"Milestone ID"
+//	@Param			milestone		body		milestone.Request	true	"Milestone Request"
+//	@Success		200				{string}	string				"ok"
+//	@Failure		400				{object}	response.Object
+//	@Failure		403				{object}	response.Object
+//	@Failure		404				{object}	response.Object
+//	@Failure		409				{object}	response.Object
+//	@Failure		500				{object}	response.Object
+//	@Router			/projects/{id}/milestones/{milestone_id} [put]
+func (h *ProjectHandler) updateMilestone(c *gin.Context) {
+	id, milestoneID := c.Param("id"), c.Param("milestone_id")
+	req := milestone.Request{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	if req.IsEmpty() {
+		response.BadRequest(c, errors.New("at least one field must be provided for update"), req)
+		return
+	}
+
+	if err := req.ValidateUpdate(); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	if err := h.taskerService.UpdateMilestone(c, id, milestoneID, req); err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		case errors.Is(err, milestone.ErrorEndDate):
+			response.BadRequest(c, err, req)
+		case errors.Is(err, milestone.ErrorExists):
+			response.Conflict(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, "ok")
+}
+
+// deleteMilestone godoc
+//
+//	@Summary		Delete a milestone
+//	@Description	Delete a milestone of a project, its tasks are left without a milestone
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string	true	"Project ID"
+//	@Param			milestone_id	path		string	true	"Milestone ID"
+//	@Success		200				{string}	string	"Deleted Milestone ID"
+//	@Failure		403				{object}	response.Object
+//	@Failure		404				{object}	response.Object
+//	@Failure		500				{object}	response.Object
+//	@Router			/projects/{id}/milestones/{milestone_id} [delete]
+func (h *ProjectHandler) deleteMilestone(c *gin.Context) {
+	id, milestoneID := c.Param("id"), c.Param("milestone_id")
+
+	if err := h.taskerService.DeleteMilestone(c, id, milestoneID); err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, milestoneID)
+}
+
+// closeMilestone godoc
+//
+//	@Summary		Close a milestone
+//	@Description	Close a milestone or a sprint and move its unfinished tasks to next_milestone_id or, when it is not given, to the next open milestone of the project
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string					true	"Project ID"
+//	@Param			milestone_id	path		string					true	"Milestone ID"
+//	@Param			milestone		body		milestone.CloseRequest	false	"Close Request"
+//	@Success		200				{object}	milestone.CloseResponse
+//	@Failure		400				{object}	response.Object
+//	@Failure		403				{object}	response.Object
+//	@Failure		404				{object}	response.Object
+//	@Failure		409				{object}	response.Object
+//	@Failure		422				{object}	response.Object
+//	@Failure		500				{object}	response.Object
+//	@Router			/projects/{id}/milestones/{milestone_id}/close [post]
+func (h *ProjectHandler) closeMilestone(c *gin.Context) {
+	id, milestoneID := c.Param("id"), c.Param("milestone_id")
+	req := milestone.CloseRequest{}
+
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	res, err := h.taskerService.CloseMilestone(c, id, milestoneID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		case errors.Is(err, milestone.ErrorClosed):
+			response.Conflict(c, err)
+		case errors.Is(err, milestone.ErrorNextMilestone):
+			response.UnprocessableEntity(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, res)
+}
+
+func isMilestoneError(err error) bool {
+	return errors.Is(err, milestone.ErrorUnknownMilestone) ||
+		errors.Is(err, milestone.ErrorOtherProject) ||
+		errors.Is(err, milestone.ErrorClosedMilestone)
+}
@@ -0,0 +1,445 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"hard/internal/domain/audit"
+	"hard/internal/domain/member"
+	"hard/internal/domain/milestone"
+	"hard/internal/domain/project"
+	"hard/internal/domain/task"
+	"hard/internal/domain/user"
+	"hard/pkg/helpers"
+	"hard/pkg/store"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"hard/internal/service/tasker"
+)
+
+type MockMilestoneRepository struct {
+	mock.Mock
+}
+
+func (m *MockMilestoneRepository) List(ctx context.Context, projectID string, page store.Page) (dest []milestone.Entity, cursor store.Cursor, err error) {
+	args := m.Called(ctx, projectID, page)
+	return args.Get(0).([]milestone.Entity), args.Get(1).(store.Cursor), args.Error(2)
+}
+
+func (m *MockMilestoneRepository) Get(ctx context.Context, id string) (dest milestone.Entity, err error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(milestone.Entity), args.Error(1)
+}
+
+func (m *MockMilestoneRepository) GetByName(ctx context.Context, projectID, name string) (dest milestone.Entity, err error) {
+	args := m.Called(ctx, projectID, name)
+	return args.Get(0).(milestone.Entity), args.Error(1)
+}
+
+func (m *MockMilestoneRepository) Add(ctx context.Context, data milestone.Entity) (id string, err error) {
+	args := m.Called(ctx, data)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockMilestoneRepository) Update(ctx context.Context, id string, data milestone.Entity) (err error) {
+	args := m.Called(ctx, id, data)
+	return args.Error(0)
+}
+
+func (m *MockMilestoneRepository) Delete(ctx context.Context, id string) (err error) {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockMilestoneRepository) Close(ctx context.Context, id string) (closedAt string, err error) {
+	args := m.Called(ctx, id)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockMilestoneRepository) Next(ctx context.Context, data milestone.Entity) (dest milestone.Entity, err error) {
+	args := m.Called(ctx, data)
+	return args.Get(0).(milestone.Entity), args.Error(1)
+}
+
+func (m *MockMilestoneRepository) MoveOpenTasks(ctx context.Context, from, to string) (ids []string, err error) {
+	args := m.Called(ctx, from, to)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockMilestoneRepository) Stats(ctx context.Context, ids []string) (dest map[string]milestone.Stats, err error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).(map[string]milestone.Stats), args.Error(1)
+}
+
+func TestMilestones(t *testing.T) {
+	newMilestone := func(id, projectID, name, start, end string) milestone.Entity {
+		return milestone.Entity{
+			ID:        id,
+			ProjectID: projectID,
+			Name:      helpers.GetStringPtr(name),
+			StartDate: helpers.GetStringPtr(start + "T00:00:00Z"),
+			EndDate:   helpers.GetStringPtr(end + "T00:00:00Z"),
+		}
+	}
+	first := newMilestone("1", "2", "Sprint 1", "2024-05-01", "2024-05-14")
+	first.Goal = helpers.GetStringPtr("Ship search")
+	second := newMilestone("2", "2", "Sprint 2", "2024-05-15", "2024-05-28")
+	closed := newMilestone("3", "2", "Sprint 0", "2024-04-17", "2024-04-30")
+	closed.ClosedAt = helpers.GetStringPtr("2024-04-30T00:00:00Z")
+	foreign := newMilestone("4", "3", "Sprint 1", "2024-05-01", "2024-05-14")
+
+	stats := map[string]milestone.Stats{
+		"1": {MilestoneID: "1", Total: 4, Completed: 1, EstimateHours: 10, CompletedHours: 2.5},
+	}
+
+	newTask := func(id string) task.Entity {
+		return task.Entity{
+			ID:          id,
+			Title:       helpers.GetStringPtr("Task " + id),
+			Description: helpers.GetStringPtr("Task " + id),
+			Priority:    helpers.GetStringPtr("High"),
+			Status:      helpers.GetStringPtr("Active"),
+			AssigneeID:  helpers.GetStringPtr("2"),
+			ProjectID:   helpers.GetStringPtr("2"),
+			MilestoneID: helpers.GetStringPtr("1"),
+		}
+	}
+
+	empty := ""
+
+	emptyStats := `{"total_tasks":0,"completed_tasks":0,"open_tasks":0,"percent_complete":0,"estimate_hours":0,"completed_hours":0}`
+	firstJSON := `{"id":"1","project_id":"2","name":"Sprint 1","goal":"Ship search","start_date":"2024-05-01","end_date":"2024-05-14","closed":false,` +
+		`"stats":{"total_tasks":4,"completed_tasks":1,"open_tasks":3,"percent_complete":25,"estimate_hours":10,"completed_hours":2.5}}`
+	secondJSON := `{"id":"2","project_id":"2","name":"Sprint 2","start_date":"2024-05-15","end_date":"2024-05-28","closed":false,"stats":` + emptyStats + `}`
+	closedJSON := `{"id":"3","project_id":"2","name":"Sprint 0","start_date":"2024-04-17","end_date":"2024-04-30","closed_at":"2024-04-30","closed":true,"stats":` + emptyStats + `}`
+
+	tests := []struct {
+		name           string
+		caller         string
+		method         string
+		target         string
+		inputBody      string
+		expectedStatus int
+		expectedBody   string
+		expectedAdded  *milestone.Entity
+		expectedUpdate *milestone.Entity
+		expectedMoved  []string
+		expectedTask   *task.Entity
+		expectedRecord *audit.Entity
+	}{
+		{
+			name:           "List Project Milestones",
+			method:         "GET",
+			target:         "/projects/2/milestones",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[` + firstJSON + `,` + secondJSON + `,` + closedJSON + `],"success":true}`,
+		},
+		{
+			name:           "Milestones Of Unknown Project",
+			method:         "GET",
+			target:         "/projects/9/milestones",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"error not found","success":false}`,
+		},
+		{
+			name:           "Create Milestone",
+			method:         "POST",
+			target:         "/projects/2/milestones",
+			inputBody:      `{"name":" Sprint 3 ","start_date":"2024-05-29","end_date":"2024-06-11"}`,
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"data":{"id":"5","project_id":"2","name":"Sprint 3","start_date":"2024-05-29","end_date":"2024-06-11","closed":false,"stats":` + emptyStats + `},"success":true}`,
+			expectedAdded: &milestone.Entity{
+				ProjectID: "2",
+				Name:      helpers.GetStringPtr("Sprint 3"),
+				StartDate: helpers.GetStringPtr("2024-05-29"),
+				EndDate:   helpers.GetStringPtr("2024-06-11"),
+			},
+			expectedRecord: &audit.Entity{EntityType: audit.EntityMilestone, EntityID: "5", Operation: audit.OperationCreate},
+		},
+		{
+			name:           "End Date Before Start Date",
+			method:         "POST",
+			target:         "/projects/2/milestones",
+			inputBody:      `{"name":"Sprint 3","start_date":"2024-06-11","end_date":"2024-05-29"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"data":{"name":"Sprint 3","goal":null,"start_date":"2024-06-11","end_date":"2024-05-29"},"message":"end_date: cannot be before start_date","success":false}`,
+		},
+		{
+			name:           "Duplicate Milestone Name",
+			method:         "POST",
+			target:         "/projects/2/milestones",
+			inputBody:      `{"name":"Sprint 1"}`,
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"name: milestone already exists in the project","success":false}`,
+		},
+		{
+			name:           "Create Milestone Without Permission",
+			caller:         "user-id",
+			method:         "POST",
+			target:         "/projects/2/milestones",
+			inputBody:      `{"name":"Sprint 3"}`,
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"message":"permission denied","success":false}`,
+		},
+		{
+			name:           "Get Milestone",
+			method:         "GET",
+			target:         "/projects/2/milestones/1",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":` + firstJSON + `,"success":true}`,
+		},
+		{
+			name:           "Milestone Of Another Project",
+			method:         "GET",
+			target:         "/projects/2/milestones/4",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"error not found","success":false}`,
+		},
+		{
+			name:           "Change Milestone Goal",
+			method:         "PUT",
+			target:         "/projects/2/milestones/1",
+			inputBody:      `{"goal":"Ship search and filters"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":"ok","success":true}`,
+			expectedUpdate: &milestone.Entity{Goal: helpers.GetStringPtr("Ship search and filters")},
+			expectedRecord: &audit.Entity{EntityType: audit.EntityMilestone, EntityID: "1", Operation: audit.OperationUpdate},
+		},
+		{
+			name:           "End Date Before Stored Start Date",
+			method:         "PUT",
+			target:         "/projects/2/milestones/1",
+			inputBody:      `{"end_date":"2024-04-30"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"data":{"name":null,"goal":null,"start_date":null,"end_date":"2024-04-30"},"message":"end_date: cannot be before start_date","success":false}`,
+		},
+		{
+			name:           "Empty Milestone Update",
+			method:         "PUT",
+			target:         "/projects/2/milestones/1",
+			inputBody:      `{}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"data":{"name":null,"goal":null,"start_date":null,"end_date":null},"message":"at least one field must be provided for update","success":false}`,
+		},
+		{
+			name:           "Delete Milestone",
+			method:         "DELETE",
+			target:         "/projects/2/milestones/2",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":"2","success":true}`,
+			expectedRecord: &audit.Entity{EntityType: audit.EntityMilestone, EntityID: "2", Operation: audit.OperationDelete},
+		},
+		{
+			name:           "Close Sprint Into The Next One",
+			method:         "POST",
+			target:         "/projects/2/milestones/1/close",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":{"milestone":{"id":"1","project_id":"2","name":"Sprint 1","goal":"Ship search","start_date":"2024-05-01","end_date":"2024-05-14","closed_at":"2024-05-14","closed":true,` +
+				`"stats":{"total_tasks":4,"completed_tasks":1,"open_tasks":3,"percent_complete":25,"estimate_hours":10,"completed_hours":2.5}},` +
+				`"next_milestone_id":"2","moved_task_ids":["5","6"]},"success":true}`,
+			expectedMoved:  []string{"1", "2"},
+			expectedRecord: &audit.Entity{EntityType: audit.EntityTask, EntityID: "6", Operation: audit.OperationUpdate},
+		},
+		{
+			name:           "Close Sprint Into A Chosen One",
+			method:         "POST",
+			target:         "/projects/2/milestones/2/close",
+			inputBody:      `{"next_milestone_id":"1"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":{"milestone":{"id":"2","project_id":"2","name":"Sprint 2","start_date":"2024-05-15","end_date":"2024-05-28","closed_at":"2024-05-14","closed":true,"stats":` + emptyStats + `},` +
+				`"next_milestone_id":"1","moved_task_ids":[]},"success":true}`,
+			expectedMoved:  []string{"2", "1"},
+			expectedRecord: &audit.Entity{EntityType: audit.EntityMilestone, EntityID: "2", Operation: audit.OperationUpdate},
+		},
+		{
+			name:           "Close Last Sprint",
+			method:         "POST",
+			target:         "/projects/2/milestones/2/close",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":{"milestone":{"id":"2","project_id":"2","name":"Sprint 2","start_date":"2024-05-15","end_date":"2024-05-28","closed_at":"2024-05-14","closed":true,"stats":` + emptyStats + `},` +
+				`"moved_task_ids":[]},"success":true}`,
+			expectedMoved: []string{"2", ""},
+		},
+		{
+			name:           "Close Sprint Into Another Project",
+			method:         "POST",
+			target:         "/projects/2/milestones/1/close",
+			inputBody:      `{"next_milestone_id":"4"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"message":"next_milestone_id: must be another open milestone of the project","success":false}`,
+		},
+		{
+			name:           "Close Sprint Into A Closed One",
+			method:         "POST",
+			target:         "/projects/2/milestones/1/close",
+			inputBody:      `{"next_milestone_id":"3"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"message":"next_milestone_id: must be another open milestone of the project","success":false}`,
+		},
+		{
+			name:           "Close Closed Sprint",
+			method:         "POST",
+			target:         "/projects/2/milestones/3/close",
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"milestone is already closed","success":false}`,
+		},
+		{
+			name:           "Close Sprint Without Permission",
+			caller:         "user-id",
+			method:         "POST",
+			target:         "/projects/2/milestones/1/close",
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"message":"permission denied","success":false}`,
+		},
+		{
+			name:           "Task Shows Its Milestone",
+			method:         "GET",
+			target:         "/tasks/1",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"id":"1","title":"Task 1","description":"Task 1","priority":"High","status":"Active","assignee_id":"2","project_id":"2","milestone_id":"1","completed_at":""},"success":true}`,
+		},
+		{
+			name:           "Move Task To Another Milestone",
+			method:         "PUT",
+			target:         "/tasks/1",
+			inputBody:      `{"milestone_id":"2"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":"ok","success":true}`,
+			expectedTask:   &task.Entity{MilestoneID: helpers.GetStringPtr("2")},
+		},
+		{
+			name:           "Take Task Out Of Its Milestone",
+			method:         "PUT",
+			target:         "/tasks/1",
+			inputBody:      `{"milestone_id":""}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":"ok","success":true}`,
+			expectedTask:   &task.Entity{MilestoneID: &empty},
+		},
+		{
+			name:           "Move Task To Closed Milestone",
+			method:         "PUT",
+			target:         "/tasks/1",
+			inputBody:      `{"milestone_id":"3"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"message":"milestone_id: milestone is closed","success":false}`,
+		},
+		{
+			name:           "Move Task To Milestone Of Another Project",
+			method:         "PUT",
+			target:         "/tasks/1",
+			inputBody:      `{"milestone_id":"4"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"message":"milestone_id: milestone belongs to another project","success":false}`,
+		},
+		{
+			name:           "Move Task To Unknown Milestone",
+			method:         "PUT",
+			target:         "/tasks/1",
+			inputBody:      `{"milestone_id":"9"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"message":"milestone_id: milestone does not exist","success":false}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := mockAdmin()
+			mockUserRepo.On("Get", mock.Anything, "user-id").Return(user.Entity{ID: "user-id", Role: helpers.GetStringPtr("user")}, nil)
+
+			mockTaskRepo := new(MockTaskRepository)
+			mockTaskRepo.On("Get", mock.Anything, "1").Return(newTask("1"), nil)
+			mockTaskRepo.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			mockProjectRepo := new(MockProjectRepository)
+			mockProjectRepo.On("Get", mock.Anything, "2").Return(project.Entity{ID: "2", ManagerID: helpers.GetStringPtr("admin-id")}, nil)
+			mockProjectRepo.On("Get", mock.Anything, "9").Return(project.Entity{}, store.ErrorNotFound)
+
+			mockMemberRepo := new(MockMemberRepository)
+			mockMemberRepo.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(member.Entity{}, store.ErrorNotFound)
+
+			mockMilestoneRepo := new(MockMilestoneRepository)
+			mockMilestoneRepo.On("List", mock.Anything, "2", mock.Anything).Return([]milestone.Entity{first, second, closed}, store.Cursor{}, nil)
+			mockMilestoneRepo.On("Get", mock.Anything, "1").Return(first, nil)
+			mockMilestoneRepo.On("Get", mock.Anything, "2").Return(second, nil)
+			mockMilestoneRepo.On("Get", mock.Anything, "3").Return(closed, nil)
+			mockMilestoneRepo.On("Get", mock.Anything, "4").Return(foreign, nil)
+			mockMilestoneRepo.On("Get", mock.Anything, mock.Anything).Return(milestone.Entity{}, store.ErrorNotFound)
+			mockMilestoneRepo.On("GetByName", mock.Anything, "2", "Sprint 1").Return(first, nil)
+			mockMilestoneRepo.On("GetByName", mock.Anything, mock.Anything, mock.Anything).Return(milestone.Entity{}, store.ErrorNotFound)
+			mockMilestoneRepo.On("Add", mock.Anything, mock.Anything).Return("5", nil)
+			mockMilestoneRepo.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockMilestoneRepo.On("Delete", mock.Anything, mock.Anything).Return(nil)
+			mockMilestoneRepo.On("Close", mock.Anything, mock.Anything).Return("2024-05-14", nil)
+			mockMilestoneRepo.On("Next", mock.Anything, first).Return(second, nil)
+			mockMilestoneRepo.On("Next", mock.Anything, mock.Anything).Return(milestone.Entity{}, store.ErrorNotFound)
+			mockMilestoneRepo.On("MoveOpenTasks", mock.Anything, "1", "2").Return([]string{"5", "6"}, nil)
+			mockMilestoneRepo.On("MoveOpenTasks", mock.Anything, mock.Anything, mock.Anything).Return([]string(nil), nil)
+			mockMilestoneRepo.On("Stats", mock.Anything, mock.Anything).Return(stats, nil)
+
+			mockAuditRepo := new(MockAuditRepository)
+			mockAuditRepo.On("Add", mock.Anything, mock.Anything).Return(nil)
+
+			taskService, _ := tasker.New(
+				tasker.WithUserRepository(mockUserRepo),
+				tasker.WithTaskRepository(mockTaskRepo),
+				tasker.WithProjectRepository(mockProjectRepo),
+				tasker.WithMemberRepository(mockMemberRepo),
+				tasker.WithMilestoneRepository(mockMilestoneRepo),
+				tasker.WithAuditRepository(mockAuditRepo),
+			)
+
+			caller := tt.caller
+			if caller == "" {
+				caller = "admin-id"
+			}
+
+			gin.SetMode(gin.TestMode)
+			r := authenticated(caller)
+			NewTaskHandler(taskService).Routes(r.Group("/"))
+			NewProjectHandler(taskService).Routes(r.Group("/"))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.target, bytes.NewBufferString(tt.inputBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+
+			if tt.expectedAdded != nil {
+				mockMilestoneRepo.AssertCalled(t, "Add", mock.Anything, *tt.expectedAdded)
+			} else {
+				mockMilestoneRepo.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+			}
+			if tt.expectedUpdate != nil {
+				mockMilestoneRepo.AssertCalled(t, "Update", mock.Anything, mock.Anything, *tt.expectedUpdate)
+			} else {
+				mockMilestoneRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+			}
+			if tt.expectedMoved != nil {
+				mockMilestoneRepo.AssertCalled(t, "MoveOpenTasks", mock.Anything, tt.expectedMoved[0], tt.expectedMoved[1])
+			} else {
+				mockMilestoneRepo.AssertNotCalled(t, "Close", mock.Anything, mock.Anything)
+				mockMilestoneRepo.AssertNotCalled(t, "MoveOpenTasks", mock.Anything, mock.Anything, mock.Anything)
+			}
+			if tt.expectedTask != nil {
+				mockTaskRepo.AssertCalled(t, "Update", mock.Anything, "1", *tt.expectedTask)
+			} else {
+				mockTaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+			}
+			if tt.expectedRecord != nil {
+				mockAuditRepo.AssertCalled(t, "Add", mock.Anything, mock.MatchedBy(func(data audit.Entity) bool {
+					return data.EntityType == tt.expectedRecord.EntityType &&
+						data.EntityID == tt.expectedRecord.EntityID &&
+						data.Operation == tt.expectedRecord.Operation
+				}))
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"hard/pkg/store"
+	"strconv"
+)
+
+// parsePage reads the limit, after and before query parameters.
+func parsePage(c *gin.Context) (page store.Page, err error) {
+	page = store.Page{
+		After:  c.Query("after"),
+		Before: c.Query("before"),
+	}
+
+	if page.After != "" && page.Before != "" {
+		err = errors.New("after, before: cannot be used together")
+		return
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		page.Limit, err = strconv.Atoi(limit)
+		if err != nil || page.Limit < 1 || page.Limit > store.MaxLimit {
+			err = fmt.Errorf("limit: must be between 1 and %d", store.MaxLimit)
+			return
+		}
+	}
+
+	return
+}
@@ -4,12 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"hard/internal/domain/access"
 	"hard/internal/domain/project"
+	"hard/internal/domain/workflow"
 	"hard/internal/service/tasker"
 	"hard/pkg/helpers"
 	"hard/pkg/server/response"
 	"hard/pkg/store"
-	"strings"
 )
 
 type ProjectHandler struct {
@@ -24,15 +25,45 @@ func NewProjectHandler(s *tasker.Service) *ProjectHandler {
 func (h *ProjectHandler) Routes(r *gin.RouterGroup) {
 	api := r.Group("/projects")
 	{
-		api.GET("/", h.list)
+		deleted := includeDeleted(h.taskerService)
+
+		api.GET("/", deleted, h.list)
 		api.POST("/", h.add)
+		api.POST("/bulk", h.addBulk)
+		api.PATCH("/bulk", h.updateBulk)
+		api.DELETE("/bulk", h.deleteBulk)
 
-		api.GET("/:id", h.get)
-		api.GET("/:id/tasks", h.listTasks)
+		api.GET("/:id", deleted, h.get)
+		api.GET("/:id/tasks", deleted, h.listTasks)
+		api.GET("/:id/tasks/order", h.taskOrder)
+		api.GET("/:id/schedule", h.getSchedule)
+		api.GET("/:id/time", h.projectTime)
+		api.GET("/:id/reports/burndown", h.burndown)
+		api.GET("/:id/reports/velocity", h.velocity)
+		api.GET("/:id/reports/cumulative-flow", h.cumulativeFlow)
+		api.GET("/:id/workflow", h.getWorkflow)
+		api.PUT("/:id/workflow", h.saveWorkflow)
+		api.DELETE("/:id/workflow", h.deleteWorkflow)
+		api.GET("/:id/members", h.listMembers)
+		api.POST("/:id/members", h.addMember)
+		api.PUT("/:id/members/:user_id", h.updateMember)
+		api.DELETE("/:id/members/:user_id", h.deleteMember)
+		api.GET("/:id/labels", h.listLabels)
+		api.POST("/:id/labels", h.addLabel)
+		api.GET("/:id/labels/:label_id", h.getLabel)
+		api.PUT("/:id/labels/:label_id", h.updateLabel)
+		api.DELETE("/:id/labels/:label_id", h.deleteLabel)
+		api.GET("/:id/milestones", h.listMilestones)
+		api.POST("/:id/milestones", h.addMilestone)
+		api.GET("/:id/milestones/:milestone_id", h.getMilestone)
+		api.PUT("/:id/milestones/:milestone_id", h.updateMilestone)
+		api.DELETE("/:id/milestones/:milestone_id", h.deleteMilestone)
+		api.POST("/:id/milestones/:milestone_id/close", h.closeMilestone)
 		api.PUT("/:id", h.update)
 		api.DELETE("/:id", h.delete)
+		api.POST("/:id/restore", h.restore)
 
-		api.GET("/search", h.search)
+		api.GET("/search", deleted, h.search)
 	}
 }
 
@@ -43,17 +74,34 @@ func (h *ProjectHandler) Routes(r *gin.RouterGroup) {
 //	@Tags			projects
 //	@Accept			json
 //	@Produce		json
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			after	query		string	false	"Cursor of the next page"
+//	@Param			before	query		string	false	"Cursor of the previous page"
+//	@Param			include_deleted	query		bool	false	"Include soft deleted rows, admin only"
 //	@Success		200	{array}		project.Response
+//	@Failure		400	{object}	response.Object
+//	@Failure		403	{object}	response.Object
 //	@Failure		500	{object}	response.Object
 //	@Router			/projects [get]
 func (h *ProjectHandler) list(c *gin.Context) {
-	res, err := h.taskerService.ListProjects(c)
+	page, err := parsePage(c)
 	if err != nil {
-		response.InternalServerError(c, err)
+		response.BadRequest(c, err, nil)
 		return
 	}
 
-	response.OK(c, res)
+	res, cursor, err := h.taskerService.ListProjects(c, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorInvalidCursor):
+			response.BadRequest(c, err, nil)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OKPage(c, res, cursor.Next, cursor.Prev)
 }
 
 // addProject godoc
@@ -83,7 +131,7 @@ func (h *ProjectHandler) add(c *gin.Context) {
 	res, err := h.taskerService.CreateProject(c, req)
 	if err != nil {
 		switch {
-		case strings.Contains(err.Error(), "failed to parse:"):
+		case errors.Is(err, store.ErrorInvalidInput):
 			response.BadRequest(c, err, req)
 		default:
 			response.InternalServerError(c, err)
@@ -102,7 +150,10 @@ func (h *ProjectHandler) add(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			id	path		string	true	"Project ID"
+//	@Param			include_deleted	query		bool	false	"Include soft deleted rows, admin only"
 //	@Success		200	{object}	project.Response
+//	@Header			200	{string}	ETag	"Version of the project"
+//	@Failure		403	{object}	response.Object
 //	@Failure		404	{object}	response.Object
 //	@Failure		500	{object}	response.Object
 //	@Router			/projects/{id} [get]
@@ -120,6 +171,7 @@ func (h *ProjectHandler) get(c *gin.Context) {
 		return
 	}
 
+	setETag(c, res.Version)
 	response.OK(c, res)
 }
 
@@ -132,9 +184,12 @@ func (h *ProjectHandler) get(c *gin.Context) {
 //	@Produce		json
 //	@Param			id		path		string			true	"Project ID"
 //	@Param			project	body		project.Request	true	"Project Request"
+//	@Param			If-Match	header		string	false	"ETag of the version being changed"
 //	@Success		200		{string}	string			"ok"
 //	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
 //	@Failure		404		{object}	response.Object
+//	@Failure		412		{object}	response.Object
 //	@Failure		500		{object}	response.Object
 //	@Router			/projects/{id} [put]
 func (h *ProjectHandler) update(c *gin.Context) {
@@ -152,10 +207,19 @@ func (h *ProjectHandler) update(c *gin.Context) {
 		return
 	}
 
-	if err := h.taskerService.UpdateProject(c, id, req); err != nil {
+	version, ok := ifMatch(c, h.version(c, id))
+	if !ok {
+		return
+	}
+
+	if err := h.taskerService.UpdateProject(c, id, req, version); err != nil {
 		switch {
 		case errors.Is(err, store.ErrorNotFound):
 			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		case errors.Is(err, store.ErrorConflict):
+			response.PreconditionFailed(c, err)
 		default:
 			response.InternalServerError(c, err)
 		}
@@ -173,17 +237,29 @@ func (h *ProjectHandler) update(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			id	path		string	true	"Project ID"
+//	@Param			If-Match	header		string	false	"ETag of the version being changed"
 //	@Success		200	{string}	string	"Deleted Project ID"
+//	@Failure		403	{object}	response.Object
 //	@Failure		404	{object}	response.Object
+//	@Failure		412	{object}	response.Object
 //	@Failure		500	{object}	response.Object
 //	@Router			/projects/{id} [delete]
 func (h *ProjectHandler) delete(c *gin.Context) {
 	id := c.Param("id")
 
-	if err := h.taskerService.DeleteProject(c, id); err != nil {
+	version, ok := ifMatch(c, h.version(c, id))
+	if !ok {
+		return
+	}
+
+	if err := h.taskerService.DeleteProject(c, id, version); err != nil {
 		switch {
 		case errors.Is(err, store.ErrorNotFound):
 			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		case errors.Is(err, store.ErrorConflict):
+			response.PreconditionFailed(c, err)
 		default:
 			response.InternalServerError(c, err)
 		}
@@ -193,6 +269,42 @@ func (h *ProjectHandler) delete(c *gin.Context) {
 	response.OK(c, id)
 }
 
+// restoreProject godoc
+//
+//	@Summary		Restore a project
+//	@Description	Restore a soft deleted project together with the tasks deleted along with it
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Project ID"
+//	@Success		200	{object}	project.Response
+//	@Failure		403	{object}	response.Object
+//	@Failure		404	{object}	response.Object
+//	@Failure		409	{object}	response.Object
+//	@Failure		500	{object}	response.Object
+//	@Router			/projects/{id}/restore [post]
+func (h *ProjectHandler) restore(c *gin.Context) {
+	id := c.Param("id")
+
+	res, err := h.taskerService.RestoreProject(c, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		case errors.Is(err, store.ErrorNotDeleted):
+			response.Conflict(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	setETag(c, res.Version)
+	response.OK(c, res)
+}
+
 // searchProjects godoc
 //
 //	@Summary		Search projects
@@ -202,8 +314,13 @@ func (h *ProjectHandler) delete(c *gin.Context) {
 //	@Produce		json
 //	@Param			title		query		string	false	"Project Title"
 //	@Param			manager_id	query		string	false	"Manager ID"
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			after	query		string	false	"Cursor of the next page"
+//	@Param			before	query		string	false	"Cursor of the previous page"
+//	@Param			include_deleted	query		bool	false	"Include soft deleted rows, admin only"
 //	@Success		200			{array}		project.Response
 //	@Failure		400			{object}	response.Object
+//	@Failure		403			{object}	response.Object
 //	@Failure		500			{object}	response.Object
 //	@Router			/projects/search [get]
 func (h *ProjectHandler) search(c *gin.Context) {
@@ -216,13 +333,24 @@ func (h *ProjectHandler) search(c *gin.Context) {
 		return
 	}
 
-	res, err := h.taskerService.SearchProjects(c, req)
+	page, err := parsePage(c)
 	if err != nil {
-		response.InternalServerError(c, err)
+		response.BadRequest(c, err, nil)
 		return
 	}
 
-	response.OK(c, res)
+	res, cursor, err := h.taskerService.SearchProjects(c, req, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorInvalidCursor):
+			response.BadRequest(c, err, nil)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OKPage(c, res, cursor.Next, cursor.Prev)
 }
 
 // listTasks godoc
@@ -232,19 +360,106 @@ func (h *ProjectHandler) search(c *gin.Context) {
 //	@Tags			projects
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path		string	true	"Project ID"
-//	@Success		200	{array}		task.Response
-//	@Failure		404	{object}	response.Object
+//	@Param			id		path		string	true	"Project ID"
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			after	query		string	false	"Cursor of the next page"
+//	@Param			before	query		string	false	"Cursor of the previous page"
+//	@Param			include_deleted	query		bool	false	"Include soft deleted rows, admin only"
+//	@Success		200		{array}		task.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
+//	@Failure		404		{object}	response.Object
 //	@Failure		500	{object}	response.Object
 //	@Router			/projects/{id}/tasks [get]
 func (h *ProjectHandler) listTasks(c *gin.Context) {
 	id := c.Param("id")
+	page, err := parsePage(c)
+	if err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	res, cursor, err := h.taskerService.GetTasksByProject(c, id, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, store.ErrorInvalidCursor):
+			response.BadRequest(c, err, nil)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OKPage(c, res, cursor.Next, cursor.Prev)
+}
+
+// getWorkflow godoc
+//
+//	@Summary		Get project workflow
+//	@Description	Get the task statuses, transitions and terminal statuses of a project
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Project ID"
+//	@Success		200	{object}	workflow.Response
+//	@Failure		404	{object}	response.Object
+//	@Failure		500	{object}	response.Object
+//	@Router			/projects/{id}/workflow [get]
+func (h *ProjectHandler) getWorkflow(c *gin.Context) {
+	id := c.Param("id")
+
+	res, err := h.taskerService.GetWorkflow(c, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, res)
+}
+
+// saveWorkflow godoc
+//
+//	@Summary		Save project workflow
+//	@Description	Replace the workflow of a project
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string				true	"Project ID"
+//	@Param			workflow	body		workflow.Request	true	"Workflow Request"
+//	@Success		200			{object}	workflow.Response
+//	@Failure		400			{object}	response.Object
+//	@Failure		403			{object}	response.Object
+//	@Failure		404			{object}	response.Object
+//	@Failure		500			{object}	response.Object
+//	@Router			/projects/{id}/workflow [put]
+func (h *ProjectHandler) saveWorkflow(c *gin.Context) {
+	id := c.Param("id")
+	req := workflow.Request{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
 
-	res, err := h.taskerService.GetTasksByProject(c, id)
+	res, err := h.taskerService.SaveWorkflow(c, id, req)
 	if err != nil {
 		switch {
 		case errors.Is(err, store.ErrorNotFound):
 			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
 		default:
 			response.InternalServerError(c, err)
 		}
@@ -253,3 +468,34 @@ func (h *ProjectHandler) listTasks(c *gin.Context) {
 
 	response.OK(c, res)
 }
+
+// deleteWorkflow godoc
+//
+//	@Summary		Reset project workflow
+//	@Description	Delete the workflow of a project so that the default workflow applies
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Project ID"
+//	@Success		200	{string}	string	"Project ID"
+//	@Failure		403	{object}	response.Object
+//	@Failure		404	{object}	response.Object
+//	@Failure		500	{object}	response.Object
+//	@Router			/projects/{id}/workflow [delete]
+func (h *ProjectHandler) deleteWorkflow(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.taskerService.DeleteWorkflow(c, id); err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, id)
+}
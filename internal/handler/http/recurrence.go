@@ -0,0 +1,115 @@
+package http
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"hard/internal/domain/access"
+	"hard/internal/domain/recurrence"
+	"hard/pkg/server/response"
+	"hard/pkg/store"
+)
+
+// getRecurrence godoc
+//
+//	@Summary		Get the recurrence of a task
+//	@Description	Get the series the task is an occurrence of, with the date of the next occurrence
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Task ID"
+//	@Success		200	{object}	recurrence.Response
+//	@Failure		404	{object}	response.Object
+//	@Failure		500	{object}	response.Object
+//	@Router			/tasks/{id}/recurrence [get]
+func (h *TaskHandler) getRecurrence(c *gin.Context) {
+	id := c.Param("id")
+
+	res, err := h.taskerService.GetRecurrence(c, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, res)
+}
+
+// saveRecurrence godoc
+//
+//	@Summary		Make a task recur
+//	@Description	Set the recurrence rule of a task, a subset of RFC 5545 RRULE: FREQ of DAILY, WEEKLY or MONTHLY with INTERVAL, BYDAY, UNTIL and COUNT. The next occurrence is created when the latest one is completed, or on its date with the schedule trigger. Changing the rule starts the series over from its latest occurrence
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string				true	"Task ID"
+//	@Param			recurrence	body		recurrence.Request	true	"Recurrence Request"
+//	@Success		200			{object}	recurrence.Response
+//	@Failure		400			{object}	response.Object
+//	@Failure		403			{object}	response.Object
+//	@Failure		404			{object}	response.Object
+//	@Failure		500			{object}	response.Object
+//	@Router			/tasks/{id}/recurrence [put]
+func (h *TaskHandler) saveRecurrence(c *gin.Context) {
+	id := c.Param("id")
+	req := recurrence.Request{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	res, err := h.taskerService.SaveRecurrence(c, id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, res)
+}
+
+// deleteRecurrence godoc
+//
+//	@Summary		Stop a recurrence
+//	@Description	Stop the series the task is an occurrence of, the tasks already created are kept
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Task ID"
+//	@Success		200	{string}	string	"Task ID"
+//	@Failure		403	{object}	response.Object
+//	@Failure		404	{object}	response.Object
+//	@Failure		500	{object}	response.Object
+//	@Router			/tasks/{id}/recurrence [delete]
+func (h *TaskHandler) deleteRecurrence(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.taskerService.DeleteRecurrence(c, id); err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, id)
+}
@@ -0,0 +1,399 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"hard/internal/domain/label"
+	"hard/internal/domain/member"
+	"hard/internal/domain/project"
+	"hard/internal/domain/recurrence"
+	"hard/internal/domain/task"
+	"hard/internal/domain/workflow"
+	"hard/pkg/helpers"
+	"hard/pkg/store"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"hard/internal/service/tasker"
+)
+
+type MockRecurrenceRepository struct {
+	mock.Mock
+}
+
+func (m *MockRecurrenceRepository) GetByTask(ctx context.Context, taskID string) (dest recurrence.Entity, err error) {
+	args := m.Called(ctx, taskID)
+	return args.Get(0).(recurrence.Entity), args.Error(1)
+}
+
+func (m *MockRecurrenceRepository) Add(ctx context.Context, data recurrence.Entity) (id string, err error) {
+	args := m.Called(ctx, data)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockRecurrenceRepository) Update(ctx context.Context, id string, data recurrence.Entity) (err error) {
+	args := m.Called(ctx, id, data)
+	return args.Error(0)
+}
+
+func (m *MockRecurrenceRepository) Delete(ctx context.Context, id string) (err error) {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockRecurrenceRepository) ListDue(ctx context.Context, today string) (dest []recurrence.Entity, err error) {
+	args := m.Called(ctx, today)
+	return args.Get(0).([]recurrence.Entity), args.Error(1)
+}
+
+func (m *MockRecurrenceRepository) Advance(ctx context.Context, data recurrence.Occurrence, next *string, generated int) (err error) {
+	args := m.Called(ctx, data, next, generated)
+	return args.Error(0)
+}
+
+func TestRecurrence(t *testing.T) {
+	weekly := recurrence.Entity{
+		ID:        "4",
+		TaskID:    "1",
+		Rule:      "FREQ=WEEKLY;BYDAY=MO",
+		Trigger:   recurrence.TriggerSchedule,
+		StartDate: "2024-05-06T00:00:00Z",
+		LastDate:  "2024-05-06T00:00:00Z",
+		NextDate:  helpers.GetStringPtr("2024-05-13T00:00:00Z"),
+		Generated: 1,
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		target         string
+		inputBody      string
+		expectedStatus int
+		expectedBody   string
+		expectedAdded  *recurrence.Entity
+		expectedUpdate *recurrence.Entity
+		expectedDelete string
+	}{
+		{
+			name:           "Get Recurrence",
+			method:         "GET",
+			target:         "/tasks/1/recurrence",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"id":"4","task_id":"1","rule":"FREQ=WEEKLY;BYDAY=MO","trigger":"schedule","start_date":"2024-05-06","last_date":"2024-05-06","next_date":"2024-05-13","generated":1},"success":true}`,
+		},
+		{
+			name:           "Get Recurrence Of Task That Does Not Recur",
+			method:         "GET",
+			target:         "/tasks/3/recurrence",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"error not found","success":false}`,
+		},
+		{
+			name:           "Make Task Recur",
+			method:         "PUT",
+			target:         "/tasks/3/recurrence",
+			inputBody:      `{"rule":"RRULE:freq=monthly;byday=-1fr;count=3"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"id":"5","task_id":"3","rule":"FREQ=MONTHLY;BYDAY=-1FR;COUNT=3","trigger":"completion","start_date":"2024-05-31","last_date":"2024-05-31","next_date":"2024-06-28","generated":1},"success":true}`,
+			expectedAdded: &recurrence.Entity{
+				TaskID:    "3",
+				Rule:      "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+				Trigger:   recurrence.TriggerCompletion,
+				StartDate: "2024-05-31",
+				LastDate:  "2024-05-31",
+				NextDate:  helpers.GetStringPtr("2024-06-28"),
+				Generated: 1,
+			},
+		},
+		{
+			name:           "Change Rule",
+			method:         "PUT",
+			target:         "/tasks/1/recurrence",
+			inputBody:      `{"rule":"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH","trigger":"completion"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"id":"4","task_id":"1","rule":"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH","trigger":"completion","start_date":"2024-05-06","last_date":"2024-05-06","next_date":"2024-05-09","generated":1},"success":true}`,
+			expectedUpdate: &recurrence.Entity{
+				ID:        "4",
+				TaskID:    "1",
+				Rule:      "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH",
+				Trigger:   recurrence.TriggerCompletion,
+				StartDate: "2024-05-06",
+				LastDate:  "2024-05-06",
+				NextDate:  helpers.GetStringPtr("2024-05-09"),
+				Generated: 1,
+			},
+		},
+		{
+			name:           "Rule That Ends Before Its Next Occurrence",
+			method:         "PUT",
+			target:         "/tasks/1/recurrence",
+			inputBody:      `{"rule":"FREQ=DAILY;INTERVAL=7;UNTIL=20240512T235959Z","trigger":"schedule"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"id":"4","task_id":"1","rule":"FREQ=DAILY;INTERVAL=7;UNTIL=20240512","trigger":"schedule","start_date":"2024-05-06","last_date":"2024-05-06","generated":1},"success":true}`,
+			expectedUpdate: &recurrence.Entity{
+				ID:        "4",
+				TaskID:    "1",
+				Rule:      "FREQ=DAILY;INTERVAL=7;UNTIL=20240512",
+				Trigger:   recurrence.TriggerSchedule,
+				StartDate: "2024-05-06",
+				LastDate:  "2024-05-06",
+				Generated: 1,
+			},
+		},
+		{
+			name:           "Unsupported Frequency",
+			method:         "PUT",
+			target:         "/tasks/1/recurrence",
+			inputBody:      `{"rule":"FREQ=YEARLY"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"data":{"rule":"FREQ=YEARLY","trigger":null},"message":"rule: FREQ must be one of DAILY, WEEKLY, MONTHLY","success":false}`,
+		},
+		{
+			name:           "Until With Count",
+			method:         "PUT",
+			target:         "/tasks/1/recurrence",
+			inputBody:      `{"rule":"FREQ=DAILY;UNTIL=20241231;COUNT=3"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"data":{"rule":"FREQ=DAILY;UNTIL=20241231;COUNT=3","trigger":null},"message":"rule: UNTIL and COUNT cannot be used together","success":false}`,
+		},
+		{
+			name:           "Weekly Ordinal",
+			method:         "PUT",
+			target:         "/tasks/1/recurrence",
+			inputBody:      `{"rule":"FREQ=WEEKLY;BYDAY=1MO"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"data":{"rule":"FREQ=WEEKLY;BYDAY=1MO","trigger":null},"message":"rule: BYDAY ordinals such as 1MO are only allowed with FREQ=MONTHLY","success":false}`,
+		},
+		{
+			name:           "Unknown Trigger",
+			method:         "PUT",
+			target:         "/tasks/1/recurrence",
+			inputBody:      `{"rule":"FREQ=DAILY","trigger":"hourly"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"data":{"rule":"FREQ=DAILY","trigger":"hourly"},"message":"trigger: must be one of completion, schedule","success":false}`,
+		},
+		{
+			name:           "Make Missing Task Recur",
+			method:         "PUT",
+			target:         "/tasks/9/recurrence",
+			inputBody:      `{"rule":"FREQ=DAILY"}`,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"error not found","success":false}`,
+		},
+		{
+			name:           "Stop Recurrence",
+			method:         "DELETE",
+			target:         "/tasks/1/recurrence",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":"1","success":true}`,
+			expectedDelete: "4",
+		},
+		{
+			name:           "Stop Recurrence Of Task That Does Not Recur",
+			method:         "DELETE",
+			target:         "/tasks/3/recurrence",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"error not found","success":false}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := mockAdmin()
+
+			mockTaskRepo := new(MockTaskRepository)
+			mockTaskRepo.On("Get", mock.Anything, "9").Return(nil, store.ErrorNotFound)
+			mockTaskRepo.On("Get", mock.Anything, "1").Return(task.Entity{ID: "1", ProjectID: helpers.GetStringPtr("2"), StartDate: helpers.GetStringPtr("2024-05-06")}, nil)
+			mockTaskRepo.On("Get", mock.Anything, "3").Return(task.Entity{ID: "3", ProjectID: helpers.GetStringPtr("2"), DueDate: helpers.GetStringPtr("2024-05-31")}, nil)
+
+			mockProjectRepo := new(MockProjectRepository)
+			mockProjectRepo.On("Get", mock.Anything, "2").Return(project.Entity{ID: "2", ManagerID: helpers.GetStringPtr("manager-id")}, nil)
+
+			mockMemberRepo := new(MockMemberRepository)
+			mockMemberRepo.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(member.Entity{}, store.ErrorNotFound)
+
+			mockRecurrenceRepo := new(MockRecurrenceRepository)
+			mockRecurrenceRepo.On("GetByTask", mock.Anything, "1").Return(weekly, nil)
+			mockRecurrenceRepo.On("GetByTask", mock.Anything, mock.Anything).Return(recurrence.Entity{}, store.ErrorNotFound)
+			mockRecurrenceRepo.On("Add", mock.Anything, mock.Anything).Return("5", nil)
+			mockRecurrenceRepo.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockRecurrenceRepo.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+			mockAuditRepo := new(MockAuditRepository)
+			mockAuditRepo.On("Add", mock.Anything, mock.Anything).Return(nil)
+
+			taskService, _ := tasker.New(
+				tasker.WithUserRepository(mockUserRepo),
+				tasker.WithTaskRepository(mockTaskRepo),
+				tasker.WithProjectRepository(mockProjectRepo),
+				tasker.WithMemberRepository(mockMemberRepo),
+				tasker.WithRecurrenceRepository(mockRecurrenceRepo),
+				tasker.WithAuditRepository(mockAuditRepo),
+			)
+
+			gin.SetMode(gin.TestMode)
+			r := authenticated("admin-id")
+			NewTaskHandler(taskService).Routes(r.Group("/"))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.target, bytes.NewBufferString(tt.inputBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+
+			if tt.expectedAdded != nil {
+				mockRecurrenceRepo.AssertCalled(t, "Add", mock.Anything, *tt.expectedAdded)
+			} else {
+				mockRecurrenceRepo.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+			}
+			if tt.expectedUpdate != nil {
+				mockRecurrenceRepo.AssertCalled(t, "Update", mock.Anything, tt.expectedUpdate.ID, *tt.expectedUpdate)
+			} else {
+				mockRecurrenceRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+			}
+			if tt.expectedDelete != "" {
+				mockRecurrenceRepo.AssertCalled(t, "Delete", mock.Anything, tt.expectedDelete)
+			} else {
+				mockRecurrenceRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestRecurrenceScheduler(t *testing.T) {
+	// Wednesday: the weekly series missed Monday 13th, Monday 20th is due
+	now := time.Date(2024, 5, 22, 9, 30, 0, 0, time.UTC)
+
+	series := []recurrence.Entity{
+		{ID: "4", TaskID: "1", Rule: "FREQ=WEEKLY;BYDAY=MO", Trigger: recurrence.TriggerSchedule, StartDate: "2024-05-06", LastDate: "2024-05-06", NextDate: helpers.GetStringPtr("2024-05-13"), Generated: 1},
+		{ID: "5", TaskID: "3", Rule: "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3", Trigger: recurrence.TriggerCompletion, StartDate: "2024-04-26", LastDate: "2024-05-31", NextDate: helpers.GetStringPtr("2024-06-28"), Generated: 2},
+		{ID: "6", TaskID: "8", Rule: "FREQ=DAILY", Trigger: recurrence.TriggerCompletion, StartDate: "2024-05-20", LastDate: "2024-05-20", NextDate: helpers.GetStringPtr("2024-05-21"), Generated: 1},
+		{ID: "7", TaskID: "9", Rule: "FREQ=DAILY", Trigger: recurrence.TriggerSchedule, StartDate: "2024-05-20", LastDate: "2024-05-20", NextDate: helpers.GetStringPtr("2024-05-21"), Generated: 1},
+	}
+
+	checklist := task.Entity{
+		ID:          "1",
+		Title:       helpers.GetStringPtr("Release checklist"),
+		Description: helpers.GetStringPtr("Tag, build, announce"),
+		Priority:    helpers.GetStringPtr("High"),
+		Status:      helpers.GetStringPtr("Review"),
+		AssigneeID:  helpers.GetStringPtr("user-id"),
+		ProjectID:   helpers.GetStringPtr("2"),
+		StartDate:   helpers.GetStringPtr("2024-05-06T00:00:00Z"),
+		DueDate:     helpers.GetStringPtr("2024-05-08T00:00:00Z"),
+	}
+	review := task.Entity{
+		ID:          "3",
+		Title:       helpers.GetStringPtr("Dependency review"),
+		Description: helpers.GetStringPtr("Bump modules"),
+		Priority:    helpers.GetStringPtr("Low"),
+		Status:      helpers.GetStringPtr("Closed"),
+		AssigneeID:  helpers.GetStringPtr("user-id"),
+		ProjectID:   helpers.GetStringPtr("3"),
+		DueDate:     helpers.GetStringPtr("2024-05-31T00:00:00Z"),
+		CompletedAt: helpers.GetStringPtr("2024-05-21T00:00:00Z"),
+	}
+	open := task.Entity{ID: "8", Title: helpers.GetStringPtr("Standup"), ProjectID: helpers.GetStringPtr("2"), Status: helpers.GetStringPtr("Active")}
+
+	nextChecklist := task.Entity{
+		Title:       checklist.Title,
+		Description: checklist.Description,
+		Priority:    checklist.Priority,
+		Status:      helpers.GetStringPtr("Active"),
+		AssigneeID:  checklist.AssigneeID,
+		ProjectID:   checklist.ProjectID,
+		StartDate:   helpers.GetStringPtr("2024-05-20"),
+		DueDate:     helpers.GetStringPtr("2024-05-22"),
+	}
+	nextReview := task.Entity{
+		Title:       review.Title,
+		Description: review.Description,
+		Priority:    review.Priority,
+		Status:      helpers.GetStringPtr("Todo"),
+		AssigneeID:  review.AssigneeID,
+		ProjectID:   review.ProjectID,
+		DueDate:     helpers.GetStringPtr("2024-06-28"),
+	}
+
+	tests := []struct {
+		name              string
+		advanceErr        error
+		expectedCreated   int
+		expectedCommitted int
+	}{
+		{
+			name:              "Creates Due Occurrences",
+			expectedCreated:   2,
+			expectedCommitted: 2,
+		},
+		{
+			name:              "Occurrences Already Created",
+			advanceErr:        store.ErrorConflict,
+			expectedCreated:   0,
+			expectedCommitted: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockTaskRepo.On("Get", mock.Anything, "1").Return(checklist, nil)
+			mockTaskRepo.On("Get", mock.Anything, "3").Return(review, nil)
+			mockTaskRepo.On("Get", mock.Anything, "8").Return(open, nil)
+			mockTaskRepo.On("Get", mock.Anything, "9").Return(nil, store.ErrorNotFound)
+			mockTaskRepo.On("Add", mock.Anything, nextChecklist).Return("11", nil)
+			mockTaskRepo.On("Add", mock.Anything, nextReview).Return("12", nil)
+
+			mockWorkflowRepo := new(MockWorkflowRepository)
+			mockWorkflowRepo.On("Get", mock.Anything, "2").Return(workflow.Entity{}, store.ErrorNotFound)
+			mockWorkflowRepo.On("Get", mock.Anything, "3").Return(workflow.Entity{ProjectID: "3", Definition: workflow.Definition{
+				Statuses: []string{"Todo", "Closed"},
+				Terminal: []string{"Closed"},
+			}}, nil)
+
+			mockLabelRepo := new(MockLabelRepository)
+			mockLabelRepo.On("ListByTasks", mock.Anything, []string{"1"}).Return(map[string][]label.Entity{"1": {{ID: "7"}}}, nil)
+			mockLabelRepo.On("ListByTasks", mock.Anything, mock.Anything).Return(map[string][]label.Entity{}, nil)
+			mockLabelRepo.On("Attach", mock.Anything, mock.Anything).Return(nil)
+
+			mockRecurrenceRepo := new(MockRecurrenceRepository)
+			mockRecurrenceRepo.On("ListDue", mock.Anything, "2024-05-22").Return(series, nil)
+			mockRecurrenceRepo.On("Advance", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tt.advanceErr)
+
+			mockAuditRepo := new(MockAuditRepository)
+			mockAuditRepo.On("Add", mock.Anything, mock.Anything).Return(nil)
+
+			transactor := new(MockTransactor)
+
+			taskService, _ := tasker.New(
+				tasker.WithTaskRepository(mockTaskRepo),
+				tasker.WithWorkflowRepository(mockWorkflowRepo),
+				tasker.WithLabelRepository(mockLabelRepo),
+				tasker.WithRecurrenceRepository(mockRecurrenceRepo),
+				tasker.WithAuditRepository(mockAuditRepo),
+				tasker.WithTransactor(transactor),
+			)
+
+			n, err := taskService.RunRecurrences(context.Background(), now)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCreated, n)
+			assert.Equal(t, 2, transactor.started)
+			assert.Equal(t, tt.expectedCommitted, transactor.committed)
+
+			mockTaskRepo.AssertNumberOfCalls(t, "Add", 2)
+			mockLabelRepo.AssertCalled(t, "Attach", mock.Anything, label.Assignment{TaskID: "11", LabelID: "7"})
+			mockRecurrenceRepo.AssertCalled(t, "Advance", mock.Anything, recurrence.Occurrence{RecurrenceID: "4", Date: "2024-05-20", TaskID: "11"}, helpers.GetStringPtr("2024-05-27"), 2)
+			// the third and last occurrence of the series
+			mockRecurrenceRepo.AssertCalled(t, "Advance", mock.Anything, recurrence.Occurrence{RecurrenceID: "5", Date: "2024-06-28", TaskID: "12"}, (*string)(nil), 3)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package http
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"hard/internal/domain/report"
+	"hard/pkg/server/response"
+	"hard/pkg/store"
+)
+
+// burndown godoc
+//
+//	@Summary		Burndown report
+//	@Description	Get the tasks and estimate hours left in the project at the end of every day. The range defaults to the dates of the milestone, or to the last 30 days, and covers at most 366 days
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string	true	"Project ID"
+//	@Param			from			query		string	false	"First day, inclusive"	example(2024-05-01)
+//	@Param			to				query		string	false	"Last day, inclusive"	example(2024-05-14)
+//	@Param			milestone_id	query		string	false	"Only the tasks of the milestone"
+//	@Success		200				{object}	report.BurndownResponse
+//	@Failure		400				{object}	response.Object
+//	@Failure		404				{object}	response.Object
+//	@Failure		500				{object}	response.Object
+//	@Router			/projects/{id}/reports/burndown [get]
+func (h *ProjectHandler) burndown(c *gin.Context) {
+	id := c.Param("id")
+	filter := report.Filter{
+		MilestoneID: c.Query("milestone_id"),
+		From:        c.Query("from"),
+		To:          c.Query("to"),
+	}
+	if err := filter.Validate(); err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	res, err := h.taskerService.GetBurndown(c, id, filter)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case isReportError(err):
+			response.BadRequest(c, err, nil)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, res)
+}
+
+// velocity godoc
+//
+//	@Summary		Velocity report
+//	@Description	Get the tasks and estimate hours completed in the project per week or month. The range defaults to the last 12 periods and covers at most 104
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string	true	"Project ID"
+//	@Param			period			query		string	false	"Period"				Enums(week, month)
+//	@Param			from			query		string	false	"First day, inclusive"	example(2024-03-01)
+//	@Param			to				query		string	false	"Last day, inclusive"	example(2024-05-31)
+//	@Param			milestone_id	query		string	false	"Only the tasks of the milestone"
+//	@Success		200				{object}	report.VelocityResponse
+//	@Failure		400				{object}	response.Object
+//	@Failure		404				{object}	response.Object
+//	@Failure		500				{object}	response.Object
+//	@Router			/projects/{id}/reports/velocity [get]
+func (h *ProjectHandler) velocity(c *gin.Context) {
+	id := c.Param("id")
+	filter := report.Filter{
+		MilestoneID: c.Query("milestone_id"),
+		Period:      c.Query("period"),
+		From:        c.Query("from"),
+		To:          c.Query("to"),
+	}
+	if err := filter.Validate(); err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	res, err := h.taskerService.GetVelocity(c, id, filter)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case isReportError(err):
+			response.BadRequest(c, err, nil)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, res)
+}
+
+// cumulativeFlow godoc
+//
+//	@Summary		Cumulative flow report
+//	@Description	Get the number of tasks of the project in every status at the end of every day, the range is chosen as for the burndown
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string	true	"Project ID"
+//	@Param			from			query		string	false	"First day, inclusive"	example(2024-05-01)
+//	@Param			to				query		string	false	"Last day, inclusive"	example(2024-05-14)
+//	@Param			milestone_id	query		string	false	"Only the tasks of the milestone"
+//	@Success		200				{object}	report.FlowResponse
+//	@Failure		400				{object}	response.Object
+//	@Failure		404				{object}	response.Object
+//	@Failure		500				{object}	response.Object
+//	@Router			/projects/{id}/reports/cumulative-flow [get]
+func (h *ProjectHandler) cumulativeFlow(c *gin.Context) {
+	id := c.Param("id")
+	filter := report.Filter{
+		MilestoneID: c.Query("milestone_id"),
+		From:        c.Query("from"),
+		To:          c.Query("to"),
+	}
+	if err := filter.Validate(); err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	res, err := h.taskerService.GetCumulativeFlow(c, id, filter)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case isReportError(err):
+			response.BadRequest(c, err, nil)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, res)
+}
+
+func isReportError(err error) bool {
+	return errors.Is(err, report.ErrorRange) ||
+		errors.Is(err, report.ErrorDays) ||
+		errors.Is(err, report.ErrorPeriods)
+}
@@ -0,0 +1,266 @@
+package http
+
+import (
+	"context"
+	"hard/internal/domain/milestone"
+	"hard/internal/domain/project"
+	"hard/internal/domain/report"
+	"hard/internal/domain/workflow"
+	"hard/pkg/helpers"
+	"hard/pkg/store"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"hard/internal/service/tasker"
+)
+
+type MockReportRepository struct {
+	mock.Mock
+}
+
+func (m *MockReportRepository) Burndown(ctx context.Context, filter report.Filter) (dest []report.Burndown, err error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]report.Burndown), args.Error(1)
+}
+
+func (m *MockReportRepository) Velocity(ctx context.Context, filter report.Filter) (dest []report.Velocity, err error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]report.Velocity), args.Error(1)
+}
+
+func (m *MockReportRepository) CumulativeFlow(ctx context.Context, filter report.Filter) (dest []report.Flow, err error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]report.Flow), args.Error(1)
+}
+
+func TestReports(t *testing.T) {
+	definition := workflow.Definition{
+		Statuses: []string{"Todo", "Doing", "Done"},
+		Transitions: map[string][]string{
+			"Todo":  {"Doing"},
+			"Doing": {"Done"},
+		},
+		Terminal: []string{"Done"},
+	}
+	sprint := milestone.Entity{
+		ID:        "1",
+		ProjectID: "2",
+		Name:      helpers.GetStringPtr("Sprint 1"),
+		StartDate: helpers.GetStringPtr("2024-05-01T00:00:00Z"),
+		EndDate:   helpers.GetStringPtr("2024-05-03T00:00:00Z"),
+	}
+	foreign := milestone.Entity{ID: "4", ProjectID: "3", Name: helpers.GetStringPtr("Sprint 1")}
+
+	today := time.Now()
+	daily := func(from, to, milestoneID string) *report.Filter {
+		return &report.Filter{
+			ProjectID:   "2",
+			MilestoneID: milestoneID,
+			From:        from,
+			To:          to,
+			Terminal:    []string{"Done"},
+			Statuses:    []string{"Todo", "Doing", "Done"},
+		}
+	}
+	periodic := func(period, from, to, milestoneID string) *report.Filter {
+		return &report.Filter{ProjectID: "2", MilestoneID: milestoneID, Period: period, From: from, To: to, Terminal: []string{"Done"}}
+	}
+
+	burndown := []report.Burndown{
+		{Date: "2024-05-01", TotalTasks: 3, CompletedTasks: 0, RemainingTasks: 3, RemainingHours: 12},
+		{Date: "2024-05-02", TotalTasks: 4, CompletedTasks: 1, RemainingTasks: 3, RemainingHours: 9.333},
+		{Date: "2024-05-03", TotalTasks: 4, CompletedTasks: 3, RemainingTasks: 1, RemainingHours: 2.5},
+	}
+	burndownDays := `[{"date":"2024-05-01","total_tasks":3,"completed_tasks":0,"remaining_tasks":3,"remaining_hours":12},` +
+		`{"date":"2024-05-02","total_tasks":4,"completed_tasks":1,"remaining_tasks":3,"remaining_hours":9.33},` +
+		`{"date":"2024-05-03","total_tasks":4,"completed_tasks":3,"remaining_tasks":1,"remaining_hours":2.5}]`
+
+	velocity := []report.Velocity{
+		{Start: "2024-03-01", CompletedTasks: 4, CompletedHours: 16},
+		{Start: "2024-04-01", CompletedTasks: 0, CompletedHours: 0},
+		{Start: "2024-05-01", CompletedTasks: 6, CompletedHours: 21.5},
+	}
+
+	flow := []report.Flow{
+		{Date: "2024-05-01", Status: "Todo", Tasks: 3},
+		{Date: "2024-05-01", Status: "Doing", Tasks: 0},
+		{Date: "2024-05-01", Status: "Done", Tasks: 0},
+		{Date: "2024-05-01", Status: "Blocked", Tasks: 1},
+		{Date: "2024-05-02", Status: "Todo", Tasks: 1},
+		{Date: "2024-05-02", Status: "Doing", Tasks: 2},
+		{Date: "2024-05-02", Status: "Done", Tasks: 1},
+		{Date: "2024-05-02", Status: "Blocked", Tasks: 0},
+	}
+
+	tests := []struct {
+		name             string
+		target           string
+		expectedStatus   int
+		expectedBody     string
+		expectedBurndown *report.Filter
+		expectedVelocity *report.Filter
+		expectedFlow     *report.Filter
+	}{
+		{
+			name:             "Burndown",
+			target:           "/projects/2/reports/burndown?from=2024-05-01&to=2024-05-03",
+			expectedStatus:   http.StatusOK,
+			expectedBody:     `{"data":{"project_id":"2","from":"2024-05-01","to":"2024-05-03","days":` + burndownDays + `},"success":true}`,
+			expectedBurndown: daily("2024-05-01", "2024-05-03", ""),
+		},
+		{
+			name:             "Burndown Of A Sprint",
+			target:           "/projects/2/reports/burndown?milestone_id=1",
+			expectedStatus:   http.StatusOK,
+			expectedBody:     `{"data":{"project_id":"2","milestone_id":"1","from":"2024-05-01","to":"2024-05-03","days":` + burndownDays + `},"success":true}`,
+			expectedBurndown: daily("2024-05-01", "2024-05-03", "1"),
+		},
+		{
+			name:             "Burndown Of The Last 30 Days",
+			target:           "/projects/2/reports/burndown",
+			expectedStatus:   http.StatusOK,
+			expectedBurndown: daily(today.AddDate(0, 0, -29).Format("2006-01-02"), today.Format("2006-01-02"), ""),
+		},
+		{
+			name:           "Burndown Of A Sprint Of Another Project",
+			target:         "/projects/2/reports/burndown?milestone_id=4",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"error not found","success":false}`,
+		},
+		{
+			name:           "Burndown Longer Than A Year",
+			target:         "/projects/2/reports/burndown?from=2023-01-01&to=2024-05-03",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"to: a daily report covers at most 366 days","success":false}`,
+		},
+		{
+			name:           "Burndown Ending Before It Starts",
+			target:         "/projects/2/reports/burndown?from=2024-05-03&to=2024-05-01",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"to: cannot be before from","success":false}`,
+		},
+		{
+			name:           "Burndown With Invalid Date",
+			target:         "/projects/2/reports/burndown?from=May",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"from: must be a date such as 2024-05-01","success":false}`,
+		},
+		{
+			name:           "Burndown Of Unknown Project",
+			target:         "/projects/9/reports/burndown",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"error not found","success":false}`,
+		},
+		{
+			name:           "Monthly Velocity",
+			target:         "/projects/2/reports/velocity?period=month&from=2024-03-01&to=2024-05-31",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":{"project_id":"2","period":"month","from":"2024-03-01","to":"2024-05-31","periods":[` +
+				`{"start":"2024-03-01","completed_tasks":4,"completed_hours":16},` +
+				`{"start":"2024-04-01","completed_tasks":0,"completed_hours":0},` +
+				`{"start":"2024-05-01","completed_tasks":6,"completed_hours":21.5}]},"success":true}`,
+			expectedVelocity: periodic("month", "2024-03-01", "2024-05-31", ""),
+		},
+		{
+			name:             "Velocity Of The Last 12 Weeks",
+			target:           "/projects/2/reports/velocity",
+			expectedStatus:   http.StatusOK,
+			expectedVelocity: periodic("week", today.AddDate(0, 0, -77).Format("2006-01-02"), today.Format("2006-01-02"), ""),
+		},
+		{
+			name:             "Velocity Of A Sprint",
+			target:           "/projects/2/reports/velocity?period=month&from=2024-03-01&to=2024-05-31&milestone_id=1",
+			expectedStatus:   http.StatusOK,
+			expectedVelocity: periodic("month", "2024-03-01", "2024-05-31", "1"),
+		},
+		{
+			name:           "Velocity Of A Sprint Of Another Project",
+			target:         "/projects/2/reports/velocity?milestone_id=4",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"error not found","success":false}`,
+		},
+		{
+			name:           "Velocity Per Unknown Period",
+			target:         "/projects/2/reports/velocity?period=sprint",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"period: must be one of week, month","success":false}`,
+		},
+		{
+			name:           "Velocity Over Too Many Periods",
+			target:         "/projects/2/reports/velocity?period=month&from=2015-01-01&to=2024-05-31",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"to: a velocity report covers at most 104 periods","success":false}`,
+		},
+		{
+			name:           "Cumulative Flow",
+			target:         "/projects/2/reports/cumulative-flow?from=2024-05-01&to=2024-05-02",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":{"project_id":"2","from":"2024-05-01","to":"2024-05-02","statuses":["Todo","Doing","Done","Blocked"],"days":[` +
+				`{"date":"2024-05-01","statuses":{"Todo":3,"Doing":0,"Done":0,"Blocked":1}},` +
+				`{"date":"2024-05-02","statuses":{"Todo":1,"Doing":2,"Done":1,"Blocked":0}}]},"success":true}`,
+			expectedFlow: daily("2024-05-01", "2024-05-02", ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProjectRepo := new(MockProjectRepository)
+			mockProjectRepo.On("Get", mock.Anything, "2").Return(project.Entity{ID: "2"}, nil)
+			mockProjectRepo.On("Get", mock.Anything, "9").Return(project.Entity{}, store.ErrorNotFound)
+
+			mockWorkflowRepo := new(MockWorkflowRepository)
+			mockWorkflowRepo.On("Get", mock.Anything, "2").Return(workflow.Entity{ProjectID: "2", Definition: definition}, nil)
+
+			mockMilestoneRepo := new(MockMilestoneRepository)
+			mockMilestoneRepo.On("Get", mock.Anything, "1").Return(sprint, nil)
+			mockMilestoneRepo.On("Get", mock.Anything, "4").Return(foreign, nil)
+
+			mockReportRepo := new(MockReportRepository)
+			mockReportRepo.On("Burndown", mock.Anything, mock.Anything).Return(burndown, nil)
+			mockReportRepo.On("Velocity", mock.Anything, mock.Anything).Return(velocity, nil)
+			mockReportRepo.On("CumulativeFlow", mock.Anything, mock.Anything).Return(flow, nil)
+
+			taskService, _ := tasker.New(
+				tasker.WithProjectRepository(mockProjectRepo),
+				tasker.WithWorkflowRepository(mockWorkflowRepo),
+				tasker.WithMilestoneRepository(mockMilestoneRepo),
+				tasker.WithReportRepository(mockReportRepo),
+			)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			NewProjectHandler(taskService).Routes(r.Group("/"))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", tt.target, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			}
+
+			if tt.expectedBurndown != nil {
+				mockReportRepo.AssertCalled(t, "Burndown", mock.Anything, *tt.expectedBurndown)
+			} else {
+				mockReportRepo.AssertNotCalled(t, "Burndown", mock.Anything, mock.Anything)
+			}
+			if tt.expectedVelocity != nil {
+				mockReportRepo.AssertCalled(t, "Velocity", mock.Anything, *tt.expectedVelocity)
+			} else {
+				mockReportRepo.AssertNotCalled(t, "Velocity", mock.Anything, mock.Anything)
+			}
+			if tt.expectedFlow != nil {
+				mockReportRepo.AssertCalled(t, "CumulativeFlow", mock.Anything, *tt.expectedFlow)
+			} else {
+				mockReportRepo.AssertNotCalled(t, "CumulativeFlow", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package http
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"hard/internal/domain/access"
+	"hard/internal/domain/schedule"
+	"hard/pkg/server/response"
+	"hard/pkg/store"
+)
+
+// listPredecessors godoc
+//
+//	@Summary		List predecessors
+//	@Description	Get the tasks that have to finish before a task starts
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Task ID"
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			after	query		string	false	"Cursor of the next page"
+//	@Param			before	query		string	false	"Cursor of the previous page"
+//	@Param			include_deleted	query		bool	false	"Include soft deleted rows, admin only"
+//	@Success		200		{array}		task.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/tasks/{id}/predecessors [get]
+func (h *TaskHandler) listPredecessors(c *gin.Context) {
+	id := c.Param("id")
+	page, err := parsePage(c)
+	if err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	res, cursor, err := h.taskerService.ListPredecessors(c, id, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, store.ErrorInvalidCursor):
+			response.BadRequest(c, err, nil)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OKPage(c, res, cursor.Next, cursor.Prev)
+}
+
+// addPredecessor godoc
+//
+//	@Summary		Add a predecessor
+//	@Description	Make a task start after another task of the same project finishes. Links that close a cycle are rejected.
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string				true	"Task ID"
+//	@Param			predecessor	body		schedule.Request	true	"Predecessor Request"
+//	@Success		201			{object}	schedule.LinkResponse
+//	@Failure		400			{object}	response.Object
+//	@Failure		403			{object}	response.Object
+//	@Failure		404			{object}	response.Object
+//	@Failure		409			{object}	response.Object
+//	@Failure		422			{object}	response.Object
+//	@Failure		500			{object}	response.Object
+//	@Router			/tasks/{id}/predecessors [post]
+func (h *TaskHandler) addPredecessor(c *gin.Context) {
+	id := c.Param("id")
+	req := schedule.Request{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	res, err := h.taskerService.AddPredecessor(c, id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		case errors.Is(err, schedule.ErrorExists), errors.Is(err, schedule.ErrorCycle):
+			response.Conflict(c, err)
+		case errors.Is(err, schedule.ErrorSelf), errors.Is(err, schedule.ErrorUnknownPredecessor), errors.Is(err, schedule.ErrorOtherProject):
+			response.UnprocessableEntity(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.Created(c, res)
+}
+
+// deletePredecessor godoc
+//
+//	@Summary		Remove a predecessor
+//	@Description	Remove the link between a task and its predecessor
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		string	true	"Task ID"
+//	@Param			predecessor_id	path		string	true	"Predecessor Task ID"
+//	@Success		200				{string}	string	"Removed Predecessor Task ID"
+//	@Failure		403				{object}	response.Object
+//	@Failure		404				{object}	response.Object
+//	@Failure		500				{object}	response.Object
+//	@Router			/tasks/{id}/predecessors/{predecessor_id} [delete]
+func (h *TaskHandler) deletePredecessor(c *gin.Context) {
+	id, predecessorID := c.Param("id"), c.Param("predecessor_id")
+
+	if err := h.taskerService.DeletePredecessor(c, id, predecessorID); err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, predecessorID)
+}
+
+// getSchedule godoc
+//
+//	@Summary		Get project schedule
+//	@Description	Compute earliest and latest start and finish, slack and the critical path of the project tasks
+//	@Description	from their estimates (8 hours a day), start and due dates and predecessors, and compare the
+//	@Description	projected finish with the end date of the project.
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Project ID"
+//	@Success		200	{object}	schedule.Response
+//	@Failure		404	{object}	response.Object
+//	@Failure		409	{object}	response.Object
+//	@Failure		422	{object}	response.Object
+//	@Failure		500	{object}	response.Object
+//	@Router			/projects/{id}/schedule [get]
+func (h *ProjectHandler) getSchedule(c *gin.Context) {
+	id := c.Param("id")
+
+	res, err := h.taskerService.GetSchedule(c, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, schedule.ErrorCycle):
+			response.Conflict(c, err)
+		case errors.Is(err, schedule.ErrorNoStartDate):
+			response.UnprocessableEntity(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, res)
+}
@@ -0,0 +1,308 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"hard/internal/domain/audit"
+	"hard/internal/domain/project"
+	"hard/internal/domain/schedule"
+	"hard/internal/domain/task"
+	"hard/internal/domain/workflow"
+	"hard/pkg/helpers"
+	"hard/pkg/store"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"hard/internal/service/tasker"
+)
+
+type MockPredecessorRepository struct {
+	mock.Mock
+}
+
+func (m *MockPredecessorRepository) ListPredecessors(ctx context.Context, taskID string, page store.Page) (dest []task.Entity, cursor store.Cursor, err error) {
+	args := m.Called(ctx, taskID, page)
+	return args.Get(0).([]task.Entity), args.Get(1).(store.Cursor), args.Error(2)
+}
+
+func (m *MockPredecessorRepository) ListByProject(ctx context.Context, projectID string) (dest []schedule.Link, err error) {
+	args := m.Called(ctx, projectID)
+	return args.Get(0).([]schedule.Link), args.Error(1)
+}
+
+func (m *MockPredecessorRepository) Get(ctx context.Context, taskID, predecessorID string) (dest schedule.Link, err error) {
+	args := m.Called(ctx, taskID, predecessorID)
+	return args.Get(0).(schedule.Link), args.Error(1)
+}
+
+func (m *MockPredecessorRepository) Add(ctx context.Context, data schedule.Link) (err error) {
+	args := m.Called(ctx, data)
+	return args.Error(0)
+}
+
+func (m *MockPredecessorRepository) Delete(ctx context.Context, taskID, predecessorID string) (err error) {
+	args := m.Called(ctx, taskID, predecessorID)
+	return args.Error(0)
+}
+
+func (m *MockPredecessorRepository) Precedes(ctx context.Context, predecessorID, taskID string) (ok bool, err error) {
+	args := m.Called(ctx, predecessorID, taskID)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestSchedule(t *testing.T) {
+	newTask := func(id, projectID string, estimate float64) task.Entity {
+		return task.Entity{
+			ID:            id,
+			Title:         helpers.GetStringPtr("Task " + id),
+			Description:   helpers.GetStringPtr("Task " + id),
+			Priority:      helpers.GetStringPtr("High"),
+			Status:        helpers.GetStringPtr("Active"),
+			AssigneeID:    helpers.GetStringPtr("2"),
+			ProjectID:     helpers.GetStringPtr(projectID),
+			EstimateHours: &estimate,
+		}
+	}
+
+	// 1 precedes 2 and 3, 4 is in another project
+	tasks := map[string]task.Entity{"1": newTask("1", "2", 16), "2": newTask("2", "2", 24), "3": newTask("3", "2", 8), "4": newTask("4", "3", 8)}
+	first := tasks["1"]
+	first.StartDate = helpers.GetStringPtr("2024-05-01T00:00:00Z")
+	tasks["1"] = first
+	third := tasks["3"]
+	third.DueDate = helpers.GetStringPtr("2024-05-02T00:00:00Z")
+	tasks["3"] = third
+
+	createdAt := "2024-05-01T10:00:00Z"
+	links := []schedule.Link{{TaskID: "2", PredecessorID: "1"}, {TaskID: "3", PredecessorID: "1"}}
+	response := `{"id":"1","title":"Task 1","description":"Task 1","priority":"High","status":"Active","assignee_id":"2","project_id":"2","start_date":"2024-05-01T00:00:00Z","estimate_hours":16,"completed_at":""}`
+
+	tests := []struct {
+		name           string
+		method         string
+		target         string
+		inputBody      string
+		mockProject    project.Entity
+		mockLinks      []schedule.Link
+		expectedStatus int
+		expectedBody   string
+		expectedAdded  *schedule.Link
+		expectedRecord *audit.Entity
+	}{
+		{
+			name:           "List Predecessors",
+			method:         "GET",
+			target:         "/tasks/2/predecessors",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[` + response + `],"success":true}`,
+		},
+		{
+			name:           "Predecessors Of Unknown Task",
+			method:         "GET",
+			target:         "/tasks/9/predecessors",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"error not found","success":false}`,
+		},
+		{
+			name:           "Add Predecessor",
+			method:         "POST",
+			target:         "/tasks/3/predecessors",
+			inputBody:      `{"predecessor_id":"2"}`,
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"data":{"task_id":"3","predecessor_id":"2","created_at":"2024-05-01T10:00:00Z"},"success":true}`,
+			expectedAdded:  &schedule.Link{TaskID: "3", PredecessorID: "2"},
+			expectedRecord: &audit.Entity{EntityType: audit.EntityPredecessor, EntityID: "3/2", Operation: audit.OperationCreate},
+		},
+		{
+			name:           "Missing Predecessor",
+			method:         "POST",
+			target:         "/tasks/3/predecessors",
+			inputBody:      `{}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"data":{"predecessor_id":null},"message":"predecessor_id: cannot be blank","success":false}`,
+		},
+		{
+			name:           "Task Precedes Itself",
+			method:         "POST",
+			target:         "/tasks/3/predecessors",
+			inputBody:      `{"predecessor_id":"3"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"message":"predecessor_id: task cannot precede itself","success":false}`,
+		},
+		{
+			name:           "Unknown Predecessor",
+			method:         "POST",
+			target:         "/tasks/3/predecessors",
+			inputBody:      `{"predecessor_id":"9"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"message":"predecessor_id: predecessor task does not exist","success":false}`,
+		},
+		{
+			name:           "Predecessor From Another Project",
+			method:         "POST",
+			target:         "/tasks/3/predecessors",
+			inputBody:      `{"predecessor_id":"4"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"message":"predecessor_id: predecessor task belongs to another project","success":false}`,
+		},
+		{
+			name:           "Duplicate Predecessor",
+			method:         "POST",
+			target:         "/tasks/2/predecessors",
+			inputBody:      `{"predecessor_id":"1"}`,
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"task already follows the predecessor","success":false}`,
+		},
+		{
+			name:           "Predecessor Cycle",
+			method:         "POST",
+			target:         "/tasks/1/predecessors",
+			inputBody:      `{"predecessor_id":"3"}`,
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"predecessor cycle: task 1 already precedes task 3","success":false}`,
+		},
+		{
+			name:           "Delete Predecessor",
+			method:         "DELETE",
+			target:         "/tasks/2/predecessors/1",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":"1","success":true}`,
+			expectedRecord: &audit.Entity{EntityType: audit.EntityPredecessor, EntityID: "2/1", Operation: audit.OperationDelete},
+		},
+		{
+			name:           "Delete Missing Predecessor",
+			method:         "DELETE",
+			target:         "/tasks/3/predecessors/2",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"error not found","success":false}`,
+		},
+		{
+			name:           "Due Date Before Start Date",
+			method:         "PUT",
+			target:         "/tasks/1",
+			inputBody:      `{"due_date":"2024-04-30"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"message":"due_date: cannot be before start_date","success":false}`,
+		},
+		{
+			name:           "Invalid Due Date",
+			method:         "PUT",
+			target:         "/tasks/1",
+			inputBody:      `{"due_date":"tomorrow"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Schedule With Critical Path",
+			method:         "GET",
+			target:         "/projects/2/schedule",
+			mockProject:    project.Entity{ID: "2", StartDate: helpers.GetStringPtr("2024-05-01T00:00:00Z"), EndDate: helpers.GetStringPtr("2024-05-04T00:00:00Z")},
+			mockLinks:      links,
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":{"project_id":"2","start_date":"2024-05-01","end_date":"2024-05-04","projected_finish":"2024-05-05","on_time":false,"delay_days":1,"critical_path":["1","2"],"tasks":[` +
+				`{"id":"1","title":"Task 1","duration_days":2,"earliest_start":"2024-05-01","earliest_finish":"2024-05-02","latest_start":"2024-05-01","latest_finish":"2024-05-02","slack_days":0,"critical":true,"late":false},` +
+				`{"id":"2","title":"Task 2","duration_days":3,"earliest_start":"2024-05-03","earliest_finish":"2024-05-05","latest_start":"2024-05-03","latest_finish":"2024-05-05","slack_days":0,"critical":true,"late":false},` +
+				`{"id":"3","title":"Task 3","duration_days":1,"earliest_start":"2024-05-03","earliest_finish":"2024-05-03","latest_start":"2024-05-05","latest_finish":"2024-05-05","slack_days":2,"critical":false,"due_date":"2024-05-02","late":true}` +
+				`]},"success":true}`,
+		},
+		{
+			name:           "Schedule Without Start Date",
+			method:         "GET",
+			target:         "/projects/2/schedule",
+			mockProject:    project.Entity{ID: "2"},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"message":"start_date: project has no start date to schedule from","success":false}`,
+		},
+		{
+			name:           "Schedule With Cycle",
+			method:         "GET",
+			target:         "/projects/2/schedule",
+			mockProject:    project.Entity{ID: "2", StartDate: helpers.GetStringPtr("2024-05-01T00:00:00Z")},
+			mockLinks:      []schedule.Link{{TaskID: "1", PredecessorID: "2"}, {TaskID: "2", PredecessorID: "1"}},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"predecessor cycle","success":false}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockTaskRepo.On("Get", mock.Anything, "9").Return(nil, store.ErrorNotFound)
+			for id, data := range tasks {
+				mockTaskRepo.On("Get", mock.Anything, id).Return(data, nil)
+			}
+			mockTaskRepo.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockTaskRepo.On("Descendants", mock.Anything, mock.Anything).Return([]task.Entity{}, nil)
+			mockTaskRepo.On("ListByProject", mock.Anything, "2").Return([]task.Entity{tasks["1"], tasks["2"], tasks["3"]}, nil)
+
+			mockProjectRepo := new(MockProjectRepository)
+			mockProjectRepo.On("Get", mock.Anything, "2").Return(tt.mockProject, nil)
+
+			mockWorkflowRepo := new(MockWorkflowRepository)
+			mockWorkflowRepo.On("Get", mock.Anything, mock.Anything).Return(workflow.Entity{}, store.ErrorNotFound)
+
+			mockPredecessorRepo := new(MockPredecessorRepository)
+			mockPredecessorRepo.On("ListPredecessors", mock.Anything, "2", mock.Anything).Return([]task.Entity{tasks["1"]}, store.Cursor{}, nil)
+			mockPredecessorRepo.On("ListByProject", mock.Anything, "2").Return(tt.mockLinks, nil)
+			mockPredecessorRepo.On("Get", mock.Anything, "2", "1").Return(schedule.Link{TaskID: "2", PredecessorID: "1", CreatedAt: &createdAt}, nil)
+			if tt.expectedAdded != nil {
+				added := *tt.expectedAdded
+				added.CreatedAt = &createdAt
+				mockPredecessorRepo.On("Get", mock.Anything, added.TaskID, added.PredecessorID).Return(schedule.Link{}, store.ErrorNotFound).Once()
+				mockPredecessorRepo.On("Get", mock.Anything, added.TaskID, added.PredecessorID).Return(added, nil)
+			}
+			mockPredecessorRepo.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(schedule.Link{}, store.ErrorNotFound)
+			mockPredecessorRepo.On("Add", mock.Anything, mock.Anything).Return(nil)
+			mockPredecessorRepo.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockPredecessorRepo.On("Precedes", mock.Anything, "1", "3").Return(true, nil)
+			mockPredecessorRepo.On("Precedes", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+
+			mockAuditRepo := new(MockAuditRepository)
+			mockAuditRepo.On("Add", mock.Anything, mock.Anything).Return(nil)
+
+			taskService, _ := tasker.New(
+				tasker.WithUserRepository(mockAdmin()),
+				tasker.WithTaskRepository(mockTaskRepo),
+				tasker.WithProjectRepository(mockProjectRepo),
+				tasker.WithWorkflowRepository(mockWorkflowRepo),
+				tasker.WithPredecessorRepository(mockPredecessorRepo),
+				tasker.WithAuditRepository(mockAuditRepo),
+			)
+
+			gin.SetMode(gin.TestMode)
+			r := authenticated("admin-id")
+			NewTaskHandler(taskService).Routes(r.Group("/"))
+			NewProjectHandler(taskService).Routes(r.Group("/"))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.target, bytes.NewBufferString(tt.inputBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			}
+
+			if tt.expectedAdded != nil {
+				mockPredecessorRepo.AssertCalled(t, "Add", mock.Anything, *tt.expectedAdded)
+			} else {
+				mockPredecessorRepo.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+			}
+			if tt.expectedRecord != nil {
+				mockAuditRepo.AssertCalled(t, "Add", mock.Anything, mock.MatchedBy(func(data audit.Entity) bool {
+					return data.EntityType == tt.expectedRecord.EntityType &&
+						data.EntityID == tt.expectedRecord.EntityID &&
+						data.Operation == tt.expectedRecord.Operation
+				}))
+			}
+			if tt.method == "PUT" {
+				mockTaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
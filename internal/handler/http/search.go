@@ -0,0 +1,76 @@
+package http
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"hard/internal/domain/search"
+	"hard/internal/service/tasker"
+	"hard/pkg/server/response"
+	"hard/pkg/store"
+	"strings"
+)
+
+type SearchHandler struct {
+	taskerService *tasker.Service
+}
+
+func NewSearchHandler(s *tasker.Service) *SearchHandler {
+	return &SearchHandler{taskerService: s}
+}
+
+// Routes sets up the routes for full-text search
+func (h *SearchHandler) Routes(r *gin.RouterGroup) {
+	api := r.Group("/search")
+	{
+		api.GET("/", h.search)
+	}
+}
+
+// search godoc
+//
+//	@Summary		Full-text search
+//	@Description	Search tasks, projects and users by their text fields. Hits are ranked and highlighted.
+//	@Tags			search
+//	@Accept			json
+//	@Produce		json
+//	@Param			q		query		string	true	"Search query, e.g. login bug"
+//	@Param			type	query		string	false	"Comma separated entity types: task, project, user"
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			after	query		string	false	"Cursor of the next page"
+//	@Param			before	query		string	false	"Cursor of the previous page"
+//	@Success		200		{array}		search.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/search [get]
+func (h *SearchHandler) search(c *gin.Context) {
+	req := search.Request{
+		Query: c.Query("q"),
+	}
+	if types := c.Query("type"); types != "" {
+		req.Types = strings.Split(types, ",")
+	}
+
+	if err := req.Validate(); err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	page, err := parsePage(c)
+	if err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	res, cursor, err := h.taskerService.Search(c, req, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorInvalidCursor):
+			response.BadRequest(c, err, nil)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OKPage(c, res, cursor.Next, cursor.Prev)
+}
@@ -0,0 +1,108 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"hard/pkg/helpers"
+	"hard/pkg/store"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"hard/internal/domain/search"
+	"hard/internal/service/tasker"
+)
+
+type MockSearchRepository struct {
+	mock.Mock
+}
+
+func (m *MockSearchRepository) Search(ctx context.Context, query string, types []string, page store.Page) (dest []search.Entity, cursor store.Cursor, err error) {
+	args := m.Called(ctx, query, types, page)
+	return args.Get(0).([]search.Entity), args.Get(1).(store.Cursor), args.Error(2)
+}
+
+func TestFullTextSearch(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedQuery  string
+		expectedTypes  []string
+		mockRepoOutput []search.Entity
+		mockRepoError  error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:          "Successful Search",
+			query:         "?q=login+bug",
+			expectedQuery: "login bug",
+			mockRepoOutput: []search.Entity{
+				{
+					Type:     search.TypeTask,
+					ID:       "2",
+					Title:    helpers.GetStringPtr("Implement Login"),
+					Headline: helpers.GetStringPtr("Implement <b>Login</b>"),
+					Rank:     0.5,
+				},
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[{"type":"task","id":"2","title":"Implement Login","headline":"Implement <b>Login</b>","rank":0.5}],"success":true}`,
+		},
+		{
+			name:           "Filtered By Type",
+			query:          "?q=morty&type=project,user",
+			expectedQuery:  "morty",
+			expectedTypes:  []string{"project", "user"},
+			mockRepoOutput: []search.Entity{},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[],"success":true}`,
+		},
+		{
+			name:           "Missing Query",
+			query:          "?q=+",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"q: cannot be blank","success":false}`,
+		},
+		{
+			name:           "Unknown Type",
+			query:          "?q=morty&type=comment",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"type: must be one of task, project, user","success":false}`,
+		},
+		{
+			name:           "Internal Server Error",
+			query:          "?q=morty",
+			expectedQuery:  "morty",
+			mockRepoOutput: nil,
+			mockRepoError:  errors.New("repository error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"message":"repository error","success":false}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockSearchRepository)
+			mockRepo.On("Search", mock.Anything, tt.expectedQuery, tt.expectedTypes, mock.Anything).
+				Return(tt.mockRepoOutput, store.Cursor{}, tt.mockRepoError)
+
+			service, _ := tasker.New(tasker.WithSearchRepository(mockRepo))
+			searchHandler := NewSearchHandler(service)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/search", searchHandler.search)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/search"+tt.query, nil)
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
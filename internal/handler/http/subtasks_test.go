@@ -0,0 +1,223 @@
+package http
+
+import (
+	"bytes"
+	"hard/internal/domain/task"
+	"hard/internal/domain/workflow"
+	"hard/pkg/helpers"
+	"hard/pkg/store"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"hard/internal/service/tasker"
+)
+
+func TestSubtasks(t *testing.T) {
+	newTask := func(id, parentID, projectID, status string) task.Entity {
+		data := task.Entity{
+			ID:          id,
+			Title:       helpers.GetStringPtr("Task " + id),
+			Description: helpers.GetStringPtr("Task " + id),
+			Priority:    helpers.GetStringPtr("High"),
+			Status:      helpers.GetStringPtr(status),
+			AssigneeID:  helpers.GetStringPtr("2"),
+			ProjectID:   helpers.GetStringPtr(projectID),
+		}
+		if parentID != "" {
+			data.ParentID = helpers.GetStringPtr(parentID)
+		}
+		if status == "Done" {
+			data.CompletedAt = helpers.GetStringPtr("2024-05-01")
+		}
+		return data
+	}
+
+	// 1 -> 2 (done), 3 -> 4 (done), 5
+	root := newTask("1", "", "2", "Active")
+	descendants := []task.Entity{
+		newTask("2", "1", "2", "Done"),
+		newTask("3", "1", "2", "Active"),
+		newTask("4", "3", "2", "Done"),
+		newTask("5", "3", "2", "Active"),
+	}
+	tasks := map[string]task.Entity{"1": root, "6": newTask("6", "", "3", "Active")}
+	for _, data := range descendants {
+		tasks[data.ID] = data
+	}
+
+	tests := []struct {
+		name           string
+		closePolicy    task.ClosePolicy
+		method         string
+		target         string
+		inputBody      string
+		expectedStatus int
+		expectedBody   string
+		expectedClosed []string
+	}{
+		{
+			name:           "List Direct Subtasks",
+			method:         "GET",
+			target:         "/tasks/1/subtasks",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[{"id":"2","title":"Task 2","description":"Task 2","priority":"High","status":"Done","assignee_id":"2","project_id":"2","parent_id":"1","completed_at":"2024-05-01"},{"id":"3","title":"Task 3","description":"Task 3","priority":"High","status":"Active","assignee_id":"2","project_id":"2","parent_id":"1","completed_at":""}],"success":true}`,
+		},
+		{
+			name:           "Subtasks Of Unknown Task",
+			method:         "GET",
+			target:         "/tasks/9/subtasks",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"error not found","success":false}`,
+		},
+		{
+			name:           "Tree Rolls Up Progress",
+			method:         "GET",
+			target:         "/tasks/1/tree",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":{"id":"1","title":"Task 1","description":"Task 1","priority":"High","status":"Active","assignee_id":"2","project_id":"2","completed_at":"","progress":75,"subtasks":[
+				{"id":"2","title":"Task 2","description":"Task 2","priority":"High","status":"Done","assignee_id":"2","project_id":"2","parent_id":"1","completed_at":"2024-05-01","progress":100,"subtasks":[]},
+				{"id":"3","title":"Task 3","description":"Task 3","priority":"High","status":"Active","assignee_id":"2","project_id":"2","parent_id":"1","completed_at":"","progress":50,"subtasks":[
+					{"id":"4","title":"Task 4","description":"Task 4","priority":"High","status":"Done","assignee_id":"2","project_id":"2","parent_id":"3","completed_at":"2024-05-01","progress":100,"subtasks":[]},
+					{"id":"5","title":"Task 5","description":"Task 5","priority":"High","status":"Active","assignee_id":"2","project_id":"2","parent_id":"3","completed_at":"","progress":0,"subtasks":[]}]}]},"success":true}`,
+		},
+		{
+			name:           "Nest Under Own Subtask",
+			method:         "PUT",
+			target:         "/tasks/1",
+			inputBody:      `{"parent_id":"5"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"message":"parent_id: task cannot be nested under itself or its subtasks","success":false}`,
+		},
+		{
+			name:           "Nest Under Itself",
+			method:         "PUT",
+			target:         "/tasks/5",
+			inputBody:      `{"parent_id":"5"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"message":"parent_id: task cannot be nested under itself or its subtasks","success":false}`,
+		},
+		{
+			name:           "Parent Of Another Project",
+			method:         "PUT",
+			target:         "/tasks/5",
+			inputBody:      `{"parent_id":"6"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"message":"parent_id: parent task belongs to another project","success":false}`,
+		},
+		{
+			name:           "Unknown Parent",
+			method:         "PUT",
+			target:         "/tasks/5",
+			inputBody:      `{"parent_id":"9"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"message":"parent_id: parent task does not exist","success":false}`,
+		},
+		{
+			name:           "Move Parent To Another Project",
+			method:         "PUT",
+			target:         "/tasks/3",
+			inputBody:      `{"project_id":"3"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"message":"project_id: task with subtasks cannot be moved to another project","success":false}`,
+		},
+		{
+			name:           "Reparent",
+			method:         "PUT",
+			target:         "/tasks/5",
+			inputBody:      `{"parent_id":"2"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":"ok","success":true}`,
+		},
+		{
+			name:           "Block Closing With Open Subtasks",
+			closePolicy:    task.ClosePolicyBlock,
+			method:         "PUT",
+			target:         "/tasks/1",
+			inputBody:      `{"status":"Done"}`,
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"task has open subtasks: 3, 5","success":false}`,
+		},
+		{
+			name:           "Cascade Closing To Open Subtasks",
+			closePolicy:    task.ClosePolicyCascade,
+			method:         "PUT",
+			target:         "/tasks/1",
+			inputBody:      `{"status":"Done"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":"ok","success":true}`,
+			expectedClosed: []string{"1", "3", "5"},
+		},
+		{
+			name:           "Allow Closing With Open Subtasks",
+			closePolicy:    task.ClosePolicyAllow,
+			method:         "PUT",
+			target:         "/tasks/1",
+			inputBody:      `{"status":"Done"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":"ok","success":true}`,
+			expectedClosed: []string{"1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockTaskRepo.On("Get", mock.Anything, "9").Return(nil, store.ErrorNotFound)
+			for id, data := range tasks {
+				mockTaskRepo.On("Get", mock.Anything, id).Return(data, nil)
+			}
+			mockTaskRepo.On("ListSubtasks", mock.Anything, "1", mock.Anything).Return(descendants[:2], store.Cursor{}, nil)
+			mockTaskRepo.On("Descendants", mock.Anything, "1").Return(descendants, nil)
+			mockTaskRepo.On("Descendants", mock.Anything, "3").Return(descendants[2:], nil)
+			mockTaskRepo.On("Ancestors", mock.Anything, "5").Return([]string{"5", "3", "1"}, nil)
+			mockTaskRepo.On("Ancestors", mock.Anything, "2").Return([]string{"2", "1"}, nil)
+			mockTaskRepo.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			mockWorkflowRepo := new(MockWorkflowRepository)
+			mockWorkflowRepo.On("Get", mock.Anything, mock.Anything).Return(workflow.Entity{}, store.ErrorNotFound)
+
+			mockDependencyRepo := new(MockDependencyRepository)
+			mockDependencyRepo.On("OpenBlockers", mock.Anything, mock.Anything).Return([]string{}, nil)
+
+			configs := []tasker.Configuration{
+				tasker.WithUserRepository(mockAdmin()),
+				tasker.WithTaskRepository(mockTaskRepo),
+				tasker.WithWorkflowRepository(mockWorkflowRepo),
+				tasker.WithDependencyRepository(mockDependencyRepo),
+			}
+			if tt.closePolicy != "" {
+				configs = append(configs, tasker.WithClosePolicy(tt.closePolicy))
+			}
+			taskService, _ := tasker.New(configs...)
+
+			gin.SetMode(gin.TestMode)
+			r := authenticated("admin-id")
+			NewTaskHandler(taskService).Routes(r.Group("/"))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.target, bytes.NewBufferString(tt.inputBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+
+			if tt.method == "PUT" && tt.expectedStatus != http.StatusOK {
+				mockTaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+			}
+			for _, id := range tt.expectedClosed {
+				mockTaskRepo.AssertCalled(t, "Update", mock.Anything, id, mock.MatchedBy(func(data task.Entity) bool {
+					return *data.Status == "Done" && *data.CompletedAt != ""
+				}))
+			}
+			if tt.expectedClosed != nil {
+				mockTaskRepo.AssertNumberOfCalls(t, "Update", len(tt.expectedClosed))
+			}
+		})
+	}
+}
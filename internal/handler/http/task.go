@@ -4,12 +4,14 @@ import (
 	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"hard/internal/domain/access"
+	"hard/internal/domain/dependency"
+	"hard/internal/domain/member"
 	"hard/internal/domain/task"
+	"hard/internal/domain/workflow"
 	"hard/internal/service/tasker"
-	"hard/pkg/helpers"
 	"hard/pkg/server/response"
 	"hard/pkg/store"
-	"strings"
 )
 
 type TaskHandler struct {
@@ -24,14 +26,53 @@ func NewTaskHandler(s *tasker.Service) *TaskHandler {
 func (h *TaskHandler) Routes(r *gin.RouterGroup) {
 	api := r.Group("/tasks")
 	{
-		api.GET("/", h.list)
+		deleted := includeDeleted(h.taskerService)
+
+		api.GET("/", deleted, h.list)
 		api.POST("/", h.add)
+		api.POST("/bulk", h.addBulk)
+		api.PATCH("/bulk", h.updateBulk)
+		api.DELETE("/bulk", h.deleteBulk)
 
-		api.GET("/:id", h.get)
+		api.GET("/:id", deleted, h.get)
 		api.PUT("/:id", h.update)
 		api.DELETE("/:id", h.delete)
+		api.POST("/:id/restore", h.restore)
+		api.GET("/:id/history", deleted, h.history)
+		api.GET("/:id/subtasks", deleted, h.subtasks)
+		api.GET("/:id/tree", deleted, h.tree)
+		api.GET("/:id/dependencies", deleted, h.listDependencies)
+		api.POST("/:id/dependencies", h.addDependency)
+		api.DELETE("/:id/dependencies/:blocker_id", h.deleteDependency)
+		api.GET("/:id/dependents", deleted, h.listDependents)
+		api.GET("/:id/predecessors", deleted, h.listPredecessors)
+		api.POST("/:id/predecessors", h.addPredecessor)
+		api.DELETE("/:id/predecessors/:predecessor_id", h.deletePredecessor)
+		api.GET("/:id/labels", deleted, h.listTaskLabels)
+		api.POST("/:id/labels", h.attachLabel)
+		api.DELETE("/:id/labels/:label_id", h.detachLabel)
+		api.GET("/:id/comments", deleted, h.listComments)
+		api.POST("/:id/comments", h.addComment)
+		api.GET("/:id/comments/:comment_id", deleted, h.getComment)
+		api.PUT("/:id/comments/:comment_id", h.updateComment)
+		api.DELETE("/:id/comments/:comment_id", h.deleteComment)
+		api.GET("/:id/comments/:comment_id/history", deleted, h.commentHistory)
+		api.GET("/:id/attachments", deleted, h.listAttachments)
+		api.POST("/:id/attachments", h.uploadAttachment)
+		api.GET("/:id/attachments/:attachment_id", deleted, h.getAttachment)
+		api.GET("/:id/attachments/:attachment_id/content", deleted, h.downloadAttachment)
+		api.DELETE("/:id/attachments/:attachment_id", h.deleteAttachment)
+		api.POST("/:id/timer/start", h.startTimer)
+		api.POST("/:id/timer/stop", h.stopTimer)
+		api.GET("/:id/time", h.taskTime)
+		api.GET("/:id/time/entries", h.listTimeEntries)
+		api.POST("/:id/time/entries", h.logTime)
+		api.DELETE("/:id/time/entries/:entry_id", h.deleteTimeEntry)
+		api.GET("/:id/recurrence", deleted, h.getRecurrence)
+		api.PUT("/:id/recurrence", h.saveRecurrence)
+		api.DELETE("/:id/recurrence", h.deleteRecurrence)
 
-		api.GET("/search", h.search)
+		api.GET("/search", deleted, h.search)
 	}
 }
 
@@ -41,18 +82,35 @@ func (h *TaskHandler) Routes(r *gin.RouterGroup) {
 //	@Tags			tasks
 //	@Accept			json
 //	@Produce		json
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			after	query		string	false	"Cursor of the next page"
+//	@Param			before	query		string	false	"Cursor of the previous page"
+//	@Param			include_deleted	query		bool	false	"Include soft deleted rows, admin only"
 //	@Success		200	{array}		task.Response
+//	@Failure		400	{object}	response.Object
+//	@Failure		403	{object}	response.Object
 //	@Failure		500	{object}	response.Object
 //	@Router			/tasks [get]
 func (h *TaskHandler) list(c *gin.Context) {
-	res, err := h.taskerService.ListTasks(c)
+	page, err := parsePage(c)
+	if err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	res, cursor, err := h.taskerService.ListTasks(c, page)
 	if err != nil {
-		response.InternalServerError(c, err)
+		switch {
+		case errors.Is(err, store.ErrorInvalidCursor):
+			response.BadRequest(c, err, nil)
+		default:
+			response.InternalServerError(c, err)
+		}
 		return
 	}
 	//err := errors.New("repository error")
 	//response.InternalServerError(c, err)
-	response.OK(c, res)
+	response.OKPage(c, res, cursor.Next, cursor.Prev)
 }
 
 // addTask godoc
@@ -64,6 +122,8 @@ func (h *TaskHandler) list(c *gin.Context) {
 //	@Param			task	body		task.Request	true	"Task Request"
 //	@Success		201		{object}	task.Response
 //	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
+//	@Failure		422		{object}	response.Object
 //	@Failure		500		{object}	response.Object
 //	@Router			/tasks [post]
 func (h *TaskHandler) add(c *gin.Context) {
@@ -81,8 +141,12 @@ func (h *TaskHandler) add(c *gin.Context) {
 	res, err := h.taskerService.CreateTask(c, req)
 	if err != nil {
 		switch {
-		case strings.Contains(err.Error(), "failed to parse:"):
+		case errors.Is(err, store.ErrorInvalidInput):
 			response.BadRequest(c, err, req)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		case errors.Is(err, workflow.ErrorUnknownStatus), errors.Is(err, member.ErrorNotMember), isParentError(err), isMilestoneError(err):
+			response.UnprocessableEntity(c, err)
 		default:
 			response.InternalServerError(c, err)
 		}
@@ -99,7 +163,10 @@ func (h *TaskHandler) add(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			id	path		string	true	"Task ID"
+//	@Param			include_deleted	query		bool	false	"Include soft deleted rows, admin only"
 //	@Success		200	{object}	task.Response
+//	@Header			200	{string}	ETag	"Version of the task"
+//	@Failure		403	{object}	response.Object
 //	@Failure		404	{object}	response.Object
 //	@Failure		500	{object}	response.Object
 //	@Router			/tasks/{id} [get]
@@ -117,6 +184,7 @@ func (h *TaskHandler) get(c *gin.Context) {
 		return
 	}
 
+	setETag(c, res.Version)
 	response.OK(c, res)
 }
 
@@ -128,9 +196,14 @@ func (h *TaskHandler) get(c *gin.Context) {
 //	@Produce		json
 //	@Param			id		path		string			true	"Task ID"
 //	@Param			task	body		task.Request	true	"Task Request"
+//	@Param			If-Match	header		string	false	"ETag of the version being changed"
 //	@Success		200		{string}	string			"ok"
 //	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
 //	@Failure		404		{object}	response.Object
+//	@Failure		409		{object}	response.Object
+//	@Failure		412		{object}	response.Object
+//	@Failure		422		{object}	response.Object
 //	@Failure		500		{object}	response.Object
 //	@Router			/tasks/{id} [put]
 func (h *TaskHandler) update(c *gin.Context) {
@@ -142,17 +215,35 @@ func (h *TaskHandler) update(c *gin.Context) {
 		return
 	}
 
-	if req.Title == nil && req.Description == nil && req.Priority == nil &&
-		req.Status == nil && req.AssigneeID == nil && req.ProjectID == nil {
+	if req.IsEmpty() {
 		err := fmt.Errorf("bad request")
 		response.BadRequest(c, err, req)
 		return
 	}
 
-	if err := h.taskerService.UpdateTask(c, id, req); err != nil {
+	if err := req.ValidateUpdate(); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	version, ok := ifMatch(c, h.version(c, id))
+	if !ok {
+		return
+	}
+
+	if err := h.taskerService.UpdateTask(c, id, req, version); err != nil {
 		switch {
 		case errors.Is(err, store.ErrorNotFound):
 			response.NotFound(c, err)
+		case errors.Is(err, workflow.ErrorTransitionNotAllowed), errors.Is(err, task.ErrorOpenSubtasks), errors.Is(err, dependency.ErrorBlocked):
+			response.Conflict(c, err)
+		case errors.Is(err, workflow.ErrorUnknownStatus), errors.Is(err, member.ErrorNotMember), isParentError(err), isMilestoneError(err),
+			errors.Is(err, task.ErrorDueDate):
+			response.UnprocessableEntity(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		case errors.Is(err, store.ErrorConflict):
+			response.PreconditionFailed(c, err)
 		default:
 			response.InternalServerError(c, err)
 		}
@@ -169,17 +260,29 @@ func (h *TaskHandler) update(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			id	path		string	true	"Task ID"
+//	@Param			If-Match	header		string	false	"ETag of the version being changed"
 //	@Success		200	{string}	string	"Deleted Task ID"
+//	@Failure		403	{object}	response.Object
 //	@Failure		404	{object}	response.Object
+//	@Failure		412	{object}	response.Object
 //	@Failure		500	{object}	response.Object
 //	@Router			/tasks/{id} [delete]
 func (h *TaskHandler) delete(c *gin.Context) {
 	id := c.Param("id")
 
-	if err := h.taskerService.DeleteTask(c, id); err != nil {
+	version, ok := ifMatch(c, h.version(c, id))
+	if !ok {
+		return
+	}
+
+	if err := h.taskerService.DeleteTask(c, id, version); err != nil {
 		switch {
 		case errors.Is(err, store.ErrorNotFound):
 			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		case errors.Is(err, store.ErrorConflict):
+			response.PreconditionFailed(c, err)
 		default:
 			response.InternalServerError(c, err)
 		}
@@ -189,39 +292,215 @@ func (h *TaskHandler) delete(c *gin.Context) {
 	response.OK(c, id)
 }
 
+// restoreTask godoc
+//	@Summary		Restore a task
+//	@Description	Restore a soft deleted task
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Task ID"
+//	@Success		200	{object}	task.Response
+//	@Failure		403	{object}	response.Object
+//	@Failure		404	{object}	response.Object
+//	@Failure		409	{object}	response.Object
+//	@Failure		500	{object}	response.Object
+//	@Router			/tasks/{id}/restore [post]
+func (h *TaskHandler) restore(c *gin.Context) {
+	id := c.Param("id")
+
+	res, err := h.taskerService.RestoreTask(c, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		case errors.Is(err, store.ErrorNotDeleted), errors.Is(err, task.ErrorProjectDeleted), errors.Is(err, task.ErrorParentDeleted):
+			response.Conflict(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	setETag(c, res.Version)
+	response.OK(c, res)
+}
+
+// taskHistory godoc
+//	@Summary		Get task history
+//	@Description	Get the recorded changes of a task, newest first. Admins, the manager and members of its project and its assignee can read them
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Task ID"
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			after	query		string	false	"Cursor of the next page"
+//	@Param			before	query		string	false	"Cursor of the previous page"
+//	@Param			include_deleted	query		bool	false	"Include soft deleted rows, admin only"
+//	@Success		200		{array}		audit.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/tasks/{id}/history [get]
+func (h *TaskHandler) history(c *gin.Context) {
+	id := c.Param("id")
+	page, err := parsePage(c)
+	if err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	res, cursor, err := h.taskerService.GetTaskHistory(c, id, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorInvalidCursor):
+			response.BadRequest(c, err, nil)
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OKPage(c, res, cursor.Next, cursor.Prev)
+}
+
+// listSubtasks godoc
+//	@Summary		List subtasks
+//	@Description	Get the direct subtasks of a task
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Task ID"
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			after	query		string	false	"Cursor of the next page"
+//	@Param			before	query		string	false	"Cursor of the previous page"
+//	@Param			include_deleted	query		bool	false	"Include soft deleted rows, admin only"
+//	@Success		200		{array}		task.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/tasks/{id}/subtasks [get]
+func (h *TaskHandler) subtasks(c *gin.Context) {
+	id := c.Param("id")
+	page, err := parsePage(c)
+	if err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	res, cursor, err := h.taskerService.ListSubtasks(c, id, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, store.ErrorInvalidCursor):
+			response.BadRequest(c, err, nil)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OKPage(c, res, cursor.Next, cursor.Prev)
+}
+
+// taskTree godoc
+//	@Summary		Get task tree
+//	@Description	Get a task with its subtasks at any depth. Progress is 0 or 100 for a task without subtasks
+//	@Description	and the mean progress of the subtasks otherwise.
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Task ID"
+//	@Param			include_deleted	query		bool	false	"Include soft deleted rows, admin only"
+//	@Success		200	{object}	task.Node
+//	@Failure		403	{object}	response.Object
+//	@Failure		404	{object}	response.Object
+//	@Failure		500	{object}	response.Object
+//	@Router			/tasks/{id}/tree [get]
+func (h *TaskHandler) tree(c *gin.Context) {
+	id := c.Param("id")
+
+	res, err := h.taskerService.GetTaskTree(c, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, res)
+}
+
 // searchTasks godoc
 //	@Summary		Search tasks
-//	@Description	Search tasks by title, priority, status, assignee_id, or project_id
+//	@Description	Search tasks with filter expressions: field=value, field!=value, field>value, field>=value,
+//	@Description	field<value, field<=value, field~substring and field=in:a,b. Sort with sort=-priority,created_at.
+//	@Description	Fields: id, title, description, priority, status, assignee_id, project_id, milestone_id, start_date, due_date, completed_at, created_at, updated_at.
+//	@Description	Labels: label=bug, label!=bug, any of label=in:bug,ui, all of label=all:bug,ui.
 //	@Tags			tasks
 //	@Accept			json
 //	@Produce		json
-//	@Param			title		query		string	false	"Task Title"
-//	@Param			priority	query		string	false	"Task Priority"
-//	@Param			status		query		string	false	"Task Status"
+//	@Param			title		query		string	false	"Task Title, e.g. title~login"
+//	@Param			priority	query		string	false	"Task Priority, e.g. priority!=Low"
+//	@Param			status		query		string	false	"Task Status, e.g. status=in:Active,Review"
 //	@Param			assignee_id	query		string	false	"Assignee ID"
 //	@Param			project_id	query		string	false	"Project ID"
+//	@Param			label		query		string	false	"Label Name, e.g. label=in:bug,ui or label=all:bug,ui"
+//	@Param			sort		query		string	false	"Sort fields, prefixed with - for descending order"
+//	@Param			limit		query		int		false	"Page size"
+//	@Param			after		query		string	false	"Cursor of the next page"
+//	@Param			before		query		string	false	"Cursor of the previous page"
+//	@Param			include_deleted	query		bool	false	"Include soft deleted rows, admin only"
 //	@Success		200			{array}		task.Response
-//	@Failure		400			{object}	response.Object
+//	@Failure		400			{object}	response.Object{data=task.FilterError}
+//	@Failure		403			{object}	response.Object{data=task.FilterError}
 //	@Failure		500			{object}	response.Object
 //	@Router			/tasks/search [get]
 func (h *TaskHandler) search(c *gin.Context) {
-	data := task.Request{
-		Title:      helpers.GetStringPtr(c.Query("title")),
-		Priority:   helpers.GetStringPtr(c.Query("priority")),
-		Status:     helpers.GetStringPtr(c.Query("status")),
-		AssigneeID: helpers.GetStringPtr(c.Query("assignee_id")),
-		ProjectID:  helpers.GetStringPtr(c.Query("project_id")),
-	}
-	if task.IsEmpty(data) {
+	filter, err := task.ParseFilter(c.Request.URL.RawQuery)
+	if err != nil {
+		response.BadRequest(c, err, err)
+		return
+	}
+	if len(filter.Conditions) == 0 {
 		response.BadRequest(c, errors.New("query parameters required"), nil)
 		return
 	}
 
-	res, err := h.taskerService.SearchTasks(c, data)
+	page, err := parsePage(c)
 	if err != nil {
-		response.InternalServerError(c, err)
+		response.BadRequest(c, err, nil)
 		return
 	}
 
-	response.OK(c, res)
+	res, cursor, err := h.taskerService.SearchTasks(c, filter, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorInvalidCursor):
+			response.BadRequest(c, err, nil)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OKPage(c, res, cursor.Next, cursor.Prev)
+}
+
+func isParentError(err error) bool {
+	return errors.Is(err, task.ErrorUnknownParent) ||
+		errors.Is(err, task.ErrorParentProject) ||
+		errors.Is(err, task.ErrorParentCycle) ||
+		errors.Is(err, task.ErrorMoveSubtasks)
 }
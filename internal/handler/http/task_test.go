@@ -4,19 +4,20 @@ import (
 	"bytes"
 	"context"
 	"errors"
-	"fmt"
 	"hard/pkg/helpers"
 	"hard/pkg/store"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
-	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"hard/internal/domain/member"
 	"hard/internal/domain/task"
+	"hard/internal/domain/workflow"
 	"hard/internal/service/tasker"
 )
 
@@ -24,9 +25,9 @@ type MockTaskRepository struct {
 	mock.Mock
 }
 
-func (m *MockTaskRepository) List(ctx context.Context) (dest []task.Entity, err error) {
-	args := m.Called(ctx)
-	return args.Get(0).([]task.Entity), args.Error(1)
+func (m *MockTaskRepository) List(ctx context.Context, page store.Page) (dest []task.Entity, cursor store.Cursor, err error) {
+	args := m.Called(ctx, page)
+	return args.Get(0).([]task.Entity), args.Get(1).(store.Cursor), args.Error(2)
 }
 
 func (m *MockTaskRepository) Add(ctx context.Context, data task.Entity) (id string, err error) {
@@ -34,6 +35,11 @@ func (m *MockTaskRepository) Add(ctx context.Context, data task.Entity) (id stri
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockTaskRepository) AddMany(ctx context.Context, data []task.Entity) (ids []string, err error) {
+	args := m.Called(ctx, data)
+	return args.Get(0).([]string), args.Error(1)
+}
+
 func (m *MockTaskRepository) Get(ctx context.Context, id string) (dest task.Entity, err error) {
 	args := m.Called(ctx, id)
 
@@ -50,16 +56,75 @@ func (m *MockTaskRepository) Update(ctx context.Context, id string, dest task.En
 	return args.Error(0)
 }
 
-func (m *MockTaskRepository) Delete(ctx context.Context, id string) (err error) {
+func (m *MockTaskRepository) Delete(ctx context.Context, id string, version *int) (err error) {
+	args := m.Called(ctx, id, version)
+	return args.Error(0)
+}
+
+func (m *MockTaskRepository) Restore(ctx context.Context, id string) (err error) {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *MockTaskRepository) Search(ctx context.Context, data task.Entity) (dest []task.Entity, err error) {
-	args := m.Called(ctx, data)
+func (m *MockTaskRepository) Purge(ctx context.Context, retention time.Duration) (n int64, err error) {
+	args := m.Called(ctx, retention)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTaskRepository) Search(ctx context.Context, filter task.Filter, page store.Page) (dest []task.Entity, cursor store.Cursor, err error) {
+	args := m.Called(ctx, filter, page)
+	return args.Get(0).([]task.Entity), args.Get(1).(store.Cursor), args.Error(2)
+}
+
+func (m *MockTaskRepository) ListSubtasks(ctx context.Context, id string, page store.Page) (dest []task.Entity, cursor store.Cursor, err error) {
+	args := m.Called(ctx, id, page)
+	return args.Get(0).([]task.Entity), args.Get(1).(store.Cursor), args.Error(2)
+}
+
+func (m *MockTaskRepository) Descendants(ctx context.Context, id string) (dest []task.Entity, err error) {
+	args := m.Called(ctx, id)
 	return args.Get(0).([]task.Entity), args.Error(1)
 }
 
+func (m *MockTaskRepository) Ancestors(ctx context.Context, id string) (dest []string, err error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockTaskRepository) ListByProject(ctx context.Context, projectID string) (dest []task.Entity, err error) {
+	args := m.Called(ctx, projectID)
+	return args.Get(0).([]task.Entity), args.Error(1)
+}
+
+func (m *MockTaskRepository) ListByProjects(ctx context.Context, projectIDs []string, limit int) (dest map[string][]task.Entity, err error) {
+	args := m.Called(ctx, projectIDs, limit)
+	return args.Get(0).(map[string][]task.Entity), args.Error(1)
+}
+
+func (m *MockTaskRepository) ListByAssignees(ctx context.Context, assigneeIDs []string, limit int) (dest map[string][]task.Entity, err error) {
+	args := m.Called(ctx, assigneeIDs, limit)
+	return args.Get(0).(map[string][]task.Entity), args.Error(1)
+}
+
+type MockWorkflowRepository struct {
+	mock.Mock
+}
+
+func (m *MockWorkflowRepository) Get(ctx context.Context, projectID string) (dest workflow.Entity, err error) {
+	args := m.Called(ctx, projectID)
+	return args.Get(0).(workflow.Entity), args.Error(1)
+}
+
+func (m *MockWorkflowRepository) Save(ctx context.Context, data workflow.Entity) (err error) {
+	args := m.Called(ctx, data)
+	return args.Error(0)
+}
+
+func (m *MockWorkflowRepository) Delete(ctx context.Context, projectID string) (err error) {
+	args := m.Called(ctx, projectID)
+	return args.Error(0)
+}
+
 func TestList(t *testing.T) {
 	mockTasks := []task.Entity{
 		{
@@ -84,7 +149,9 @@ func TestList(t *testing.T) {
 
 	tests := []struct {
 		name           string
+		query          string
 		mockRepoOutput []task.Entity
+		mockRepoCursor store.Cursor
 		mockRepoError  error
 		expectedStatus int
 		expectedBody   string
@@ -107,6 +174,30 @@ func TestList(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectedBody:   `{"data":[],"success":true}`,
 		},
+		{
+			name:           "Paginated List",
+			query:          "?limit=1&after=" + store.EncodeCursor("1"),
+			mockRepoOutput: mockTasks[1:],
+			mockRepoCursor: store.Cursor{Next: store.EncodeCursor("2"), Prev: store.EncodeCursor("2")},
+			mockRepoError:  nil,
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":[{"id":"2","title":"Task 2","description":"Description of Task 2",
+				"priority":"Medium","status":"Pending","assignee_id":"2","project_id":"2","completed_at":""}],
+				"next_cursor":"` + store.EncodeCursor("2") + `","prev_cursor":"` + store.EncodeCursor("2") + `","success":true}`,
+		},
+		{
+			name:           "Invalid Limit",
+			query:          "?limit=0",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"limit: must be between 1 and 100","success":false}`,
+		},
+		{
+			name:           "Invalid Cursor",
+			query:          "?after=garbage",
+			mockRepoError:  store.ErrorInvalidCursor,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"invalid cursor","success":false}`,
+		},
 		{
 			name:           "Internal Server Error",
 			mockRepoOutput: nil,
@@ -119,7 +210,7 @@ func TestList(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockTaskRepository)
-			mockRepo.On("List", mock.Anything).Return(tt.mockRepoOutput, tt.mockRepoError)
+			mockRepo.On("List", mock.Anything, mock.Anything).Return(tt.mockRepoOutput, tt.mockRepoCursor, tt.mockRepoError)
 
 			taskService, _ := tasker.New(tasker.WithTaskRepository(mockRepo))
 			taskHandler := NewTaskHandler(taskService)
@@ -129,7 +220,7 @@ func TestList(t *testing.T) {
 			r.GET("/tasks", taskHandler.list)
 
 			w := httptest.NewRecorder()
-			req := httptest.NewRequest("GET", "/tasks", nil)
+			req := httptest.NewRequest("GET", "/tasks"+tt.query, nil)
 			r.ServeHTTP(w, req)
 
 			assert.Equal(t, tt.expectedStatus, w.Code)
@@ -170,7 +261,7 @@ func TestAdd(t *testing.T) {
 			mockRepoOutput: "new-task-id",
 			mockRepoError:  nil,
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   `{"data":{"id":"","title":null,"description":"A new task description","priority":"High","status":"Active","assignee_id":"1","project_id":"2","completed_at":null},"message":"title: cannot be blank","success":false}`,
+			expectedBody:   `{"data":{"id":"","title":null,"description":"A new task description","priority":"High","status":"Active","assignee_id":"1","project_id":"2","parent_id":null,"start_date":null,"due_date":null,"estimate_hours":null,"milestone_id":null,"completed_at":null},"message":"title: cannot be blank","success":false}`,
 		},
 		{
 			name:           "Invalid JSON Payload",
@@ -179,7 +270,7 @@ func TestAdd(t *testing.T) {
 			mockRepoOutput: "",
 			mockRepoError:  nil,
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   `{"data":{"id":"","title":null,"description":null,"priority":null,"status":null,"assignee_id":null,"project_id":null,"completed_at":null},"message":"invalid character '}' looking for beginning of object key string","success":false}`,
+			expectedBody:   `{"data":{"id":"","title":null,"description":null,"priority":null,"status":null,"assignee_id":null,"project_id":null,"parent_id":null,"start_date":null,"due_date":null,"estimate_hours":null,"milestone_id":null,"completed_at":null},"message":"invalid character '}' looking for beginning of object key string","success":false}`,
 		},
 		{
 			name:           "Internal Server Error",
@@ -205,12 +296,23 @@ func TestAdd(t *testing.T) {
 
 			mockRepo.On("Add", mock.Anything, tt.inputData).Return(tt.mockRepoOutput, tt.mockRepoError)
 
-			taskService, _ := tasker.New(tasker.WithTaskRepository(mockRepo))
+			mockWorkflowRepo := new(MockWorkflowRepository)
+			mockWorkflowRepo.On("Get", mock.Anything, mock.Anything).Return(workflow.Entity{}, store.ErrorNotFound)
+
+			mockMemberRepo := new(MockMemberRepository)
+			mockMemberRepo.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(member.Entity{}, nil)
+
+			taskService, _ := tasker.New(
+				tasker.WithTaskRepository(mockRepo),
+				tasker.WithWorkflowRepository(mockWorkflowRepo),
+				tasker.WithMemberRepository(mockMemberRepo),
+				tasker.WithUserRepository(mockAdmin()),
+			)
 
 			taskHandler := NewTaskHandler(taskService)
 
 			gin.SetMode(gin.TestMode)
-			r := gin.New()
+			r := authenticated("admin-id")
 			r.POST("/tasks", taskHandler.add)
 
 			w := httptest.NewRecorder()
@@ -294,16 +396,32 @@ func TestGet(t *testing.T) {
 }
 
 func TestUpdate(t *testing.T) {
+	today := time.Now().Format("2006-01-02")
+	active := task.Entity{ID: "mock-task-id", Status: helpers.GetStringPtr("Active"), ProjectID: helpers.GetStringPtr("3")}
+	done := task.Entity{ID: "mock-task-id", Status: helpers.GetStringPtr("Done"), ProjectID: helpers.GetStringPtr("1"), CompletedAt: &today}
+	custom := workflow.Entity{ProjectID: "3", Definition: workflow.Definition{
+		Statuses:    []string{"Active", "InProgress", "Done"},
+		Transitions: map[string][]string{"Active": {"InProgress"}, "InProgress": {"Done"}},
+		Terminal:    []string{"Done"},
+	}}
+
 	tests := []struct {
-		name           string
-		inputBody      string
-		mockRepoError  error
-		expectedStatus int
-		expectedBody   string
+		name                string
+		inputBody           string
+		mockCurrent         task.Entity
+		mockStale           *task.Entity
+		mockWorkflow        workflow.Entity
+		mockWorkflowError   error
+		mockRepoError       error
+		expectedCompletedAt *string
+		expectedStatus      int
+		expectedBody        string
 	}{
 		{
 			name:           "Successful Update",
 			inputBody:      `{"title":"Updated Task","description":"This is an updated task","priority":"Medium","status":"InProgress","assignee_id":"2","project_id":"3"}`,
+			mockCurrent:    active,
+			mockWorkflow:   custom,
 			mockRepoError:  nil,
 			expectedStatus: http.StatusOK,
 			expectedBody:   `{"data":"ok","success":true}`,
@@ -313,18 +431,80 @@ func TestUpdate(t *testing.T) {
 			inputBody:      `{"title":"Updated Task","description":"This is an updated task","priority":"Medium","status":"InProgress","assignee_id":"2","project_id":"3",}`,
 			mockRepoError:  nil,
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   `{"data":{"id":"","title":null,"description":null,"priority":null,"status":null,"assignee_id":null,"project_id":null,"completed_at":null},"message":"invalid character '}' looking for beginning of object key string","success":false}`,
+			expectedBody:   `{"data":{"id":"","title":null,"description":null,"priority":null,"status":null,"assignee_id":null,"project_id":null,"parent_id":null,"start_date":null,"due_date":null,"estimate_hours":null,"milestone_id":null,"completed_at":null},"message":"invalid character '}' looking for beginning of object key string","success":false}`,
+		},
+		{
+			name:           "Invalid Priority",
+			inputBody:      `{"priority":"Urgent"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"data":{"id":"","title":null,"description":null,"priority":"Urgent","status":null,"assignee_id":null,"project_id":null,"parent_id":null,"start_date":null,"due_date":null,"estimate_hours":null,"milestone_id":null,"completed_at":null},"message":"priority: must be one of Low, Medium, High","success":false}`,
 		},
 		{
 			name:           "Task Not Found",
 			inputBody:      `{"title":"Updated Task","description":"This is an updated task","priority":"Medium","status":"InProgress","assignee_id":"2","project_id":"3"}`,
+			mockCurrent:    active,
+			mockWorkflow:   custom,
 			mockRepoError:  store.ErrorNotFound,
 			expectedStatus: http.StatusNotFound,
 			expectedBody:   `{"message":"error not found","success":false}`,
 		},
+		{
+			name:           "Illegal Transition",
+			inputBody:      `{"status":"Done"}`,
+			mockCurrent:    active,
+			mockWorkflow:   custom,
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"status transition is not allowed by the project workflow: \"Active\" -> \"Done\"","success":false}`,
+		},
+		{
+			name:           "Transition From The Locked Status",
+			inputBody:      `{"status":"InProgress"}`,
+			mockCurrent:    task.Entity{ID: "mock-task-id", Status: helpers.GetStringPtr("Done"), ProjectID: helpers.GetStringPtr("3")},
+			mockStale:      &active,
+			mockWorkflow:   custom,
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"status transition is not allowed by the project workflow: \"Done\" -> \"InProgress\"","success":false}`,
+		},
+		{
+			name:              "Unknown Status",
+			inputBody:         `{"status":"done"}`,
+			mockCurrent:       done,
+			mockWorkflowError: store.ErrorNotFound,
+			expectedStatus:    http.StatusUnprocessableEntity,
+			expectedBody:      `{"message":"status is not defined by the project workflow: \"done\", allowed: [Active Review Done]","success":false}`,
+		},
+		{
+			name:                "Completing Sets Completed At",
+			inputBody:           `{"status":"Done"}`,
+			mockCurrent:         task.Entity{ID: "mock-task-id", Status: helpers.GetStringPtr("Review"), ProjectID: helpers.GetStringPtr("1")},
+			mockWorkflowError:   store.ErrorNotFound,
+			expectedCompletedAt: &today,
+			expectedStatus:      http.StatusOK,
+			expectedBody:        `{"data":"ok","success":true}`,
+		},
+		{
+			name:                "Reopening Clears Completed At",
+			inputBody:           `{"status":"Active"}`,
+			mockCurrent:         done,
+			mockWorkflowError:   store.ErrorNotFound,
+			expectedCompletedAt: helpers.GetStringPtr(""),
+			expectedStatus:      http.StatusOK,
+			expectedBody:        `{"data":"ok","success":true}`,
+		},
+		{
+			name:                "Only Completed At",
+			inputBody:           `{"completed_at":"2024-05-01"}`,
+			mockCurrent:         active,
+			mockWorkflow:        custom,
+			expectedCompletedAt: helpers.GetStringPtr("2024-05-01"),
+			expectedStatus:      http.StatusOK,
+			expectedBody:        `{"data":"ok","success":true}`,
+		},
 		{
 			name:           "Internal Server Error",
 			inputBody:      `{"title":"Updated Task","description":"This is an updated task","priority":"Medium","status":"InProgress","assignee_id":"2","project_id":"3"}`,
+			mockCurrent:    active,
+			mockWorkflow:   custom,
 			mockRepoError:  errors.New("repository error"),
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody:   `{"message":"repository error","success":false}`,
@@ -334,13 +514,38 @@ func TestUpdate(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockTaskRepository)
-			mockRepo.On("Update", mock.Anything, "mock-task-id", mock.AnythingOfType("task.Entity")).Return(tt.mockRepoError)
-
-			taskService, _ := tasker.New(tasker.WithTaskRepository(mockRepo))
+			if tt.mockStale != nil {
+				// another writer changed the task, only the locked read sees it
+				locked := mock.MatchedBy(func(ctx context.Context) bool { return store.Locked(ctx) != "" })
+				mockRepo.On("Get", locked, "mock-task-id").Return(tt.mockCurrent, nil)
+				mockRepo.On("Get", mock.Anything, "mock-task-id").Return(*tt.mockStale, nil)
+			}
+			mockRepo.On("Get", mock.Anything, "mock-task-id").Return(tt.mockCurrent, nil)
+			mockRepo.On("Update", mock.Anything, "mock-task-id", mock.MatchedBy(func(data task.Entity) bool {
+				return tt.expectedCompletedAt == nil || data.CompletedAt != nil && *data.CompletedAt == *tt.expectedCompletedAt
+			})).Return(tt.mockRepoError)
+			mockRepo.On("Descendants", mock.Anything, "mock-task-id").Return([]task.Entity{}, nil)
+
+			mockWorkflowRepo := new(MockWorkflowRepository)
+			mockWorkflowRepo.On("Get", mock.Anything, mock.Anything).Return(tt.mockWorkflow, tt.mockWorkflowError)
+
+			mockMemberRepo := new(MockMemberRepository)
+			mockMemberRepo.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(member.Entity{}, nil)
+
+			mockDependencyRepo := new(MockDependencyRepository)
+			mockDependencyRepo.On("OpenBlockers", mock.Anything, []string{"mock-task-id"}).Return([]string{}, nil)
+
+			taskService, _ := tasker.New(
+				tasker.WithTaskRepository(mockRepo),
+				tasker.WithWorkflowRepository(mockWorkflowRepo),
+				tasker.WithMemberRepository(mockMemberRepo),
+				tasker.WithDependencyRepository(mockDependencyRepo),
+				tasker.WithUserRepository(mockAdmin()),
+			)
 			taskHandler := NewTaskHandler(taskService)
 
 			gin.SetMode(gin.TestMode)
-			r := gin.New()
+			r := authenticated("admin-id")
 			r.PUT("/tasks/:id", taskHandler.update)
 
 			w := httptest.NewRecorder()
@@ -389,13 +594,14 @@ func TestDelete(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockTaskRepository)
-			mockRepo.On("Delete", mock.Anything, tt.taskID).Return(tt.mockRepoError)
+			mockRepo.On("Get", mock.Anything, tt.taskID).Return(task.Entity{ID: tt.taskID}, nil)
+			mockRepo.On("Delete", mock.Anything, tt.taskID, mock.Anything).Return(tt.mockRepoError)
 
-			taskService, _ := tasker.New(tasker.WithTaskRepository(mockRepo))
+			taskService, _ := tasker.New(tasker.WithTaskRepository(mockRepo), tasker.WithUserRepository(mockAdmin()))
 			taskHandler := NewTaskHandler(taskService)
 
 			gin.SetMode(gin.TestMode)
-			r := gin.New()
+			r := authenticated("admin-id")
 			r.DELETE("/tasks/:id", taskHandler.delete)
 
 			w := httptest.NewRecorder()
@@ -411,21 +617,23 @@ func TestDelete(t *testing.T) {
 func TestSearch(t *testing.T) {
 	tests := []struct {
 		name           string
-		queryParams    map[string]string
+		query          string
+		expectedFilter task.Filter
 		mockRepoOutput []task.Entity
 		mockRepoError  error
 		expectedStatus int
 		expectedBody   string
 	}{
 		{
-			name: "Successful Search",
-			queryParams: map[string]string{
-				"title":       "test",
-				"priority":    "High",
-				"status":      "Active",
-				"assignee_id": "1",
-				"project_id":  "2",
-			},
+			name:  "Successful Search",
+			query: "title=test&priority=High&status=Active&assignee_id=1&project_id=2",
+			expectedFilter: task.Filter{Conditions: []task.Condition{
+				{Field: task.FieldTitle, Operator: task.OperatorEqual, Values: []string{"test"}},
+				{Field: task.FieldPriority, Operator: task.OperatorEqual, Values: []string{"High"}},
+				{Field: task.FieldStatus, Operator: task.OperatorEqual, Values: []string{"Active"}},
+				{Field: task.FieldAssigneeID, Operator: task.OperatorEqual, Values: []string{"1"}},
+				{Field: task.FieldProjectID, Operator: task.OperatorEqual, Values: []string{"2"}},
+			}},
 			mockRepoOutput: []task.Entity{
 				{
 					ID:          "1",
@@ -443,27 +651,96 @@ func TestSearch(t *testing.T) {
 			expectedBody: `{"data":[{"id":"1","title":"test task","description":"A test task description",
 			"priority":"High","status":"Active","assignee_id":"1","project_id":"2","completed_at":""}],"success":true}`,
 		},
+		{
+			name:  "Operators And Sort",
+			query: "status=in:Active,Review&priority!=Low&completed_at%3E=2024-01-01&title~login&sort=-priority,created_at&limit=10",
+			expectedFilter: task.Filter{
+				Conditions: []task.Condition{
+					{Field: task.FieldStatus, Operator: task.OperatorIn, Values: []string{"Active", "Review"}},
+					{Field: task.FieldPriority, Operator: task.OperatorNotEqual, Values: []string{"Low"}},
+					{Field: task.FieldCompletedAt, Operator: task.OperatorGreaterEqual, Values: []string{"2024-01-01"}},
+					{Field: task.FieldTitle, Operator: task.OperatorContains, Values: []string{"login"}},
+				},
+				Sort: []task.Order{
+					{Field: task.FieldPriority, Desc: true},
+					{Field: task.FieldCreatedAt},
+				},
+			},
+			mockRepoOutput: []task.Entity{},
+			mockRepoError:  nil,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[],"success":true}`,
+		},
+		{
+			name:  "Labels Any And All",
+			query: "label=in:bug,ui&label=all:backend,api&label!=wontfix",
+			expectedFilter: task.Filter{
+				Conditions: []task.Condition{
+					{Field: task.FieldLabel, Operator: task.OperatorIn, Values: []string{"bug", "ui"}},
+					{Field: task.FieldLabel, Operator: task.OperatorAll, Values: []string{"backend", "api"}},
+					{Field: task.FieldLabel, Operator: task.OperatorNotEqual, Values: []string{"wontfix"}},
+				},
+			},
+			mockRepoOutput: []task.Entity{},
+			mockRepoError:  nil,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[],"success":true}`,
+		},
+		{
+			name:           "Labels Are Not Sortable",
+			query:          "label=bug&sort=label",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"data":{"field":"sort","value":"label","reason":"field is not sortable"},"message":"sort: field is not sortable","success":false}`,
+		},
+		{
+			name:           "All Of Is Only For Labels",
+			query:          "status=all:Active,Review",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"data":{"field":"status","operator":"all","reason":"operator not supported for field"},"message":"status: operator not supported for field","success":false}`,
+		},
 		{
 			name:           "Missing Query Parameters",
-			queryParams:    map[string]string{},
+			query:          "",
 			mockRepoOutput: nil,
 			mockRepoError:  nil,
 			expectedStatus: http.StatusBadRequest,
 			expectedBody:   `{"message":"query parameters required","success":false}`,
 		},
 		{
-			name:           "Empty Result Search",
-			queryParams:    map[string]string{"title": "nonexistent"},
+			name:           "Unknown Field",
+			query:          "owner=1",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"data":{"field":"owner","reason":"unknown field"},"message":"owner: unknown field","success":false}`,
+		},
+		{
+			name:           "Unsupported Operator",
+			query:          "completed_at~2024",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"data":{"field":"completed_at","operator":"~","reason":"operator not supported for field"},"message":"completed_at: operator not supported for field","success":false}`,
+		},
+		{
+			name:           "Invalid Value",
+			query:          "assignee_id=in:1,me",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"data":{"field":"assignee_id","operator":"in","value":"me","reason":"value must be a number"},"message":"assignee_id: value must be a number","success":false}`,
+		},
+		{
+			name:  "Empty Result Search",
+			query: "title=nonexistent",
+			expectedFilter: task.Filter{Conditions: []task.Condition{
+				{Field: task.FieldTitle, Operator: task.OperatorEqual, Values: []string{"nonexistent"}},
+			}},
 			mockRepoOutput: []task.Entity{},
 			mockRepoError:  nil,
 			expectedStatus: http.StatusOK,
 			expectedBody:   `{"data":[],"success":true}`,
 		},
 		{
-			name: "Internal Server Error",
-			queryParams: map[string]string{
-				"title": "test",
-			},
+			name:  "Internal Server Error",
+			query: "title=test",
+			expectedFilter: task.Filter{Conditions: []task.Condition{
+				{Field: task.FieldTitle, Operator: task.OperatorEqual, Values: []string{"test"}},
+			}},
 			mockRepoOutput: nil,
 			mockRepoError:  errors.New("repository error"),
 			expectedStatus: http.StatusInternalServerError,
@@ -474,15 +751,9 @@ func TestSearch(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockTaskRepository)
-			mockRepo.On("Search", mock.Anything, mock.MatchedBy(func(data task.Entity) bool {
-				return reflect.DeepEqual(data, task.Entity{
-					Title:      helpers.GetStringPtr(tt.queryParams["title"]),
-					Priority:   helpers.GetStringPtr(tt.queryParams["priority"]),
-					Status:     helpers.GetStringPtr(tt.queryParams["status"]),
-					AssigneeID: helpers.GetStringPtr(tt.queryParams["assignee_id"]),
-					ProjectID:  helpers.GetStringPtr(tt.queryParams["project_id"]),
-				})
-			})).Return(tt.mockRepoOutput, tt.mockRepoError)
+			mockRepo.On("Search", mock.Anything, mock.MatchedBy(func(filter task.Filter) bool {
+				return reflect.DeepEqual(filter, tt.expectedFilter)
+			}), mock.Anything).Return(tt.mockRepoOutput, store.Cursor{}, tt.mockRepoError)
 
 			taskService, _ := tasker.New(tasker.WithTaskRepository(mockRepo))
 			taskHandler := NewTaskHandler(taskService)
@@ -491,9 +762,8 @@ func TestSearch(t *testing.T) {
 			r := gin.New()
 			r.GET("/tasks/search", taskHandler.search)
 
-			url := "/tasks/search?" + encodeQueryParams(tt.queryParams)
 			w := httptest.NewRecorder()
-			req := httptest.NewRequest("GET", url, nil)
+			req := httptest.NewRequest("GET", "/tasks/search?"+tt.query, nil)
 			r.ServeHTTP(w, req)
 
 			assert.Equal(t, tt.expectedStatus, w.Code)
@@ -501,11 +771,3 @@ func TestSearch(t *testing.T) {
 		})
 	}
 }
-
-func encodeQueryParams(params map[string]string) string {
-	var encodedParams []string
-	for key, value := range params {
-		encodedParams = append(encodedParams, fmt.Sprintf("%s=%s", key, value))
-	}
-	return strings.Join(encodedParams, "&")
-}
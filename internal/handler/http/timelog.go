@@ -0,0 +1,325 @@
+package http
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"hard/internal/domain/access"
+	"hard/internal/domain/timelog"
+	"hard/pkg/server/response"
+	"hard/pkg/store"
+	"io"
+)
+
+// startTimer godoc
+//
+//	@Summary		Start a timer
+//	@Description	Start tracking the time of the caller on a task, a user has at most one running timer
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"Task ID"
+//	@Param			timer	body		timelog.TimerRequest	false	"Timer Request"
+//	@Success		201		{object}	timelog.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		409		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/tasks/{id}/timer/start [post]
+func (h *TaskHandler) startTimer(c *gin.Context) {
+	id := c.Param("id")
+	req := timelog.TimerRequest{}
+
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	res, err := h.taskerService.StartTimer(c, id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		case errors.Is(err, timelog.ErrorRunning):
+			response.Conflict(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.Created(c, res)
+}
+
+// stopTimer godoc
+//
+//	@Summary		Stop a timer
+//	@Description	Stop the running timer of the caller on a task, the note replaces the one given at start
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"Task ID"
+//	@Param			timer	body		timelog.TimerRequest	false	"Timer Request"
+//	@Success		200		{object}	timelog.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		409		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/tasks/{id}/timer/stop [post]
+func (h *TaskHandler) stopTimer(c *gin.Context) {
+	id := c.Param("id")
+	req := timelog.TimerRequest{}
+
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	res, err := h.taskerService.StopTimer(c, id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		case errors.Is(err, timelog.ErrorNotRunning):
+			response.Conflict(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, res)
+}
+
+// taskTime godoc
+//
+//	@Summary		Task time summary
+//	@Description	Get the time logged on a task per user compared with the task estimate, running timers count up to now
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Task ID"
+//	@Success		200	{object}	timelog.TaskSummary
+//	@Failure		404	{object}	response.Object
+//	@Failure		500	{object}	response.Object
+//	@Router			/tasks/{id}/time [get]
+func (h *TaskHandler) taskTime(c *gin.Context) {
+	id := c.Param("id")
+
+	res, err := h.taskerService.GetTaskTime(c, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, res)
+}
+
+// listTimeEntries godoc
+//
+//	@Summary		List time entries
+//	@Description	Get the time entries of a task, including running timers
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Task ID"
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			after	query		string	false	"Cursor of the next page"
+//	@Param			before	query		string	false	"Cursor of the previous page"
+//	@Success		200		{array}		timelog.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/tasks/{id}/time/entries [get]
+func (h *TaskHandler) listTimeEntries(c *gin.Context) {
+	id := c.Param("id")
+	page, err := parsePage(c)
+	if err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	res, cursor, err := h.taskerService.ListTimeEntries(c, id, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, store.ErrorInvalidCursor):
+			response.BadRequest(c, err, nil)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OKPage(c, res, cursor.Next, cursor.Prev)
+}
+
+// logTime godoc
+//
+//	@Summary		Log time
+//	@Description	Log time the caller spent on a task without a timer, the end is given as ended_at or duration_minutes
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string			true	"Task ID"
+//	@Param			entry	body		timelog.Request	true	"Time Entry Request"
+//	@Success		201		{object}	timelog.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/tasks/{id}/time/entries [post]
+func (h *TaskHandler) logTime(c *gin.Context) {
+	id := c.Param("id")
+	req := timelog.Request{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	res, err := h.taskerService.LogTime(c, id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.Created(c, res)
+}
+
+// deleteTimeEntry godoc
+//
+//	@Summary		Delete a time entry
+//	@Description	Delete a time entry of a task, deleting a running timer discards it
+//	@Tags			tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string	true	"Task ID"
+//	@Param			entry_id	path		string	true	"Time Entry ID"
+//	@Success		200			{string}	string	"Deleted Time Entry ID"
+//	@Failure		403			{object}	response.Object
+//	@Failure		404			{object}	response.Object
+//	@Failure		500			{object}	response.Object
+//	@Router			/tasks/{id}/time/entries/{entry_id} [delete]
+func (h *TaskHandler) deleteTimeEntry(c *gin.Context) {
+	id, entryID := c.Param("id"), c.Param("entry_id")
+
+	if err := h.taskerService.DeleteTimeEntry(c, id, entryID); err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, entryID)
+}
+
+// projectTime godoc
+//
+//	@Summary		Project time summary
+//	@Description	Roll the time logged on the project tasks up and compare it with the sum of their estimates
+//	@Tags			projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Project ID"
+//	@Success		200	{object}	timelog.ProjectSummary
+//	@Failure		404	{object}	response.Object
+//	@Failure		500	{object}	response.Object
+//	@Router			/projects/{id}/time [get]
+func (h *ProjectHandler) projectTime(c *gin.Context) {
+	id := c.Param("id")
+
+	res, err := h.taskerService.GetProjectTime(c, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, res)
+}
+
+// userTime godoc
+//
+//	@Summary		User time summary
+//	@Description	Get the time a user logged per task and project, entries are matched by the date they started
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"User ID"
+//	@Param			from	query		string	false	"First date, inclusive"	example(2024-05-01)
+//	@Param			to		query		string	false	"Last date, inclusive"	example(2024-05-31)
+//	@Success		200		{object}	timelog.UserSummary
+//	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/users/{id}/time [get]
+func (h *UserHandler) userTime(c *gin.Context) {
+	id := c.Param("id")
+	filter := timelog.Filter{
+		From: c.Query("from"),
+		To:   c.Query("to"),
+	}
+	if err := filter.Validate(); err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	res, err := h.taskerService.GetUserTime(c, id, filter)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, res)
+}
@@ -0,0 +1,413 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"hard/internal/domain/member"
+	"hard/internal/domain/project"
+	"hard/internal/domain/task"
+	"hard/internal/domain/timelog"
+	"hard/internal/domain/user"
+	"hard/pkg/helpers"
+	"hard/pkg/store"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"hard/internal/service/tasker"
+)
+
+type MockTimeEntryRepository struct {
+	mock.Mock
+}
+
+func (m *MockTimeEntryRepository) List(ctx context.Context, taskID string, page store.Page) (dest []timelog.Entity, cursor store.Cursor, err error) {
+	args := m.Called(ctx, taskID, page)
+	return args.Get(0).([]timelog.Entity), args.Get(1).(store.Cursor), args.Error(2)
+}
+
+func (m *MockTimeEntryRepository) Get(ctx context.Context, id string) (dest timelog.Entity, err error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(timelog.Entity), args.Error(1)
+}
+
+func (m *MockTimeEntryRepository) GetRunning(ctx context.Context, userID string) (dest timelog.Entity, err error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(timelog.Entity), args.Error(1)
+}
+
+func (m *MockTimeEntryRepository) Add(ctx context.Context, data timelog.Entity) (id string, err error) {
+	args := m.Called(ctx, data)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTimeEntryRepository) Start(ctx context.Context, data timelog.Entity) (id string, err error) {
+	args := m.Called(ctx, data)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTimeEntryRepository) Stop(ctx context.Context, id string, note *string) (err error) {
+	args := m.Called(ctx, id, note)
+	return args.Error(0)
+}
+
+func (m *MockTimeEntryRepository) Delete(ctx context.Context, id string) (err error) {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTimeEntryRepository) Totals(ctx context.Context, filter timelog.Filter) (dest []timelog.Total, err error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]timelog.Total), args.Error(1)
+}
+
+func TestTimeTracking(t *testing.T) {
+	newEntry := func(id, userID, taskID, startedAt, endedAt string, seconds int64) timelog.Entity {
+		data := timelog.Entity{ID: id, UserID: userID, TaskID: taskID, StartedAt: helpers.GetStringPtr(startedAt)}
+		if endedAt != "" {
+			data.EndedAt = helpers.GetStringPtr(endedAt)
+			data.DurationSeconds = &seconds
+		}
+		return data
+	}
+	entries := map[string]timelog.Entity{
+		"7":  newEntry("7", "user-id", "1", "2024-05-01T09:00:00Z", "2024-05-01T11:00:00Z", 7200),
+		"8":  newEntry("8", "user-id", "1", "2024-05-02T09:00:00Z", "", 0),
+		"9":  newEntry("9", "other-id", "2", "2024-05-02T09:30:00Z", "", 0),
+		"10": newEntry("10", "admin-id", "1", "2024-05-02T10:00:00Z", "", 0),
+	}
+	stopped := newEntry("8", "user-id", "1", "2024-05-02T09:00:00Z", "2024-05-02T10:30:00Z", 5400)
+	estimate := func(hours float64) *float64 { return &hours }
+
+	tests := []struct {
+		name           string
+		caller         string
+		method         string
+		target         string
+		inputBody      string
+		expectedStatus int
+		expectedBody   string
+		expectedAdded  *timelog.Entity
+		expectedStart  bool
+		expectedStop   string
+		expectedDelete string
+	}{
+		{
+			name:           "Start Timer",
+			method:         "POST",
+			target:         "/tasks/1/timer/start",
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"data":{"id":"10","user_id":"admin-id","task_id":"1","started_at":"2024-05-02T10:00:00Z","duration_seconds":0,"running":true},"success":true}`,
+			expectedStart:  true,
+		},
+		{
+			name:           "Start Second Timer",
+			caller:         "user-id",
+			method:         "POST",
+			target:         "/tasks/3/timer/start",
+			inputBody:      `{"note":"switching"}`,
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"a timer is already running, stop it first","success":false}`,
+		},
+		{
+			name:           "Start Timer Concurrently",
+			caller:         "racer-id",
+			method:         "POST",
+			target:         "/tasks/1/timer/start",
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"a timer is already running, stop it first","success":false}`,
+			expectedStart:  true,
+		},
+		{
+			name:           "Start Timer On Missing Task",
+			method:         "POST",
+			target:         "/tasks/9/timer/start",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"error not found","success":false}`,
+		},
+		{
+			name:           "Stop Timer",
+			caller:         "user-id",
+			method:         "POST",
+			target:         "/tasks/1/timer/stop",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"id":"8","user_id":"user-id","task_id":"1","started_at":"2024-05-02T09:00:00Z","ended_at":"2024-05-02T10:30:00Z","duration_seconds":5400,"running":false},"success":true}`,
+			expectedStop:   "8",
+		},
+		{
+			name:           "Stop Timer Of Another Task",
+			caller:         "other-id",
+			method:         "POST",
+			target:         "/tasks/1/timer/stop",
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"no timer is running on the task","success":false}`,
+		},
+		{
+			name:           "Stop Without Timer",
+			method:         "POST",
+			target:         "/tasks/1/timer/stop",
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"no timer is running on the task","success":false}`,
+		},
+		{
+			name:           "Log Time With Duration",
+			caller:         "user-id",
+			method:         "POST",
+			target:         "/tasks/1/time/entries",
+			inputBody:      `{"started_at":"2024-05-03T14:00:00+02:00","duration_minutes":90,"note":"review"}`,
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"data":{"id":"11","user_id":"user-id","task_id":"1","started_at":"2024-05-03T12:00:00Z","ended_at":"2024-05-03T13:30:00Z","duration_seconds":5400,"note":"review","running":false},"success":true}`,
+			expectedAdded: &timelog.Entity{
+				UserID:          "user-id",
+				TaskID:          "1",
+				StartedAt:       helpers.GetStringPtr("2024-05-03T12:00:00Z"),
+				EndedAt:         helpers.GetStringPtr("2024-05-03T13:30:00Z"),
+				DurationSeconds: func() *int64 { n := int64(5400); return &n }(),
+				Note:            helpers.GetStringPtr("review"),
+			},
+		},
+		{
+			name:           "Log Time With End",
+			caller:         "user-id",
+			method:         "POST",
+			target:         "/tasks/1/time/entries",
+			inputBody:      `{"started_at":"2024-05-03T12:00:00Z","ended_at":"2024-05-03T12:45:00Z"}`,
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"data":{"id":"11","user_id":"user-id","task_id":"1","started_at":"2024-05-03T12:00:00Z","ended_at":"2024-05-03T12:45:00Z","duration_seconds":2700,"running":false},"success":true}`,
+			expectedAdded: &timelog.Entity{
+				UserID:          "user-id",
+				TaskID:          "1",
+				StartedAt:       helpers.GetStringPtr("2024-05-03T12:00:00Z"),
+				EndedAt:         helpers.GetStringPtr("2024-05-03T12:45:00Z"),
+				DurationSeconds: func() *int64 { n := int64(2700); return &n }(),
+			},
+		},
+		{
+			name:           "Log Time With End And Duration",
+			method:         "POST",
+			target:         "/tasks/1/time/entries",
+			inputBody:      `{"started_at":"2024-05-03T12:00:00Z","ended_at":"2024-05-03T12:45:00Z","duration_minutes":45}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"data":{"started_at":"2024-05-03T12:00:00Z","ended_at":"2024-05-03T12:45:00Z","duration_minutes":45,"note":null},"message":"ended_at: either ended_at or duration_minutes must be set","success":false}`,
+		},
+		{
+			name:           "Log Time Ending Before Start",
+			method:         "POST",
+			target:         "/tasks/1/time/entries",
+			inputBody:      `{"started_at":"2024-05-03T12:00:00Z","ended_at":"2024-05-03T11:00:00Z"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"data":{"started_at":"2024-05-03T12:00:00Z","ended_at":"2024-05-03T11:00:00Z","duration_minutes":null,"note":null},"message":"ended_at: cannot be before started_at","success":false}`,
+		},
+		{
+			name:           "Log More Than A Day",
+			method:         "POST",
+			target:         "/tasks/1/time/entries",
+			inputBody:      `{"started_at":"2024-05-03T12:00:00Z","duration_minutes":1500}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"data":{"started_at":"2024-05-03T12:00:00Z","ended_at":null,"duration_minutes":1500,"note":null},"message":"ended_at: an entry can log at most 24 hours","success":false}`,
+		},
+		{
+			name:           "List Time Entries",
+			method:         "GET",
+			target:         "/tasks/1/time/entries",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[{"id":"7","user_id":"user-id","task_id":"1","started_at":"2024-05-01T09:00:00Z","ended_at":"2024-05-01T11:00:00Z","duration_seconds":7200,"running":false},{"id":"8","user_id":"user-id","task_id":"1","started_at":"2024-05-02T09:00:00Z","duration_seconds":0,"running":true}],"success":true}`,
+		},
+		{
+			name:           "Task Time Over Estimate",
+			method:         "GET",
+			target:         "/tasks/1/time",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"task_id":"1","estimate_hours":10,"logged_hours":11.5,"remaining_hours":0,"overrun_hours":1.5,"over_estimate":true,"running":true,"users":[{"user_id":"user-id","hours":9.5},{"user_id":"admin-id","hours":2}]},"success":true}`,
+		},
+		{
+			name:           "Task Time Without Entries",
+			method:         "GET",
+			target:         "/tasks/3/time",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"task_id":"3","estimate_hours":6,"logged_hours":0,"remaining_hours":6,"overrun_hours":0,"over_estimate":false,"running":false,"users":[]},"success":true}`,
+		},
+		{
+			name:           "Project Time Rollup",
+			method:         "GET",
+			target:         "/projects/2/time",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":{"project_id":"2","estimate_hours":16,"logged_hours":12.25,"remaining_hours":3.75,"overrun_hours":0,"over_estimate":false,
+				"tasks":[
+					{"task_id":"1","title":"Login","estimate_hours":10,"logged_hours":11.5,"over_estimate":true},
+					{"task_id":"3","title":"Signup","estimate_hours":6,"logged_hours":0,"over_estimate":false},
+					{"task_id":"5","title":"Research","logged_hours":0.75,"over_estimate":false}
+				],
+				"users":[{"user_id":"user-id","hours":10.25},{"user_id":"admin-id","hours":2}]},"success":true}`,
+		},
+		{
+			name:           "User Time",
+			caller:         "user-id",
+			method:         "GET",
+			target:         "/users/user-id/time?from=2024-05-01&to=2024-05-31",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":{"user_id":"user-id","from":"2024-05-01","to":"2024-05-31","logged_hours":10.25,"running":true,
+				"tasks":[{"task_id":"1","title":"Login","project_id":"2","logged_hours":9.5,"over_estimate":false},{"task_id":"5","title":"Research","project_id":"2","logged_hours":0.75,"over_estimate":false}],
+				"projects":[{"project_id":"2","hours":10.25}]},"success":true}`,
+		},
+		{
+			name:           "User Time Of Another User",
+			caller:         "other-id",
+			method:         "GET",
+			target:         "/users/user-id/time",
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"message":"permission denied","success":false}`,
+		},
+		{
+			name:           "User Time With Bad Range",
+			caller:         "user-id",
+			method:         "GET",
+			target:         "/users/user-id/time?from=2024-05-31&to=2024-05-01",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"to: cannot be before from","success":false}`,
+		},
+		{
+			name:           "Delete Entry Of Another User",
+			caller:         "other-id",
+			method:         "DELETE",
+			target:         "/tasks/1/time/entries/7",
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"message":"permission denied","success":false}`,
+		},
+		{
+			name:           "Delete Entry Of Another Task",
+			caller:         "user-id",
+			method:         "DELETE",
+			target:         "/tasks/3/time/entries/7",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"error not found","success":false}`,
+		},
+		{
+			name:           "Delete Own Entry",
+			caller:         "user-id",
+			method:         "DELETE",
+			target:         "/tasks/1/time/entries/7",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":"7","success":true}`,
+			expectedDelete: "7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := mockAdmin()
+			mockUserRepo.On("Get", mock.Anything, "user-id").Return(user.Entity{ID: "user-id", Role: helpers.GetStringPtr("user")}, nil)
+			mockUserRepo.On("Get", mock.Anything, "other-id").Return(user.Entity{ID: "other-id", Role: helpers.GetStringPtr("user")}, nil)
+			mockUserRepo.On("Get", mock.Anything, "racer-id").Return(user.Entity{ID: "racer-id", Role: helpers.GetStringPtr("user")}, nil)
+
+			login := task.Entity{ID: "1", Title: helpers.GetStringPtr("Login"), ProjectID: helpers.GetStringPtr("2"), EstimateHours: estimate(10)}
+			signup := task.Entity{ID: "3", Title: helpers.GetStringPtr("Signup"), ProjectID: helpers.GetStringPtr("2"), EstimateHours: estimate(6)}
+			research := task.Entity{ID: "5", Title: helpers.GetStringPtr("Research"), ProjectID: helpers.GetStringPtr("2")}
+			idle := task.Entity{ID: "6", Title: helpers.GetStringPtr("Idle"), ProjectID: helpers.GetStringPtr("2")}
+
+			mockTaskRepo := new(MockTaskRepository)
+			mockTaskRepo.On("Get", mock.Anything, "9").Return(nil, store.ErrorNotFound)
+			mockTaskRepo.On("Get", mock.Anything, "1").Return(login, nil)
+			mockTaskRepo.On("Get", mock.Anything, "3").Return(signup, nil)
+			mockTaskRepo.On("ListByProject", mock.Anything, "2").Return([]task.Entity{login, signup, research, idle}, nil)
+
+			mockProjectRepo := new(MockProjectRepository)
+			mockProjectRepo.On("Get", mock.Anything, "2").Return(project.Entity{ID: "2", ManagerID: helpers.GetStringPtr("manager-id")}, nil)
+
+			mockMemberRepo := new(MockMemberRepository)
+			for _, userID := range []string{"user-id", "racer-id"} {
+				mockMemberRepo.On("Get", mock.Anything, "2", userID).Return(member.Entity{ProjectID: "2", UserID: userID, Role: helpers.GetStringPtr(member.RoleMember)}, nil)
+			}
+			mockMemberRepo.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(member.Entity{}, store.ErrorNotFound)
+
+			mockTimeRepo := new(MockTimeEntryRepository)
+			for id, data := range entries {
+				if id == "8" && tt.expectedStop != "" {
+					data = stopped
+				}
+				mockTimeRepo.On("Get", mock.Anything, id).Return(data, nil)
+			}
+			mockTimeRepo.On("Get", mock.Anything, mock.Anything).Return(timelog.Entity{}, store.ErrorNotFound)
+			mockTimeRepo.On("GetRunning", mock.Anything, "user-id").Return(entries["8"], nil)
+			mockTimeRepo.On("GetRunning", mock.Anything, "other-id").Return(entries["9"], nil)
+			mockTimeRepo.On("GetRunning", mock.Anything, mock.Anything).Return(timelog.Entity{}, store.ErrorNotFound)
+			// the running timer of racer-id is started after the check for it
+			mockTimeRepo.On("Start", mock.Anything, timelog.Entity{UserID: "racer-id", TaskID: "1"}).Return("", timelog.ErrorRunning)
+			mockTimeRepo.On("Start", mock.Anything, mock.Anything).Return("10", nil)
+			mockTimeRepo.On("Stop", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockTimeRepo.On("Add", mock.Anything, mock.Anything).Return("11", nil)
+			mockTimeRepo.On("Delete", mock.Anything, mock.Anything).Return(nil)
+			mockTimeRepo.On("List", mock.Anything, "1", mock.Anything).Return([]timelog.Entity{entries["7"], entries["8"]}, store.Cursor{}, nil)
+			mockTimeRepo.On("Totals", mock.Anything, timelog.Filter{TaskID: "1"}).Return([]timelog.Total{
+				{UserID: "user-id", TaskID: "1", Seconds: 34200, Running: true},
+				{UserID: "admin-id", TaskID: "1", Seconds: 7200},
+			}, nil)
+			mockTimeRepo.On("Totals", mock.Anything, timelog.Filter{TaskID: "3"}).Return([]timelog.Total{}, nil)
+			mockTimeRepo.On("Totals", mock.Anything, timelog.Filter{ProjectID: "2"}).Return([]timelog.Total{
+				{UserID: "user-id", TaskID: "1", Seconds: 34200},
+				{UserID: "admin-id", TaskID: "1", Seconds: 7200},
+				{UserID: "user-id", TaskID: "5", Seconds: 2700},
+			}, nil)
+			mockTimeRepo.On("Totals", mock.Anything, timelog.Filter{UserID: "user-id", From: "2024-05-01", To: "2024-05-31"}).Return([]timelog.Total{
+				{UserID: "user-id", TaskID: "5", ProjectID: helpers.GetStringPtr("2"), Title: helpers.GetStringPtr("Research"), Seconds: 2700},
+				{UserID: "user-id", TaskID: "1", ProjectID: helpers.GetStringPtr("2"), Title: helpers.GetStringPtr("Login"), Seconds: 34200, Running: true},
+			}, nil)
+
+			mockAuditRepo := new(MockAuditRepository)
+			mockAuditRepo.On("Add", mock.Anything, mock.Anything).Return(nil)
+
+			taskService, _ := tasker.New(
+				tasker.WithUserRepository(mockUserRepo),
+				tasker.WithTaskRepository(mockTaskRepo),
+				tasker.WithProjectRepository(mockProjectRepo),
+				tasker.WithMemberRepository(mockMemberRepo),
+				tasker.WithTimeEntryRepository(mockTimeRepo),
+				tasker.WithAuditRepository(mockAuditRepo),
+			)
+
+			caller := tt.caller
+			if caller == "" {
+				caller = "admin-id"
+			}
+
+			gin.SetMode(gin.TestMode)
+			r := authenticated(caller)
+			NewTaskHandler(taskService).Routes(r.Group("/"))
+			NewProjectHandler(taskService).Routes(r.Group("/"))
+			NewUserHandler(taskService).Routes(r.Group("/"))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.target, bytes.NewBufferString(tt.inputBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+
+			if tt.expectedStart {
+				mockTimeRepo.AssertCalled(t, "Start", mock.Anything, timelog.Entity{UserID: caller, TaskID: "1"})
+			} else {
+				mockTimeRepo.AssertNotCalled(t, "Start", mock.Anything, mock.Anything)
+			}
+			if tt.expectedStop != "" {
+				mockTimeRepo.AssertCalled(t, "Stop", mock.Anything, tt.expectedStop, (*string)(nil))
+			} else {
+				mockTimeRepo.AssertNotCalled(t, "Stop", mock.Anything, mock.Anything, mock.Anything)
+			}
+			if tt.expectedAdded != nil {
+				mockTimeRepo.AssertCalled(t, "Add", mock.Anything, *tt.expectedAdded)
+			} else {
+				mockTimeRepo.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+			}
+			if tt.expectedDelete != "" {
+				mockTimeRepo.AssertCalled(t, "Delete", mock.Anything, tt.expectedDelete)
+			} else {
+				mockTimeRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
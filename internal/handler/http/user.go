@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"hard/internal/domain/access"
 	"hard/internal/domain/user"
 	"hard/internal/service/tasker"
 	"hard/pkg/server/response"
@@ -22,15 +23,25 @@ func NewUserHandler(s *tasker.Service) *UserHandler {
 func (h *UserHandler) Routes(r *gin.RouterGroup) {
 	api := r.Group("/users")
 	{
-		api.GET("/", h.list)
+		deleted := includeDeleted(h.taskerService)
+
+		api.GET("/", deleted, h.list)
 		api.POST("/", h.add)
+		api.POST("/bulk", h.addBulk)
+		api.PATCH("/bulk", h.updateBulk)
+		api.DELETE("/bulk", h.deleteBulk)
 
-		api.GET("/:id", h.get)
-		api.GET("/:id/tasks", h.listTasks)
+		api.GET("/:id", deleted, h.get)
+		api.GET("/:id/tasks", deleted, h.listTasks)
+		api.GET("/:id/projects", deleted, h.listProjects)
+		api.GET("/:id/mentions", deleted, h.listMentions)
+		api.GET("/:id/time", h.userTime)
 		api.PUT("/:id", h.update)
+		api.PUT("/:id/password", h.setPassword)
 		api.DELETE("/:id", h.delete)
+		api.POST("/:id/restore", h.restore)
 
-		api.GET("/search", h.search)
+		api.GET("/search", deleted, h.search)
 	}
 }
 
@@ -41,17 +52,34 @@ func (h *UserHandler) Routes(r *gin.RouterGroup) {
 //	@Tags			users
 //	@Accept			json
 //	@Produce		json
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			after	query		string	false	"Cursor of the next page"
+//	@Param			before	query		string	false	"Cursor of the previous page"
+//	@Param			include_deleted	query		bool	false	"Include soft deleted rows, admin only"
 //	@Success		200	{array}		user.Response
+//	@Failure		400	{object}	response.Object
+//	@Failure		403	{object}	response.Object
 //	@Failure		500	{object}	response.Object
 //	@Router			/users [get]
 func (h *UserHandler) list(c *gin.Context) {
-	res, err := h.taskerService.ListUsers(c)
+	page, err := parsePage(c)
 	if err != nil {
-		response.InternalServerError(c, err)
+		response.BadRequest(c, err, nil)
 		return
 	}
 
-	response.OK(c, res)
+	res, cursor, err := h.taskerService.ListUsers(c, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorInvalidCursor):
+			response.BadRequest(c, err, nil)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OKPage(c, res, cursor.Next, cursor.Prev)
 }
 
 // addUser godoc
@@ -64,6 +92,7 @@ func (h *UserHandler) list(c *gin.Context) {
 //	@Param			user	body		user.Request	true	"User Request"
 //	@Success		200		{object}	user.Response
 //	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
 //	@Failure		500		{object}	response.Object
 //	@Router			/users [post]
 func (h *UserHandler) add(c *gin.Context) {
@@ -81,7 +110,12 @@ func (h *UserHandler) add(c *gin.Context) {
 
 	res, err := h.taskerService.CreateUser(c, req)
 	if err != nil {
-		response.InternalServerError(c, err)
+		switch {
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
 		return
 	}
 
@@ -96,7 +130,10 @@ func (h *UserHandler) add(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			id	path		string	true	"User ID"
+//	@Param			include_deleted	query		bool	false	"Include soft deleted rows, admin only"
 //	@Success		200	{object}	user.Response
+//	@Header			200	{string}	ETag	"Version of the user"
+//	@Failure		403	{object}	response.Object
 //	@Failure		404	{object}	response.Object
 //	@Failure		500	{object}	response.Object
 //	@Router			/users/{id} [get]
@@ -114,6 +151,7 @@ func (h *UserHandler) get(c *gin.Context) {
 		return
 	}
 
+	setETag(c, res.Version)
 	response.OK(c, res)
 }
 
@@ -126,9 +164,12 @@ func (h *UserHandler) get(c *gin.Context) {
 //	@Produce		json
 //	@Param			id		path		string			true	"User ID"
 //	@Param			user	body		user.Request	true	"User Request"
+//	@Param			If-Match	header		string	false	"ETag of the version being changed"
 //	@Success		200		{string}	string			"ok"
 //	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
 //	@Failure		404		{object}	response.Object
+//	@Failure		412		{object}	response.Object
 //	@Failure		500		{object}	response.Object
 //	@Router			/users/{id} [put]
 func (h *UserHandler) update(c *gin.Context) {
@@ -139,16 +180,69 @@ func (h *UserHandler) update(c *gin.Context) {
 		response.BadRequest(c, err, req)
 		return
 	}
-	if req.Email == nil && req.Role == nil && req.FullName == nil {
+	if req.IsEmpty() {
 		err := fmt.Errorf("bad request")
 		response.BadRequest(c, err, req)
 		return
 	}
 
-	if err := h.taskerService.UpdateUser(c, id, req); err != nil {
+	version, ok := ifMatch(c, h.version(c, id))
+	if !ok {
+		return
+	}
+
+	if err := h.taskerService.UpdateUser(c, id, req, version); err != nil {
 		switch {
 		case errors.Is(err, store.ErrorNotFound):
 			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		case errors.Is(err, store.ErrorConflict):
+			response.PreconditionFailed(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, "ok")
+}
+
+// setUserPassword godoc
+//
+//	@Summary		Set a user's password
+//	@Description	Replace the login password of a user, at least 8 characters and at most 72 bytes
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string					true	"User ID"
+//	@Param			password	body		user.PasswordRequest	true	"Password Request"
+//	@Success		200			{string}	string					"ok"
+//	@Failure		400			{object}	response.Object
+//	@Failure		403			{object}	response.Object
+//	@Failure		404			{object}	response.Object
+//	@Failure		500			{object}	response.Object
+//	@Router			/users/{id}/password [put]
+func (h *UserHandler) setPassword(c *gin.Context) {
+	id := c.Param("id")
+	req := user.PasswordRequest{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	if err := h.taskerService.SetPassword(c, id, req); err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
 		default:
 			response.InternalServerError(c, err)
 		}
@@ -166,17 +260,29 @@ func (h *UserHandler) update(c *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //	@Param			id	path		string	true	"User ID"
+//	@Param			If-Match	header		string	false	"ETag of the version being changed"
 //	@Success		200	{string}	string	"Deleted User ID"
+//	@Failure		403	{object}	response.Object
 //	@Failure		404	{object}	response.Object
+//	@Failure		412	{object}	response.Object
 //	@Failure		500	{object}	response.Object
 //	@Router			/users/{id} [delete]
 func (h *UserHandler) delete(c *gin.Context) {
 	id := c.Param("id")
 
-	if err := h.taskerService.DeleteUser(c, id); err != nil {
+	version, ok := ifMatch(c, h.version(c, id))
+	if !ok {
+		return
+	}
+
+	if err := h.taskerService.DeleteUser(c, id, version); err != nil {
 		switch {
 		case errors.Is(err, store.ErrorNotFound):
 			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		case errors.Is(err, store.ErrorConflict):
+			response.PreconditionFailed(c, err)
 		default:
 			response.InternalServerError(c, err)
 		}
@@ -186,6 +292,42 @@ func (h *UserHandler) delete(c *gin.Context) {
 	response.OK(c, id)
 }
 
+// restoreUser godoc
+//
+//	@Summary		Restore a user
+//	@Description	Restore a soft deleted user
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	user.Response
+//	@Failure		403	{object}	response.Object
+//	@Failure		404	{object}	response.Object
+//	@Failure		409	{object}	response.Object
+//	@Failure		500	{object}	response.Object
+//	@Router			/users/{id}/restore [post]
+func (h *UserHandler) restore(c *gin.Context) {
+	id := c.Param("id")
+
+	res, err := h.taskerService.RestoreUser(c, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		case errors.Is(err, store.ErrorNotDeleted):
+			response.Conflict(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	setETag(c, res.Version)
+	response.OK(c, res)
+}
+
 // searchUsers godoc
 //
 //	@Summary		Search users
@@ -195,8 +337,13 @@ func (h *UserHandler) delete(c *gin.Context) {
 //	@Produce		json
 //	@Param			name	query		string	false	"User Name"
 //	@Param			email	query		string	false	"User Email"
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			after	query		string	false	"Cursor of the next page"
+//	@Param			before	query		string	false	"Cursor of the previous page"
+//	@Param			include_deleted	query		bool	false	"Include soft deleted rows, admin only"
 //	@Success		200		{array}		user.Response
 //	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
 //	@Failure		500		{object}	response.Object
 //	@Router			/users/search [get]
 func (h *UserHandler) search(c *gin.Context) {
@@ -207,13 +354,24 @@ func (h *UserHandler) search(c *gin.Context) {
 		return
 	}
 
-	res, err := h.taskerService.SearchUser(c, name, email)
+	page, err := parsePage(c)
 	if err != nil {
-		response.InternalServerError(c, err)
+		response.BadRequest(c, err, nil)
 		return
 	}
 
-	response.OK(c, res)
+	res, cursor, err := h.taskerService.SearchUser(c, name, email, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorInvalidCursor):
+			response.BadRequest(c, err, nil)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OKPage(c, res, cursor.Next, cursor.Prev)
 }
 
 // listUserTasks godoc
@@ -223,24 +381,79 @@ func (h *UserHandler) search(c *gin.Context) {
 //	@Tags			users
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path		string	true	"User ID"
-//	@Success		200	{array}		task.Response
-//	@Failure		404	{object}	response.Object
+//	@Param			id		path		string	true	"User ID"
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			after	query		string	false	"Cursor of the next page"
+//	@Param			before	query		string	false	"Cursor of the previous page"
+//	@Param			include_deleted	query		bool	false	"Include soft deleted rows, admin only"
+//	@Success		200		{array}		task.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
+//	@Failure		404		{object}	response.Object
 //	@Failure		500	{object}	response.Object
 //	@Router			/users/{id}/tasks [get]
 func (h *UserHandler) listTasks(c *gin.Context) {
 	id := c.Param("id")
+	page, err := parsePage(c)
+	if err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
 
-	res, err := h.taskerService.GetTasksByUser(c, id)
+	res, cursor, err := h.taskerService.GetTasksByUser(c, id, page)
 	if err != nil {
 		switch {
 		case errors.Is(err, store.ErrorNotFound):
 			response.NotFound(c, err)
+		case errors.Is(err, store.ErrorInvalidCursor):
+			response.BadRequest(c, err, nil)
 		default:
 			response.InternalServerError(c, err)
 		}
 		return
 	}
 
-	response.OK(c, res)
+	response.OKPage(c, res, cursor.Next, cursor.Prev)
+}
+
+// listUserProjects godoc
+//
+//	@Summary		List projects by user
+//	@Description	Get a list of projects the user is a member of
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"User ID"
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			after	query		string	false	"Cursor of the next page"
+//	@Param			before	query		string	false	"Cursor of the previous page"
+//	@Param			include_deleted	query		bool	false	"Include soft deleted rows, admin only"
+//	@Success		200		{array}		project.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/users/{id}/projects [get]
+func (h *UserHandler) listProjects(c *gin.Context) {
+	id := c.Param("id")
+	page, err := parsePage(c)
+	if err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	res, cursor, err := h.taskerService.GetProjectsByUser(c, id, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, store.ErrorInvalidCursor):
+			response.BadRequest(c, err, nil)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OKPage(c, res, cursor.Next, cursor.Prev)
 }
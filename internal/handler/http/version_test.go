@@ -0,0 +1,185 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"hard/internal/domain/task"
+	"hard/pkg/helpers"
+	"hard/pkg/store"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"hard/internal/service/tasker"
+)
+
+func TestOptimisticConcurrency(t *testing.T) {
+	version := 3
+	current := task.Entity{
+		ID:          "5",
+		Title:       helpers.GetStringPtr("Rescue"),
+		Description: helpers.GetStringPtr("Rescue"),
+		Priority:    helpers.GetStringPtr("High"),
+		Status:      helpers.GetStringPtr("Active"),
+		AssigneeID:  helpers.GetStringPtr("2"),
+		ProjectID:   helpers.GetStringPtr("2"),
+		Version:     &version,
+	}
+
+	tests := []struct {
+		name            string
+		method          string
+		ifMatch         string
+		inputBody       string
+		mockRepoError   error
+		expectedVersion *int
+		expectedStatus  int
+		expectedBody    string
+		expectedETag    string
+	}{
+		{
+			name:           "Get Carries ETag",
+			method:         "GET",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"id":"5","title":"Rescue","description":"Rescue","priority":"High","status":"Active","assignee_id":"2","project_id":"2","completed_at":"","version":3},"success":true}`,
+			expectedETag:   `"3"`,
+		},
+		{
+			name:            "Update Matching Version",
+			method:          "PUT",
+			ifMatch:         `"3"`,
+			inputBody:       `{"title":"Rescue Morty"}`,
+			expectedVersion: &version,
+			expectedStatus:  http.StatusOK,
+			expectedBody:    `{"data":"ok","success":true}`,
+		},
+		{
+			name:           "Update Without If-Match",
+			method:         "PUT",
+			inputBody:      `{"title":"Rescue Morty"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":"ok","success":true}`,
+		},
+		{
+			name:           "Update Stale Version",
+			method:         "PUT",
+			ifMatch:        `"2"`,
+			inputBody:      `{"title":"Rescue Morty"}`,
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedBody:   `{"message":"error conflict","success":false}`,
+		},
+		{
+			name:            "Concurrent Update",
+			method:          "PUT",
+			ifMatch:         `"3"`,
+			inputBody:       `{"title":"Rescue Morty"}`,
+			mockRepoError:   store.ErrorConflict,
+			expectedVersion: &version,
+			expectedStatus:  http.StatusPreconditionFailed,
+			expectedBody:    `{"message":"error conflict","success":false}`,
+		},
+		{
+			name:           "Weak ETag Never Matches",
+			method:         "PUT",
+			ifMatch:        `W/"3"`,
+			inputBody:      `{"title":"Rescue Morty"}`,
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedBody:   `{"message":"error conflict","success":false}`,
+		},
+		{
+			name:            "One Of Several ETags Matches",
+			method:          "PUT",
+			ifMatch:         `"2", W/"3", "3"`,
+			inputBody:       `{"title":"Rescue Morty"}`,
+			expectedVersion: &version,
+			expectedStatus:  http.StatusOK,
+			expectedBody:    `{"data":"ok","success":true}`,
+		},
+		{
+			name:           "None Of Several ETags Matches",
+			method:         "PUT",
+			ifMatch:        `"1", "2"`,
+			inputBody:      `{"title":"Rescue Morty"}`,
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedBody:   `{"message":"error conflict","success":false}`,
+		},
+		{
+			name:            "Delete With Several ETags",
+			method:          "DELETE",
+			ifMatch:         `"3", "4"`,
+			expectedVersion: &version,
+			expectedStatus:  http.StatusOK,
+			expectedBody:    `{"data":"5","success":true}`,
+		},
+		{
+			name:            "Delete Matching Version",
+			method:          "DELETE",
+			ifMatch:         `"3"`,
+			expectedVersion: &version,
+			expectedStatus:  http.StatusOK,
+			expectedBody:    `{"data":"5","success":true}`,
+		},
+		{
+			name:           "Delete Stale Version",
+			method:         "DELETE",
+			ifMatch:        `"4"`,
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedBody:   `{"message":"error conflict","success":false}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockTaskRepo.On("Get", mock.Anything, "5").Return(current, nil)
+			mockTaskRepo.On("Update", mock.Anything, "5", mock.Anything).Return(tt.mockRepoError)
+			mockTaskRepo.On("Delete", mock.Anything, "5", mock.Anything).Return(tt.mockRepoError)
+
+			taskService, _ := tasker.New(
+				tasker.WithUserRepository(mockAdmin()),
+				tasker.WithTaskRepository(mockTaskRepo),
+			)
+
+			gin.SetMode(gin.TestMode)
+			r := authenticated("admin-id")
+			NewTaskHandler(taskService).Routes(r.Group("/"))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, "/tasks/5", bytes.NewBufferString(tt.inputBody))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			assert.Equal(t, tt.expectedETag, w.Header().Get("ETag"))
+
+			// the version is checked against the task locked by the write
+			locked := mock.MatchedBy(func(ctx context.Context) bool { return store.Locked(ctx) != "" })
+			switch tt.method {
+			case "PUT":
+				if tt.expectedStatus == http.StatusOK || tt.mockRepoError != nil {
+					mockTaskRepo.AssertCalled(t, "Get", locked, "5")
+					mockTaskRepo.AssertCalled(t, "Update", mock.Anything, "5", mock.MatchedBy(func(data task.Entity) bool {
+						return assert.ObjectsAreEqual(tt.expectedVersion, data.Version)
+					}))
+				} else {
+					mockTaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+				}
+			case "DELETE":
+				if tt.expectedStatus == http.StatusOK {
+					mockTaskRepo.AssertCalled(t, "Get", locked, "5")
+					mockTaskRepo.AssertCalled(t, "Delete", mock.Anything, "5", tt.expectedVersion)
+				} else {
+					mockTaskRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package http
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"hard/internal/domain/access"
+	"hard/internal/domain/webhook"
+	"hard/internal/service/tasker"
+	"hard/pkg/server/response"
+	"hard/pkg/store"
+)
+
+type WebhookHandler struct {
+	taskerService *tasker.Service
+}
+
+func NewWebhookHandler(s *tasker.Service) *WebhookHandler {
+	return &WebhookHandler{taskerService: s}
+}
+
+// Routes sets up the routes for webhook subscriptions
+func (h *WebhookHandler) Routes(r *gin.RouterGroup) {
+	api := r.Group("/webhooks")
+	{
+		api.GET("/", h.list)
+		api.POST("/", h.add)
+
+		api.GET("/:id", h.get)
+		api.PUT("/:id", h.update)
+		api.DELETE("/:id", h.delete)
+
+		api.GET("/:id/deliveries", h.listDeliveries)
+		api.POST("/:id/deliveries/:delivery_id/retry", h.retryDelivery)
+	}
+}
+
+// listWebhooks godoc
+//
+//	@Summary		List webhooks
+//	@Description	Get the webhook subscriptions, their secrets are not returned
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			after	query		string	false	"Cursor of the next page"
+//	@Param			before	query		string	false	"Cursor of the previous page"
+//	@Success		200		{array}		webhook.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/webhooks [get]
+func (h *WebhookHandler) list(c *gin.Context) {
+	page, err := parsePage(c)
+	if err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	res, cursor, err := h.taskerService.ListWebhooks(c, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorInvalidCursor):
+			response.BadRequest(c, err, nil)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OKPage(c, res, cursor.Next, cursor.Prev)
+}
+
+// addWebhook godoc
+//
+//	@Summary		Create a webhook
+//	@Description	Subscribe a URL to events such as task.created, task.status_changed or project.deleted, task.* and * match several. A secret is generated when none is given, it is only returned here
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			webhook	body		webhook.Request	true	"Webhook Request"
+//	@Success		201		{object}	webhook.Response
+//	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/webhooks [post]
+func (h *WebhookHandler) add(c *gin.Context) {
+	req := webhook.Request{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	res, err := h.taskerService.CreateWebhook(c, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.Created(c, res)
+}
+
+// getWebhook godoc
+//
+//	@Summary		Get a webhook
+//	@Description	Get a webhook subscription by ID
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Webhook ID"
+//	@Success		200	{object}	webhook.Response
+//	@Failure		403	{object}	response.Object
+//	@Failure		404	{object}	response.Object
+//	@Failure		500	{object}	response.Object
+//	@Router			/webhooks/{id} [get]
+func (h *WebhookHandler) get(c *gin.Context) {
+	id := c.Param("id")
+
+	res, err := h.taskerService.GetWebhook(c, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, res)
+}
+
+// updateWebhook godoc
+//
+//	@Summary		Update a webhook
+//	@Description	Change the URL, the events or the secret of a webhook, or pause it with active false
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string			true	"Webhook ID"
+//	@Param			webhook	body		webhook.Request	true	"Webhook Request"
+//	@Success		200		{string}	string			"ok"
+//	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/webhooks/{id} [put]
+func (h *WebhookHandler) update(c *gin.Context) {
+	id := c.Param("id")
+	req := webhook.Request{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	if req.IsEmpty() {
+		response.BadRequest(c, errors.New("at least one field must be provided for update"), req)
+		return
+	}
+
+	if err := req.ValidateUpdate(); err != nil {
+		response.BadRequest(c, err, req)
+		return
+	}
+
+	if err := h.taskerService.UpdateWebhook(c, id, req); err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, "ok")
+}
+
+// deleteWebhook godoc
+//
+//	@Summary		Delete a webhook
+//	@Description	Delete a webhook with its delivery log, its pending deliveries are dropped
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Webhook ID"
+//	@Success		200	{string}	string	"Deleted Webhook ID"
+//	@Failure		403	{object}	response.Object
+//	@Failure		404	{object}	response.Object
+//	@Failure		500	{object}	response.Object
+//	@Router			/webhooks/{id} [delete]
+func (h *WebhookHandler) delete(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.taskerService.DeleteWebhook(c, id); err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, id)
+}
+
+// listWebhookDeliveries godoc
+//
+//	@Summary		List webhook deliveries
+//	@Description	Get the delivery log of a webhook, newest first, with the attempts and the last response of every delivery
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"Webhook ID"
+//	@Param			status	query		string	false	"Delivery status: pending, delivered or dead"
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			after	query		string	false	"Cursor of the next page"
+//	@Param			before	query		string	false	"Cursor of the previous page"
+//	@Success		200		{array}		webhook.DeliveryResponse
+//	@Failure		400		{object}	response.Object
+//	@Failure		403		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) listDeliveries(c *gin.Context) {
+	id := c.Param("id")
+
+	filter := webhook.DeliveryFilter{Status: c.Query("status")}
+	if err := filter.Validate(); err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	page, err := parsePage(c)
+	if err != nil {
+		response.BadRequest(c, err, nil)
+		return
+	}
+
+	res, cursor, err := h.taskerService.ListWebhookDeliveries(c, id, filter, page)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, store.ErrorInvalidCursor):
+			response.BadRequest(c, err, nil)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OKPage(c, res, cursor.Next, cursor.Prev)
+}
+
+// retryWebhookDelivery godoc
+//
+//	@Summary		Retry a webhook delivery
+//	@Description	Send a delivered or dead delivery again on the next dispatch, with all of its attempts
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string	true	"Webhook ID"
+//	@Param			delivery_id	path		string	true	"Delivery ID"
+//	@Success		200			{string}	string	"ok"
+//	@Failure		403			{object}	response.Object
+//	@Failure		404			{object}	response.Object
+//	@Failure		409			{object}	response.Object
+//	@Failure		500			{object}	response.Object
+//	@Router			/webhooks/{id}/deliveries/{delivery_id}/retry [post]
+func (h *WebhookHandler) retryDelivery(c *gin.Context) {
+	id, deliveryID := c.Param("id"), c.Param("delivery_id")
+
+	if err := h.taskerService.RedeliverWebhook(c, id, deliveryID); err != nil {
+		switch {
+		case errors.Is(err, store.ErrorNotFound):
+			response.NotFound(c, err)
+		case errors.Is(err, access.ErrorForbidden):
+			response.Forbidden(c, err)
+		case errors.Is(err, webhook.ErrorPending):
+			response.Conflict(c, err)
+		default:
+			response.InternalServerError(c, err)
+		}
+		return
+	}
+
+	response.OK(c, "ok")
+}
@@ -0,0 +1,618 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"hard/internal/domain/audit"
+	"hard/internal/domain/member"
+	"hard/internal/domain/project"
+	"hard/internal/domain/task"
+	"hard/internal/domain/user"
+	"hard/internal/domain/webhook"
+	"hard/internal/domain/workflow"
+	"hard/pkg/auth"
+	"hard/pkg/helpers"
+	"hard/pkg/store"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"hard/internal/service/tasker"
+)
+
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepository) List(ctx context.Context, page store.Page) (dest []webhook.Entity, cursor store.Cursor, err error) {
+	args := m.Called(ctx, page)
+	return args.Get(0).([]webhook.Entity), args.Get(1).(store.Cursor), args.Error(2)
+}
+
+func (m *MockWebhookRepository) Get(ctx context.Context, id string) (dest webhook.Entity, err error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(webhook.Entity), args.Error(1)
+}
+
+func (m *MockWebhookRepository) Add(ctx context.Context, data webhook.Entity) (id string, err error) {
+	args := m.Called(ctx, data)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockWebhookRepository) Update(ctx context.Context, id string, data webhook.Entity) (err error) {
+	args := m.Called(ctx, id, data)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) Delete(ctx context.Context, id string) (err error) {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) Enqueue(ctx context.Context, data webhook.Event) (err error) {
+	args := m.Called(ctx, data)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) Claim(ctx context.Context, limit int, lease time.Duration) (dest []webhook.Job, err error) {
+	args := m.Called(ctx, limit, lease)
+	return args.Get(0).([]webhook.Job), args.Error(1)
+}
+
+func (m *MockWebhookRepository) Complete(ctx context.Context, id string, data webhook.Attempt) (err error) {
+	args := m.Called(ctx, id, data)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) ListDeliveries(ctx context.Context, webhookID string, filter webhook.DeliveryFilter, page store.Page) (dest []webhook.Delivery, cursor store.Cursor, err error) {
+	args := m.Called(ctx, webhookID, filter, page)
+	return args.Get(0).([]webhook.Delivery), args.Get(1).(store.Cursor), args.Error(2)
+}
+
+func (m *MockWebhookRepository) GetDelivery(ctx context.Context, id string) (dest webhook.Delivery, err error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(webhook.Delivery), args.Error(1)
+}
+
+func (m *MockWebhookRepository) Redeliver(ctx context.Context, id string) (err error) {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestWebhooks(t *testing.T) {
+	active, paused := true, false
+	code := func(n int) *int { return &n }
+	hook := webhook.Entity{
+		ID:        "1",
+		URL:       helpers.GetStringPtr("https://example.com/hooks"),
+		Events:    webhook.Events{"task.*", "project.deleted"},
+		Secret:    helpers.GetStringPtr("0123456789abcdef"),
+		Active:    &active,
+		CreatedAt: helpers.GetStringPtr("2024-05-01T10:00:00Z"),
+	}
+	dead := webhook.Delivery{
+		ID:             "7",
+		WebhookID:      "1",
+		EventID:        "3",
+		Event:          "task.status_changed",
+		Entity:         "task",
+		EntityID:       "5",
+		Status:         webhook.StatusDead,
+		Attempts:       8,
+		NextAttemptAt:  helpers.GetStringPtr("2024-05-01T18:00:00Z"),
+		LastAttemptAt:  helpers.GetStringPtr("2024-05-01T17:00:00Z"),
+		LastStatusCode: code(503),
+		LastError:      helpers.GetStringPtr("receiver responded with 503 Service Unavailable"),
+		CreatedAt:      helpers.GetStringPtr("2024-05-01T10:00:00Z"),
+	}
+	pending := webhook.Delivery{ID: "8", WebhookID: "1", Status: webhook.StatusPending}
+	foreign := webhook.Delivery{ID: "9", WebhookID: "2", Status: webhook.StatusDead}
+
+	created := webhook.Entity{
+		URL:    helpers.GetStringPtr("https://example.com/hooks"),
+		Events: webhook.Events{"task.*", "project.deleted"},
+		Secret: helpers.GetStringPtr("0123456789abcdef"),
+		Active: &active,
+	}
+
+	tests := []struct {
+		name              string
+		callerID          string
+		method            string
+		target            string
+		inputBody         string
+		expectedStatus    int
+		expectedBody      string
+		expectedAdd       *webhook.Entity
+		expectedUpdate    *webhook.Entity
+		expectedRedeliver bool
+	}{
+		{
+			name:           "List",
+			callerID:       "admin-id",
+			method:         "GET",
+			target:         "/webhooks/",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[{"id":"1","url":"https://example.com/hooks","events":["task.*","project.deleted"],"active":true,"created_at":"2024-05-01T10:00:00Z"}],"success":true}`,
+		},
+		{
+			name:           "Create Returns The Secret Once",
+			callerID:       "admin-id",
+			method:         "POST",
+			target:         "/webhooks/",
+			inputBody:      `{"url":"https://example.com/hooks","events":["task.*","project.deleted"],"secret":"0123456789abcdef"}`,
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"data":{"id":"1","url":"https://example.com/hooks","events":["task.*","project.deleted"],"active":true,"secret":"0123456789abcdef"},"success":true}`,
+			expectedAdd:    &created,
+		},
+		{
+			name:           "Create With Unknown Event",
+			callerID:       "admin-id",
+			method:         "POST",
+			target:         "/webhooks/",
+			inputBody:      `{"url":"https://example.com/hooks","events":["invoice.paid"]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"events: unknown event \"invoice.paid\"","success":false,"data":{"url":"https://example.com/hooks","events":["invoice.paid"],"secret":null,"active":null}}`,
+		},
+		{
+			name:           "Create With Relative URL",
+			callerID:       "admin-id",
+			method:         "POST",
+			target:         "/webhooks/",
+			inputBody:      `{"url":"/hooks"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"url: must be an absolute http or https URL","success":false,"data":{"url":"/hooks","events":null,"secret":null,"active":null}}`,
+		},
+		{
+			name:           "Create With Short Secret",
+			callerID:       "admin-id",
+			method:         "POST",
+			target:         "/webhooks/",
+			inputBody:      `{"url":"https://example.com/hooks","secret":"short"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"secret: must be at least 16 characters","success":false,"data":{"url":"https://example.com/hooks","events":null,"secret":"short","active":null}}`,
+		},
+		{
+			name:           "Webhooks Are Admin Only",
+			callerID:       "2",
+			method:         "POST",
+			target:         "/webhooks/",
+			inputBody:      `{"url":"https://example.com/hooks"}`,
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"message":"permission denied","success":false}`,
+		},
+		{
+			name:           "Get",
+			callerID:       "admin-id",
+			method:         "GET",
+			target:         "/webhooks/1",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"id":"1","url":"https://example.com/hooks","events":["task.*","project.deleted"],"active":true,"created_at":"2024-05-01T10:00:00Z"},"success":true}`,
+		},
+		{
+			name:           "Get Unknown",
+			callerID:       "admin-id",
+			method:         "GET",
+			target:         "/webhooks/9",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"error not found","success":false}`,
+		},
+		{
+			name:           "Pause",
+			callerID:       "admin-id",
+			method:         "PUT",
+			target:         "/webhooks/1",
+			inputBody:      `{"active":false}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":"ok","success":true}`,
+			expectedUpdate: &webhook.Entity{Active: &paused},
+		},
+		{
+			name:           "Update Without Fields",
+			callerID:       "admin-id",
+			method:         "PUT",
+			target:         "/webhooks/1",
+			inputBody:      `{}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"at least one field must be provided for update","success":false,"data":{"url":null,"events":null,"secret":null,"active":null}}`,
+		},
+		{
+			name:           "Delete",
+			callerID:       "admin-id",
+			method:         "DELETE",
+			target:         "/webhooks/1",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":"1","success":true}`,
+		},
+		{
+			name:           "Delivery Log",
+			callerID:       "admin-id",
+			method:         "GET",
+			target:         "/webhooks/1/deliveries?status=dead",
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":[{"id":"7","webhook_id":"1","event_id":"3","event":"task.status_changed","entity":"task","entity_id":"5",` +
+				`"status":"dead","attempts":8,"last_attempt_at":"2024-05-01T17:00:00Z","last_status_code":503,` +
+				`"last_error":"receiver responded with 503 Service Unavailable","created_at":"2024-05-01T10:00:00Z"}],"success":true}`,
+		},
+		{
+			name:           "Delivery Log With Unknown Status",
+			callerID:       "admin-id",
+			method:         "GET",
+			target:         "/webhooks/1/deliveries?status=lost",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"status: must be one of pending, delivered, dead","success":false}`,
+		},
+		{
+			name:           "Delivery Log Of Unknown Webhook",
+			callerID:       "admin-id",
+			method:         "GET",
+			target:         "/webhooks/9/deliveries",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"error not found","success":false}`,
+		},
+		{
+			name:              "Retry A Dead Delivery",
+			callerID:          "admin-id",
+			method:            "POST",
+			target:            "/webhooks/1/deliveries/7/retry",
+			expectedStatus:    http.StatusOK,
+			expectedBody:      `{"data":"ok","success":true}`,
+			expectedRedeliver: true,
+		},
+		{
+			name:           "Retry A Pending Delivery",
+			callerID:       "admin-id",
+			method:         "POST",
+			target:         "/webhooks/1/deliveries/8/retry",
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"delivery is still pending","success":false}`,
+		},
+		{
+			name:           "Retry A Delivery Of Another Webhook",
+			callerID:       "admin-id",
+			method:         "POST",
+			target:         "/webhooks/1/deliveries/9/retry",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"message":"error not found","success":false}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepo := mockAdmin()
+			mockUserRepo.On("Get", mock.Anything, "2").Return(user.Entity{ID: "2", Role: helpers.GetStringPtr("scientist")}, nil)
+
+			mockWebhookRepo := new(MockWebhookRepository)
+			mockWebhookRepo.On("List", mock.Anything, mock.Anything).Return([]webhook.Entity{hook}, store.Cursor{}, nil)
+			mockWebhookRepo.On("Get", mock.Anything, "1").Return(hook, nil)
+			mockWebhookRepo.On("Get", mock.Anything, "9").Return(webhook.Entity{}, store.ErrorNotFound)
+			mockWebhookRepo.On("Add", mock.Anything, mock.Anything).Return("1", nil)
+			mockWebhookRepo.On("Update", mock.Anything, "1", mock.Anything).Return(nil)
+			mockWebhookRepo.On("Delete", mock.Anything, "1").Return(nil)
+			mockWebhookRepo.On("Enqueue", mock.Anything, mock.Anything).Return(nil)
+			mockWebhookRepo.On("ListDeliveries", mock.Anything, "1", webhook.DeliveryFilter{Status: "dead"}, mock.Anything).Return([]webhook.Delivery{dead}, store.Cursor{}, nil)
+			mockWebhookRepo.On("GetDelivery", mock.Anything, "7").Return(dead, nil)
+			mockWebhookRepo.On("GetDelivery", mock.Anything, "8").Return(pending, nil)
+			mockWebhookRepo.On("GetDelivery", mock.Anything, "9").Return(foreign, nil)
+			mockWebhookRepo.On("Redeliver", mock.Anything, "7").Return(nil)
+
+			taskService, _ := tasker.New(
+				tasker.WithUserRepository(mockUserRepo),
+				tasker.WithWebhookRepository(mockWebhookRepo),
+			)
+
+			gin.SetMode(gin.TestMode)
+			r := authenticated(tt.callerID)
+			NewWebhookHandler(taskService).Routes(r.Group("/"))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.target, bytes.NewBufferString(tt.inputBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+
+			if tt.expectedAdd != nil {
+				mockWebhookRepo.AssertCalled(t, "Add", mock.Anything, *tt.expectedAdd)
+			} else {
+				mockWebhookRepo.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+			}
+			if tt.expectedUpdate != nil {
+				mockWebhookRepo.AssertCalled(t, "Update", mock.Anything, "1", *tt.expectedUpdate)
+			} else {
+				mockWebhookRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+			}
+			if tt.expectedRedeliver {
+				mockWebhookRepo.AssertCalled(t, "Redeliver", mock.Anything, "7")
+			} else {
+				mockWebhookRepo.AssertNotCalled(t, "Redeliver", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestWebhookSecretIsGenerated(t *testing.T) {
+	mockWebhookRepo := new(MockWebhookRepository)
+	mockWebhookRepo.On("Add", mock.Anything, mock.Anything).Return("1", nil)
+	mockWebhookRepo.On("Enqueue", mock.Anything, mock.Anything).Return(nil)
+
+	taskService, _ := tasker.New(
+		tasker.WithUserRepository(mockAdmin()),
+		tasker.WithWebhookRepository(mockWebhookRepo),
+	)
+
+	res, err := taskService.CreateWebhook(authenticatedContext("admin-id"), webhook.Request{URL: helpers.GetStringPtr("https://example.com/hooks")})
+
+	assert.NoError(t, err)
+	assert.Len(t, res.Secret, 64)
+	assert.Equal(t, []string{"*"}, res.Events)
+
+	// the audit log and the webhook events only see a fingerprint of the secret
+	added := mockWebhookRepo.Calls[0].Arguments.Get(1).(webhook.Entity)
+	assert.Equal(t, res.Secret, *added.Secret)
+	mockWebhookRepo.AssertCalled(t, "Enqueue", mock.Anything, mock.MatchedBy(func(data webhook.Event) bool {
+		secret := data.Changes["secret"].New
+		return data.Event == "webhook.created" && secret != res.Secret && secret == *added.Redacted().Secret
+	}))
+}
+
+func TestWebhookEvents(t *testing.T) {
+	current := task.Entity{
+		ID:        "5",
+		Title:     helpers.GetStringPtr("Rescue"),
+		Status:    helpers.GetStringPtr("Active"),
+		ProjectID: helpers.GetStringPtr("1"),
+	}
+	beta := project.Entity{ID: "2", Title: helpers.GetStringPtr("Beta")}
+
+	tests := []struct {
+		name              string
+		method            string
+		target            string
+		inputBody         string
+		mockEnqueueError  error
+		expectedStatus    int
+		expectedEvents    []webhook.Event
+		expectedCommitted int
+	}{
+		{
+			name:           "Status Change",
+			method:         "PUT",
+			target:         "/tasks/5",
+			inputBody:      `{"status":"Review"}`,
+			expectedStatus: http.StatusOK,
+			expectedEvents: []webhook.Event{
+				{Event: "task.updated", Entity: "task", EntityID: "5", ActorID: helpers.GetStringPtr("admin-id"), Changes: audit.Diff{"status": {Old: "Active", New: "Review"}}},
+				{Event: "task.status_changed", Entity: "task", EntityID: "5", ActorID: helpers.GetStringPtr("admin-id"), Changes: audit.Diff{"status": {Old: "Active", New: "Review"}}},
+			},
+			expectedCommitted: 1,
+		},
+		{
+			name:           "Other Change",
+			method:         "PUT",
+			target:         "/tasks/5",
+			inputBody:      `{"title":"Rescue the cat"}`,
+			expectedStatus: http.StatusOK,
+			expectedEvents: []webhook.Event{
+				{Event: "task.updated", Entity: "task", EntityID: "5", ActorID: helpers.GetStringPtr("admin-id"), Changes: audit.Diff{"title": {Old: "Rescue", New: "Rescue the cat"}}},
+			},
+			expectedCommitted: 1,
+		},
+		{
+			name:           "Project Deletion",
+			method:         "DELETE",
+			target:         "/projects/2",
+			expectedStatus: http.StatusOK,
+			expectedEvents: []webhook.Event{
+				{Event: "project.deleted", Entity: "project", EntityID: "2", ActorID: helpers.GetStringPtr("admin-id"), Changes: audit.Diff{"id": {Old: "2"}, "title": {Old: "Beta"}}},
+			},
+			expectedCommitted: 1,
+		},
+		{
+			name:              "Failed Outbox Rolls Back",
+			method:            "DELETE",
+			target:            "/projects/2",
+			mockEnqueueError:  errors.New("outbox error"),
+			expectedStatus:    http.StatusInternalServerError,
+			expectedCommitted: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTaskRepo := new(MockTaskRepository)
+			mockTaskRepo.On("Get", mock.Anything, "5").Return(current, nil)
+			mockTaskRepo.On("Update", mock.Anything, "5", mock.Anything).Return(nil)
+
+			mockProjectRepo := new(MockProjectRepository)
+			mockProjectRepo.On("Get", mock.Anything, "2").Return(beta, nil)
+			mockProjectRepo.On("Delete", mock.Anything, "2", mock.Anything).Return(nil)
+
+			mockMemberRepo := new(MockMemberRepository)
+			mockMemberRepo.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(member.Entity{}, nil)
+
+			mockWorkflowRepo := new(MockWorkflowRepository)
+			mockWorkflowRepo.On("Get", mock.Anything, mock.Anything).Return(workflow.Entity{}, store.ErrorNotFound)
+
+			mockWebhookRepo := new(MockWebhookRepository)
+			mockWebhookRepo.On("Enqueue", mock.Anything, mock.Anything).Return(tt.mockEnqueueError)
+
+			transactor := new(MockTransactor)
+			taskService, _ := tasker.New(
+				tasker.WithUserRepository(mockAdmin()),
+				tasker.WithTaskRepository(mockTaskRepo),
+				tasker.WithProjectRepository(mockProjectRepo),
+				tasker.WithMemberRepository(mockMemberRepo),
+				tasker.WithWorkflowRepository(mockWorkflowRepo),
+				tasker.WithWebhookRepository(mockWebhookRepo),
+				tasker.WithTransactor(transactor),
+			)
+
+			gin.SetMode(gin.TestMode)
+			r := authenticated("admin-id")
+			api := r.Group("/")
+			NewTaskHandler(taskService).Routes(api)
+			NewProjectHandler(taskService).Routes(api)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.target, bytes.NewBufferString(tt.inputBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedCommitted, transactor.committed)
+			if tt.expectedEvents != nil {
+				mockWebhookRepo.AssertNumberOfCalls(t, "Enqueue", len(tt.expectedEvents))
+				for _, event := range tt.expectedEvents {
+					mockWebhookRepo.AssertCalled(t, "Enqueue", mock.Anything, event)
+				}
+			}
+		})
+	}
+}
+
+func TestWebhookDispatch(t *testing.T) {
+	type received struct {
+		header http.Header
+		body   []byte
+	}
+	code := func(n int) *int { return &n }
+
+	event := webhook.Event{
+		ID:        "3",
+		Event:     "task.status_changed",
+		Entity:    "task",
+		EntityID:  "5",
+		ActorID:   helpers.GetStringPtr("2"),
+		Changes:   audit.Diff{"status": {Old: "Active", New: "Review"}},
+		CreatedAt: helpers.GetStringPtr("2024-05-01T10:00:00Z"),
+	}
+	body := `{"id":"3","event":"task.status_changed","entity":"task","entity_id":"5","actor_id":"2",` +
+		`"changes":{"status":{"old":"Active","new":"Review"}},"created_at":"2024-05-01T10:00:00Z"}`
+
+	tests := []struct {
+		name            string
+		receiverStatus  int
+		unreachable     bool
+		attempts        int
+		expectedN       int
+		expectedAttempt webhook.Attempt
+	}{
+		{
+			name:            "Delivered",
+			receiverStatus:  http.StatusNoContent,
+			expectedN:       1,
+			expectedAttempt: webhook.Attempt{Status: webhook.StatusDelivered, StatusCode: code(204)},
+		},
+		{
+			name:           "Failed Attempt Is Retried With Backoff",
+			receiverStatus: http.StatusServiceUnavailable,
+			attempts:       2,
+			expectedAttempt: webhook.Attempt{
+				Status:     webhook.StatusPending,
+				StatusCode: code(503),
+				Error:      helpers.GetStringPtr("receiver responded with 503 Service Unavailable"),
+				RetryIn:    2 * time.Minute,
+			},
+		},
+		{
+			name:           "Last Failed Attempt Is Dead",
+			receiverStatus: http.StatusInternalServerError,
+			attempts:       2,
+			expectedAttempt: webhook.Attempt{
+				Status:     webhook.StatusDead,
+				StatusCode: code(500),
+				Error:      helpers.GetStringPtr("receiver responded with 500 Internal Server Error"),
+			},
+		},
+		{
+			name:        "Unreachable Receiver Is Retried",
+			unreachable: true,
+			expectedAttempt: webhook.Attempt{
+				Status:  webhook.StatusPending,
+				RetryIn: 30 * time.Second,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := make(chan received, 1)
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				requests <- received{header: r.Header, body: b}
+				w.WriteHeader(tt.receiverStatus)
+			}))
+			defer receiver.Close()
+			if tt.unreachable {
+				receiver.Close()
+			}
+
+			job := webhook.Job{
+				DeliveryID: "7",
+				WebhookID:  "1",
+				Attempts:   tt.attempts,
+				URL:        receiver.URL,
+				Secret:     "0123456789abcdef",
+				Event:      event,
+			}
+			limits := webhook.Limits{Timeout: time.Second, MaxAttempts: 8}
+			if tt.expectedAttempt.Status == webhook.StatusDead {
+				limits.MaxAttempts = 3
+			}
+
+			var completed webhook.Attempt
+			mockWebhookRepo := new(MockWebhookRepository)
+			mockWebhookRepo.On("Claim", mock.Anything, 50, time.Minute).Return([]webhook.Job{job}, nil)
+			mockWebhookRepo.On("Complete", mock.Anything, "7", mock.Anything).Run(func(args mock.Arguments) {
+				completed = args.Get(2).(webhook.Attempt)
+			}).Return(nil)
+
+			taskService, _ := tasker.New(
+				tasker.WithWebhookRepository(mockWebhookRepo),
+				tasker.WithWebhookLimits(limits),
+			)
+
+			n, err := taskService.DispatchWebhooks(context.Background())
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedN, n)
+			if tt.unreachable {
+				// the error of a refused connection depends on the platform
+				assert.NotNil(t, completed.Error)
+				completed.Error = nil
+			}
+			assert.Equal(t, tt.expectedAttempt, completed)
+
+			if !tt.unreachable {
+				got := <-requests
+				assert.JSONEq(t, body, string(got.body))
+				assert.Equal(t, webhook.Sign("0123456789abcdef", got.body), got.header.Get(webhook.HeaderSignature))
+				assert.Equal(t, "task.status_changed", got.header.Get(webhook.HeaderEvent))
+				assert.Equal(t, "7", got.header.Get(webhook.HeaderDelivery))
+				assert.Equal(t, "application/json", got.header.Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, webhook.Backoff(1))
+	assert.Equal(t, time.Minute, webhook.Backoff(2))
+	assert.Equal(t, 32*time.Minute, webhook.Backoff(7))
+	assert.Equal(t, time.Hour, webhook.Backoff(8))
+	assert.Equal(t, time.Hour, webhook.Backoff(50))
+}
+
+func authenticatedContext(userID string) context.Context {
+	return auth.WithUserID(context.Background(), userID)
+}
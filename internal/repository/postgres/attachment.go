@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"hard/internal/domain/attachment"
+	"hard/pkg/store"
+)
+
+type AttachmentRepository struct {
+	db store.DB
+}
+
+func NewAttachmentRepository(db *sqlx.DB) *AttachmentRepository {
+	return &AttachmentRepository{db: store.NewDB(db)}
+}
+
+func (r *AttachmentRepository) List(ctx context.Context, taskID string, page store.Page) (dest []attachment.Entity, cursor store.Cursor, err error) {
+	query := `
+		SELECT id, task_id, uploader_id, file_name, content_type, size, blob_key, created_at
+		FROM attachments
+		WHERE task_id=$1`
+
+	query, args, err := page.Keyset(query, "id", []any{taskID})
+	if err != nil {
+		return
+	}
+
+	if err = r.db.SelectContext(ctx, &dest, query, args...); err != nil {
+		return
+	}
+
+	dest, cursor = store.Paginate(page, dest, attachmentCursor)
+
+	return
+}
+
+func (r *AttachmentRepository) Get(ctx context.Context, id string) (dest attachment.Entity, err error) {
+	query := `
+		SELECT id, task_id, uploader_id, file_name, content_type, size, blob_key, created_at
+		FROM attachments
+		WHERE id=$1`
+
+	args := []any{id}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *AttachmentRepository) Add(ctx context.Context, data attachment.Entity) (id string, err error) {
+	query := `
+		INSERT INTO attachments (task_id, uploader_id, file_name, content_type, size, blob_key)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+
+	args := []any{data.TaskID, data.UploaderID, data.FileName, data.ContentType, data.Size, data.BlobKey}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *AttachmentRepository) Delete(ctx context.Context, id string) (err error) {
+	query := `
+		DELETE FROM attachments
+		WHERE id=$1
+		RETURNING id`
+
+	args := []any{id}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+// ListOrphans returns the attachments left without a task by the foreign key
+// after their task was hard deleted.
+func (r *AttachmentRepository) ListOrphans(ctx context.Context, limit int) (dest []attachment.Entity, err error) {
+	query := `
+		SELECT id, task_id, uploader_id, file_name, content_type, size, blob_key, created_at
+		FROM attachments
+		WHERE task_id IS NULL
+		ORDER BY id
+		LIMIT $1`
+
+	args := []any{limit}
+
+	err = r.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func attachmentCursor(data attachment.Entity) []string {
+	return []string{data.ID}
+}
@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"hard/internal/domain/audit"
+	"hard/pkg/store"
+	"strings"
+)
+
+type AuditRepository struct {
+	db store.DB
+}
+
+func NewAuditRepository(db *sqlx.DB) *AuditRepository {
+	return &AuditRepository{db: store.NewDB(db)}
+}
+
+func (r *AuditRepository) Add(ctx context.Context, data audit.Entity) (err error) {
+	query := `
+		INSERT INTO audit_log (actor_id, entity, entity_id, operation, diff)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	args := []any{data.ActorID, data.EntityType, data.EntityID, data.Operation, data.Diff}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+
+	return
+}
+
+// List returns the newest records first.
+func (r *AuditRepository) List(ctx context.Context, filter audit.Filter, page store.Page) (dest []audit.Entity, cursor store.Cursor, err error) {
+	sets, args := r.prepareFilter(filter)
+	query := fmt.Sprintf(`
+		SELECT id, actor_id, entity, entity_id, operation, diff, created_at
+		FROM audit_log
+		WHERE 1=1 %s`, strings.Join(sets, " "))
+
+	query, args, err = page.KeysetBy(query, args, store.Key{Expr: "id", Desc: true})
+	if err != nil {
+		return
+	}
+
+	if err = r.db.SelectContext(ctx, &dest, query, args...); err != nil {
+		return
+	}
+
+	dest, cursor = store.Paginate(page, dest, auditCursor)
+
+	return
+}
+
+func (r *AuditRepository) prepareFilter(filter audit.Filter) (sets []string, args []any) {
+	if filter.EntityType != "" {
+		args = append(args, filter.EntityType)
+		sets = append(sets, fmt.Sprintf("AND entity=$%d", len(args)))
+	}
+	if filter.EntityID != "" {
+		args = append(args, filter.EntityID)
+		sets = append(sets, fmt.Sprintf("AND entity_id=$%d", len(args)))
+	}
+	if filter.ActorID != "" {
+		args = append(args, filter.ActorID)
+		sets = append(sets, fmt.Sprintf("AND actor_id=$%d", len(args)))
+	}
+	return
+}
+
+func auditCursor(data audit.Entity) []string {
+	return []string{data.ID}
+}
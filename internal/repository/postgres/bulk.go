@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"strconv"
+	"strings"
+)
+
+// numbered returns a CTE that takes one id per row from the serial sequence of
+// table and pairs it with the row ordinal, starting at 1. Bulk inserts write
+// these ids explicitly and read them back ordered by ord, so the result follows
+// the input order whatever order the rows are inserted or returned in.
+func numbered(table string, rows int) string {
+	return `numbered AS (
+			SELECT nextval(pg_get_serial_sequence('` + table + `', 'id')) AS id, ord
+			FROM generate_series(1, ` + strconv.Itoa(rows) + `) AS ord
+		)`
+}
+
+// rowValues returns the rows of a multi-row VALUES list whose first column is
+// the id numbered for the row, such as
+// ((SELECT id FROM numbered WHERE ord = 1), $1, $2) for one row of two columns.
+func rowValues(rows, columns int) string {
+	var b strings.Builder
+	for row := 0; row < rows; row++ {
+		if row > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString("((SELECT id FROM numbered WHERE ord = ")
+		b.WriteString(strconv.Itoa(row + 1))
+		b.WriteByte(')')
+		for column := 0; column < columns; column++ {
+			b.WriteString(", $")
+			b.WriteString(strconv.Itoa(row*columns + column + 1))
+		}
+		b.WriteByte(')')
+	}
+	return b.String()
+}
@@ -0,0 +1,228 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"hard/internal/domain/comment"
+	"hard/pkg/store"
+)
+
+type CommentRepository struct {
+	db store.DB
+}
+
+func NewCommentRepository(db *sqlx.DB) *CommentRepository {
+	return &CommentRepository{db: store.NewDB(db)}
+}
+
+// List returns the comments of the task in the order they were written,
+// replies refer to their thread through parent_comment_id.
+func (r *CommentRepository) List(ctx context.Context, taskID string, page store.Page) (dest []comment.Entity, cursor store.Cursor, err error) {
+	query := `
+		SELECT id, task_id, author_id, parent_comment_id, body, created_at, edited_at
+		FROM comments
+		WHERE task_id=$1`
+
+	query, args, err := page.Keyset(query, "id", []any{taskID})
+	if err != nil {
+		return
+	}
+
+	if err = r.db.SelectContext(ctx, &dest, query, args...); err != nil {
+		return
+	}
+
+	dest, cursor = store.Paginate(page, dest, commentCursor)
+
+	return
+}
+
+func (r *CommentRepository) Get(ctx context.Context, id string) (dest comment.Entity, err error) {
+	query := `
+		SELECT id, task_id, author_id, parent_comment_id, body, created_at, edited_at
+		FROM comments
+		WHERE id=$1`
+
+	args := []any{id}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *CommentRepository) Add(ctx context.Context, data comment.Entity) (id string, err error) {
+	query := `
+		INSERT INTO comments (task_id, author_id, parent_comment_id, body)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`
+
+	args := []any{data.TaskID, data.AuthorID, data.ParentCommentID, data.Body}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+// Update replaces the body of the comment and marks it as edited.
+func (r *CommentRepository) Update(ctx context.Context, id string, data comment.Entity) (err error) {
+	query := `
+		UPDATE comments
+		SET body=$1, edited_at=CURRENT_TIMESTAMP, updated_at=CURRENT_TIMESTAMP
+		WHERE id=$2
+		RETURNING id`
+
+	args := []any{data.Body, id}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+// Delete removes the comment, its replies, revisions and mentions go with it.
+func (r *CommentRepository) Delete(ctx context.Context, id string) (err error) {
+	query := `
+		DELETE FROM comments
+		WHERE id=$1
+		RETURNING id`
+
+	args := []any{id}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+// ListRevisions returns the previous bodies of the comment, oldest first.
+func (r *CommentRepository) ListRevisions(ctx context.Context, id string, page store.Page) (dest []comment.Revision, cursor store.Cursor, err error) {
+	query := `
+		SELECT id, comment_id, editor_id, body, created_at
+		FROM comment_revisions
+		WHERE comment_id=$1`
+
+	query, args, err := page.Keyset(query, "id", []any{id})
+	if err != nil {
+		return
+	}
+
+	if err = r.db.SelectContext(ctx, &dest, query, args...); err != nil {
+		return
+	}
+
+	dest, cursor = store.Paginate(page, dest, revisionCursor)
+
+	return
+}
+
+func (r *CommentRepository) AddRevision(ctx context.Context, data comment.Revision) (err error) {
+	query := `
+		INSERT INTO comment_revisions (comment_id, editor_id, body)
+		VALUES ($1, $2, $3)`
+
+	args := []any{data.CommentID, data.EditorID, data.Body}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+
+	return
+}
+
+// ListMentions returns the ids of the users mentioned in each of the comments.
+func (r *CommentRepository) ListMentions(ctx context.Context, ids []string) (dest map[string][]string, err error) {
+	query := `
+		SELECT comment_id, user_id
+		FROM comment_mentions
+		WHERE comment_id = ANY($1::int[])
+		ORDER BY user_id`
+
+	args := []any{pq.Array(ids)}
+
+	var rows []struct {
+		CommentID string `db:"comment_id"`
+		UserID    string `db:"user_id"`
+	}
+	if err = r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return
+	}
+
+	dest = make(map[string][]string, len(ids))
+	for _, row := range rows {
+		dest[row.CommentID] = append(dest[row.CommentID], row.UserID)
+	}
+
+	return
+}
+
+// SetMentions replaces the users mentioned in the comment.
+func (r *CommentRepository) SetMentions(ctx context.Context, id string, userIDs []string) (err error) {
+	query := `
+		DELETE FROM comment_mentions
+		WHERE comment_id=$1 AND NOT user_id = ANY($2::int[])`
+
+	// a nil slice would be passed as NULL and keep every mention
+	if userIDs == nil {
+		userIDs = []string{}
+	}
+	args := []any{id, pq.Array(userIDs)}
+
+	if _, err = r.db.ExecContext(ctx, query, args...); err != nil {
+		return
+	}
+
+	query = `
+		INSERT INTO comment_mentions (comment_id, user_id)
+		SELECT $1, unnest($2::int[])
+		ON CONFLICT DO NOTHING`
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+
+	return
+}
+
+// ListMentioning returns the comments on live tasks that mention the user.
+func (r *CommentRepository) ListMentioning(ctx context.Context, userID string, page store.Page) (dest []comment.Entity, cursor store.Cursor, err error) {
+	query := `
+		SELECT c.id, c.task_id, c.author_id, c.parent_comment_id, c.body, c.created_at, c.edited_at
+		FROM comment_mentions m
+		JOIN comments c ON c.id = m.comment_id
+		JOIN tasks t ON t.id = c.task_id
+		WHERE m.user_id=$1` + store.NotDeleted(ctx, "t.deleted_at")
+
+	query, args, err := page.Keyset(query, "c.id", []any{userID})
+	if err != nil {
+		return
+	}
+
+	if err = r.db.SelectContext(ctx, &dest, query, args...); err != nil {
+		return
+	}
+
+	dest, cursor = store.Paginate(page, dest, commentCursor)
+
+	return
+}
+
+func commentCursor(data comment.Entity) []string {
+	return []string{data.ID}
+}
+
+func revisionCursor(data comment.Revision) []string {
+	return []string{data.ID}
+}
@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"hard/internal/domain/dependency"
+	"hard/internal/domain/task"
+	"hard/pkg/store"
+)
+
+type DependencyRepository struct {
+	db store.DB
+}
+
+func NewDependencyRepository(db *sqlx.DB) *DependencyRepository {
+	return &DependencyRepository{db: store.NewDB(db)}
+}
+
+func (r *DependencyRepository) ListBlockers(ctx context.Context, taskID string, page store.Page) (dest []task.Entity, cursor store.Cursor, err error) {
+	query := `
+		SELECT t.id, t.title, t.description, t.priority, t.status, t.assignee_id, t.project_id, t.parent_id, t.start_date, t.due_date, t.estimate_hours, t.milestone_id, t.completed_at, t.deleted_at, t.version
+		FROM task_dependencies d
+		JOIN tasks t ON t.id = d.blocker_id
+		WHERE d.task_id=$1` + store.NotDeleted(ctx, "t.deleted_at")
+
+	query, args, err := page.Keyset(query, "t.id", []any{taskID})
+	if err != nil {
+		return
+	}
+
+	if err = r.db.SelectContext(ctx, &dest, query, args...); err != nil {
+		return
+	}
+
+	dest, cursor = store.Paginate(page, dest, taskCursor)
+
+	return
+}
+
+func (r *DependencyRepository) ListDependents(ctx context.Context, taskID string, page store.Page) (dest []task.Entity, cursor store.Cursor, err error) {
+	query := `
+		SELECT t.id, t.title, t.description, t.priority, t.status, t.assignee_id, t.project_id, t.parent_id, t.start_date, t.due_date, t.estimate_hours, t.milestone_id, t.completed_at, t.deleted_at, t.version
+		FROM task_dependencies d
+		JOIN tasks t ON t.id = d.task_id
+		WHERE d.blocker_id=$1` + store.NotDeleted(ctx, "t.deleted_at")
+
+	query, args, err := page.Keyset(query, "t.id", []any{taskID})
+	if err != nil {
+		return
+	}
+
+	if err = r.db.SelectContext(ctx, &dest, query, args...); err != nil {
+		return
+	}
+
+	dest, cursor = store.Paginate(page, dest, taskCursor)
+
+	return
+}
+
+// ListByProject returns the links between live tasks of the project.
+func (r *DependencyRepository) ListByProject(ctx context.Context, projectID string) (dest []dependency.Entity, err error) {
+	query := `
+		SELECT d.task_id, d.blocker_id, d.created_at
+		FROM task_dependencies d
+		JOIN tasks t ON t.id = d.task_id
+		JOIN tasks b ON b.id = d.blocker_id
+		WHERE t.project_id=$1 AND b.project_id=$1 AND t.deleted_at IS NULL AND b.deleted_at IS NULL
+		ORDER BY d.task_id, d.blocker_id`
+
+	args := []any{projectID}
+
+	err = r.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (r *DependencyRepository) Get(ctx context.Context, taskID, blockerID string) (dest dependency.Entity, err error) {
+	query := `
+		SELECT task_id, blocker_id, created_at
+		FROM task_dependencies
+		WHERE task_id=$1 AND blocker_id=$2`
+
+	args := []any{taskID, blockerID}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *DependencyRepository) Add(ctx context.Context, data dependency.Entity) (err error) {
+	query := `
+		INSERT INTO task_dependencies (task_id, blocker_id)
+		VALUES ($1, $2)`
+
+	args := []any{data.TaskID, data.BlockerID}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+
+	return
+}
+
+func (r *DependencyRepository) Delete(ctx context.Context, taskID, blockerID string) (err error) {
+	query := `
+		DELETE FROM task_dependencies
+		WHERE task_id=$1 AND blocker_id=$2
+		RETURNING task_id`
+
+	args := []any{taskID, blockerID}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&taskID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+// Blocks reports whether the blocker blocks the task directly or through
+// other tasks.
+func (r *DependencyRepository) Blocks(ctx context.Context, blockerID, taskID string) (ok bool, err error) {
+	query := `
+		WITH RECURSIVE blocked AS (
+			SELECT task_id
+			FROM task_dependencies
+			WHERE blocker_id=$1
+			UNION
+			SELECT d.task_id
+			FROM task_dependencies d
+			JOIN blocked b ON d.blocker_id = b.task_id
+		)
+		SELECT EXISTS (SELECT 1 FROM blocked WHERE task_id=$2)`
+
+	args := []any{blockerID, taskID}
+
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(&ok)
+
+	return
+}
+
+// OpenBlockers returns the ids of the live, not completed tasks blocking any of
+// the tasks. Blockers among the tasks themselves are skipped.
+func (r *DependencyRepository) OpenBlockers(ctx context.Context, taskIDs []string) (dest []string, err error) {
+	query := `
+		SELECT DISTINCT t.id
+		FROM task_dependencies d
+		JOIN tasks t ON t.id = d.blocker_id
+		WHERE d.task_id = ANY($1::int[]) AND NOT d.blocker_id = ANY($1::int[])
+			AND t.completed_at IS NULL AND t.deleted_at IS NULL
+		ORDER BY t.id`
+
+	args := []any{pq.Array(taskIDs)}
+
+	err = r.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
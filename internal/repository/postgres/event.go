@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"hard/internal/domain/event"
+	"hard/pkg/store"
+	"time"
+)
+
+const (
+	eventChannel = "event_stream"
+	// eventPayloadLimit keeps a notification under the 8000 bytes PostgreSQL
+	// allows, the changes of a larger event are left out.
+	eventPayloadLimit = 7000
+)
+
+type EventRepository struct {
+	db  store.DB
+	dsn string
+}
+
+// NewEventRepository listens on a connection of its own to dsn.
+func NewEventRepository(db *sqlx.DB, dsn string) *EventRepository {
+	return &EventRepository{db: store.NewDB(db), dsn: dsn}
+}
+
+type eventNotification struct {
+	ID    int64       `json:"id"`
+	Event event.Event `json:"event"`
+}
+
+func (r *EventRepository) NextID(ctx context.Context) (id int64, err error) {
+	query := `
+		SELECT nextval('event_stream_seq')`
+
+	err = r.db.QueryRowContext(ctx, query).Scan(&id)
+
+	return
+}
+
+func (r *EventRepository) Notify(ctx context.Context, id int64, data event.Event) (err error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	if len(payload) > eventPayloadLimit {
+		data.Changes = nil
+		if payload, err = json.Marshal(data); err != nil {
+			return
+		}
+	}
+
+	query := `
+		SELECT pg_notify($1, json_build_object('id', $2::bigint, 'event', $3::json)::text)`
+
+	args := []any{eventChannel, id, string(payload)}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+
+	return
+}
+
+func (r *EventRepository) Listen(ctx context.Context, fn func(id int64, data event.Event), lost func()) (err error) {
+	listener := pq.NewListener(r.dsn, time.Second, time.Minute, nil)
+	defer listener.Close()
+
+	if err = listener.Listen(eventChannel); err != nil {
+		return
+	}
+
+	ping := time.NewTicker(time.Minute)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case n := <-listener.Notify:
+			// a nil notification follows a reconnect, those sent meanwhile are lost
+			if n == nil {
+				lost()
+				continue
+			}
+			var data eventNotification
+			if err := json.Unmarshal([]byte(n.Extra), &data); err != nil {
+				continue
+			}
+			fn(data.ID, data.Event)
+		case <-ping.C:
+			go listener.Ping()
+		}
+	}
+}
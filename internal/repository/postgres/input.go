@@ -0,0 +1,19 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"hard/pkg/store"
+)
+
+// invalidInput tells a value the database cannot take, such as a malformed
+// date or id, from other failures of a write.
+func invalidInput(err error) error {
+	var pqErr *pq.Error
+	// class 22 is data_exception: bad text representation, out of range, too long
+	if errors.As(err, &pqErr) && pqErr.Code.Class() == "22" {
+		return fmt.Errorf("%w: %s", store.ErrorInvalidInput, pqErr.Message)
+	}
+	return err
+}
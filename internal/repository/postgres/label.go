@@ -0,0 +1,235 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"hard/internal/domain/label"
+	"hard/pkg/store"
+	"strings"
+)
+
+type LabelRepository struct {
+	db store.DB
+}
+
+func NewLabelRepository(db *sqlx.DB) *LabelRepository {
+	return &LabelRepository{db: store.NewDB(db)}
+}
+
+func (r *LabelRepository) List(ctx context.Context, projectID string, page store.Page) (dest []label.Entity, cursor store.Cursor, err error) {
+	query := `
+		SELECT id, project_id, name, color
+		FROM labels
+		WHERE project_id=$1`
+
+	query, args, err := page.Keyset(query, "id", []any{projectID})
+	if err != nil {
+		return
+	}
+
+	if err = r.db.SelectContext(ctx, &dest, query, args...); err != nil {
+		return
+	}
+
+	dest, cursor = store.Paginate(page, dest, labelCursor)
+
+	return
+}
+
+func (r *LabelRepository) Get(ctx context.Context, id string) (dest label.Entity, err error) {
+	query := `
+		SELECT id, project_id, name, color
+		FROM labels
+		WHERE id=$1`
+
+	args := []any{id}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *LabelRepository) GetByName(ctx context.Context, projectID, name string) (dest label.Entity, err error) {
+	query := `
+		SELECT id, project_id, name, color
+		FROM labels
+		WHERE project_id=$1 AND name=$2`
+
+	args := []any{projectID, name}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *LabelRepository) Add(ctx context.Context, data label.Entity) (id string, err error) {
+	query := `
+		INSERT INTO labels (project_id, name, color)
+		VALUES ($1, $2, $3)
+		RETURNING id`
+
+	args := []any{data.ProjectID, data.Name, data.Color}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *LabelRepository) Update(ctx context.Context, id string, data label.Entity) (err error) {
+	sets, args := r.prepareArgs(data)
+	if len(args) > 0 {
+		args = append(args, id)
+		sets = append(sets, "updated_at=CURRENT_TIMESTAMP")
+
+		query := fmt.Sprintf("UPDATE labels SET %s WHERE id=$%d RETURNING id", strings.Join(sets, ", "), len(args))
+
+		if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = store.ErrorNotFound
+			}
+		}
+	}
+
+	return
+}
+
+func (r *LabelRepository) prepareArgs(data label.Entity) (sets []string, args []any) {
+	if data.Name != nil {
+		args = append(args, data.Name)
+		sets = append(sets, fmt.Sprintf("name=$%d", len(args)))
+	}
+
+	if data.Color != nil {
+		args = append(args, data.Color)
+		sets = append(sets, fmt.Sprintf("color=$%d", len(args)))
+	}
+
+	return
+}
+
+// Delete removes the label, task_labels rows go with it.
+func (r *LabelRepository) Delete(ctx context.Context, id string) (err error) {
+	query := `
+		DELETE FROM labels
+		WHERE id=$1
+		RETURNING id`
+
+	args := []any{id}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+// ListByTasks returns the labels of each of the tasks ordered by name.
+func (r *LabelRepository) ListByTasks(ctx context.Context, taskIDs []string) (dest map[string][]label.Entity, err error) {
+	query := `
+		SELECT tl.task_id, l.id, l.project_id, l.name, l.color
+		FROM task_labels tl
+		JOIN labels l ON l.id = tl.label_id
+		WHERE tl.task_id = ANY($1::int[])
+		ORDER BY l.name, l.id`
+
+	args := []any{pq.Array(taskIDs)}
+
+	var rows []struct {
+		TaskID string `db:"task_id"`
+		label.Entity
+	}
+	if err = r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return
+	}
+
+	dest = make(map[string][]label.Entity, len(taskIDs))
+	for _, row := range rows {
+		dest[row.TaskID] = append(dest[row.TaskID], row.Entity)
+	}
+
+	return
+}
+
+func (r *LabelRepository) GetAssignment(ctx context.Context, taskID, labelID string) (dest label.Assignment, err error) {
+	query := `
+		SELECT task_id, label_id, created_at
+		FROM task_labels
+		WHERE task_id=$1 AND label_id=$2`
+
+	args := []any{taskID, labelID}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *LabelRepository) Attach(ctx context.Context, data label.Assignment) (err error) {
+	query := `
+		INSERT INTO task_labels (task_id, label_id)
+		VALUES ($1, $2)`
+
+	args := []any{data.TaskID, data.LabelID}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+
+	return
+}
+
+func (r *LabelRepository) Detach(ctx context.Context, taskID, labelID string) (err error) {
+	query := `
+		DELETE FROM task_labels
+		WHERE task_id=$1 AND label_id=$2
+		RETURNING task_id`
+
+	args := []any{taskID, labelID}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&taskID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+// DetachOtherProjects removes the labels that do not belong to the project of
+// the task, after the task moved to another project.
+func (r *LabelRepository) DetachOtherProjects(ctx context.Context, taskID string) (err error) {
+	query := `
+		DELETE FROM task_labels tl
+		USING labels l, tasks t
+		WHERE tl.task_id=$1 AND l.id = tl.label_id AND t.id = tl.task_id
+			AND l.project_id <> t.project_id`
+
+	args := []any{taskID}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+
+	return
+}
+
+func labelCursor(data label.Entity) []string {
+	return []string{data.ID}
+}
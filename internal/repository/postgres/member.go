@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"hard/internal/domain/member"
+	"hard/pkg/store"
+)
+
+type MemberRepository struct {
+	db store.DB
+}
+
+func NewMemberRepository(db *sqlx.DB) *MemberRepository {
+	return &MemberRepository{db: store.NewDB(db)}
+}
+
+func (r *MemberRepository) List(ctx context.Context, projectID string, page store.Page) (dest []member.Entity, cursor store.Cursor, err error) {
+	query := `
+		SELECT m.project_id, m.user_id, m.role, u.full_name, u.email
+		FROM project_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.project_id=$1` + store.NotDeleted(ctx, "u.deleted_at")
+
+	query, args, err := page.Keyset(query, "m.user_id", []any{projectID})
+	if err != nil {
+		return
+	}
+
+	if err = r.db.SelectContext(ctx, &dest, query, args...); err != nil {
+		return
+	}
+
+	dest, cursor = store.Paginate(page, dest, memberCursor)
+
+	return
+}
+
+func (r *MemberRepository) Get(ctx context.Context, projectID, userID string) (dest member.Entity, err error) {
+	query := `
+		SELECT m.project_id, m.user_id, m.role, u.full_name, u.email
+		FROM project_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.project_id=$1 AND m.user_id=$2` + store.NotDeleted(ctx, "u.deleted_at")
+
+	args := []any{projectID, userID}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *MemberRepository) Add(ctx context.Context, data member.Entity) (err error) {
+	query := `
+		INSERT INTO project_members (project_id, user_id, role)
+		VALUES ($1, $2, $3)`
+
+	args := []any{data.ProjectID, data.UserID, data.Role}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+
+	return
+}
+
+func (r *MemberRepository) Update(ctx context.Context, data member.Entity) (err error) {
+	query := `
+		UPDATE project_members
+		SET role=$3, updated_at=CURRENT_TIMESTAMP
+		WHERE project_id=$1 AND user_id=$2
+		RETURNING user_id`
+
+	args := []any{data.ProjectID, data.UserID, data.Role}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&data.UserID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *MemberRepository) Delete(ctx context.Context, projectID, userID string) (err error) {
+	query := `
+		DELETE FROM project_members
+		WHERE project_id=$1 AND user_id=$2
+		RETURNING user_id`
+
+	args := []any{projectID, userID}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func memberCursor(data member.Entity) []string {
+	return []string{data.UserID}
+}
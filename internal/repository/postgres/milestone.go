@@ -0,0 +1,238 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"hard/internal/domain/milestone"
+	"hard/pkg/store"
+	"strings"
+)
+
+type MilestoneRepository struct {
+	db store.DB
+}
+
+func NewMilestoneRepository(db *sqlx.DB) *MilestoneRepository {
+	return &MilestoneRepository{db: store.NewDB(db)}
+}
+
+func (r *MilestoneRepository) List(ctx context.Context, projectID string, page store.Page) (dest []milestone.Entity, cursor store.Cursor, err error) {
+	query := `
+		SELECT id, project_id, name, goal, start_date, end_date, closed_at
+		FROM milestones
+		WHERE project_id=$1`
+
+	query, args, err := page.Keyset(query, "id", []any{projectID})
+	if err != nil {
+		return
+	}
+
+	if err = r.db.SelectContext(ctx, &dest, query, args...); err != nil {
+		return
+	}
+
+	dest, cursor = store.Paginate(page, dest, milestoneCursor)
+
+	return
+}
+
+func (r *MilestoneRepository) Get(ctx context.Context, id string) (dest milestone.Entity, err error) {
+	query := `
+		SELECT id, project_id, name, goal, start_date, end_date, closed_at
+		FROM milestones
+		WHERE id=$1`
+
+	args := []any{id}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *MilestoneRepository) GetByName(ctx context.Context, projectID, name string) (dest milestone.Entity, err error) {
+	query := `
+		SELECT id, project_id, name, goal, start_date, end_date, closed_at
+		FROM milestones
+		WHERE project_id=$1 AND name=$2`
+
+	args := []any{projectID, name}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *MilestoneRepository) Add(ctx context.Context, data milestone.Entity) (id string, err error) {
+	query := `
+		INSERT INTO milestones (project_id, name, goal, start_date, end_date)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`
+
+	args := []any{data.ProjectID, data.Name, data.Goal, data.StartDate, data.EndDate}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *MilestoneRepository) Update(ctx context.Context, id string, data milestone.Entity) (err error) {
+	sets, args := r.prepareArgs(data)
+	if len(args) > 0 {
+		args = append(args, id)
+		sets = append(sets, "updated_at=CURRENT_TIMESTAMP")
+
+		query := fmt.Sprintf("UPDATE milestones SET %s WHERE id=$%d RETURNING id", strings.Join(sets, ", "), len(args))
+
+		if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = store.ErrorNotFound
+			}
+		}
+	}
+
+	return
+}
+
+func (r *MilestoneRepository) prepareArgs(data milestone.Entity) (sets []string, args []any) {
+	if data.Name != nil {
+		args = append(args, data.Name)
+		sets = append(sets, fmt.Sprintf("name=$%d", len(args)))
+	}
+
+	if data.Goal != nil {
+		args = append(args, sql.NullString{String: *data.Goal, Valid: *data.Goal != ""})
+		sets = append(sets, fmt.Sprintf("goal=$%d", len(args)))
+	}
+
+	if data.StartDate != nil {
+		args = append(args, sql.NullString{String: *data.StartDate, Valid: *data.StartDate != ""})
+		sets = append(sets, fmt.Sprintf("start_date=$%d::date", len(args)))
+	}
+
+	if data.EndDate != nil {
+		args = append(args, sql.NullString{String: *data.EndDate, Valid: *data.EndDate != ""})
+		sets = append(sets, fmt.Sprintf("end_date=$%d::date", len(args)))
+	}
+
+	return
+}
+
+// Delete removes the milestone, its tasks are left without one.
+func (r *MilestoneRepository) Delete(ctx context.Context, id string) (err error) {
+	query := `
+		DELETE FROM milestones
+		WHERE id=$1
+		RETURNING id`
+
+	args := []any{id}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *MilestoneRepository) Close(ctx context.Context, id string) (closedAt string, err error) {
+	query := `
+		UPDATE milestones
+		SET closed_at=CURRENT_DATE, updated_at=CURRENT_TIMESTAMP
+		WHERE id=$1 AND closed_at IS NULL
+		RETURNING closed_at`
+
+	args := []any{id}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&closedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *MilestoneRepository) Next(ctx context.Context, data milestone.Entity) (dest milestone.Entity, err error) {
+	query := `
+		SELECT id, project_id, name, goal, start_date, end_date, closed_at
+		FROM milestones
+		WHERE project_id=$1 AND id<>$2 AND closed_at IS NULL
+			AND ($3::date IS NULL OR start_date IS NULL OR start_date >= $3::date)
+		ORDER BY start_date NULLS LAST, id
+		LIMIT 1`
+
+	args := []any{data.ProjectID, data.ID, data.StartDate}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+// MoveOpenTasks bumps the version of the moved tasks like any other update.
+func (r *MilestoneRepository) MoveOpenTasks(ctx context.Context, from, to string) (ids []string, err error) {
+	query := `
+		WITH moved AS (
+			UPDATE tasks
+			SET milestone_id=$2::int, updated_at=CURRENT_TIMESTAMP, version=version+1
+			WHERE milestone_id=$1 AND completed_at IS NULL AND deleted_at IS NULL
+			RETURNING id
+		)
+		SELECT id FROM moved ORDER BY id`
+
+	args := []any{from, sql.NullString{String: to, Valid: to != ""}}
+
+	err = r.db.SelectContext(ctx, &ids, query, args...)
+
+	return
+}
+
+func (r *MilestoneRepository) Stats(ctx context.Context, ids []string) (dest map[string]milestone.Stats, err error) {
+	query := `
+		SELECT milestone_id,
+			COUNT(*) AS total,
+			COUNT(completed_at) AS completed,
+			COALESCE(SUM(estimate_hours), 0)::float8 AS estimate_hours,
+			COALESCE(SUM(estimate_hours) FILTER (WHERE completed_at IS NOT NULL), 0)::float8 AS completed_hours
+		FROM tasks
+		WHERE milestone_id = ANY($1::int[]) AND deleted_at IS NULL
+		GROUP BY milestone_id`
+
+	args := []any{pq.Array(ids)}
+
+	var rows []milestone.Stats
+	if err = r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return
+	}
+
+	dest = make(map[string]milestone.Stats, len(rows))
+	for _, row := range rows {
+		dest[row.MilestoneID] = row
+	}
+
+	return
+}
+
+func milestoneCursor(data milestone.Entity) []string {
+	return []string{data.ID}
+}
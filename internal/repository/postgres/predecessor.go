@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"hard/internal/domain/schedule"
+	"hard/internal/domain/task"
+	"hard/pkg/store"
+)
+
+type PredecessorRepository struct {
+	db store.DB
+}
+
+func NewPredecessorRepository(db *sqlx.DB) *PredecessorRepository {
+	return &PredecessorRepository{db: store.NewDB(db)}
+}
+
+func (r *PredecessorRepository) ListPredecessors(ctx context.Context, taskID string, page store.Page) (dest []task.Entity, cursor store.Cursor, err error) {
+	query := `
+		SELECT t.id, t.title, t.description, t.priority, t.status, t.assignee_id, t.project_id, t.parent_id, t.start_date, t.due_date, t.estimate_hours, t.milestone_id, t.completed_at, t.deleted_at, t.version
+		FROM task_predecessors p
+		JOIN tasks t ON t.id = p.predecessor_id
+		WHERE p.task_id=$1` + store.NotDeleted(ctx, "t.deleted_at")
+
+	query, args, err := page.Keyset(query, "t.id", []any{taskID})
+	if err != nil {
+		return
+	}
+
+	if err = r.db.SelectContext(ctx, &dest, query, args...); err != nil {
+		return
+	}
+
+	dest, cursor = store.Paginate(page, dest, taskCursor)
+
+	return
+}
+
+// ListByProject returns the links between live tasks of the project.
+func (r *PredecessorRepository) ListByProject(ctx context.Context, projectID string) (dest []schedule.Link, err error) {
+	query := `
+		SELECT p.task_id, p.predecessor_id, p.created_at
+		FROM task_predecessors p
+		JOIN tasks t ON t.id = p.task_id
+		JOIN tasks b ON b.id = p.predecessor_id
+		WHERE t.project_id=$1 AND b.project_id=$1 AND t.deleted_at IS NULL AND b.deleted_at IS NULL
+		ORDER BY p.task_id, p.predecessor_id`
+
+	args := []any{projectID}
+
+	err = r.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (r *PredecessorRepository) Get(ctx context.Context, taskID, predecessorID string) (dest schedule.Link, err error) {
+	query := `
+		SELECT task_id, predecessor_id, created_at
+		FROM task_predecessors
+		WHERE task_id=$1 AND predecessor_id=$2`
+
+	args := []any{taskID, predecessorID}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *PredecessorRepository) Add(ctx context.Context, data schedule.Link) (err error) {
+	query := `
+		INSERT INTO task_predecessors (task_id, predecessor_id)
+		VALUES ($1, $2)`
+
+	args := []any{data.TaskID, data.PredecessorID}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+
+	return
+}
+
+func (r *PredecessorRepository) Delete(ctx context.Context, taskID, predecessorID string) (err error) {
+	query := `
+		DELETE FROM task_predecessors
+		WHERE task_id=$1 AND predecessor_id=$2
+		RETURNING task_id`
+
+	args := []any{taskID, predecessorID}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&taskID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+// Precedes reports whether the predecessor comes before the task directly or
+// through other tasks.
+func (r *PredecessorRepository) Precedes(ctx context.Context, predecessorID, taskID string) (ok bool, err error) {
+	query := `
+		WITH RECURSIVE successors AS (
+			SELECT task_id
+			FROM task_predecessors
+			WHERE predecessor_id=$1
+			UNION
+			SELECT p.task_id
+			FROM task_predecessors p
+			JOIN successors s ON p.predecessor_id = s.task_id
+		)
+		SELECT EXISTS (SELECT 1 FROM successors WHERE task_id=$2)`
+
+	args := []any{predecessorID, taskID}
+
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(&ok)
+
+	return
+}
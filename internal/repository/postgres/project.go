@@ -6,27 +6,38 @@ import (
 	"errors"
 	"fmt"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"hard/internal/domain/project"
 	"hard/internal/domain/task"
 	"hard/pkg/store"
 	"strings"
+	"time"
 )
 
 type ProjectRepository struct {
-	db *sqlx.DB
+	db store.DB
 }
 
 func NewProjectRepository(db *sqlx.DB) *ProjectRepository {
-	return &ProjectRepository{db: db}
+	return &ProjectRepository{db: store.NewDB(db)}
 }
 
-func (r *ProjectRepository) List(ctx context.Context) (dest []project.Entity, err error) {
+func (r *ProjectRepository) List(ctx context.Context, page store.Page) (dest []project.Entity, cursor store.Cursor, err error) {
 	query := `
-			SELECT id, title, description, start_date, end_date, manager_id 
+			SELECT id, title, description, start_date, end_date, manager_id, deleted_at, version
 			FROM projects
-			ORDER BY id`
+			WHERE 1=1` + store.NotDeleted(ctx, "deleted_at")
 
-	err = r.db.SelectContext(ctx, &dest, query)
+	query, args, err := page.Keyset(query, "id", nil)
+	if err != nil {
+		return
+	}
+
+	if err = r.db.SelectContext(ctx, &dest, query, args...); err != nil {
+		return
+	}
+
+	dest, cursor = store.Paginate(page, dest, projectCursor)
 
 	return
 }
@@ -43,16 +54,38 @@ func (r *ProjectRepository) Add(ctx context.Context, data project.Entity) (id st
 		if errors.Is(err, sql.ErrNoRows) {
 			err = store.ErrorNotFound
 		}
+		err = invalidInput(err)
+	}
+
+	return
+}
+
+// AddMany inserts the projects with one statement and returns their ids in the
+// order of data, matched to the rows by their ordinal.
+func (r *ProjectRepository) AddMany(ctx context.Context, data []project.Entity) (ids []string, err error) {
+	query := `
+		WITH ` + numbered("projects", len(data)) + `, created AS (
+			INSERT INTO projects (id, title, description, start_date, end_date, manager_id)
+			VALUES ` + rowValues(len(data), 5) + `
+			RETURNING id
+		)
+		SELECT n.id FROM numbered n JOIN created c ON c.id = n.id ORDER BY n.ord`
+
+	args := make([]any, 0, len(data)*5)
+	for _, row := range data {
+		args = append(args, row.Title, row.Description, row.StartDate, row.EndDate, row.ManagerID)
 	}
 
+	err = invalidInput(r.db.SelectContext(ctx, &ids, query, args...))
+
 	return
 }
 
 func (r *ProjectRepository) Get(ctx context.Context, id string) (dest project.Entity, err error) {
 	query := `
-		SELECT id, title, description, start_date, end_date, manager_id
+		SELECT id, title, description, start_date, end_date, manager_id, deleted_at, version
 		FROM projects 
-		WHERE id=$1`
+		WHERE id=$1` + store.NotDeleted(ctx, "deleted_at")
 
 	args := []any{id}
 
@@ -65,18 +98,42 @@ func (r *ProjectRepository) Get(ctx context.Context, id string) (dest project.En
 	return
 }
 
+// GetMany returns the projects with the given ids, the missing ones are skipped.
+func (r *ProjectRepository) GetMany(ctx context.Context, ids []string) (dest []project.Entity, err error) {
+	query := `
+		SELECT id, title, description, start_date, end_date, manager_id, deleted_at, version
+		FROM projects 
+		WHERE id = ANY($1::int[])` + store.NotDeleted(ctx, "deleted_at") + `
+		ORDER BY id`
+
+	args := []any{pq.Array(ids)}
+
+	err = r.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
 func (r *ProjectRepository) Update(ctx context.Context, id string, data project.Entity) (err error) {
 	sets, args := r.prepareArgs(data)
 	if len(args) > 0 {
 		args = append(args, id)
-		sets = append(sets, "updated_at=CURRENT_TIMESTAMP")
+		sets = append(sets, "updated_at=CURRENT_TIMESTAMP", "version=version+1")
+		where := fmt.Sprintf("id=$%d AND deleted_at IS NULL", len(args))
+		if data.Version != nil {
+			args = append(args, *data.Version)
+			where += fmt.Sprintf(" AND version=$%d", len(args))
+		}
 
-		query := fmt.Sprintf("UPDATE projects SET %s WHERE id=$%d RETURNING id", strings.Join(sets, ", "), len(args))
+		query := fmt.Sprintf("UPDATE projects SET %s WHERE %s RETURNING id", strings.Join(sets, ", "), where)
 
 		if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
 				err = store.ErrorNotFound
+				if data.Version != nil {
+					err = versionConflict(ctx, r.db, "projects", id)
+				}
 			}
+			err = invalidInput(err)
 		}
 	}
 
@@ -112,11 +169,57 @@ func (r *ProjectRepository) prepareArgs(data project.Entity) (sets []string, arg
 	return
 }
 
-func (r *ProjectRepository) Delete(ctx context.Context, id string) (err error) {
+// Delete soft deletes the project together with its tasks. The tasks get the
+// same deleted_at so that Restore can bring back exactly those.
+func (r *ProjectRepository) Delete(ctx context.Context, id string, version *int) (err error) {
 	query := `
-		DELETE FROM projects
-		WHERE id=$1
-		RETURNING id`
+		WITH project AS (
+			UPDATE projects
+			SET deleted_at=CURRENT_TIMESTAMP, version=version+1
+			WHERE id=$1 AND deleted_at IS NULL AND ($2::int IS NULL OR version=$2)
+			RETURNING id, deleted_at
+		), project_tasks AS (
+			UPDATE tasks t
+			SET deleted_at=p.deleted_at, version=t.version+1
+			FROM project p
+			WHERE t.project_id = p.id AND t.deleted_at IS NULL
+		)
+		SELECT id FROM project`
+
+	args := []any{id, version}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+			if version != nil {
+				err = versionConflict(ctx, r.db, "projects", id)
+			}
+		}
+	}
+
+	return
+}
+
+// Restore brings back the project and the tasks deleted along with it.
+func (r *ProjectRepository) Restore(ctx context.Context, id string) (err error) {
+	query := `
+		WITH project AS (
+			SELECT id, deleted_at
+			FROM projects
+			WHERE id=$1 AND deleted_at IS NOT NULL
+		), project_tasks AS (
+			UPDATE tasks t
+			SET deleted_at=NULL, version=t.version+1
+			FROM project p
+			WHERE t.project_id = p.id AND t.deleted_at = p.deleted_at
+		), restored AS (
+			UPDATE projects
+			SET deleted_at=NULL, version=projects.version+1
+			FROM project p
+			WHERE projects.id = p.id
+			RETURNING projects.id
+		)
+		SELECT id FROM restored`
 
 	args := []any{id}
 
@@ -129,26 +232,51 @@ func (r *ProjectRepository) Delete(ctx context.Context, id string) (err error) {
 	return
 }
 
-func (r *ProjectRepository) Search(ctx context.Context, data project.Entity) (dest []project.Entity, err error) {
-	query := "SELECT id, title, description, start_date, end_date, manager_id FROM projects WHERE 1=1"
+// Purge hard deletes the projects soft deleted longer than retention ago, their
+// tasks are removed by the foreign key.
+func (r *ProjectRepository) Purge(ctx context.Context, retention time.Duration) (n int64, err error) {
+	query := `
+		DELETE FROM projects
+		WHERE deleted_at < CURRENT_TIMESTAMP - $1 * INTERVAL '1 second'`
+
+	args := []any{retention.Seconds()}
+
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return
+	}
+
+	return res.RowsAffected()
+}
+
+func (r *ProjectRepository) Search(ctx context.Context, data project.Entity, page store.Page) (dest []project.Entity, cursor store.Cursor, err error) {
+	query := "SELECT id, title, description, start_date, end_date, manager_id, deleted_at, version FROM projects WHERE 1=1" + store.NotDeleted(ctx, "deleted_at")
 
 	sets, args := r.prepareArgs(data)
 	if len(sets) > 0 {
 		query += " AND " + strings.Join(sets, " AND ")
 	}
 
-	err = r.db.SelectContext(ctx, &dest, query, args...)
+	query, args, err = page.Keyset(query, "id", args)
+	if err != nil {
+		return
+	}
+
+	if err = r.db.SelectContext(ctx, &dest, query, args...); err != nil {
+		return
+	}
+
+	dest, cursor = store.Paginate(page, dest, projectCursor)
 
 	return
 }
 
-func (r *ProjectRepository) ListTasks(ctx context.Context, id string) (dest []task.Entity, err error) {
+func (r *ProjectRepository) ListTasks(ctx context.Context, id string, page store.Page) (dest []task.Entity, cursor store.Cursor, err error) {
 	args := []any{id}
 	existsQuery := `
 			SELECT 1
 			FROM projects 
-			WHERE id=$1
-		`
+			WHERE id=$1` + store.NotDeleted(ctx, "deleted_at")
 
 	if err = r.db.QueryRowContext(ctx, existsQuery, id).Scan(&id); err != nil {
 		err = store.ErrorNotFound
@@ -156,13 +284,24 @@ func (r *ProjectRepository) ListTasks(ctx context.Context, id string) (dest []ta
 	}
 
 	query := `
-		SELECT id, title, description, priority, status, assignee_id, project_id, completed_at
+		SELECT id, title, description, priority, status, assignee_id, project_id, parent_id, start_date, due_date, estimate_hours, milestone_id, completed_at, deleted_at, version
 		FROM tasks 
-		WHERE project_id=$1`
+		WHERE project_id=$1` + store.NotDeleted(ctx, "deleted_at")
+
+	query, args, err = page.Keyset(query, "id", args)
+	if err != nil {
+		return
+	}
 
 	if err = r.db.SelectContext(ctx, &dest, query, args...); err != nil {
 		return
 	}
 
+	dest, cursor = store.Paginate(page, dest, taskCursor)
+
 	return
 }
+
+func projectCursor(data project.Entity) []string {
+	return []string{data.ID}
+}
@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"hard/internal/domain/recurrence"
+	"hard/pkg/store"
+)
+
+type RecurrenceRepository struct {
+	db store.DB
+}
+
+func NewRecurrenceRepository(db *sqlx.DB) *RecurrenceRepository {
+	return &RecurrenceRepository{db: store.NewDB(db)}
+}
+
+func (r *RecurrenceRepository) GetByTask(ctx context.Context, taskID string) (dest recurrence.Entity, err error) {
+	query := `
+		SELECT id, task_id, rule, trigger, start_date, last_date, next_date, generated
+		FROM recurrences
+		WHERE task_id=$1 OR id IN (SELECT recurrence_id FROM recurrence_occurrences WHERE task_id=$1)`
+
+	args := []any{taskID}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *RecurrenceRepository) Add(ctx context.Context, data recurrence.Entity) (id string, err error) {
+	query := `
+		WITH series AS (
+			INSERT INTO recurrences (task_id, rule, trigger, start_date, last_date, next_date, generated)
+			VALUES ($1, $2, $3, $4, $5::date, $6, $7)
+			RETURNING id
+		), occurrence AS (
+			INSERT INTO recurrence_occurrences (recurrence_id, occurrence, task_id)
+			SELECT id, $5::date, $1 FROM series
+		)
+		SELECT id FROM series`
+
+	args := []any{data.TaskID, data.Rule, data.Trigger, data.StartDate, data.LastDate, data.NextDate, data.Generated}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *RecurrenceRepository) Update(ctx context.Context, id string, data recurrence.Entity) (err error) {
+	query := `
+		UPDATE recurrences
+		SET rule=$2, trigger=$3, start_date=$4, last_date=$5, next_date=$6, generated=$7, updated_at=CURRENT_TIMESTAMP
+		WHERE id=$1
+		RETURNING id`
+
+	args := []any{id, data.Rule, data.Trigger, data.StartDate, data.LastDate, data.NextDate, data.Generated}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *RecurrenceRepository) Delete(ctx context.Context, id string) (err error) {
+	query := `
+		DELETE FROM recurrences
+		WHERE id=$1
+		RETURNING id`
+
+	args := []any{id}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+// ListDue leaves out series whose latest occurrence is soft deleted, they are
+// paused until it is restored.
+func (r *RecurrenceRepository) ListDue(ctx context.Context, today string) (dest []recurrence.Entity, err error) {
+	query := `
+		SELECT r.id, r.task_id, r.rule, r.trigger, r.start_date, r.last_date, r.next_date, r.generated
+		FROM recurrences r
+		JOIN tasks t ON t.id = r.task_id
+		WHERE r.next_date IS NOT NULL AND t.deleted_at IS NULL
+			AND (r.trigger='schedule' AND r.next_date <= $1::date OR r.trigger='completion' AND t.completed_at IS NOT NULL)
+		ORDER BY r.id`
+
+	args := []any{today}
+
+	err = r.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+// Advance claims the occurrence first: a second run creating the same one
+// inserts nothing, so the series is left alone and the caller rolls back.
+func (r *RecurrenceRepository) Advance(ctx context.Context, data recurrence.Occurrence, next *string, generated int) (err error) {
+	query := `
+		WITH claimed AS (
+			INSERT INTO recurrence_occurrences (recurrence_id, occurrence, task_id)
+			VALUES ($1, $2::date, $3)
+			ON CONFLICT DO NOTHING
+			RETURNING recurrence_id
+		)
+		UPDATE recurrences
+		SET task_id=$3, last_date=$2::date, next_date=$4, generated=$5::int, updated_at=CURRENT_TIMESTAMP
+		WHERE id IN (SELECT recurrence_id FROM claimed) AND generated=$5::int-1
+		RETURNING id`
+
+	args := []any{data.RecurrenceID, data.Date, data.TaskID, next, generated}
+
+	var id string
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorConflict
+		}
+	}
+
+	return
+}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"hard/internal/domain/report"
+	"hard/pkg/store"
+)
+
+// taskStates finds the status every task of the report had at the end of
+// every day: $1 is the project, $2 and $3 the first and the last day and $4
+// an optional milestone. A task counts from the day it was created until the
+// day it was deleted.
+const taskStates = `
+	WITH days AS (
+		SELECT day::date AS day
+		FROM generate_series($2::date::timestamp, $3::date::timestamp, interval '1 day') AS day
+	), scope AS (
+		SELECT id, estimate_hours, created_at, deleted_at
+		FROM tasks
+		WHERE project_id=$1 AND ($4::int IS NULL OR milestone_id=$4::int)
+	), states AS (
+		SELECT d.day, s.id, s.estimate_hours, (
+			SELECT c.to_status
+			FROM task_status_changes c
+			WHERE c.task_id=s.id AND c.changed_at < d.day + 1
+			ORDER BY c.changed_at DESC, c.id DESC
+			LIMIT 1
+		) AS status
+		FROM days d
+		JOIN scope s ON s.created_at < d.day + 1 AND (s.deleted_at IS NULL OR s.deleted_at >= d.day + 1)
+	)`
+
+type ReportRepository struct {
+	db store.DB
+}
+
+func NewReportRepository(db *sqlx.DB) *ReportRepository {
+	return &ReportRepository{db: store.NewDB(db)}
+}
+
+func (r *ReportRepository) Burndown(ctx context.Context, filter report.Filter) (dest []report.Burndown, err error) {
+	query := taskStates + `
+		SELECT to_char(d.day, 'YYYY-MM-DD') AS date,
+			COUNT(st.id) AS total_tasks,
+			COUNT(st.id) FILTER (WHERE st.status = ANY($5::text[])) AS completed_tasks,
+			COUNT(st.id) FILTER (WHERE st.status IS NULL OR NOT st.status = ANY($5::text[])) AS remaining_tasks,
+			COALESCE(SUM(st.estimate_hours) FILTER (WHERE st.status IS NULL OR NOT st.status = ANY($5::text[])), 0)::float8 AS remaining_hours
+		FROM days d
+		LEFT JOIN states st ON st.day=d.day
+		GROUP BY d.day
+		ORDER BY d.day`
+
+	args := []any{filter.ProjectID, filter.From, filter.To, nullID(filter.MilestoneID), pq.Array(filter.Terminal)}
+
+	err = r.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+// Velocity counts a task in the period it entered a terminal status from a
+// status that is not one, a task created completed counts too. The first and
+// the last periods are counted whole, $6 is an optional milestone.
+func (r *ReportRepository) Velocity(ctx context.Context, filter report.Filter) (dest []report.Velocity, err error) {
+	query := `
+		WITH periods AS (
+			SELECT start::date AS start
+			FROM generate_series(
+				date_trunc($2, $3::date::timestamp),
+				date_trunc($2, $4::date::timestamp),
+				('1 ' || $2)::interval
+			) AS start
+		), completions AS (
+			SELECT DISTINCT ON (c.task_id, date_trunc($2, c.changed_at))
+				c.task_id, date_trunc($2, c.changed_at)::date AS start, t.estimate_hours
+			FROM task_status_changes c
+			JOIN tasks t ON t.id=c.task_id
+			WHERE t.project_id=$1 AND t.deleted_at IS NULL
+				AND ($6::int IS NULL OR t.milestone_id=$6::int)
+				AND c.to_status = ANY($5::text[])
+				AND (c.from_status IS NULL OR NOT c.from_status = ANY($5::text[]))
+				AND c.changed_at >= date_trunc($2, $3::date::timestamp)
+				AND c.changed_at < date_trunc($2, $4::date::timestamp) + ('1 ' || $2)::interval
+		)
+		SELECT to_char(p.start, 'YYYY-MM-DD') AS start,
+			COUNT(c.task_id) AS completed_tasks,
+			COALESCE(SUM(c.estimate_hours), 0)::float8 AS completed_hours
+		FROM periods p
+		LEFT JOIN completions c ON c.start=p.start
+		GROUP BY p.start
+		ORDER BY p.start`
+
+	args := []any{filter.ProjectID, filter.Period, filter.From, filter.To, pq.Array(filter.Terminal), nullID(filter.MilestoneID)}
+
+	err = r.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+// CumulativeFlow fills in zeros so that every day lists the same statuses:
+// those of the workflow and any other status a task had in the range.
+func (r *ReportRepository) CumulativeFlow(ctx context.Context, filter report.Filter) (dest []report.Flow, err error) {
+	query := taskStates + `, statuses AS (
+			SELECT status, array_position($5::text[], status) AS position
+			FROM (
+				SELECT unnest($5::text[]) AS status
+				UNION
+				SELECT status FROM states WHERE status IS NOT NULL
+			) AS known
+		)
+		SELECT to_char(d.day, 'YYYY-MM-DD') AS date, s.status, COUNT(st.id) AS tasks
+		FROM days d
+		CROSS JOIN statuses s
+		LEFT JOIN states st ON st.day=d.day AND st.status=s.status
+		GROUP BY d.day, s.status, s.position
+		ORDER BY d.day, s.position NULLS LAST, s.status`
+
+	args := []any{filter.ProjectID, filter.From, filter.To, nullID(filter.MilestoneID), pq.Array(filter.Statuses)}
+
+	err = r.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+// nullID passes an optional ID, an empty one is NULL.
+func nullID(id string) sql.NullString {
+	return sql.NullString{String: id, Valid: id != ""}
+}
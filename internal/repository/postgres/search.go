@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"hard/internal/domain/search"
+	"hard/pkg/store"
+)
+
+type SearchRepository struct {
+	db store.DB
+}
+
+func NewSearchRepository(db *sqlx.DB) *SearchRepository {
+	return &SearchRepository{db: store.NewDB(db)}
+}
+
+// searchRow carries the keyset values of a ranked hit.
+type searchRow struct {
+	search.Entity
+	Cursor pq.StringArray `db:"cursor"`
+}
+
+// Search runs the query against the search_vector columns of tasks, projects
+// and users. Hits are ordered by rank, type and id so that they can be paged.
+func (r *SearchRepository) Search(ctx context.Context, query string, types []string, page store.Page) (dest []search.Entity, cursor store.Cursor, err error) {
+	if len(types) == 0 {
+		types = search.Types
+	}
+
+	sql := `
+		SELECT type, id, title, headline, rank, ARRAY[rank::text, type, id::text] AS cursor
+		FROM (
+			SELECT 'task' AS type, id, title,
+				ts_headline('english', title || ' ' || coalesce(description, ''), q) AS headline,
+				ts_rank(search_vector, q) AS rank
+			FROM tasks, websearch_to_tsquery('english', $1) q
+			WHERE search_vector @@ q AND deleted_at IS NULL
+			UNION ALL
+			SELECT 'project', id, title,
+				ts_headline('english', title || ' ' || coalesce(description, ''), q),
+				ts_rank(search_vector, q)
+			FROM projects, websearch_to_tsquery('english', $1) q
+			WHERE search_vector @@ q AND deleted_at IS NULL
+			UNION ALL
+			SELECT 'user', id, full_name,
+				ts_headline('simple', full_name || ' ' || email, q),
+				ts_rank(search_vector, q)
+			FROM users, websearch_to_tsquery('simple', $1) q
+			WHERE search_vector @@ q AND deleted_at IS NULL
+		) hits
+		WHERE type = ANY($2)`
+
+	args := []any{query, pq.Array(types)}
+
+	sql, args, err = page.KeysetBy(sql, args,
+		store.Key{Expr: "rank", Desc: true},
+		store.Key{Expr: "type"},
+		store.Key{Expr: "id"},
+	)
+	if err != nil {
+		return
+	}
+
+	var rows []searchRow
+	if err = r.db.SelectContext(ctx, &rows, sql, args...); err != nil {
+		return
+	}
+
+	rows, cursor = store.Paginate(page, rows, func(row searchRow) []string { return row.Cursor })
+	for _, row := range rows {
+		dest = append(dest, row.Entity)
+	}
+
+	return
+}
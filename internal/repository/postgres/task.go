@@ -6,52 +6,99 @@ import (
 	"errors"
 	"fmt"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"hard/internal/domain/task"
 	"hard/pkg/store"
 	"strings"
+	"time"
 )
 
+// maxDepth bounds the walk up the task hierarchy so that a cycle left in the
+// table cannot loop forever.
+const maxDepth = 1000
+
 type TaskRepository struct {
-	db *sqlx.DB
+	db store.DB
 }
 
 func NewTaskRepository(db *sqlx.DB) *TaskRepository {
-	return &TaskRepository{db: db}
+	return &TaskRepository{db: store.NewDB(db)}
 }
 
-func (r *TaskRepository) List(ctx context.Context) (dest []task.Entity, err error) {
+func (r *TaskRepository) List(ctx context.Context, page store.Page) (dest []task.Entity, cursor store.Cursor, err error) {
 	query := `
-			SELECT id, title, description, priority, status, assignee_id, project_id, completed_at
+			SELECT id, title, description, priority, status, assignee_id, project_id, parent_id, start_date, due_date, estimate_hours, milestone_id, completed_at, deleted_at, version
 			FROM tasks
-			ORDER BY id`
+			WHERE 1=1` + store.NotDeleted(ctx, "deleted_at")
+
+	query, args, err := page.Keyset(query, "id", nil)
+	if err != nil {
+		return
+	}
 
-	err = r.db.SelectContext(ctx, &dest, query)
+	if err = r.db.SelectContext(ctx, &dest, query, args...); err != nil {
+		return
+	}
+
+	dest, cursor = store.Paginate(page, dest, taskCursor)
 
 	return
 }
 
+// Add starts the status history of the task with its first status.
 func (r *TaskRepository) Add(ctx context.Context, data task.Entity) (id string, err error) {
 	query := `
-		INSERT INTO tasks (title, description, priority, status, assignee_id, project_id, completed_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7) 
-		RETURNING id`
-
-	args := []any{data.Title, data.Description, data.Priority, data.Status, data.AssigneeID, data.ProjectID, data.CompletedAt}
+		WITH created AS (
+			INSERT INTO tasks (title, description, priority, status, assignee_id, project_id, parent_id, start_date, due_date, estimate_hours, milestone_id, completed_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			RETURNING id, status
+		), changed AS (
+			INSERT INTO task_status_changes (task_id, to_status)
+			SELECT id, status FROM created
+		)
+		SELECT id FROM created`
+
+	args := []any{data.Title, data.Description, data.Priority, data.Status, data.AssigneeID, data.ProjectID, data.ParentID, data.StartDate, data.DueDate, data.EstimateHours, data.MilestoneID, data.CompletedAt}
 
 	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			err = store.ErrorNotFound
 		}
+		err = invalidInput(err)
+	}
+
+	return
+}
+
+// AddMany inserts the tasks and their initial statuses with one statement and
+// returns their ids in the order of data, matched to the rows by their ordinal.
+func (r *TaskRepository) AddMany(ctx context.Context, data []task.Entity) (ids []string, err error) {
+	query := `
+		WITH ` + numbered("tasks", len(data)) + `, created AS (
+			INSERT INTO tasks (id, title, description, priority, status, assignee_id, project_id, parent_id, start_date, due_date, estimate_hours, milestone_id, completed_at)
+			VALUES ` + rowValues(len(data), 12) + `
+			RETURNING id, status
+		), changed AS (
+			INSERT INTO task_status_changes (task_id, to_status)
+			SELECT id, status FROM created
+		)
+		SELECT n.id FROM numbered n JOIN created c ON c.id = n.id ORDER BY n.ord`
+
+	args := make([]any, 0, len(data)*12)
+	for _, row := range data {
+		args = append(args, row.Title, row.Description, row.Priority, row.Status, row.AssigneeID, row.ProjectID, row.ParentID, row.StartDate, row.DueDate, row.EstimateHours, row.MilestoneID, row.CompletedAt)
 	}
 
+	err = invalidInput(r.db.SelectContext(ctx, &ids, query, args...))
+
 	return
 }
 
 func (r *TaskRepository) Get(ctx context.Context, id string) (dest task.Entity, err error) {
 	query := `
-		SELECT id, title, description, priority, status, assignee_id, project_id, completed_at 
+		SELECT id, title, description, priority, status, assignee_id, project_id, parent_id, start_date, due_date, estimate_hours, milestone_id, completed_at, deleted_at, version
 		FROM tasks 
-		WHERE id=$1`
+		WHERE id=$1` + store.NotDeleted(ctx, "deleted_at") + store.Locked(ctx)
 
 	args := []any{id}
 
@@ -64,17 +111,44 @@ func (r *TaskRepository) Get(ctx context.Context, id string) (dest task.Entity,
 	return
 }
 
+// Update records the change in task_status_changes when it changes the status.
 func (r *TaskRepository) Update(ctx context.Context, id string, data task.Entity) (err error) {
 	sets, args := r.prepareArgs(data)
 	if len(args) > 0 {
 		args = append(args, id)
-		sets = append(sets, "updated_at=CURRENT_TIMESTAMP")
+		idArg := len(args)
+		sets = append(sets, "updated_at=CURRENT_TIMESTAMP", "version=version+1")
+		where := fmt.Sprintf("id=$%d AND deleted_at IS NULL", idArg)
+		if data.Version != nil {
+			args = append(args, *data.Version)
+			where += fmt.Sprintf(" AND version=$%d", len(args))
+		}
+
+		query := fmt.Sprintf("UPDATE tasks SET %s WHERE %s RETURNING id", strings.Join(sets, ", "), where)
+		if data.Status != nil {
+			// the locked subquery reads the status the update replaces
+			query = fmt.Sprintf(`
+				WITH updated AS (
+					UPDATE tasks SET %s
+					FROM (SELECT id AS previous_id, status AS previous_status FROM tasks WHERE id=$%d FOR UPDATE) AS previous
+					WHERE tasks.id=previous.previous_id AND %s
+					RETURNING tasks.id, previous.previous_status, tasks.status
+				), changed AS (
+					INSERT INTO task_status_changes (task_id, from_status, to_status)
+					SELECT id, previous_status, status FROM updated
+					WHERE previous_status IS DISTINCT FROM status
+				)
+				SELECT id FROM updated`, strings.Join(sets, ", "), idArg, where)
+		}
 
-		query := fmt.Sprintf("UPDATE tasks SET %s WHERE id=$%d RETURNING id", strings.Join(sets, ", "), len(args))
 		if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
 				err = store.ErrorNotFound
+				if data.Version != nil {
+					err = versionConflict(ctx, r.db, "tasks", id)
+				}
 			}
+			err = invalidInput(err)
 		}
 	}
 
@@ -112,19 +186,99 @@ func (r *TaskRepository) prepareArgs(data task.Entity) (sets []string, args []an
 		sets = append(sets, fmt.Sprintf("project_id=$%d", len(args)))
 	}
 
+	if data.ParentID != nil {
+		// an empty parent_id detaches the task from its parent
+		args = append(args, sql.NullString{String: *data.ParentID, Valid: *data.ParentID != ""})
+		sets = append(sets, fmt.Sprintf("parent_id=$%d::int", len(args)))
+	}
+
+	if data.StartDate != nil {
+		args = append(args, sql.NullString{String: *data.StartDate, Valid: *data.StartDate != ""})
+		sets = append(sets, fmt.Sprintf("start_date=$%d::date", len(args)))
+	}
+
+	if data.DueDate != nil {
+		args = append(args, sql.NullString{String: *data.DueDate, Valid: *data.DueDate != ""})
+		sets = append(sets, fmt.Sprintf("due_date=$%d::date", len(args)))
+	}
+
+	if data.EstimateHours != nil {
+		args = append(args, data.EstimateHours)
+		sets = append(sets, fmt.Sprintf("estimate_hours=$%d", len(args)))
+	}
+
+	if data.MilestoneID != nil {
+		// an empty milestone_id takes the task out of its milestone
+		args = append(args, sql.NullString{String: *data.MilestoneID, Valid: *data.MilestoneID != ""})
+		sets = append(sets, fmt.Sprintf("milestone_id=$%d::int", len(args)))
+	}
+
 	if data.CompletedAt != nil {
-		args = append(args, *data.CompletedAt)
+		// an empty completed_at reopens the task
+		args = append(args, sql.NullString{String: *data.CompletedAt, Valid: *data.CompletedAt != ""})
 		sets = append(sets, fmt.Sprintf("completed_at=$%d", len(args)))
 	}
 
 	return
 }
 
-func (r *TaskRepository) Delete(ctx context.Context, id string) (err error) {
+// Delete soft deletes the task together with its subtasks. The subtasks get
+// the same deleted_at so that Restore can bring back exactly those.
+func (r *TaskRepository) Delete(ctx context.Context, id string, version *int) (err error) {
 	query := `
-		DELETE FROM tasks
-		WHERE id=$1
-		RETURNING id`
+		WITH RECURSIVE subtree AS (
+			SELECT id
+			FROM tasks
+			WHERE id=$1 AND deleted_at IS NULL AND ($2::int IS NULL OR version=$2)
+			UNION
+			SELECT t.id
+			FROM tasks t
+			JOIN subtree s ON t.parent_id = s.id
+			WHERE t.deleted_at IS NULL
+		), deleted AS (
+			UPDATE tasks
+			SET deleted_at=CURRENT_TIMESTAMP, version=version+1
+			WHERE id IN (SELECT id FROM subtree)
+			RETURNING id
+		)
+		SELECT id FROM deleted WHERE id=$1`
+
+	args := []any{id, version}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+			if version != nil {
+				err = versionConflict(ctx, r.db, "tasks", id)
+			}
+		}
+	}
+
+	return
+}
+
+// Restore brings back the task and the subtasks deleted along with it.
+func (r *TaskRepository) Restore(ctx context.Context, id string) (err error) {
+	query := `
+		WITH RECURSIVE task AS (
+			SELECT id, deleted_at
+			FROM tasks
+			WHERE id=$1 AND deleted_at IS NOT NULL
+		), subtree AS (
+			SELECT id
+			FROM task
+			UNION
+			SELECT t.id
+			FROM tasks t
+			JOIN subtree s ON t.parent_id = s.id
+			JOIN task r ON t.deleted_at = r.deleted_at
+		), restored AS (
+			UPDATE tasks
+			SET deleted_at=NULL, version=version+1
+			WHERE id IN (SELECT id FROM subtree)
+			RETURNING id
+		)
+		SELECT id FROM restored WHERE id=$1`
 
 	args := []any{id}
 
@@ -137,15 +291,177 @@ func (r *TaskRepository) Delete(ctx context.Context, id string) (err error) {
 	return
 }
 
-func (r *TaskRepository) Search(ctx context.Context, data task.Entity) (dest []task.Entity, err error) {
-	query := "SELECT id, title, description, priority, status, assignee_id, project_id, completed_at FROM tasks WHERE 1=1"
+// Purge hard deletes the tasks soft deleted longer than retention ago.
+func (r *TaskRepository) Purge(ctx context.Context, retention time.Duration) (n int64, err error) {
+	query := `
+		DELETE FROM tasks
+		WHERE deleted_at < CURRENT_TIMESTAMP - $1 * INTERVAL '1 second'`
+
+	args := []any{retention.Seconds()}
+
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return
+	}
+
+	return res.RowsAffected()
+}
+
+func (r *TaskRepository) Search(ctx context.Context, filter task.Filter, page store.Page) (dest []task.Entity, cursor store.Cursor, err error) {
+	keys := r.prepareSort(filter.Sort)
 
-	sets, args := r.prepareArgs(data)
+	values := make([]string, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, key.Expr+"::text")
+	}
+	query := fmt.Sprintf("SELECT id, title, description, priority, status, assignee_id, project_id, parent_id, start_date, due_date, estimate_hours, milestone_id, completed_at, deleted_at, version, ARRAY[%s] AS cursor FROM tasks WHERE 1=1%s", strings.Join(values, ", "), store.NotDeleted(ctx, "deleted_at"))
+
+	sets, args := r.prepareFilter(filter.Conditions, nil)
 	if len(sets) > 0 {
 		query += " AND " + strings.Join(sets, " AND ")
 	}
 
+	query, args, err = page.KeysetBy(query, args, keys...)
+	if err != nil {
+		return
+	}
+
+	var rows []taskRow
+	if err = r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return
+	}
+
+	rows, cursor = store.Paginate(page, rows, func(row taskRow) []string { return row.Cursor })
+	for _, row := range rows {
+		dest = append(dest, row.Entity)
+	}
+
+	return
+}
+
+func (r *TaskRepository) ListSubtasks(ctx context.Context, id string, page store.Page) (dest []task.Entity, cursor store.Cursor, err error) {
+	query := `
+			SELECT id, title, description, priority, status, assignee_id, project_id, parent_id, start_date, due_date, estimate_hours, milestone_id, completed_at, deleted_at, version
+			FROM tasks
+			WHERE parent_id=$1` + store.NotDeleted(ctx, "deleted_at")
+
+	query, args, err := page.Keyset(query, "id", []any{id})
+	if err != nil {
+		return
+	}
+
+	if err = r.db.SelectContext(ctx, &dest, query, args...); err != nil {
+		return
+	}
+
+	dest, cursor = store.Paginate(page, dest, taskCursor)
+
+	return
+}
+
+// Descendants returns the subtasks of the task at any depth, ordered by id.
+func (r *TaskRepository) Descendants(ctx context.Context, id string) (dest []task.Entity, err error) {
+	query := `
+		WITH RECURSIVE subtree AS (
+			SELECT id, title, description, priority, status, assignee_id, project_id, parent_id, start_date, due_date, estimate_hours, milestone_id, completed_at, deleted_at, version
+			FROM tasks
+			WHERE parent_id=$1` + store.NotDeleted(ctx, "deleted_at") + `
+			UNION
+			SELECT t.id, t.title, t.description, t.priority, t.status, t.assignee_id, t.project_id, t.parent_id, t.start_date, t.due_date, t.estimate_hours, t.milestone_id, t.completed_at, t.deleted_at, t.version
+			FROM tasks t
+			JOIN subtree s ON t.parent_id = s.id
+			WHERE 1=1` + store.NotDeleted(ctx, "t.deleted_at") + `
+		)
+		SELECT id, title, description, priority, status, assignee_id, project_id, parent_id, start_date, due_date, estimate_hours, milestone_id, completed_at, deleted_at, version
+		FROM subtree
+		ORDER BY id`
+
+	args := []any{id}
+
+	err = r.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+// Ancestors returns the id of the task followed by the ids of its parents up
+// to the root, deleted tasks included.
+func (r *TaskRepository) Ancestors(ctx context.Context, id string) (dest []string, err error) {
+	query := `
+		WITH RECURSIVE ancestors AS (
+			SELECT id, parent_id, 0 AS depth
+			FROM tasks
+			WHERE id=$1
+			UNION
+			SELECT t.id, t.parent_id, a.depth+1
+			FROM tasks t
+			JOIN ancestors a ON t.id = a.parent_id
+			WHERE a.depth < $2
+		)
+		SELECT id FROM ancestors ORDER BY depth`
+
+	args := []any{id, maxDepth}
+
+	err = r.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+// ListByProject returns all live tasks of the project ordered by id.
+func (r *TaskRepository) ListByProject(ctx context.Context, projectID string) (dest []task.Entity, err error) {
+	query := `
+		SELECT id, title, description, priority, status, assignee_id, project_id, parent_id, start_date, due_date, estimate_hours, milestone_id, completed_at, deleted_at, version
+		FROM tasks
+		WHERE project_id=$1 AND deleted_at IS NULL
+		ORDER BY id`
+
+	args := []any{projectID}
+
 	err = r.db.SelectContext(ctx, &dest, query, args...)
 
 	return
 }
+
+// ListByProjects returns up to limit tasks of each of the projects ordered by id.
+func (r *TaskRepository) ListByProjects(ctx context.Context, projectIDs []string, limit int) (dest map[string][]task.Entity, err error) {
+	return r.listBy(ctx, "project_id", projectIDs, limit)
+}
+
+// ListByAssignees returns up to limit tasks of each of the users ordered by id.
+func (r *TaskRepository) ListByAssignees(ctx context.Context, assigneeIDs []string, limit int) (dest map[string][]task.Entity, err error) {
+	return r.listBy(ctx, "assignee_id", assigneeIDs, limit)
+}
+
+// listBy groups the tasks by the value of column, numbering the rows of each
+// group to apply the limit per group in a single query.
+func (r *TaskRepository) listBy(ctx context.Context, column string, ids []string, limit int) (dest map[string][]task.Entity, err error) {
+	query := `
+		SELECT ` + column + ` AS group_id, id, title, description, priority, status, assignee_id, project_id, parent_id, start_date, due_date, estimate_hours, milestone_id, completed_at, deleted_at, version
+		FROM (
+			SELECT *, row_number() OVER (PARTITION BY ` + column + ` ORDER BY id) AS n
+			FROM tasks
+			WHERE ` + column + ` = ANY($1::int[])` + store.NotDeleted(ctx, "deleted_at") + `
+		) t
+		WHERE n <= $2
+		ORDER BY id`
+
+	args := []any{pq.Array(ids), limit}
+
+	var rows []struct {
+		GroupID string `db:"group_id"`
+		task.Entity
+	}
+	if err = r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return
+	}
+
+	dest = make(map[string][]task.Entity, len(ids))
+	for _, row := range rows {
+		dest[row.GroupID] = append(dest[row.GroupID], row.Entity)
+	}
+
+	return
+}
+
+func taskCursor(data task.Entity) []string {
+	return []string{data.ID}
+}
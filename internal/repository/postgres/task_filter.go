@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"fmt"
+	"github.com/lib/pq"
+	"hard/internal/domain/task"
+	"hard/pkg/store"
+	"strconv"
+	"strings"
+)
+
+var (
+	priorityRank = buildPriorityRank()
+	likeEscaper  = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+)
+
+// taskSortColumns maps sortable fields to expressions that are never NULL, so
+// that they can take part in keyset comparisons.
+var taskSortColumns = map[task.Field]string{
+	task.FieldID:          "id",
+	task.FieldTitle:       "title",
+	task.FieldPriority:    priorityRank,
+	task.FieldStatus:      "status",
+	task.FieldAssigneeID:  "COALESCE(assignee_id, 0)",
+	task.FieldProjectID:   "project_id",
+	task.FieldMilestoneID: "COALESCE(milestone_id, 0)",
+	task.FieldStartDate:   "COALESCE(start_date, 'infinity'::date)",
+	task.FieldDueDate:     "COALESCE(due_date, 'infinity'::date)",
+	task.FieldCompletedAt: "COALESCE(completed_at, 'infinity'::date)",
+	task.FieldCreatedAt:   "COALESCE(created_at, 'infinity'::timestamp)",
+	task.FieldUpdatedAt:   "COALESCE(updated_at, 'infinity'::timestamp)",
+}
+
+// taskRow carries the keyset values of a task selected with a custom ordering.
+type taskRow struct {
+	task.Entity
+	Cursor pq.StringArray `db:"cursor"`
+}
+
+func buildPriorityRank() string {
+	rank := "(CASE priority"
+	for i, priority := range task.Priorities {
+		rank += fmt.Sprintf(" WHEN %s THEN %d", pq.QuoteLiteral(priority), i+1)
+	}
+	return rank + " ELSE 0 END)"
+}
+
+// prepareFilter compiles the filter conditions into parameterized predicates.
+// Field names and operators come from the whitelist in the task package, only
+// values are passed as arguments.
+func (r *TaskRepository) prepareFilter(conditions []task.Condition, args []any) (sets []string, _ []any) {
+	for _, cond := range conditions {
+		if task.KindOf(cond.Field) == task.KindLabel {
+			var set string
+			set, args = r.prepareLabelFilter(cond, args)
+			sets = append(sets, set)
+			continue
+		}
+
+		column := string(cond.Field)
+		value := any(cond.Values[0])
+
+		switch task.KindOf(cond.Field) {
+		case task.KindDate:
+			column += "::date"
+		case task.KindRank:
+			if cond.Operator != task.OperatorEqual && cond.Operator != task.OperatorNotEqual && cond.Operator != task.OperatorIn {
+				column = priorityRank
+				value = strconv.Itoa(task.PriorityRank(cond.Values[0]))
+			}
+		}
+
+		switch cond.Operator {
+		case task.OperatorIn:
+			args = append(args, pq.Array(cond.Values))
+			sets = append(sets, fmt.Sprintf("%s = ANY($%d)", column, len(args)))
+		case task.OperatorContains:
+			args = append(args, "%"+likeEscaper.Replace(cond.Values[0])+"%")
+			sets = append(sets, fmt.Sprintf("%s ILIKE $%d", column, len(args)))
+		case task.OperatorNotEqual:
+			args = append(args, value)
+			sets = append(sets, fmt.Sprintf("%s IS DISTINCT FROM $%d", column, len(args)))
+		default:
+			args = append(args, value)
+			sets = append(sets, fmt.Sprintf("%s %s $%d", column, cond.Operator, len(args)))
+		}
+	}
+
+	return sets, args
+}
+
+// labelExists matches the tasks that have a label whose name satisfies the
+// predicate.
+const labelExists = "EXISTS (SELECT 1 FROM task_labels tl JOIN labels l ON l.id = tl.label_id WHERE tl.task_id = tasks.id AND l.name %s)"
+
+// prepareLabelFilter compiles a label condition: label=bug, label!=bug, any of
+// label=in:bug,ui and all of label=all:bug,ui.
+func (r *TaskRepository) prepareLabelFilter(cond task.Condition, args []any) (set string, _ []any) {
+	switch cond.Operator {
+	case task.OperatorIn:
+		args = append(args, pq.Array(cond.Values))
+		return fmt.Sprintf(labelExists, fmt.Sprintf("= ANY($%d)", len(args))), args
+	case task.OperatorAll:
+		exists := make([]string, 0, len(cond.Values))
+		for _, value := range cond.Values {
+			args = append(args, value)
+			exists = append(exists, fmt.Sprintf(labelExists, fmt.Sprintf("= $%d", len(args))))
+		}
+		return "(" + strings.Join(exists, " AND ") + ")", args
+	case task.OperatorNotEqual:
+		args = append(args, cond.Values[0])
+		return "NOT " + fmt.Sprintf(labelExists, fmt.Sprintf("= $%d", len(args))), args
+	default:
+		args = append(args, cond.Values[0])
+		return fmt.Sprintf(labelExists, fmt.Sprintf("= $%d", len(args))), args
+	}
+}
+
+// prepareSort returns the keyset ordering, id is always the final tie-breaker.
+func (r *TaskRepository) prepareSort(sort []task.Order) (keys []store.Key) {
+	for _, order := range sort {
+		if order.Field == task.FieldID {
+			continue
+		}
+		keys = append(keys, store.Key{Expr: taskSortColumns[order.Field], Desc: order.Desc})
+	}
+
+	idDesc := false
+	for _, order := range sort {
+		if order.Field == task.FieldID {
+			idDesc = order.Desc
+		}
+	}
+
+	return append(keys, store.Key{Expr: "id", Desc: idDesc})
+}
@@ -0,0 +1,209 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"hard/internal/domain/timelog"
+	"hard/pkg/store"
+	"strings"
+)
+
+type TimeEntryRepository struct {
+	db store.DB
+}
+
+func NewTimeEntryRepository(db *sqlx.DB) *TimeEntryRepository {
+	return &TimeEntryRepository{db: store.NewDB(db)}
+}
+
+func (r *TimeEntryRepository) List(ctx context.Context, taskID string, page store.Page) (dest []timelog.Entity, cursor store.Cursor, err error) {
+	query := `
+		SELECT id, user_id, task_id, started_at, ended_at, duration_seconds, note
+		FROM time_entries
+		WHERE task_id=$1`
+
+	query, args, err := page.Keyset(query, "id", []any{taskID})
+	if err != nil {
+		return
+	}
+
+	if err = r.db.SelectContext(ctx, &dest, query, args...); err != nil {
+		return
+	}
+
+	dest, cursor = store.Paginate(page, dest, timeEntryCursor)
+
+	return
+}
+
+func (r *TimeEntryRepository) Get(ctx context.Context, id string) (dest timelog.Entity, err error) {
+	query := `
+		SELECT id, user_id, task_id, started_at, ended_at, duration_seconds, note
+		FROM time_entries
+		WHERE id=$1`
+
+	args := []any{id}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *TimeEntryRepository) GetRunning(ctx context.Context, userID string) (dest timelog.Entity, err error) {
+	query := `
+		SELECT id, user_id, task_id, started_at, ended_at, duration_seconds, note
+		FROM time_entries
+		WHERE user_id=$1 AND ended_at IS NULL`
+
+	args := []any{userID}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *TimeEntryRepository) Add(ctx context.Context, data timelog.Entity) (id string, err error) {
+	query := `
+		INSERT INTO time_entries (user_id, task_id, started_at, ended_at, duration_seconds, note)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+
+	args := []any{data.UserID, data.TaskID, data.StartedAt, data.EndedAt, data.DurationSeconds, data.Note}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *TimeEntryRepository) Start(ctx context.Context, data timelog.Entity) (id string, err error) {
+	query := `
+		INSERT INTO time_entries (user_id, task_id, started_at, note)
+		VALUES ($1, $2, CURRENT_TIMESTAMP, $3)
+		RETURNING id`
+
+	args := []any{data.UserID, data.TaskID, data.Note}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		var pqErr *pq.Error
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			err = store.ErrorNotFound
+		// another timer was started between the check for a running one and this insert
+		case errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "time_entries_running_idx":
+			err = timelog.ErrorRunning
+		}
+	}
+
+	return
+}
+
+func (r *TimeEntryRepository) Stop(ctx context.Context, id string, note *string) (err error) {
+	query := `
+		UPDATE time_entries
+		SET ended_at=CURRENT_TIMESTAMP,
+			duration_seconds=EXTRACT(EPOCH FROM CURRENT_TIMESTAMP - started_at)::int,
+			note=COALESCE($2, note),
+			updated_at=CURRENT_TIMESTAMP
+		WHERE id=$1 AND ended_at IS NULL
+		RETURNING id`
+
+	args := []any{id, note}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *TimeEntryRepository) Delete(ctx context.Context, id string) (err error) {
+	query := `
+		DELETE FROM time_entries
+		WHERE id=$1
+		RETURNING id`
+
+	args := []any{id}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+// Totals sums the logged time per user and task, running timers count up to
+// now. Time on soft deleted tasks is left out.
+func (r *TimeEntryRepository) Totals(ctx context.Context, filter timelog.Filter) (dest []timelog.Total, err error) {
+	query := `
+		SELECT e.user_id, e.task_id, t.project_id, t.title,
+			SUM(COALESCE(e.duration_seconds, EXTRACT(EPOCH FROM CURRENT_TIMESTAMP - e.started_at)))::bigint AS seconds,
+			BOOL_OR(e.ended_at IS NULL) AS running
+		FROM time_entries e
+		JOIN tasks t ON t.id = e.task_id
+		WHERE 1=1` + store.NotDeleted(ctx, "t.deleted_at")
+
+	sets, args := r.prepareFilter(filter)
+	if len(sets) > 0 {
+		query += " AND " + strings.Join(sets, " AND ")
+	}
+	query += `
+		GROUP BY e.user_id, e.task_id, t.project_id, t.title
+		ORDER BY e.task_id, e.user_id`
+
+	err = r.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (r *TimeEntryRepository) prepareFilter(filter timelog.Filter) (sets []string, args []any) {
+	if filter.TaskID != "" {
+		args = append(args, filter.TaskID)
+		sets = append(sets, fmt.Sprintf("e.task_id=$%d", len(args)))
+	}
+
+	if filter.UserID != "" {
+		args = append(args, filter.UserID)
+		sets = append(sets, fmt.Sprintf("e.user_id=$%d", len(args)))
+	}
+
+	if filter.ProjectID != "" {
+		args = append(args, filter.ProjectID)
+		sets = append(sets, fmt.Sprintf("t.project_id=$%d", len(args)))
+	}
+
+	if filter.From != "" {
+		args = append(args, filter.From)
+		sets = append(sets, fmt.Sprintf("e.started_at >= $%d::date", len(args)))
+	}
+
+	if filter.To != "" {
+		args = append(args, filter.To)
+		sets = append(sets, fmt.Sprintf("e.started_at < $%d::date + 1", len(args)))
+	}
+
+	return
+}
+
+func timeEntryCursor(data timelog.Entity) []string {
+	return []string{data.ID}
+}
@@ -6,26 +6,38 @@ import (
 	"errors"
 	"fmt"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"hard/internal/domain/project"
 	"hard/internal/domain/task"
 	"hard/internal/domain/user"
 	"hard/pkg/store"
 	"strings"
+	"time"
 )
 
 type UserRepository struct {
-	db *sqlx.DB
+	db store.DB
 }
 
 func NewUserRepository(db *sqlx.DB) *UserRepository {
-	return &UserRepository{db: db}
+	return &UserRepository{db: store.NewDB(db)}
 }
-func (r *UserRepository) List(ctx context.Context) (dest []user.Entity, err error) {
+func (r *UserRepository) List(ctx context.Context, page store.Page) (dest []user.Entity, cursor store.Cursor, err error) {
 	query := `
-			SELECT id, full_name, email, role 
+			SELECT id, full_name, email, role, deleted_at, version
 			FROM users
-			ORDER BY id`
+			WHERE 1=1` + store.NotDeleted(ctx, "deleted_at")
 
-	err = r.db.SelectContext(ctx, &dest, query)
+	query, args, err := page.Keyset(query, "id", nil)
+	if err != nil {
+		return
+	}
+
+	if err = r.db.SelectContext(ctx, &dest, query, args...); err != nil {
+		return
+	}
+
+	dest, cursor = store.Paginate(page, dest, userCursor)
 
 	return
 }
@@ -47,11 +59,32 @@ func (r *UserRepository) Add(ctx context.Context, data user.Entity) (id string,
 	return
 }
 
+// AddMany inserts the users with one statement and returns their ids in the
+// order of data, matched to the rows by their ordinal.
+func (r *UserRepository) AddMany(ctx context.Context, data []user.Entity) (ids []string, err error) {
+	query := `
+		WITH ` + numbered("users", len(data)) + `, created AS (
+			INSERT INTO users (id, full_name, email, role)
+			VALUES ` + rowValues(len(data), 3) + `
+			RETURNING id
+		)
+		SELECT n.id FROM numbered n JOIN created c ON c.id = n.id ORDER BY n.ord`
+
+	args := make([]any, 0, len(data)*3)
+	for _, row := range data {
+		args = append(args, row.FullName, row.Email, row.Role)
+	}
+
+	err = r.db.SelectContext(ctx, &ids, query, args...)
+
+	return
+}
+
 func (r *UserRepository) Get(ctx context.Context, id string) (dest user.Entity, err error) {
 	query := `
-		SELECT id, full_name, email, role
+		SELECT id, full_name, email, role, deleted_at, version
 		FROM users 
-		WHERE id=$1`
+		WHERE id=$1` + store.NotDeleted(ctx, "deleted_at")
 
 	args := []any{id}
 
@@ -64,17 +97,94 @@ func (r *UserRepository) Get(ctx context.Context, id string) (dest user.Entity,
 	return
 }
 
+// GetMany returns the users with the given ids, the missing ones are skipped.
+func (r *UserRepository) GetMany(ctx context.Context, ids []string) (dest []user.Entity, err error) {
+	query := `
+		SELECT id, full_name, email, role, deleted_at, version
+		FROM users 
+		WHERE id = ANY($1::int[])` + store.NotDeleted(ctx, "deleted_at") + `
+		ORDER BY id`
+
+	args := []any{pq.Array(ids)}
+
+	err = r.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (dest user.Entity, err error) {
+	query := `
+		SELECT id, full_name, email, role, deleted_at, version
+		FROM users 
+		WHERE lower(email)=lower($1)` + store.NotDeleted(ctx, "deleted_at")
+
+	args := []any{email}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+// GetPasswordHash returns the bcrypt hash of the login password of the user,
+// empty when none is set.
+func (r *UserRepository) GetPasswordHash(ctx context.Context, id string) (hash string, err error) {
+	query := `
+		SELECT COALESCE(password_hash, '')
+		FROM users 
+		WHERE id=$1 AND deleted_at IS NULL`
+
+	args := []any{id}
+
+	if err = r.db.GetContext(ctx, &hash, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *UserRepository) SetPasswordHash(ctx context.Context, id string, hash string) (err error) {
+	query := `
+		UPDATE users
+		SET password_hash=$2, updated_at=CURRENT_TIMESTAMP
+		WHERE id=$1 AND deleted_at IS NULL
+		RETURNING id`
+
+	args := []any{id, hash}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
 func (r *UserRepository) Update(ctx context.Context, id string, data user.Entity) (err error) {
 	sets, args := r.prepareArgs(data)
 	if len(args) > 0 {
 		args = append(args, id)
-		sets = append(sets, "updated_at=CURRENT_TIMESTAMP")
+		sets = append(sets, "updated_at=CURRENT_TIMESTAMP", "version=version+1")
+		where := fmt.Sprintf("id=$%d AND deleted_at IS NULL", len(args))
+		if data.Version != nil {
+			args = append(args, *data.Version)
+			where += fmt.Sprintf(" AND version=$%d", len(args))
+		}
 
-		query := fmt.Sprintf("UPDATE users SET %s WHERE id=$%d RETURNING id", strings.Join(sets, ", "), len(args))
+		query := fmt.Sprintf("UPDATE users SET %s WHERE %s RETURNING id", strings.Join(sets, ", "), where)
 
 		if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
 				err = store.ErrorNotFound
+				if data.Version != nil {
+					err = versionConflict(ctx, r.db, "users", id)
+				}
 			}
 		}
 	}
@@ -101,30 +211,73 @@ func (r *UserRepository) prepareArgs(data user.Entity) (sets []string, args []an
 	return
 }
 
-func (r *UserRepository) Delete(ctx context.Context, id string) (err error) {
+// Delete soft deletes the user, the projects they manage and the tasks
+// assigned to them are kept.
+func (r *UserRepository) Delete(ctx context.Context, id string, version *int) (err error) {
 	deleteQuery := `
-		DELETE FROM users
-		WHERE id=$1
+		UPDATE users
+		SET deleted_at=CURRENT_TIMESTAMP, version=version+1
+		WHERE id=$1 AND deleted_at IS NULL AND ($2::int IS NULL OR version=$2)
 		RETURNING id`
 
-	args := []any{id}
+	args := []any{id, version}
 
 	if err = r.db.QueryRowContext(ctx, deleteQuery, args...).Scan(&id); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			err = store.ErrorNotFound
+			if version != nil {
+				err = versionConflict(ctx, r.db, "users", id)
+			}
 		}
 	}
 
 	return
 }
 
-func (r *UserRepository) ListTasks(ctx context.Context, id string) (dest []task.Entity, err error) {
+func (r *UserRepository) Restore(ctx context.Context, id string) (err error) {
+	query := `
+		UPDATE users
+		SET deleted_at=NULL, version=version+1
+		WHERE id=$1 AND deleted_at IS NOT NULL
+		RETURNING id`
+
+	args := []any{id}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+// Purge hard deletes the users soft deleted longer than retention ago. Users that
+// still manage a project are kept until the project is purged, users with
+// logged time until the tasks of their time entries are purged.
+func (r *UserRepository) Purge(ctx context.Context, retention time.Duration) (n int64, err error) {
+	query := `
+		DELETE FROM users u
+		WHERE u.deleted_at < CURRENT_TIMESTAMP - $1 * INTERVAL '1 second'
+		AND NOT EXISTS (SELECT 1 FROM projects p WHERE p.manager_id = u.id)
+		AND NOT EXISTS (SELECT 1 FROM time_entries t WHERE t.user_id = u.id)`
+
+	args := []any{retention.Seconds()}
+
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return
+	}
+
+	return res.RowsAffected()
+}
+
+func (r *UserRepository) ListTasks(ctx context.Context, id string, page store.Page) (dest []task.Entity, cursor store.Cursor, err error) {
 	args := []any{id}
 	existsQuery := `
 			SELECT 1
 			FROM users 
-			WHERE id=$1
-		`
+			WHERE id=$1` + store.NotDeleted(ctx, "deleted_at")
 
 	if err = r.db.QueryRowContext(ctx, existsQuery, id).Scan(&id); err != nil {
 		err = store.ErrorNotFound
@@ -132,24 +285,76 @@ func (r *UserRepository) ListTasks(ctx context.Context, id string) (dest []task.
 	}
 
 	query := `
-		SELECT id, title, description, priority, status, assignee_id, project_id, completed_at
+		SELECT id, title, description, priority, status, assignee_id, project_id, parent_id, start_date, due_date, estimate_hours, milestone_id, completed_at, deleted_at, version
 		FROM tasks 
-		WHERE assignee_id=$1`
+		WHERE assignee_id=$1` + store.NotDeleted(ctx, "deleted_at")
+
+	query, args, err = page.Keyset(query, "id", args)
+	if err != nil {
+		return
+	}
+
+	if err = r.db.SelectContext(ctx, &dest, query, args...); err != nil {
+		return
+	}
+
+	dest, cursor = store.Paginate(page, dest, taskCursor)
+
+	return
+}
+
+func (r *UserRepository) ListProjects(ctx context.Context, id string, page store.Page) (dest []project.Entity, cursor store.Cursor, err error) {
+	args := []any{id}
+	existsQuery := `
+			SELECT 1
+			FROM users 
+			WHERE id=$1` + store.NotDeleted(ctx, "deleted_at")
+
+	if err = r.db.QueryRowContext(ctx, existsQuery, id).Scan(&id); err != nil {
+		err = store.ErrorNotFound
+		return
+	}
+
+	query := `
+		SELECT p.id, p.title, p.description, p.start_date, p.end_date, p.manager_id, p.deleted_at, p.version
+		FROM projects p
+		JOIN project_members m ON m.project_id = p.id
+		WHERE m.user_id=$1` + store.NotDeleted(ctx, "p.deleted_at")
+
+	query, args, err = page.Keyset(query, "p.id", args)
+	if err != nil {
+		return
+	}
 
 	if err = r.db.SelectContext(ctx, &dest, query, args...); err != nil {
 		return
 	}
 
+	dest, cursor = store.Paginate(page, dest, projectCursor)
+
 	return
 }
 
-func (r *UserRepository) Search(ctx context.Context, name string, email string) (dest []user.Entity, err error) {
+func (r *UserRepository) Search(ctx context.Context, name string, email string, page store.Page) (dest []user.Entity, cursor store.Cursor, err error) {
 	sets, args := r.prepareSearchArgs(name, email)
-	query := fmt.Sprintf("SELECT id, full_name, email, role FROM users WHERE 1=1 %s", strings.Join(sets, " "))
+	query := fmt.Sprintf("SELECT id, full_name, email, role, deleted_at, version FROM users WHERE 1=1%s %s", store.NotDeleted(ctx, "deleted_at"), strings.Join(sets, " "))
 
-	err = r.db.SelectContext(ctx, &dest, query, args...)
+	query, args, err = page.Keyset(query, "id", args)
+	if err != nil {
+		return
+	}
+
+	if err = r.db.SelectContext(ctx, &dest, query, args...); err != nil {
+		return
+	}
+
+	dest, cursor = store.Paginate(page, dest, userCursor)
+
+	return
+}
 
-	return dest, nil
+func userCursor(data user.Entity) []string {
+	return []string{data.ID}
 }
 
 func (r *UserRepository) prepareSearchArgs(name string, email string) (sets []string, args []any) {
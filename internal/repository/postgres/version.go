@@ -0,0 +1,25 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hard/pkg/store"
+)
+
+// versionConflict tells a stale version from a missing row once a write
+// guarded by the version of the row matched nothing.
+func versionConflict(ctx context.Context, db store.DB, table, id string) (err error) {
+	query := fmt.Sprintf("SELECT 1 FROM %s WHERE id=$1 AND deleted_at IS NULL", table)
+
+	var exists int
+	if err = db.QueryRowContext(ctx, query, id).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+		return
+	}
+
+	return store.ErrorConflict
+}
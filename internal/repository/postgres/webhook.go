@@ -0,0 +1,294 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"hard/internal/domain/webhook"
+	"hard/pkg/store"
+	"strings"
+	"time"
+)
+
+type WebhookRepository struct {
+	db store.DB
+}
+
+func NewWebhookRepository(db *sqlx.DB) *WebhookRepository {
+	return &WebhookRepository{db: store.NewDB(db)}
+}
+
+func (r *WebhookRepository) List(ctx context.Context, page store.Page) (dest []webhook.Entity, cursor store.Cursor, err error) {
+	query := `
+		SELECT id, url, events, secret, active, created_at, updated_at
+		FROM webhooks
+		WHERE 1=1`
+
+	query, args, err := page.Keyset(query, "id", nil)
+	if err != nil {
+		return
+	}
+
+	if err = r.db.SelectContext(ctx, &dest, query, args...); err != nil {
+		return
+	}
+
+	dest, cursor = store.Paginate(page, dest, webhookCursor)
+
+	return
+}
+
+func (r *WebhookRepository) Get(ctx context.Context, id string) (dest webhook.Entity, err error) {
+	query := `
+		SELECT id, url, events, secret, active, created_at, updated_at
+		FROM webhooks
+		WHERE id=$1`
+
+	args := []any{id}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *WebhookRepository) Add(ctx context.Context, data webhook.Entity) (id string, err error) {
+	query := `
+		INSERT INTO webhooks (url, events, secret, active)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`
+
+	args := []any{data.URL, data.Events, data.Secret, data.Active}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *WebhookRepository) Update(ctx context.Context, id string, data webhook.Entity) (err error) {
+	sets, args := r.prepareArgs(data)
+	if len(args) > 0 {
+		args = append(args, id)
+		sets = append(sets, "updated_at=CURRENT_TIMESTAMP")
+
+		query := fmt.Sprintf("UPDATE webhooks SET %s WHERE id=$%d RETURNING id", strings.Join(sets, ", "), len(args))
+
+		if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = store.ErrorNotFound
+			}
+		}
+	}
+
+	return
+}
+
+func (r *WebhookRepository) prepareArgs(data webhook.Entity) (sets []string, args []any) {
+	if data.URL != nil {
+		args = append(args, data.URL)
+		sets = append(sets, fmt.Sprintf("url=$%d", len(args)))
+	}
+
+	if data.Events != nil {
+		args = append(args, data.Events)
+		sets = append(sets, fmt.Sprintf("events=$%d", len(args)))
+	}
+
+	if data.Secret != nil {
+		args = append(args, data.Secret)
+		sets = append(sets, fmt.Sprintf("secret=$%d", len(args)))
+	}
+
+	if data.Active != nil {
+		args = append(args, data.Active)
+		sets = append(sets, fmt.Sprintf("active=$%d", len(args)))
+	}
+
+	return
+}
+
+// Delete removes the webhook with its deliveries.
+func (r *WebhookRepository) Delete(ctx context.Context, id string) (err error) {
+	query := `
+		DELETE FROM webhooks
+		WHERE id=$1
+		RETURNING id`
+
+	args := []any{id}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+// Enqueue runs in the transaction of the change, so that the event is only
+// delivered when the change is committed.
+func (r *WebhookRepository) Enqueue(ctx context.Context, data webhook.Event) (err error) {
+	query := `
+		WITH subscribed AS (
+			SELECT id
+			FROM webhooks
+			WHERE active AND EXISTS (
+				SELECT 1 FROM jsonb_array_elements_text(events) AS pattern WHERE pattern = ANY($6::text[])
+			)
+		), event AS (
+			INSERT INTO webhook_events (event, entity, entity_id, actor_id, changes)
+			SELECT $1, $2, $3, $4::int, $5
+			WHERE EXISTS (SELECT 1 FROM subscribed)
+			RETURNING id
+		)
+		INSERT INTO webhook_deliveries (webhook_id, event_id)
+		SELECT s.id, e.id
+		FROM subscribed s
+		CROSS JOIN event e`
+
+	args := []any{data.Event, data.Entity, data.EntityID, data.ActorID, data.Changes, pq.Array(webhook.Patterns(data.Event))}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+
+	return
+}
+
+// Claim skips the rows locked by a concurrent claim and the deliveries of
+// inactive webhooks, which wait until the webhook is activated again.
+func (r *WebhookRepository) Claim(ctx context.Context, limit int, lease time.Duration) (dest []webhook.Job, err error) {
+	query := `
+		WITH due AS (
+			SELECT d.id
+			FROM webhook_deliveries d
+			JOIN webhooks w ON w.id = d.webhook_id
+			WHERE d.status='pending' AND d.next_attempt_at <= CURRENT_TIMESTAMP AND w.active
+			ORDER BY d.next_attempt_at, d.id
+			LIMIT $1
+			FOR UPDATE OF d SKIP LOCKED
+		), claimed AS (
+			UPDATE webhook_deliveries d
+			SET next_attempt_at=CURRENT_TIMESTAMP + $2::float8 * interval '1 second'
+			FROM due
+			WHERE d.id = due.id
+			RETURNING d.id, d.webhook_id, d.event_id, d.attempts
+		)
+		SELECT c.id AS delivery_id, c.webhook_id, c.attempts, w.url, w.secret,
+			e.id AS "event.id", e.event AS "event.event", e.entity AS "event.entity",
+			e.entity_id AS "event.entity_id", e.actor_id AS "event.actor_id",
+			e.changes AS "event.changes", e.created_at AS "event.created_at"
+		FROM claimed c
+		JOIN webhooks w ON w.id = c.webhook_id
+		JOIN webhook_events e ON e.id = c.event_id
+		ORDER BY c.id`
+
+	args := []any{limit, lease.Seconds()}
+
+	err = r.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (r *WebhookRepository) Complete(ctx context.Context, id string, data webhook.Attempt) (err error) {
+	query := `
+		UPDATE webhook_deliveries
+		SET status=$2::varchar, attempts=attempts+1, last_attempt_at=CURRENT_TIMESTAMP,
+			last_status_code=$3, last_error=$4,
+			next_attempt_at=CASE WHEN $2::varchar='pending' THEN CURRENT_TIMESTAMP + $5::float8 * interval '1 second' END,
+			delivered_at=CASE WHEN $2::varchar='delivered' THEN CURRENT_TIMESTAMP END,
+			updated_at=CURRENT_TIMESTAMP
+		WHERE id=$1
+		RETURNING id`
+
+	args := []any{id, data.Status, data.StatusCode, data.Error, data.RetryIn.Seconds()}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, webhookID string, filter webhook.DeliveryFilter, page store.Page) (dest []webhook.Delivery, cursor store.Cursor, err error) {
+	query := `
+		SELECT d.id, d.webhook_id, d.event_id, e.event, e.entity, e.entity_id, d.status, d.attempts,
+			d.next_attempt_at, d.last_attempt_at, d.last_status_code, d.last_error, d.delivered_at, d.created_at
+		FROM webhook_deliveries d
+		JOIN webhook_events e ON e.id = d.event_id
+		WHERE d.webhook_id=$1`
+
+	args := []any{webhookID}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		query += fmt.Sprintf(" AND d.status=$%d", len(args))
+	}
+
+	query, args, err = page.KeysetBy(query, args, store.Key{Expr: "d.id", Desc: true})
+	if err != nil {
+		return
+	}
+
+	if err = r.db.SelectContext(ctx, &dest, query, args...); err != nil {
+		return
+	}
+
+	dest, cursor = store.Paginate(page, dest, deliveryCursor)
+
+	return
+}
+
+func (r *WebhookRepository) GetDelivery(ctx context.Context, id string) (dest webhook.Delivery, err error) {
+	query := `
+		SELECT d.id, d.webhook_id, d.event_id, e.event, e.entity, e.entity_id, d.status, d.attempts,
+			d.next_attempt_at, d.last_attempt_at, d.last_status_code, d.last_error, d.delivered_at, d.created_at
+		FROM webhook_deliveries d
+		JOIN webhook_events e ON e.id = d.event_id
+		WHERE d.id=$1`
+
+	args := []any{id}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *WebhookRepository) Redeliver(ctx context.Context, id string) (err error) {
+	query := `
+		UPDATE webhook_deliveries
+		SET status='pending', attempts=0, next_attempt_at=CURRENT_TIMESTAMP, delivered_at=NULL, updated_at=CURRENT_TIMESTAMP
+		WHERE id=$1
+		RETURNING id`
+
+	args := []any{id}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func webhookCursor(data webhook.Entity) []string {
+	return []string{data.ID}
+}
+
+func deliveryCursor(data webhook.Delivery) []string {
+	return []string{data.ID}
+}
@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"hard/internal/domain/workflow"
+	"hard/pkg/store"
+)
+
+type WorkflowRepository struct {
+	db store.DB
+}
+
+func NewWorkflowRepository(db *sqlx.DB) *WorkflowRepository {
+	return &WorkflowRepository{db: store.NewDB(db)}
+}
+
+func (r *WorkflowRepository) Get(ctx context.Context, projectID string) (dest workflow.Entity, err error) {
+	query := `
+		SELECT project_id, definition
+		FROM workflows
+		WHERE project_id=$1`
+
+	args := []any{projectID}
+
+	if err = r.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (r *WorkflowRepository) Save(ctx context.Context, data workflow.Entity) (err error) {
+	query := `
+		INSERT INTO workflows (project_id, definition)
+		VALUES ($1, $2)
+		ON CONFLICT (project_id) DO UPDATE
+		SET definition=EXCLUDED.definition, updated_at=CURRENT_TIMESTAMP`
+
+	args := []any{data.ProjectID, data.Definition}
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+
+	return
+}
+
+func (r *WorkflowRepository) Delete(ctx context.Context, projectID string) (err error) {
+	query := `
+		DELETE FROM workflows
+		WHERE project_id=$1
+		RETURNING project_id`
+
+	args := []any{projectID}
+
+	if err = r.db.QueryRowContext(ctx, query, args...).Scan(&projectID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = store.ErrorNotFound
+		}
+	}
+
+	return
+}
@@ -1,9 +1,24 @@
 package repository
 
 import (
+	"hard/internal/domain/attachment"
+	"hard/internal/domain/audit"
+	"hard/internal/domain/comment"
+	"hard/internal/domain/dependency"
+	"hard/internal/domain/event"
+	"hard/internal/domain/label"
+	"hard/internal/domain/member"
+	"hard/internal/domain/milestone"
 	"hard/internal/domain/project"
+	"hard/internal/domain/recurrence"
+	"hard/internal/domain/report"
+	"hard/internal/domain/schedule"
+	"hard/internal/domain/search"
 	"hard/internal/domain/task"
+	"hard/internal/domain/timelog"
 	"hard/internal/domain/user"
+	"hard/internal/domain/webhook"
+	"hard/internal/domain/workflow"
 	"hard/internal/repository/postgres"
 	"hard/pkg/store"
 )
@@ -13,9 +28,28 @@ type Configuration func(r *Repository) error
 type Repository struct {
 	postgres store.SQLX
 
-	User    user.Repository
-	Task    task.Repository
-	Project project.Repository
+	Transactor store.Transactor
+
+	User        user.Repository
+	Task        task.Repository
+	Project     project.Repository
+	Search      search.Repository
+	Workflow    workflow.Repository
+	Member      member.Repository
+	Audit       audit.Repository
+	Dependency  dependency.Repository
+	Predecessor schedule.Repository
+	Label       label.Repository
+	Comment     comment.Repository
+	Attachment  attachment.Repository
+	TimeEntry   timelog.Repository
+	Recurrence  recurrence.Repository
+	Milestone   milestone.Repository
+	Report      report.Repository
+	Webhook     webhook.Repository
+	Event       event.Repository
+
+	Blob store.BlobStore
 }
 
 func New(configs ...Configuration) (s *Repository, err error) {
@@ -40,9 +74,40 @@ func WithPostgresStore(dbName string) Configuration {
 			return
 		}
 
+		r.Transactor = store.NewDB(r.postgres.Client)
+
 		r.User = postgres.NewUserRepository(r.postgres.Client)
 		r.Task = postgres.NewTaskRepository(r.postgres.Client)
 		r.Project = postgres.NewProjectRepository(r.postgres.Client)
+		r.Search = postgres.NewSearchRepository(r.postgres.Client)
+		r.Workflow = postgres.NewWorkflowRepository(r.postgres.Client)
+		r.Member = postgres.NewMemberRepository(r.postgres.Client)
+		r.Audit = postgres.NewAuditRepository(r.postgres.Client)
+		r.Dependency = postgres.NewDependencyRepository(r.postgres.Client)
+		r.Predecessor = postgres.NewPredecessorRepository(r.postgres.Client)
+		r.Label = postgres.NewLabelRepository(r.postgres.Client)
+		r.Comment = postgres.NewCommentRepository(r.postgres.Client)
+		r.Attachment = postgres.NewAttachmentRepository(r.postgres.Client)
+		r.TimeEntry = postgres.NewTimeEntryRepository(r.postgres.Client)
+		r.Recurrence = postgres.NewRecurrenceRepository(r.postgres.Client)
+		r.Milestone = postgres.NewMilestoneRepository(r.postgres.Client)
+		r.Report = postgres.NewReportRepository(r.postgres.Client)
+		r.Webhook = postgres.NewWebhookRepository(r.postgres.Client)
+		r.Event = postgres.NewEventRepository(r.postgres.Client, dbName)
+		return
+	}
+}
+
+func WithLocalBlobStore(dir string) Configuration {
+	return func(r *Repository) (err error) {
+		r.Blob, err = store.NewLocalBlobStore(dir)
+		return
+	}
+}
+
+func WithS3BlobStore(config store.S3Config) Configuration {
+	return func(r *Repository) (err error) {
+		r.Blob, err = store.NewS3BlobStore(config)
 		return
 	}
 }
@@ -0,0 +1,86 @@
+package tasker
+
+import (
+	"context"
+	"errors"
+	"hard/internal/domain/access"
+	"hard/internal/domain/member"
+	"hard/pkg/auth"
+	"hard/pkg/store"
+)
+
+// relationRoles maps membership relations to the least project role that
+// satisfies them.
+var relationRoles = map[access.Relation]string{
+	access.RelationOwner:      member.RoleOwner,
+	access.RelationMaintainer: member.RoleMaintainer,
+	access.RelationMember:     member.RoleMember,
+}
+
+// authorize checks the action against the permission matrix. Roles are
+// checked first, relations that need the project are resolved only when the
+// role does not grant the action. An action missing from the matrix is denied.
+func (s *Service) authorize(ctx context.Context, action access.Action, resource access.Resource) (err error) {
+	rule, ok := s.policy[action]
+	if !ok {
+		return access.ErrorForbidden
+	}
+
+	callerID, ok := auth.UserID(ctx)
+	if !ok {
+		return access.ErrorForbidden
+	}
+
+	if rule.HasRole(access.RoleAny) {
+		return
+	}
+
+	// a soft deleted caller has no permissions, even when ctx includes deleted rows
+	caller, err := s.userRepository.Get(store.WithDeleted(ctx, false), callerID)
+	if err != nil {
+		if errors.Is(err, store.ErrorNotFound) {
+			err = access.ErrorForbidden
+		}
+		return
+	}
+	if caller.Role != nil && rule.HasRole(*caller.Role) {
+		return
+	}
+
+	for _, relation := range rule.Relations {
+		switch relation {
+		case access.RelationSelf:
+			if resource.UserID == callerID {
+				return
+			}
+		case access.RelationAssignee:
+			if resource.AssigneeID == callerID {
+				return
+			}
+		case access.RelationManager:
+			if resource.ProjectID == "" {
+				continue
+			}
+			project, err := s.projectRepository.Get(ctx, resource.ProjectID)
+			if err != nil {
+				return err
+			}
+			if project.ManagerID != nil && *project.ManagerID == callerID {
+				return nil
+			}
+		case access.RelationOwner, access.RelationMaintainer, access.RelationMember:
+			if resource.ProjectID == "" {
+				continue
+			}
+			membership, err := s.memberRepository.Get(ctx, resource.ProjectID, callerID)
+			if err != nil && !errors.Is(err, store.ErrorNotFound) {
+				return err
+			}
+			if err == nil && membership.AtLeast(relationRoles[relation]) {
+				return nil
+			}
+		}
+	}
+
+	return access.ErrorForbidden
+}
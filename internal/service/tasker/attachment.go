@@ -0,0 +1,229 @@
+package tasker
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"hard/internal/domain/access"
+	"hard/internal/domain/attachment"
+	"hard/internal/domain/audit"
+	"hard/pkg/auth"
+	"hard/pkg/store"
+	"io"
+	"mime"
+	"net/http"
+)
+
+// orphanBatch is how many blobs of hard deleted tasks a purge removes per query.
+const orphanBatch = 100
+
+// AttachmentLimits returns the size and content type limits of uploads.
+func (s *Service) AttachmentLimits() attachment.Limits {
+	return s.attachmentLimits
+}
+
+func (s *Service) ListAttachments(ctx context.Context, taskID string, page store.Page) (res []attachment.Response, cursor store.Cursor, err error) {
+	if _, err = s.taskRepository.Get(ctx, taskID); err != nil {
+		return
+	}
+
+	data, cursor, err := s.attachmentRepository.List(ctx, taskID, page)
+	if err != nil {
+		return
+	}
+
+	res = attachment.ParseFromEntities(data)
+
+	return
+}
+
+func (s *Service) GetAttachment(ctx context.Context, taskID, id string) (res attachment.Response, err error) {
+	if _, err = s.taskRepository.Get(ctx, taskID); err != nil {
+		return
+	}
+
+	data, err := s.taskAttachment(ctx, taskID, id)
+	if err != nil {
+		return
+	}
+
+	res = attachment.ParseFromEntity(data)
+
+	return
+}
+
+// OpenAttachment returns the attachment along with its content, the caller
+// closes the content.
+func (s *Service) OpenAttachment(ctx context.Context, taskID, id string) (res attachment.Response, content io.ReadSeekCloser, err error) {
+	if _, err = s.taskRepository.Get(ctx, taskID); err != nil {
+		return
+	}
+
+	data, err := s.taskAttachment(ctx, taskID, id)
+	if err != nil {
+		return
+	}
+	if content, err = s.blobStore.Open(ctx, data.BlobKey); err != nil {
+		return
+	}
+
+	res = attachment.ParseFromEntity(data)
+
+	return
+}
+
+// UploadAttachment stores the content and attaches it to the task. The content
+// type is detected from the first bytes rather than trusted from the client.
+func (s *Service) UploadAttachment(ctx context.Context, taskID string, req attachment.Request, content io.Reader) (res attachment.Response, err error) {
+	current, err := s.taskRepository.Get(ctx, taskID)
+	if err != nil {
+		return
+	}
+	if err = s.authorize(ctx, access.ActionUpdateTask, taskResource(current)); err != nil {
+		return
+	}
+
+	if limit := s.attachmentLimits.MaxSize; limit > 0 && req.Size > limit {
+		return res, attachment.TooLarge(limit)
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(content, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return
+	}
+	head = head[:n]
+
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil {
+		return
+	}
+	if !s.attachmentLimits.Allows(contentType) {
+		return res, attachment.ErrorType
+	}
+
+	key, err := blobKey(taskID)
+	if err != nil {
+		return
+	}
+
+	fileName := attachment.CleanFileName(req.FileName)
+	data := attachment.Entity{
+		TaskID:      &taskID,
+		FileName:    &fileName,
+		ContentType: &contentType,
+		Size:        &req.Size,
+		BlobKey:     key,
+	}
+	if uploaderID, ok := auth.UserID(ctx); ok {
+		data.UploaderID = &uploaderID
+	}
+
+	if err = s.blobStore.Put(ctx, key, io.MultiReader(bytes.NewReader(head), content), req.Size, contentType); err != nil {
+		return
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if data.ID, err = s.attachmentRepository.Add(ctx, data); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityAttachment, data.ID, audit.OperationCreate, audit.Compare(nil, data))
+	})
+	if err != nil {
+		// the row was not written, nothing refers to the blob
+		s.blobStore.Delete(context.WithoutCancel(ctx), key)
+		return
+	}
+
+	if data, err = s.attachmentRepository.Get(ctx, data.ID); err != nil {
+		return
+	}
+
+	res = attachment.ParseFromEntity(data)
+
+	return
+}
+
+// DeleteAttachment removes the attachment, the blob is removed once the row is
+// gone so that a failed transaction leaves the attachment intact.
+func (s *Service) DeleteAttachment(ctx context.Context, taskID, id string) (err error) {
+	current, err := s.taskRepository.Get(ctx, taskID)
+	if err != nil {
+		return
+	}
+	data, err := s.taskAttachment(ctx, taskID, id)
+	if err != nil {
+		return
+	}
+
+	resource := taskResource(current)
+	if data.UploaderID != nil {
+		resource.UserID = *data.UploaderID
+	}
+	if err = s.authorize(ctx, access.ActionDeleteAttachment, resource); err != nil {
+		return
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.attachmentRepository.Delete(ctx, id); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityAttachment, id, audit.OperationDelete, audit.Compare(data, nil))
+	})
+	if err != nil {
+		return
+	}
+
+	return s.blobStore.Delete(ctx, data.BlobKey)
+}
+
+// purgeAttachments removes the blobs of the tasks that were hard deleted, the
+// foreign key leaves their rows without a task. Soft deleted tasks keep their
+// attachments so that restoring them brings the files back.
+func (s *Service) purgeAttachments(ctx context.Context) (err error) {
+	if s.attachmentRepository == nil || s.blobStore == nil {
+		return
+	}
+
+	for {
+		data, err := s.attachmentRepository.ListOrphans(ctx, orphanBatch)
+		if err != nil {
+			return err
+		}
+
+		for _, object := range data {
+			if err = s.blobStore.Delete(ctx, object.BlobKey); err != nil {
+				return err
+			}
+			if err = s.attachmentRepository.Delete(ctx, object.ID); err != nil {
+				return err
+			}
+		}
+
+		if len(data) < orphanBatch {
+			return nil
+		}
+	}
+}
+
+// taskAttachment returns the attachment when it belongs to the task.
+func (s *Service) taskAttachment(ctx context.Context, taskID, id string) (data attachment.Entity, err error) {
+	if data, err = s.attachmentRepository.Get(ctx, id); err != nil {
+		return
+	}
+	if data.TaskID == nil || *data.TaskID != taskID {
+		err = store.ErrorNotFound
+	}
+
+	return
+}
+
+// blobKey returns a new random key under the directory of the task.
+func blobKey(taskID string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "tasks/" + taskID + "/" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,105 @@
+package tasker
+
+import (
+	"context"
+	"hard/internal/domain/access"
+	"hard/internal/domain/audit"
+	"hard/pkg/auth"
+	"hard/pkg/store"
+)
+
+func (s *Service) ListAudit(ctx context.Context, filter audit.Filter, page store.Page) (res []audit.Response, cursor store.Cursor, err error) {
+	if err = s.authorize(ctx, access.ActionReadAudit, access.Resource{}); err != nil {
+		return
+	}
+
+	data, cursor, err := s.auditRepository.List(ctx, filter, page)
+	if err != nil {
+		return
+	}
+
+	res = audit.ParseFromEntities(data)
+
+	return
+}
+
+// GetTaskHistory lists the recorded changes of a task to those who can see
+// its project.
+func (s *Service) GetTaskHistory(ctx context.Context, id string, page store.Page) (res []audit.Response, cursor store.Cursor, err error) {
+	current, err := s.taskRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+	if err = s.authorize(ctx, access.ActionReadHistory, taskResource(current)); err != nil {
+		return
+	}
+
+	filter := audit.Filter{EntityType: audit.EntityTask, EntityID: id}
+
+	data, cursor, err := s.auditRepository.List(ctx, filter, page)
+	if err != nil {
+		return
+	}
+
+	res = audit.ParseFromEntities(data)
+
+	return
+}
+
+// transaction runs fn in a database transaction, so that a change and its
+// audit record are committed together. Its events are streamed once the
+// outermost transaction commits.
+func (s *Service) transaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(pendingEventsKey{}).(*pendingEvents); ok {
+		return s.run(ctx, fn)
+	}
+
+	pending := &pendingEvents{}
+	if err = s.run(context.WithValue(ctx, pendingEventsKey{}, pending), fn); err != nil {
+		return
+	}
+	for _, data := range pending.data {
+		s.stream(ctx, data)
+	}
+
+	return
+}
+
+func (s *Service) run(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.transactor == nil {
+		return fn(ctx)
+	}
+	return s.transactor.Transaction(ctx, fn)
+}
+
+// record appends the change to the audit log and raises its webhook and
+// stream events, updates that change nothing are neither recorded nor raised.
+func (s *Service) record(ctx context.Context, entity, id string, operation audit.Operation, diff audit.Diff) (err error) {
+	if operation == audit.OperationUpdate && len(diff) == 0 {
+		return
+	}
+
+	var actorID *string
+	if userID, ok := auth.UserID(ctx); ok {
+		actorID = &userID
+	}
+
+	if s.auditRepository != nil {
+		data := audit.Entity{
+			ActorID:    actorID,
+			EntityType: entity,
+			EntityID:   id,
+			Operation:  operation,
+			Diff:       diff,
+		}
+		if err = s.auditRepository.Add(ctx, data); err != nil {
+			return
+		}
+	}
+
+	if err = s.publish(ctx, entity, id, operation, diff, actorID); err != nil {
+		return
+	}
+
+	return s.raise(ctx, entity, id, operation, diff, actorID)
+}
@@ -0,0 +1,116 @@
+package tasker
+
+import (
+	"context"
+	"errors"
+	"hard/internal/domain/access"
+	"hard/internal/domain/audit"
+	"hard/internal/domain/user"
+	"hard/pkg/auth"
+	"hard/pkg/store"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// dummyHash is compared against when the email is unknown or the user has no
+// password, so that the response time does not tell registered emails apart.
+const dummyHash = "$2a$10$oUx5a..OzDvEZQ0lXPmSf.hLTAkqI2DnKXybFmoHfLZkBPLxGct6a"
+
+// DirectoryProvider authenticates users registered in the users table against
+// the bcrypt hash of their own password. Users without a password cannot log in.
+type DirectoryProvider struct {
+	userRepository user.Repository
+}
+
+func NewDirectoryProvider(userRepository user.Repository) *DirectoryProvider {
+	return &DirectoryProvider{userRepository: userRepository}
+}
+
+func (p *DirectoryProvider) Authenticate(ctx context.Context, email, password string) (userID string, err error) {
+	hash := dummyHash
+
+	data, err := p.userRepository.GetByEmail(ctx, email)
+	switch {
+	case err == nil:
+		stored, err := p.userRepository.GetPasswordHash(ctx, data.ID)
+		if err != nil && !errors.Is(err, store.ErrorNotFound) {
+			return "", err
+		}
+		if stored != "" {
+			hash = stored
+		}
+	case !errors.Is(err, store.ErrorNotFound):
+		return
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) != nil || hash == dummyHash {
+		return "", auth.ErrorInvalidCredentials
+	}
+
+	return data.ID, nil
+}
+
+// SetPassword replaces the login password of the user.
+func (s *Service) SetPassword(ctx context.Context, id string, req user.PasswordRequest) (err error) {
+	if err = s.authorize(ctx, access.ActionUpdateUser, access.Resource{UserID: id}); err != nil {
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return
+	}
+
+	return s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.userRepository.SetPasswordHash(ctx, id, string(hash)); err != nil {
+			return
+		}
+		// the hash itself stays out of the audit log and the event stream
+		return s.record(ctx, audit.EntityUser, id, audit.OperationUpdate, audit.Diff{"password": {}})
+	})
+}
+
+// InitPassword sets the login password of the user with the given email when
+// it has none yet, so that the admin created by the migrations can log in.
+func (s *Service) InitPassword(ctx context.Context, email, password string) (err error) {
+	data, err := s.userRepository.GetByEmail(ctx, email)
+	if err != nil {
+		return
+	}
+
+	current, err := s.userRepository.GetPasswordHash(ctx, data.ID)
+	if err != nil || current != "" {
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return
+	}
+
+	return s.userRepository.SetPasswordHash(ctx, data.ID, string(hash))
+}
+
+func (s *Service) Login(ctx context.Context, req user.LoginRequest) (res user.TokenResponse, err error) {
+	userID, err := s.identityProvider.Authenticate(ctx, req.Email, req.Password)
+	if err != nil {
+		return
+	}
+
+	data, err := s.userRepository.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrorNotFound) {
+			err = auth.ErrorInvalidCredentials
+		}
+		return
+	}
+
+	res.AccessToken, res.ExpiresAt, err = s.tokens.Issue(data.ID)
+	if err != nil {
+		return
+	}
+	res.TokenType = "Bearer"
+	res.User = user.ParseFromEntity(data)
+
+	return
+}
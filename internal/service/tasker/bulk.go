@@ -0,0 +1,232 @@
+package tasker
+
+import (
+	"context"
+	"hard/internal/domain/access"
+	"hard/internal/domain/audit"
+	"hard/internal/domain/bulk"
+	"hard/internal/domain/project"
+	"hard/internal/domain/task"
+	"hard/internal/domain/user"
+)
+
+// CreateTasks creates the tasks of a bulk request with a multi-row insert,
+// each task is checked like one created on its own.
+func (s *Service) CreateTasks(ctx context.Context, reqs []task.Request, mode bulk.Mode) (res []bulk.Outcome, err error) {
+	data := make([]task.Entity, len(reqs))
+	res = make([]bulk.Outcome, len(reqs))
+	for i, req := range reqs {
+		data[i], res[i].Err = s.newTask(ctx, req)
+	}
+
+	created := func(ctx context.Context, data task.Entity) error {
+		return s.record(ctx, audit.EntityTask, data.ID, audit.OperationCreate, audit.Compare(nil, data))
+	}
+	parse := func(data task.Entity) any {
+		return task.ParseFromEntity(data)
+	}
+
+	err = createMany(ctx, s, mode, data, res, s.taskRepository.AddMany, setTaskID, created, parse)
+
+	return
+}
+
+func (s *Service) UpdateTasks(ctx context.Context, items []bulk.Update[task.Request], mode bulk.Mode) (res []bulk.Outcome, err error) {
+	return s.applyMany(ctx, mode, len(items), func(ctx context.Context, i int) (string, error) {
+		return items[i].ID, s.UpdateTask(ctx, items[i].ID, items[i].Data, items[i].Version)
+	})
+}
+
+func (s *Service) DeleteTasks(ctx context.Context, keys []bulk.Key, mode bulk.Mode) (res []bulk.Outcome, err error) {
+	return s.applyMany(ctx, mode, len(keys), func(ctx context.Context, i int) (string, error) {
+		return keys[i].ID, s.DeleteTask(ctx, keys[i].ID, keys[i].Version)
+	})
+}
+
+// CreateUsers creates the users of a bulk request with a multi-row insert.
+func (s *Service) CreateUsers(ctx context.Context, reqs []user.Request, mode bulk.Mode) (res []bulk.Outcome, err error) {
+	if err = s.authorize(ctx, access.ActionCreateUser, access.Resource{}); err != nil {
+		return
+	}
+
+	data := make([]user.Entity, len(reqs))
+	res = make([]bulk.Outcome, len(reqs))
+	for i, req := range reqs {
+		data[i] = user.Entity{
+			FullName: req.FullName,
+			Email:    req.Email,
+			Role:     req.Role,
+		}
+	}
+
+	created := func(ctx context.Context, data user.Entity) error {
+		return s.record(ctx, audit.EntityUser, data.ID, audit.OperationCreate, audit.Compare(nil, data))
+	}
+	parse := func(data user.Entity) any {
+		return user.ParseFromEntity(data)
+	}
+
+	err = createMany(ctx, s, mode, data, res, s.userRepository.AddMany, setUserID, created, parse)
+
+	return
+}
+
+func (s *Service) UpdateUsers(ctx context.Context, items []bulk.Update[user.Request], mode bulk.Mode) (res []bulk.Outcome, err error) {
+	return s.applyMany(ctx, mode, len(items), func(ctx context.Context, i int) (string, error) {
+		return items[i].ID, s.UpdateUser(ctx, items[i].ID, items[i].Data, items[i].Version)
+	})
+}
+
+func (s *Service) DeleteUsers(ctx context.Context, keys []bulk.Key, mode bulk.Mode) (res []bulk.Outcome, err error) {
+	return s.applyMany(ctx, mode, len(keys), func(ctx context.Context, i int) (string, error) {
+		return keys[i].ID, s.DeleteUser(ctx, keys[i].ID, keys[i].Version)
+	})
+}
+
+// CreateProjects creates the projects of a bulk request with a multi-row
+// insert, their managers become their owners.
+func (s *Service) CreateProjects(ctx context.Context, reqs []project.Request, mode bulk.Mode) (res []bulk.Outcome, err error) {
+	data := make([]project.Entity, len(reqs))
+	res = make([]bulk.Outcome, len(reqs))
+	for i, req := range reqs {
+		data[i] = project.Entity{
+			Title:       req.Title,
+			Description: req.Description,
+			StartDate:   req.StartDate,
+			EndDate:     req.EndDate,
+			ManagerID:   req.ManagerID,
+		}
+	}
+
+	created := func(ctx context.Context, data project.Entity) (err error) {
+		if err = s.record(ctx, audit.EntityProject, data.ID, audit.OperationCreate, audit.Compare(nil, data)); err != nil {
+			return
+		}
+		return s.ensureOwner(ctx, data.ID, *data.ManagerID)
+	}
+	parse := func(data project.Entity) any {
+		return project.ParseFromEntity(data)
+	}
+
+	err = createMany(ctx, s, mode, data, res, s.projectRepository.AddMany, setProjectID, created, parse)
+
+	return
+}
+
+func (s *Service) UpdateProjects(ctx context.Context, items []bulk.Update[project.Request], mode bulk.Mode) (res []bulk.Outcome, err error) {
+	return s.applyMany(ctx, mode, len(items), func(ctx context.Context, i int) (string, error) {
+		return items[i].ID, s.UpdateProject(ctx, items[i].ID, items[i].Data, items[i].Version)
+	})
+}
+
+func (s *Service) DeleteProjects(ctx context.Context, keys []bulk.Key, mode bulk.Mode) (res []bulk.Outcome, err error) {
+	return s.applyMany(ctx, mode, len(keys), func(ctx context.Context, i int) (string, error) {
+		return keys[i].ID, s.DeleteProject(ctx, keys[i].ID, keys[i].Version)
+	})
+}
+
+// applyMany runs fn for each item. In atomic mode the items share one
+// transaction, the first failure rolls it back and the items after it are not
+// tried. In partial mode each item runs on its own like a single request.
+func (s *Service) applyMany(ctx context.Context, mode bulk.Mode, n int, fn func(ctx context.Context, i int) (string, error)) (res []bulk.Outcome, err error) {
+	res = make([]bulk.Outcome, n)
+
+	if mode == bulk.ModePartial {
+		for i := range res {
+			res[i].ID, res[i].Err = fn(ctx, i)
+		}
+		return
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) error {
+		for i := range res {
+			if res[i].ID, res[i].Err = fn(ctx, i); res[i].Err != nil {
+				return res[i].Err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		bulk.Abort(res, err)
+		err = bulk.FirstError(res)
+	}
+
+	return
+}
+
+// createMany inserts the rows whose checks passed, res holds the failed checks
+// and gets the outcome of every row. The rows are inserted with one statement
+// in a transaction that also records them. In atomic mode a failed check or
+// insert fails every row. In partial mode a failed insert is retried row by
+// row, so that a row rejected by the database does not fail the others.
+func createMany[E any](ctx context.Context, s *Service, mode bulk.Mode, data []E, res []bulk.Outcome,
+	insert func(ctx context.Context, data []E) ([]string, error),
+	setID func(data *E, id string),
+	created func(ctx context.Context, data E) error,
+	parse func(data E) any) (err error) {
+
+	if mode == bulk.ModeAtomic {
+		if err = bulk.FirstError(res); err != nil {
+			bulk.Abort(res, err)
+			return
+		}
+	}
+
+	var rows []int
+	for i := range res {
+		if res[i].Err == nil {
+			rows = append(rows, i)
+		}
+	}
+
+	add := func(rows []int) error {
+		batch := make([]E, len(rows))
+		for j, i := range rows {
+			batch[j] = data[i]
+		}
+
+		return s.transaction(ctx, func(ctx context.Context) (err error) {
+			ids, err := insert(ctx, batch)
+			if err != nil {
+				return
+			}
+			for j := range batch {
+				setID(&batch[j], ids[j])
+				if err = created(ctx, batch[j]); err != nil {
+					return
+				}
+			}
+
+			for j, i := range rows {
+				res[i].ID, res[i].Data = ids[j], parse(batch[j])
+			}
+			return
+		})
+	}
+
+	if len(rows) == 0 {
+		return
+	}
+	if err = add(rows); err == nil {
+		return
+	}
+
+	if mode == bulk.ModeAtomic {
+		bulk.Abort(res, err)
+		return bulk.FirstError(res)
+	}
+
+	err = nil
+	for _, i := range rows {
+		res[i].ID, res[i].Data = "", nil
+		res[i].Err = add([]int{i})
+	}
+
+	return
+}
+
+func setTaskID(data *task.Entity, id string) { data.ID = id }
+
+func setUserID(data *user.Entity, id string) { data.ID = id }
+
+func setProjectID(data *project.Entity, id string) { data.ID = id }
@@ -0,0 +1,281 @@
+package tasker
+
+import (
+	"context"
+	"errors"
+	"hard/internal/domain/access"
+	"hard/internal/domain/audit"
+	"hard/internal/domain/comment"
+	"hard/pkg/auth"
+	"hard/pkg/store"
+)
+
+func (s *Service) ListComments(ctx context.Context, taskID string, page store.Page) (res []comment.Response, cursor store.Cursor, err error) {
+	if _, err = s.taskRepository.Get(ctx, taskID); err != nil {
+		return
+	}
+
+	data, cursor, err := s.commentRepository.List(ctx, taskID, page)
+	if err != nil {
+		return
+	}
+
+	res = comment.ParseFromEntities(data)
+	err = s.withMentions(ctx, res)
+
+	return
+}
+
+func (s *Service) GetComment(ctx context.Context, taskID, id string) (res comment.Response, err error) {
+	if _, err = s.taskRepository.Get(ctx, taskID); err != nil {
+		return
+	}
+
+	data, err := s.taskComment(ctx, taskID, id)
+	if err != nil {
+		return
+	}
+
+	list := []comment.Response{comment.ParseFromEntity(data)}
+	if err = s.withMentions(ctx, list); err != nil {
+		return
+	}
+	res = list[0]
+
+	return
+}
+
+// CreateComment adds a comment by the caller to the task, or a reply when
+// the request names a parent comment of the same task.
+func (s *Service) CreateComment(ctx context.Context, taskID string, req comment.Request) (res comment.Response, err error) {
+	if _, err = s.taskRepository.Get(ctx, taskID); err != nil {
+		return
+	}
+
+	authorID, ok := auth.UserID(ctx)
+	if !ok {
+		return res, access.ErrorForbidden
+	}
+
+	if req.ParentCommentID != nil {
+		parent, err := s.commentRepository.Get(ctx, *req.ParentCommentID)
+		if err != nil {
+			if errors.Is(err, store.ErrorNotFound) {
+				err = comment.ErrorUnknownParent
+			}
+			return res, err
+		}
+		if parent.TaskID != taskID {
+			return res, comment.ErrorOtherTask
+		}
+	}
+
+	mentions, err := s.resolveMentions(ctx, *req.Body)
+	if err != nil {
+		return
+	}
+
+	data := comment.Entity{
+		TaskID:          taskID,
+		AuthorID:        &authorID,
+		ParentCommentID: req.ParentCommentID,
+		Body:            req.Body,
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if data.ID, err = s.commentRepository.Add(ctx, data); err != nil {
+			return
+		}
+		if err = s.commentRepository.SetMentions(ctx, data.ID, mentions); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityComment, data.ID, audit.OperationCreate, audit.Compare(nil, data))
+	})
+	if err != nil {
+		return
+	}
+
+	if data, err = s.commentRepository.Get(ctx, data.ID); err != nil {
+		return
+	}
+
+	res = comment.ParseFromEntity(data)
+	res.Mentions = mentions
+
+	return
+}
+
+// UpdateComment replaces the body of the comment, the previous body is kept
+// in its history and the mentions are resolved again.
+func (s *Service) UpdateComment(ctx context.Context, taskID, id string, req comment.Request) (err error) {
+	resource, current, err := s.commentResource(ctx, taskID, id)
+	if err != nil {
+		return
+	}
+	if err = s.authorize(ctx, access.ActionUpdateComment, resource); err != nil {
+		return
+	}
+
+	if current.Body != nil && *current.Body == *req.Body {
+		return
+	}
+
+	mentions, err := s.resolveMentions(ctx, *req.Body)
+	if err != nil {
+		return
+	}
+
+	revision := comment.Revision{
+		CommentID: id,
+		Body:      current.Body,
+	}
+	if editorID, ok := auth.UserID(ctx); ok {
+		revision.EditorID = &editorID
+	}
+	data := comment.Entity{
+		Body: req.Body,
+	}
+
+	return s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.commentRepository.AddRevision(ctx, revision); err != nil {
+			return
+		}
+		if err = s.commentRepository.Update(ctx, id, data); err != nil {
+			return
+		}
+		if err = s.commentRepository.SetMentions(ctx, id, mentions); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityComment, id, audit.OperationUpdate, audit.Changes(current, data))
+	})
+}
+
+// DeleteComment removes the comment together with its replies.
+func (s *Service) DeleteComment(ctx context.Context, taskID, id string) (err error) {
+	resource, current, err := s.commentResource(ctx, taskID, id)
+	if err != nil {
+		return
+	}
+	if err = s.authorize(ctx, access.ActionDeleteComment, resource); err != nil {
+		return
+	}
+
+	return s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.commentRepository.Delete(ctx, id); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityComment, id, audit.OperationDelete, audit.Compare(current, nil))
+	})
+}
+
+// ListCommentHistory returns the previous bodies of the comment, oldest first.
+func (s *Service) ListCommentHistory(ctx context.Context, taskID, id string, page store.Page) (res []comment.RevisionResponse, cursor store.Cursor, err error) {
+	if _, err = s.taskRepository.Get(ctx, taskID); err != nil {
+		return
+	}
+	if _, err = s.taskComment(ctx, taskID, id); err != nil {
+		return
+	}
+
+	data, cursor, err := s.commentRepository.ListRevisions(ctx, id, page)
+	if err != nil {
+		return
+	}
+
+	res = comment.ParseFromRevisions(data)
+
+	return
+}
+
+// ListMentions returns the comments in which the user was mentioned.
+func (s *Service) ListMentions(ctx context.Context, userID string, page store.Page) (res []comment.Response, cursor store.Cursor, err error) {
+	if _, err = s.userRepository.Get(ctx, userID); err != nil {
+		return
+	}
+
+	data, cursor, err := s.commentRepository.ListMentioning(ctx, userID, page)
+	if err != nil {
+		return
+	}
+
+	res = comment.ParseFromEntities(data)
+	err = s.withMentions(ctx, res)
+
+	return
+}
+
+// taskComment returns the comment when it belongs to the task.
+func (s *Service) taskComment(ctx context.Context, taskID, id string) (data comment.Entity, err error) {
+	if data, err = s.commentRepository.Get(ctx, id); err != nil {
+		return
+	}
+	if data.TaskID != taskID {
+		err = store.ErrorNotFound
+	}
+
+	return
+}
+
+// commentResource returns the comment of the task along with the resource to
+// authorize, the author takes the place of the user.
+func (s *Service) commentResource(ctx context.Context, taskID, id string) (resource access.Resource, data comment.Entity, err error) {
+	current, err := s.taskRepository.Get(ctx, taskID)
+	if err != nil {
+		return
+	}
+	if data, err = s.taskComment(ctx, taskID, id); err != nil {
+		return
+	}
+
+	resource = taskResource(current)
+	if data.AuthorID != nil {
+		resource.UserID = *data.AuthorID
+	}
+
+	return
+}
+
+// resolveMentions returns the ids of the users mentioned in the body, emails
+// of unknown users are skipped.
+func (s *Service) resolveMentions(ctx context.Context, body string) (userIDs []string, err error) {
+	seen := map[string]bool{}
+	for _, email := range comment.Mentions(body) {
+		data, err := s.userRepository.GetByEmail(store.WithDeleted(ctx, false), email)
+		if errors.Is(err, store.ErrorNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !seen[data.ID] {
+			seen[data.ID] = true
+			userIDs = append(userIDs, data.ID)
+		}
+	}
+
+	return
+}
+
+// withMentions fills in the mentioned users of the comments with a single
+// query.
+func (s *Service) withMentions(ctx context.Context, res []comment.Response) (err error) {
+	if len(res) == 0 {
+		return
+	}
+
+	ids := make([]string, 0, len(res))
+	for _, object := range res {
+		ids = append(ids, object.ID)
+	}
+
+	mentions, err := s.commentRepository.ListMentions(ctx, ids)
+	if err != nil {
+		return
+	}
+
+	for i := range res {
+		res[i].Mentions = mentions[res[i].ID]
+	}
+
+	return
+}
@@ -0,0 +1,52 @@
+package tasker
+
+import (
+	"context"
+	"hard/internal/domain/access"
+	"hard/internal/domain/audit"
+	"hard/pkg/store"
+	"time"
+)
+
+// IncludeDeleted returns a context in which lists, gets and searches also
+// return soft deleted users, projects and tasks.
+func (s *Service) IncludeDeleted(ctx context.Context) (context.Context, error) {
+	if err := s.authorize(ctx, access.ActionReadDeleted, access.Resource{}); err != nil {
+		return ctx, err
+	}
+	return store.WithDeleted(ctx, true), nil
+}
+
+// Purge hard deletes the users, projects and tasks soft deleted longer than
+// retention ago and returns how many rows were removed, the blobs of the
+// attachments of the removed tasks go after them.
+func (s *Service) Purge(ctx context.Context, retention time.Duration) (n int64, err error) {
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		tasks, err := s.taskRepository.Purge(ctx, retention)
+		if err != nil {
+			return
+		}
+		projects, err := s.projectRepository.Purge(ctx, retention)
+		if err != nil {
+			return
+		}
+		users, err := s.userRepository.Purge(ctx, retention)
+		if err != nil {
+			return
+		}
+		n = tasks + projects + users
+		return
+	})
+	if err != nil {
+		return
+	}
+
+	err = s.purgeAttachments(ctx)
+
+	return
+}
+
+// restored is the audit diff of a restore, it clears deleted_at.
+func restored(deletedAt *string) audit.Diff {
+	return audit.Diff{"deleted_at": {Old: *deletedAt}}
+}
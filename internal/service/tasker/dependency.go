@@ -0,0 +1,180 @@
+package tasker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hard/internal/domain/access"
+	"hard/internal/domain/audit"
+	"hard/internal/domain/dependency"
+	"hard/internal/domain/task"
+	"hard/pkg/store"
+	"strings"
+)
+
+// ListDependencies returns the tasks blocking the task.
+func (s *Service) ListDependencies(ctx context.Context, id string, page store.Page) (res []task.Response, cursor store.Cursor, err error) {
+	if _, err = s.taskRepository.Get(ctx, id); err != nil {
+		return
+	}
+
+	data, cursor, err := s.dependencyRepository.ListBlockers(ctx, id, page)
+	if err != nil {
+		return
+	}
+
+	res = task.ParseFromEntities(data)
+
+	return
+}
+
+// ListDependents returns the tasks blocked by the task.
+func (s *Service) ListDependents(ctx context.Context, id string, page store.Page) (res []task.Response, cursor store.Cursor, err error) {
+	if _, err = s.taskRepository.Get(ctx, id); err != nil {
+		return
+	}
+
+	data, cursor, err := s.dependencyRepository.ListDependents(ctx, id, page)
+	if err != nil {
+		return
+	}
+
+	res = task.ParseFromEntities(data)
+
+	return
+}
+
+func (s *Service) AddDependency(ctx context.Context, id string, req dependency.Request) (res dependency.Response, err error) {
+	current, err := s.taskRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+	if err = s.authorize(ctx, access.ActionUpdateTask, taskResource(current)); err != nil {
+		return
+	}
+
+	if *req.BlockerID == id {
+		return res, dependency.ErrorSelf
+	}
+
+	blocker, err := s.taskRepository.Get(ctx, *req.BlockerID)
+	if err != nil {
+		if errors.Is(err, store.ErrorNotFound) {
+			err = dependency.ErrorUnknownBlocker
+		}
+		return
+	}
+	if !s.crossProject && !sameProject(current, blocker) {
+		return res, dependency.ErrorCrossProject
+	}
+
+	if _, err = s.dependencyRepository.Get(ctx, id, blocker.ID); err == nil {
+		return res, dependency.ErrorExists
+	} else if !errors.Is(err, store.ErrorNotFound) {
+		return
+	}
+
+	// the new link closes a cycle when the task already blocks its blocker
+	cycle, err := s.dependencyRepository.Blocks(ctx, id, blocker.ID)
+	if err != nil {
+		return
+	}
+	if cycle {
+		return res, fmt.Errorf("%w: task %s already blocks task %s", dependency.ErrorCycle, id, blocker.ID)
+	}
+
+	data := dependency.Entity{
+		TaskID:    id,
+		BlockerID: blocker.ID,
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.dependencyRepository.Add(ctx, data); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityDependency, dependencyID(data), audit.OperationCreate, audit.Compare(nil, data))
+	})
+	if err != nil {
+		return
+	}
+
+	if data, err = s.dependencyRepository.Get(ctx, id, blocker.ID); err != nil {
+		return
+	}
+
+	res = dependency.ParseFromEntity(data)
+
+	return
+}
+
+func (s *Service) DeleteDependency(ctx context.Context, id, blockerID string) (err error) {
+	current, err := s.taskRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+	if err = s.authorize(ctx, access.ActionUpdateTask, taskResource(current)); err != nil {
+		return
+	}
+
+	data, err := s.dependencyRepository.Get(ctx, id, blockerID)
+	if err != nil {
+		return
+	}
+
+	return s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.dependencyRepository.Delete(ctx, id, blockerID); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityDependency, dependencyID(data), audit.OperationDelete, audit.Compare(data, nil))
+	})
+}
+
+// GetTaskOrder returns the tasks of the project in topological order: every
+// task comes after the tasks blocking it.
+func (s *Service) GetTaskOrder(ctx context.Context, projectID string) (res []task.Response, err error) {
+	if _, err = s.projectRepository.Get(ctx, projectID); err != nil {
+		return
+	}
+
+	tasks, err := s.taskRepository.ListByProject(ctx, projectID)
+	if err != nil {
+		return
+	}
+
+	links, err := s.dependencyRepository.ListByProject(ctx, projectID)
+	if err != nil {
+		return
+	}
+
+	data, err := dependency.Sort(tasks, links)
+	if err != nil {
+		return
+	}
+
+	res = task.ParseFromEntities(data)
+
+	return
+}
+
+// checkBlockers fails when any of the tasks being closed is blocked by an open
+// task that is not closed along with it.
+func (s *Service) checkBlockers(ctx context.Context, ids ...string) (err error) {
+	blockers, err := s.dependencyRepository.OpenBlockers(ctx, ids)
+	if err != nil {
+		return
+	}
+	if len(blockers) > 0 {
+		return fmt.Errorf("%w: %s", dependency.ErrorBlocked, strings.Join(blockers, ", "))
+	}
+
+	return
+}
+
+// dependencyID identifies a dependency in the audit log.
+func dependencyID(data dependency.Entity) string {
+	return data.TaskID + "/" + data.BlockerID
+}
+
+func sameProject(a, b task.Entity) bool {
+	return a.ProjectID != nil && b.ProjectID != nil && *a.ProjectID == *b.ProjectID
+}
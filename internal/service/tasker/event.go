@@ -0,0 +1,151 @@
+package tasker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hard/internal/domain/audit"
+	"hard/internal/domain/event"
+	"hard/internal/domain/webhook"
+	"hard/pkg/pubsub"
+	"hard/pkg/store"
+	"sync"
+	"time"
+)
+
+type pendingEventsKey struct{}
+
+// pendingEvents are the events of a transaction, streamed once it commits.
+type pendingEvents struct {
+	mu   sync.Mutex
+	data []event.Event
+}
+
+// SubscribeEvents starts streaming the task and project events the filter
+// matches. When after is the ID of the last event the client got, replay are
+// the events it missed, complete is false when some of them are lost.
+func (s *Service) SubscribeEvents(ctx context.Context, filter event.Filter, after int64) (sub *pubsub.Subscription[event.Event], replay []pubsub.Message[event.Event], complete bool, err error) {
+	if filter.ProjectID != "" {
+		if _, err = s.projectRepository.Get(ctx, filter.ProjectID); err != nil {
+			return
+		}
+	}
+	if filter.AssigneeID != "" {
+		if _, err = s.userRepository.Get(ctx, filter.AssigneeID); err != nil {
+			return
+		}
+	}
+
+	sub, replay, complete = s.eventHub.Subscribe(after, filter.Match)
+
+	return
+}
+
+// ListenEvents streams the events other replicas notify until ctx is done.
+// Events notified while the connection was down are lost, the clients
+// resuming from before are told to reload.
+func (s *Service) ListenEvents(ctx context.Context) error {
+	if s.eventRepository == nil || !s.eventNotify {
+		return nil
+	}
+
+	return s.eventRepository.Listen(ctx, func(id int64, data event.Event) {
+		s.eventHub.Publish(id, data)
+	}, s.eventHub.Reset)
+}
+
+// raise queues the event of a task or project change until the transaction
+// commits, other entities are not streamed. Events are only streamed with an
+// event repository, whose sequence gives them their IDs.
+func (s *Service) raise(ctx context.Context, entity, id string, operation audit.Operation, diff audit.Diff, actorID *string) (err error) {
+	if s.eventHub == nil || s.eventRepository == nil || entity != audit.EntityTask && entity != audit.EntityProject {
+		return
+	}
+
+	data := event.Event{
+		Type:      webhook.EventName(entity, operation),
+		Entity:    entity,
+		EntityID:  id,
+		Changes:   diff,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	if actorID != nil {
+		data.ActorID = *actorID
+	}
+
+	switch entity {
+	case audit.EntityProject:
+		data.ProjectID = id
+	case audit.EntityTask:
+		// the task is read in the transaction, so it is already changed
+		current, err := s.taskRepository.Get(store.WithDeleted(ctx, true), id)
+		switch {
+		case err == nil:
+			if current.ProjectID != nil {
+				data.ProjectID = *current.ProjectID
+			}
+			if current.AssigneeID != nil {
+				data.AssigneeID = *current.AssigneeID
+			}
+		case errors.Is(err, store.ErrorNotFound):
+			data.ProjectID = diffValue(diff, "project_id")
+			data.AssigneeID = diffValue(diff, "assignee_id")
+		default:
+			return err
+		}
+		if change, ok := diff["project_id"]; ok && change.Old != nil && operation == audit.OperationUpdate {
+			data.PreviousProjectID = fmt.Sprint(change.Old)
+		}
+		if change, ok := diff["assignee_id"]; ok && change.Old != nil && operation == audit.OperationUpdate {
+			data.PreviousAssigneeID = fmt.Sprint(change.Old)
+		}
+	}
+
+	if pending, ok := ctx.Value(pendingEventsKey{}).(*pendingEvents); ok {
+		pending.mu.Lock()
+		pending.data = append(pending.data, data)
+		pending.mu.Unlock()
+		return
+	}
+
+	s.stream(ctx, data)
+
+	return
+}
+
+// stream sends the event with an ID from the event sequence to the clients of
+// every replica through the event repository, or to those of this one only.
+func (s *Service) stream(ctx context.Context, data event.Event) {
+	// the change is committed, the event must go out even if the caller left
+	ctx = context.WithoutCancel(ctx)
+
+	id, err := s.eventRepository.NextID(ctx)
+	if err != nil {
+		// the event cannot be streamed without an ID, the clients resuming
+		// from before it are told to reload
+		s.eventHub.Reset()
+		return
+	}
+
+	if s.eventNotify {
+		if err = s.eventRepository.Notify(ctx, id, data); err == nil {
+			return
+		}
+	}
+
+	s.eventHub.Publish(id, data)
+}
+
+// diffValue is the new value of a column, or the old one when it was removed.
+func diffValue(diff audit.Diff, column string) string {
+	change, ok := diff[column]
+	switch {
+	case !ok:
+		return ""
+	case change.New != nil:
+		return fmt.Sprint(change.New)
+	case change.Old != nil:
+		return fmt.Sprint(change.Old)
+	}
+	return ""
+}
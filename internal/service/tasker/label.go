@@ -0,0 +1,260 @@
+package tasker
+
+import (
+	"context"
+	"errors"
+	"hard/internal/domain/access"
+	"hard/internal/domain/audit"
+	"hard/internal/domain/label"
+	"hard/internal/domain/task"
+	"hard/pkg/store"
+	"strings"
+)
+
+func (s *Service) ListLabels(ctx context.Context, projectID string, page store.Page) (res []label.Response, cursor store.Cursor, err error) {
+	if _, err = s.projectRepository.Get(ctx, projectID); err != nil {
+		return
+	}
+
+	data, cursor, err := s.labelRepository.List(ctx, projectID, page)
+	if err != nil {
+		return
+	}
+
+	res = label.ParseFromEntities(data)
+
+	return
+}
+
+func (s *Service) CreateLabel(ctx context.Context, projectID string, req label.Request) (res label.Response, err error) {
+	if _, err = s.projectRepository.Get(ctx, projectID); err != nil {
+		return
+	}
+	if err = s.authorize(ctx, access.ActionManageLabels, access.Resource{ProjectID: projectID}); err != nil {
+		return
+	}
+
+	name := strings.TrimSpace(*req.Name)
+	data := label.Entity{
+		ProjectID: projectID,
+		Name:      &name,
+		Color:     req.Color,
+	}
+
+	if err = s.checkLabelName(ctx, projectID, "", name); err != nil {
+		return
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if data.ID, err = s.labelRepository.Add(ctx, data); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityLabel, data.ID, audit.OperationCreate, audit.Compare(nil, data))
+	})
+	if err != nil {
+		return
+	}
+
+	res = label.ParseFromEntity(data)
+
+	return
+}
+
+func (s *Service) GetLabel(ctx context.Context, projectID, id string) (res label.Response, err error) {
+	data, err := s.projectLabel(ctx, projectID, id)
+	if err != nil {
+		return
+	}
+
+	res = label.ParseFromEntity(data)
+
+	return
+}
+
+func (s *Service) UpdateLabel(ctx context.Context, projectID, id string, req label.Request) (err error) {
+	current, err := s.projectLabel(ctx, projectID, id)
+	if err != nil {
+		return
+	}
+	if err = s.authorize(ctx, access.ActionManageLabels, access.Resource{ProjectID: projectID}); err != nil {
+		return
+	}
+
+	data := label.Entity{
+		Color: req.Color,
+	}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if err = s.checkLabelName(ctx, projectID, id, name); err != nil {
+			return
+		}
+		data.Name = &name
+	}
+
+	return s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.labelRepository.Update(ctx, id, data); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityLabel, id, audit.OperationUpdate, audit.Changes(current, data))
+	})
+}
+
+// DeleteLabel removes the label from the project and from all of its tasks.
+func (s *Service) DeleteLabel(ctx context.Context, projectID, id string) (err error) {
+	current, err := s.projectLabel(ctx, projectID, id)
+	if err != nil {
+		return
+	}
+	if err = s.authorize(ctx, access.ActionManageLabels, access.Resource{ProjectID: projectID}); err != nil {
+		return
+	}
+
+	return s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.labelRepository.Delete(ctx, id); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityLabel, id, audit.OperationDelete, audit.Compare(current, nil))
+	})
+}
+
+func (s *Service) ListTaskLabels(ctx context.Context, id string) (res []label.Response, err error) {
+	if _, err = s.taskRepository.Get(ctx, id); err != nil {
+		return
+	}
+
+	labels, err := s.labelRepository.ListByTasks(ctx, []string{id})
+	if err != nil {
+		return
+	}
+
+	res = label.ParseFromEntities(labels[id])
+
+	return
+}
+
+// AttachLabel attaches a label of the task's project to the task.
+func (s *Service) AttachLabel(ctx context.Context, id string, req label.AttachRequest) (res label.Response, err error) {
+	current, err := s.taskRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+	if err = s.authorize(ctx, access.ActionUpdateTask, taskResource(current)); err != nil {
+		return
+	}
+
+	data, err := s.labelRepository.Get(ctx, *req.LabelID)
+	if err != nil {
+		if errors.Is(err, store.ErrorNotFound) {
+			err = label.ErrorUnknownLabel
+		}
+		return
+	}
+	if current.ProjectID == nil || *current.ProjectID != data.ProjectID {
+		return res, label.ErrorOtherProject
+	}
+
+	if _, err = s.labelRepository.GetAssignment(ctx, id, data.ID); err == nil {
+		return res, label.ErrorAttached
+	} else if !errors.Is(err, store.ErrorNotFound) {
+		return
+	}
+
+	assignment := label.Assignment{
+		TaskID:  id,
+		LabelID: data.ID,
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.labelRepository.Attach(ctx, assignment); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityTaskLabel, assignmentID(assignment), audit.OperationCreate, audit.Compare(nil, assignment))
+	})
+	if err != nil {
+		return
+	}
+
+	res = label.ParseFromEntity(data)
+
+	return
+}
+
+func (s *Service) DetachLabel(ctx context.Context, id, labelID string) (err error) {
+	current, err := s.taskRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+	if err = s.authorize(ctx, access.ActionUpdateTask, taskResource(current)); err != nil {
+		return
+	}
+
+	data, err := s.labelRepository.GetAssignment(ctx, id, labelID)
+	if err != nil {
+		return
+	}
+
+	return s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.labelRepository.Detach(ctx, id, labelID); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityTaskLabel, assignmentID(data), audit.OperationDelete, audit.Compare(data, nil))
+	})
+}
+
+// projectLabel returns the label when it belongs to the project.
+func (s *Service) projectLabel(ctx context.Context, projectID, id string) (data label.Entity, err error) {
+	if data, err = s.labelRepository.Get(ctx, id); err != nil {
+		return
+	}
+	if data.ProjectID != projectID {
+		err = store.ErrorNotFound
+	}
+
+	return
+}
+
+// checkLabelName fails when another label of the project has the name.
+func (s *Service) checkLabelName(ctx context.Context, projectID, id, name string) (err error) {
+	existing, err := s.labelRepository.GetByName(ctx, projectID, name)
+	if errors.Is(err, store.ErrorNotFound) {
+		return nil
+	}
+	if err != nil {
+		return
+	}
+	if existing.ID != id {
+		return label.ErrorExists
+	}
+
+	return
+}
+
+// withLabels fills in the labels of the tasks with a single query.
+func (s *Service) withLabels(ctx context.Context, res []task.Response) (err error) {
+	if s.labelRepository == nil || len(res) == 0 {
+		return
+	}
+
+	ids := make([]string, 0, len(res))
+	for _, object := range res {
+		ids = append(ids, object.ID)
+	}
+
+	labels, err := s.labelRepository.ListByTasks(ctx, ids)
+	if err != nil {
+		return
+	}
+
+	for i := range res {
+		if data, ok := labels[res[i].ID]; ok {
+			res[i].Labels = label.ParseFromEntities(data)
+		}
+	}
+
+	return
+}
+
+// assignmentID identifies a label of a task in the audit log.
+func assignmentID(data label.Assignment) string {
+	return data.TaskID + "/" + data.LabelID
+}
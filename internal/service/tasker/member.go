@@ -0,0 +1,182 @@
+package tasker
+
+import (
+	"context"
+	"errors"
+	"hard/internal/domain/access"
+	"hard/internal/domain/audit"
+	"hard/internal/domain/member"
+	"hard/internal/domain/project"
+	"hard/internal/domain/task"
+	"hard/pkg/store"
+)
+
+func (s *Service) ListMembers(ctx context.Context, projectID string, page store.Page) (res []member.Response, cursor store.Cursor, err error) {
+	if _, err = s.projectRepository.Get(ctx, projectID); err != nil {
+		return
+	}
+
+	data, cursor, err := s.memberRepository.List(ctx, projectID, page)
+	if err != nil {
+		return
+	}
+
+	res = member.ParseFromEntities(data)
+
+	return
+}
+
+func (s *Service) AddMember(ctx context.Context, projectID string, req member.Request) (res member.Response, err error) {
+	if _, err = s.projectRepository.Get(ctx, projectID); err != nil {
+		return
+	}
+	if err = s.authorize(ctx, access.ActionManageMembers, access.Resource{ProjectID: projectID}); err != nil {
+		return
+	}
+
+	if _, err = s.userRepository.Get(ctx, *req.UserID); err != nil {
+		if errors.Is(err, store.ErrorNotFound) {
+			err = member.ErrorUnknownUser
+		}
+		return
+	}
+
+	if _, err = s.memberRepository.Get(ctx, projectID, *req.UserID); err == nil {
+		return res, member.ErrorAlreadyMember
+	} else if !errors.Is(err, store.ErrorNotFound) {
+		return
+	}
+
+	data := member.Entity{
+		ProjectID: projectID,
+		UserID:    *req.UserID,
+		Role:      req.Role,
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.memberRepository.Add(ctx, data); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityMember, memberID(data), audit.OperationCreate, audit.Compare(nil, data))
+	})
+	if err != nil {
+		return
+	}
+
+	if data, err = s.memberRepository.Get(ctx, projectID, data.UserID); err != nil {
+		return
+	}
+
+	res = member.ParseFromEntity(data)
+
+	return
+}
+
+func (s *Service) UpdateMember(ctx context.Context, projectID, userID string, req member.Request) (err error) {
+	if err = s.authorize(ctx, access.ActionManageMembers, access.Resource{ProjectID: projectID}); err != nil {
+		return
+	}
+
+	data := member.Entity{
+		ProjectID: projectID,
+		UserID:    userID,
+		Role:      req.Role,
+	}
+
+	current, err := s.memberRepository.Get(ctx, projectID, userID)
+	if err != nil {
+		return
+	}
+
+	return s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.memberRepository.Update(ctx, data); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityMember, memberID(data), audit.OperationUpdate, audit.Changes(current, data))
+	})
+}
+
+func (s *Service) DeleteMember(ctx context.Context, projectID, userID string) (err error) {
+	if err = s.authorize(ctx, access.ActionManageMembers, access.Resource{ProjectID: projectID}); err != nil {
+		return
+	}
+
+	current, err := s.memberRepository.Get(ctx, projectID, userID)
+	if err != nil {
+		return
+	}
+
+	return s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.memberRepository.Delete(ctx, projectID, userID); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityMember, memberID(current), audit.OperationDelete, audit.Compare(current, nil))
+	})
+}
+
+func (s *Service) GetProjectsByUser(ctx context.Context, id string, page store.Page) (res []project.Response, cursor store.Cursor, err error) {
+	data, cursor, err := s.userRepository.ListProjects(ctx, id, page)
+	if err != nil {
+		return
+	}
+
+	res = project.ParseFromEntities(data)
+
+	return
+}
+
+// ensureOwner makes the manager of a project one of its owners.
+func (s *Service) ensureOwner(ctx context.Context, projectID, managerID string) (err error) {
+	owner := member.RoleOwner
+	data := member.Entity{ProjectID: projectID, UserID: managerID, Role: &owner}
+
+	current, err := s.memberRepository.Get(ctx, projectID, managerID)
+	switch {
+	case errors.Is(err, store.ErrorNotFound):
+		if err = s.memberRepository.Add(ctx, data); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityMember, memberID(data), audit.OperationCreate, audit.Compare(nil, data))
+	case err != nil:
+		return
+	case current.AtLeast(member.RoleOwner):
+		return
+	default:
+		if err = s.memberRepository.Update(ctx, data); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityMember, memberID(data), audit.OperationUpdate, audit.Changes(current, data))
+	}
+}
+
+// memberID identifies a membership in the audit log.
+func memberID(data member.Entity) string {
+	return data.ProjectID + "/" + data.UserID
+}
+
+// checkAssignee verifies that the assignee of the task, after applying the
+// change in data, is a member of the task's project.
+func (s *Service) checkAssignee(ctx context.Context, current, data *task.Entity) (err error) {
+	if data.AssigneeID == nil && data.ProjectID == nil {
+		return
+	}
+
+	assigneeID, projectID := data.AssigneeID, data.ProjectID
+	if assigneeID == nil {
+		assigneeID = current.AssigneeID
+	}
+	if projectID == nil {
+		projectID = current.ProjectID
+	}
+	if assigneeID == nil || projectID == nil || *assigneeID == "" {
+		return
+	}
+
+	if _, err = s.memberRepository.Get(ctx, *projectID, *assigneeID); err != nil {
+		if errors.Is(err, store.ErrorNotFound) {
+			err = member.ErrorNotMember
+		}
+	}
+
+	return
+}
@@ -0,0 +1,318 @@
+package tasker
+
+import (
+	"context"
+	"errors"
+	"hard/internal/domain/access"
+	"hard/internal/domain/audit"
+	"hard/internal/domain/milestone"
+	"hard/internal/domain/task"
+	"hard/pkg/store"
+	"strings"
+)
+
+func (s *Service) ListMilestones(ctx context.Context, projectID string, page store.Page) (res []milestone.Response, cursor store.Cursor, err error) {
+	if _, err = s.projectRepository.Get(ctx, projectID); err != nil {
+		return
+	}
+
+	data, cursor, err := s.milestoneRepository.List(ctx, projectID, page)
+	if err != nil {
+		return
+	}
+
+	ids := make([]string, 0, len(data))
+	for _, object := range data {
+		ids = append(ids, object.ID)
+	}
+
+	stats, err := s.milestoneRepository.Stats(ctx, ids)
+	if err != nil {
+		return
+	}
+
+	res = milestone.ParseFromEntities(data, stats)
+
+	return
+}
+
+func (s *Service) CreateMilestone(ctx context.Context, projectID string, req milestone.Request) (res milestone.Response, err error) {
+	if _, err = s.projectRepository.Get(ctx, projectID); err != nil {
+		return
+	}
+	if err = s.authorize(ctx, access.ActionManageMilestones, access.Resource{ProjectID: projectID}); err != nil {
+		return
+	}
+
+	name := strings.TrimSpace(*req.Name)
+	data := milestone.Entity{
+		ProjectID: projectID,
+		Name:      &name,
+	}
+	if req.Goal != nil && *req.Goal != "" {
+		data.Goal = req.Goal
+	}
+	if req.StartDate != nil && *req.StartDate != "" {
+		data.StartDate = req.StartDate
+	}
+	if req.EndDate != nil && *req.EndDate != "" {
+		data.EndDate = req.EndDate
+	}
+
+	if err = s.checkMilestoneName(ctx, projectID, "", name); err != nil {
+		return
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if data.ID, err = s.milestoneRepository.Add(ctx, data); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityMilestone, data.ID, audit.OperationCreate, audit.Compare(nil, data))
+	})
+	if err != nil {
+		return
+	}
+
+	res = milestone.ParseFromEntity(data, milestone.Stats{})
+
+	return
+}
+
+func (s *Service) GetMilestone(ctx context.Context, projectID, id string) (res milestone.Response, err error) {
+	data, err := s.projectMilestone(ctx, projectID, id)
+	if err != nil {
+		return
+	}
+
+	stats, err := s.milestoneRepository.Stats(ctx, []string{id})
+	if err != nil {
+		return
+	}
+
+	res = milestone.ParseFromEntity(data, stats[id])
+
+	return
+}
+
+func (s *Service) UpdateMilestone(ctx context.Context, projectID, id string, req milestone.Request) (err error) {
+	current, err := s.projectMilestone(ctx, projectID, id)
+	if err != nil {
+		return
+	}
+	if err = s.authorize(ctx, access.ActionManageMilestones, access.Resource{ProjectID: projectID}); err != nil {
+		return
+	}
+
+	// the dates are checked together with the stored ones
+	stored := milestone.ParseFromEntity(current, milestone.Stats{})
+	dates := milestone.Request{StartDate: req.StartDate, EndDate: req.EndDate}
+	if dates.StartDate == nil {
+		dates.StartDate = &stored.StartDate
+	}
+	if dates.EndDate == nil {
+		dates.EndDate = &stored.EndDate
+	}
+	if err = dates.ValidateUpdate(); err != nil {
+		return
+	}
+
+	data := milestone.Entity{
+		Goal:      req.Goal,
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+	}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if err = s.checkMilestoneName(ctx, projectID, id, name); err != nil {
+			return
+		}
+		data.Name = &name
+	}
+
+	return s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.milestoneRepository.Update(ctx, id, data); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityMilestone, id, audit.OperationUpdate, audit.Changes(current, data))
+	})
+}
+
+// DeleteMilestone removes the milestone, its tasks are kept without one.
+func (s *Service) DeleteMilestone(ctx context.Context, projectID, id string) (err error) {
+	current, err := s.projectMilestone(ctx, projectID, id)
+	if err != nil {
+		return
+	}
+	if err = s.authorize(ctx, access.ActionManageMilestones, access.Resource{ProjectID: projectID}); err != nil {
+		return
+	}
+
+	return s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.milestoneRepository.Delete(ctx, id); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityMilestone, id, audit.OperationDelete, audit.Compare(current, nil))
+	})
+}
+
+// CloseMilestone closes the milestone and moves its unfinished tasks to the
+// requested milestone, or to the next open one of the project. Without a next
+// milestone the tasks are left without one.
+func (s *Service) CloseMilestone(ctx context.Context, projectID, id string, req milestone.CloseRequest) (res milestone.CloseResponse, err error) {
+	current, err := s.projectMilestone(ctx, projectID, id)
+	if err != nil {
+		return
+	}
+	if err = s.authorize(ctx, access.ActionManageMilestones, access.Resource{ProjectID: projectID}); err != nil {
+		return
+	}
+	if current.ClosedAt != nil {
+		return res, milestone.ErrorClosed
+	}
+
+	next, err := s.nextMilestone(ctx, current, req.NextMilestoneID)
+	if err != nil {
+		return
+	}
+
+	var moved []string
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		closedAt, err := s.milestoneRepository.Close(ctx, id)
+		if err != nil {
+			if errors.Is(err, store.ErrorNotFound) {
+				err = milestone.ErrorClosed
+			}
+			return
+		}
+		if err = s.record(ctx, audit.EntityMilestone, id, audit.OperationUpdate, audit.Changes(current, milestone.Entity{ClosedAt: &closedAt})); err != nil {
+			return
+		}
+		current.ClosedAt = &closedAt
+
+		if moved, err = s.milestoneRepository.MoveOpenTasks(ctx, id, next); err != nil {
+			return
+		}
+		for _, taskID := range moved {
+			diff := audit.Changes(task.Entity{MilestoneID: &id}, task.Entity{MilestoneID: &next})
+			if err = s.record(ctx, audit.EntityTask, taskID, audit.OperationUpdate, diff); err != nil {
+				return
+			}
+		}
+
+		return
+	})
+	if err != nil {
+		return
+	}
+
+	stats, err := s.milestoneRepository.Stats(ctx, []string{id})
+	if err != nil {
+		return
+	}
+
+	res = milestone.CloseResponse{
+		Milestone:       milestone.ParseFromEntity(current, stats[id]),
+		NextMilestoneID: next,
+		MovedTaskIDs:    moved,
+	}
+	if res.MovedTaskIDs == nil {
+		res.MovedTaskIDs = []string{}
+	}
+
+	return
+}
+
+// nextMilestone resolves the milestone that receives the unfinished tasks of
+// a closed one, it is empty when the project has no other open milestone.
+func (s *Service) nextMilestone(ctx context.Context, current milestone.Entity, requested *string) (id string, err error) {
+	if requested != nil && *requested != "" {
+		data, err := s.milestoneRepository.Get(ctx, *requested)
+		if err != nil {
+			if errors.Is(err, store.ErrorNotFound) {
+				err = milestone.ErrorNextMilestone
+			}
+			return "", err
+		}
+		if data.ID == current.ID || data.ProjectID != current.ProjectID || data.ClosedAt != nil {
+			return "", milestone.ErrorNextMilestone
+		}
+		return data.ID, nil
+	}
+
+	data, err := s.milestoneRepository.Next(ctx, current)
+	if errors.Is(err, store.ErrorNotFound) {
+		return "", nil
+	}
+
+	return data.ID, err
+}
+
+// projectMilestone returns the milestone when it belongs to the project.
+func (s *Service) projectMilestone(ctx context.Context, projectID, id string) (data milestone.Entity, err error) {
+	if data, err = s.milestoneRepository.Get(ctx, id); err != nil {
+		return
+	}
+	if data.ProjectID != projectID {
+		err = store.ErrorNotFound
+	}
+
+	return
+}
+
+// checkMilestoneName fails when another milestone of the project has the name.
+func (s *Service) checkMilestoneName(ctx context.Context, projectID, id, name string) (err error) {
+	existing, err := s.milestoneRepository.GetByName(ctx, projectID, name)
+	if errors.Is(err, store.ErrorNotFound) {
+		return nil
+	}
+	if err != nil {
+		return
+	}
+	if existing.ID != id {
+		return milestone.ErrorExists
+	}
+
+	return
+}
+
+// checkMilestone verifies that the milestone of the task, after applying the
+// change in data, is an open milestone of the task's project. A task moved to
+// another project leaves its milestone unless a new one is given, an empty
+// MilestoneID takes the task out of its milestone.
+func (s *Service) checkMilestone(ctx context.Context, current, data *task.Entity) (err error) {
+	if data.MilestoneID == nil {
+		if moved(current, data) && current.MilestoneID != nil {
+			empty := ""
+			data.MilestoneID = &empty
+		}
+		return
+	}
+	if *data.MilestoneID == "" {
+		return
+	}
+	// a task may stay in a milestone that was closed after it was added
+	if current.MilestoneID != nil && *current.MilestoneID == *data.MilestoneID && !moved(current, data) {
+		return
+	}
+
+	projectID := data.ProjectID
+	if projectID == nil {
+		projectID = current.ProjectID
+	}
+
+	object, err := s.milestoneRepository.Get(ctx, *data.MilestoneID)
+	if err != nil {
+		if errors.Is(err, store.ErrorNotFound) {
+			err = milestone.ErrorUnknownMilestone
+		}
+		return
+	}
+	if projectID == nil || *projectID != object.ProjectID {
+		return milestone.ErrorOtherProject
+	}
+	if object.ClosedAt != nil {
+		return milestone.ErrorClosedMilestone
+	}
+
+	return
+}
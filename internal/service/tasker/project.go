@@ -3,13 +3,15 @@ package tasker
 import (
 	"context"
 	"errors"
+	"hard/internal/domain/access"
+	"hard/internal/domain/audit"
 	"hard/internal/domain/project"
 	"hard/internal/domain/task"
 	"hard/pkg/store"
 )
 
-func (s *Service) ListProjects(ctx context.Context) (res []project.Response, err error) {
-	data, err := s.projectRepository.List(ctx)
+func (s *Service) ListProjects(ctx context.Context, page store.Page) (res []project.Response, cursor store.Cursor, err error) {
+	data, cursor, err := s.projectRepository.List(ctx, page)
 	if err != nil {
 		//fmt.Printf("failed to select: %v", err)
 		return
@@ -29,7 +31,15 @@ func (s *Service) CreateProject(ctx context.Context, req project.Request) (res p
 		ManagerID:   req.ManagerID,
 	}
 
-	data.ID, err = s.projectRepository.Add(ctx, data)
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if data.ID, err = s.projectRepository.Add(ctx, data); err != nil {
+			return
+		}
+		if err = s.record(ctx, audit.EntityProject, data.ID, audit.OperationCreate, audit.Compare(nil, data)); err != nil {
+			return
+		}
+		return s.ensureOwner(ctx, data.ID, *data.ManagerID)
+	})
 	if err != nil {
 		//fmt.Printf("failed to create: %v\n", err)
 		return
@@ -42,7 +52,7 @@ func (s *Service) CreateProject(ctx context.Context, req project.Request) (res p
 
 func (s *Service) GetProject(ctx context.Context, id string) (res project.Response, err error) {
 	data, err := s.projectRepository.Get(ctx, id)
-	if err != nil && !errors.Is(err, store.ErrorNotFound) {
+	if err != nil {
 		//fmt.Printf("failed to get by id: %v", err)
 		return
 	}
@@ -52,7 +62,7 @@ func (s *Service) GetProject(ctx context.Context, id string) (res project.Respon
 	return
 }
 
-func (s *Service) UpdateProject(ctx context.Context, id string, req project.Request) (err error) {
+func (s *Service) UpdateProject(ctx context.Context, id string, req project.Request, version *int) (err error) {
 	data := project.Entity{
 		Title:       req.Title,
 		Description: req.Description,
@@ -61,32 +71,96 @@ func (s *Service) UpdateProject(ctx context.Context, id string, req project.Requ
 		ManagerID:   req.ManagerID,
 	}
 
-	err = s.projectRepository.Update(ctx, id, data)
-	if err != nil && !errors.Is(err, store.ErrorNotFound) {
-		//fmt.Printf("failed to update by id: %v\n", err)
+	if err = s.authorize(ctx, access.ActionUpdateProject, access.Resource{ProjectID: id}); err != nil {
 		return
 	}
 
-	return
+	current, err := s.projectRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+	if err = checkVersion(current.Version, version); err != nil {
+		return
+	}
+	data.Version = version
+
+	return s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.projectRepository.Update(ctx, id, data); err != nil {
+			return
+		}
+		if err = s.record(ctx, audit.EntityProject, id, audit.OperationUpdate, audit.Changes(current, data)); err != nil {
+			return
+		}
+		if data.ManagerID != nil {
+			err = s.ensureOwner(ctx, id, *data.ManagerID)
+		}
+		return
+	})
 }
 
-func (s *Service) DeleteProject(ctx context.Context, id string) (err error) {
-	err = s.projectRepository.Delete(ctx, id)
-	if err != nil && !errors.Is(err, store.ErrorNotFound) {
-		//fmt.Printf("failed to delete by id %v\n", err)
+func (s *Service) DeleteProject(ctx context.Context, id string, version *int) (err error) {
+	if err = s.authorize(ctx, access.ActionDeleteProject, access.Resource{ProjectID: id}); err != nil {
 		return
 	}
 
+	current, err := s.projectRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+	if err = checkVersion(current.Version, version); err != nil {
+		return
+	}
+
+	return s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.projectRepository.Delete(ctx, id, version); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityProject, id, audit.OperationDelete, audit.Compare(current, nil))
+	})
+}
+
+// RestoreProject brings back the project together with the tasks deleted along
+// with it.
+func (s *Service) RestoreProject(ctx context.Context, id string) (res project.Response, err error) {
+	ctx = store.WithDeleted(ctx, true)
+
+	if err = s.authorize(ctx, access.ActionDeleteProject, access.Resource{ProjectID: id}); err != nil {
+		return
+	}
+
+	current, err := s.projectRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+	if current.DeletedAt == nil {
+		err = store.ErrorNotDeleted
+		return
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.projectRepository.Restore(ctx, id); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityProject, id, audit.OperationRestore, restored(current.DeletedAt))
+	})
+	if err != nil {
+		return
+	}
+
+	current.DeletedAt = nil
+	current.Version = bumped(current.Version)
+	res = project.ParseFromEntity(current)
+
 	return
 }
 
-func (s *Service) SearchProjects(ctx context.Context, req project.Request) (res []project.Response, err error) {
+func (s *Service) SearchProjects(ctx context.Context, req project.Request, page store.Page) (res []project.Response, cursor store.Cursor, err error) {
 	searchData := project.Entity{
 		Description: req.Description,
 		ManagerID:   req.ManagerID,
 	}
 
-	data, err := s.projectRepository.Search(ctx, searchData)
+	data, cursor, err := s.projectRepository.Search(ctx, searchData, page)
 	if err != nil && !errors.Is(err, store.ErrorNotFound) {
 		//fmt.Printf("failed to search projects: %v\n", err)
 		return
@@ -96,14 +170,49 @@ func (s *Service) SearchProjects(ctx context.Context, req project.Request) (res
 
 	return
 }
-func (s *Service) GetTasksByProject(ctx context.Context, id string) (res []task.Response, err error) {
-	data, err := s.projectRepository.ListTasks(ctx, id)
+func (s *Service) GetTasksByProject(ctx context.Context, id string, page store.Page) (res []task.Response, cursor store.Cursor, err error) {
+	data, cursor, err := s.projectRepository.ListTasks(ctx, id, page)
 	if err != nil && !errors.Is(err, store.ErrorNotFound) {
 		//fmt.Printf("failed to search tasks by project: %v\n", err)
 		return
 	}
 
 	res = task.ParseFromEntities(data)
+	if err == nil {
+		err = s.withLabels(ctx, res)
+	}
+
+	return
+}
+
+// GetProjects returns the projects with the given ids by id, the missing ones
+// are left out.
+func (s *Service) GetProjects(ctx context.Context, ids []string) (res map[string]project.Response, err error) {
+	data, err := s.projectRepository.GetMany(ctx, ids)
+	if err != nil {
+		return
+	}
+
+	res = make(map[string]project.Response, len(data))
+	for _, object := range data {
+		res[object.ID] = project.ParseFromEntity(object)
+	}
+
+	return
+}
+
+// GetTasksByProjects returns up to limit tasks of each of the projects in one
+// query, without their labels.
+func (s *Service) GetTasksByProjects(ctx context.Context, ids []string, limit int) (res map[string][]task.Response, err error) {
+	data, err := s.taskRepository.ListByProjects(ctx, ids, store.Page{Limit: limit}.Size())
+	if err != nil {
+		return
+	}
+
+	res = make(map[string][]task.Response, len(data))
+	for id, tasks := range data {
+		res[id] = task.ParseFromEntities(tasks)
+	}
 
 	return
 }
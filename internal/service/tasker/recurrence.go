@@ -0,0 +1,276 @@
+package tasker
+
+import (
+	"context"
+	"errors"
+	"hard/internal/domain/access"
+	"hard/internal/domain/audit"
+	"hard/internal/domain/label"
+	"hard/internal/domain/recurrence"
+	"hard/internal/domain/task"
+	"hard/pkg/store"
+	"time"
+)
+
+func (s *Service) GetRecurrence(ctx context.Context, taskID string) (res recurrence.Response, err error) {
+	if _, err = s.taskRepository.Get(ctx, taskID); err != nil {
+		return
+	}
+
+	data, err := s.recurrenceRepository.GetByTask(ctx, taskID)
+	if err != nil {
+		return
+	}
+
+	res = recurrence.ParseFromEntity(data)
+
+	return
+}
+
+// SaveRecurrence makes the task recur. A new series starts at the start date
+// of the task, or its due date, or today. A series that already exists starts
+// over from its latest occurrence with the new rule.
+func (s *Service) SaveRecurrence(ctx context.Context, taskID string, req recurrence.Request) (res recurrence.Response, err error) {
+	current, err := s.taskRepository.Get(ctx, taskID)
+	if err != nil {
+		return
+	}
+	if err = s.authorize(ctx, access.ActionUpdateTask, taskResource(current)); err != nil {
+		return
+	}
+
+	rule, err := recurrence.ParseRule(*req.Rule)
+	if err != nil {
+		return
+	}
+
+	previous, err := s.recurrenceRepository.GetByTask(ctx, taskID)
+	exists := err == nil
+	if err != nil && !errors.Is(err, store.ErrorNotFound) {
+		return
+	}
+
+	data := recurrence.Entity{
+		TaskID:    taskID,
+		Rule:      rule.String(),
+		Trigger:   recurrence.TriggerCompletion,
+		Generated: 1,
+	}
+	if req.Trigger != nil {
+		data.Trigger = *req.Trigger
+	}
+
+	start := anchorDate(current, time.Now())
+	if exists {
+		data.ID, data.TaskID = previous.ID, previous.TaskID
+		if start, err = recurrence.ParseDate(previous.LastDate); err != nil {
+			return
+		}
+	}
+	data.StartDate = recurrence.FormatDate(start)
+	data.LastDate = data.StartDate
+	if next, ok := rule.Next(start, start, data.Generated); ok {
+		date := recurrence.FormatDate(next)
+		data.NextDate = &date
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if exists {
+			if err = s.recurrenceRepository.Update(ctx, data.ID, data); err != nil {
+				return
+			}
+			return s.record(ctx, audit.EntityRecurrence, data.ID, audit.OperationUpdate, audit.Compare(previous, data))
+		}
+
+		if data.ID, err = s.recurrenceRepository.Add(ctx, data); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityRecurrence, data.ID, audit.OperationCreate, audit.Compare(nil, data))
+	})
+	if err != nil {
+		return
+	}
+
+	res = recurrence.ParseFromEntity(data)
+
+	return
+}
+
+// DeleteRecurrence stops the series, the tasks already created are kept.
+func (s *Service) DeleteRecurrence(ctx context.Context, taskID string) (err error) {
+	current, err := s.taskRepository.Get(ctx, taskID)
+	if err != nil {
+		return
+	}
+	if err = s.authorize(ctx, access.ActionUpdateTask, taskResource(current)); err != nil {
+		return
+	}
+
+	data, err := s.recurrenceRepository.GetByTask(ctx, taskID)
+	if err != nil {
+		return
+	}
+
+	return s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.recurrenceRepository.Delete(ctx, data.ID); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityRecurrence, data.ID, audit.OperationDelete, audit.Compare(data, nil))
+	})
+}
+
+// RunRecurrences creates the occurrences due by now and returns how many tasks
+// were created. It is safe to run again or from several instances at once: an
+// occurrence is created at most once, a run that loses the race rolls back.
+func (s *Service) RunRecurrences(ctx context.Context, now time.Time) (n int, err error) {
+	if s.recurrenceRepository == nil {
+		return
+	}
+
+	today := now.UTC().Truncate(24 * time.Hour)
+	series, err := s.recurrenceRepository.ListDue(ctx, recurrence.FormatDate(today))
+	if err != nil {
+		return
+	}
+
+	var errs []error
+	for _, data := range series {
+		created, err := s.materialize(ctx, data, today)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if created {
+			n++
+		}
+	}
+
+	return n, errors.Join(errs...)
+}
+
+// materialize copies the latest occurrence of the series into a new task for
+// the due date. The dates of the copy are shifted by the same number of days,
+// its status is the first one of the project workflow and labels are kept.
+func (s *Service) materialize(ctx context.Context, series recurrence.Entity, today time.Time) (created bool, err error) {
+	rule, err := recurrence.ParseRule(series.Rule)
+	if err != nil {
+		return
+	}
+
+	source, err := s.taskRepository.Get(ctx, series.TaskID)
+	if err != nil {
+		if errors.Is(err, store.ErrorNotFound) {
+			err = nil
+		}
+		return
+	}
+
+	date, ok := recurrence.Due(rule, series, source.CompletedAt != nil, today)
+	if !ok {
+		return
+	}
+	start, err := recurrence.ParseDate(series.StartDate)
+	if err != nil {
+		return
+	}
+
+	data := task.Entity{
+		Title:         source.Title,
+		Description:   source.Description,
+		Priority:      source.Priority,
+		AssigneeID:    source.AssigneeID,
+		ProjectID:     source.ProjectID,
+		ParentID:      source.ParentID,
+		EstimateHours: source.EstimateHours,
+	}
+	if data.StartDate, data.DueDate, err = shiftDates(source, date); err != nil {
+		return
+	}
+	if source.ProjectID != nil {
+		definition, err := s.workflowOf(ctx, *source.ProjectID)
+		if err != nil {
+			return false, err
+		}
+		if len(definition.Statuses) > 0 {
+			data.Status = &definition.Statuses[0]
+		}
+	}
+
+	var labels map[string][]label.Entity
+	if s.labelRepository != nil {
+		if labels, err = s.labelRepository.ListByTasks(ctx, []string{source.ID}); err != nil {
+			return
+		}
+	}
+
+	occurrence := recurrence.Occurrence{RecurrenceID: series.ID, Date: recurrence.FormatDate(date)}
+	var next *string
+	if after, ok := rule.Next(start, date, series.Generated+1); ok {
+		formatted := recurrence.FormatDate(after)
+		next = &formatted
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if data.ID, err = s.taskRepository.Add(ctx, data); err != nil {
+			return
+		}
+		if err = s.record(ctx, audit.EntityTask, data.ID, audit.OperationCreate, audit.Compare(nil, data)); err != nil {
+			return
+		}
+		for _, object := range labels[source.ID] {
+			if err = s.labelRepository.Attach(ctx, label.Assignment{TaskID: data.ID, LabelID: object.ID}); err != nil {
+				return
+			}
+		}
+
+		occurrence.TaskID = data.ID
+		return s.recurrenceRepository.Advance(ctx, occurrence, next, series.Generated+1)
+	})
+	if errors.Is(err, store.ErrorConflict) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// anchorDate is the date a task recurs from: its start date, its due date or
+// the given day when it has neither.
+func anchorDate(data task.Entity, now time.Time) time.Time {
+	for _, value := range []*string{data.StartDate, data.DueDate} {
+		if value == nil {
+			continue
+		}
+		if date, err := recurrence.ParseDate(*value); err == nil {
+			return date
+		}
+	}
+	return now.UTC().Truncate(24 * time.Hour)
+}
+
+// shiftDates moves the dates of the task so that its anchor falls on date, a
+// task without dates gets date as its start date.
+func shiftDates(data task.Entity, date time.Time) (start, due *string, err error) {
+	anchor := anchorDate(data, date)
+	shift := func(value *string) (*string, error) {
+		if value == nil {
+			return nil, nil
+		}
+		parsed, err := recurrence.ParseDate(*value)
+		if err != nil {
+			return nil, err
+		}
+		shifted := recurrence.FormatDate(date.Add(parsed.Sub(anchor)))
+		return &shifted, nil
+	}
+
+	if data.StartDate == nil && data.DueDate == nil {
+		formatted := recurrence.FormatDate(date)
+		return &formatted, nil, nil
+	}
+	if start, err = shift(data.StartDate); err != nil {
+		return
+	}
+	due, err = shift(data.DueDate)
+
+	return
+}
@@ -0,0 +1,118 @@
+package tasker
+
+import (
+	"context"
+	"hard/internal/domain/milestone"
+	"hard/internal/domain/report"
+	"time"
+)
+
+// GetBurndown reports the work left in the project, or in one of its
+// milestones, at the end of every day. A milestone report covers the dates of
+// the milestone up to today unless from and to are given.
+func (s *Service) GetBurndown(ctx context.Context, projectID string, filter report.Filter) (res report.BurndownResponse, err error) {
+	if err = s.reportFilter(ctx, projectID, &filter); err != nil {
+		return
+	}
+
+	data, err := s.reportRepository.Burndown(ctx, filter)
+	if err != nil {
+		return
+	}
+
+	res = report.ParseBurndown(filter, data)
+
+	return
+}
+
+// GetVelocity reports the tasks and estimate hours completed in the project,
+// or in one of its milestones, per week or month.
+func (s *Service) GetVelocity(ctx context.Context, projectID string, filter report.Filter) (res report.VelocityResponse, err error) {
+	if _, err = s.projectRepository.Get(ctx, projectID); err != nil {
+		return
+	}
+	if filter.MilestoneID != "" {
+		if _, err = s.projectMilestone(ctx, projectID, filter.MilestoneID); err != nil {
+			return
+		}
+	}
+
+	definition, err := s.workflowOf(ctx, projectID)
+	if err != nil {
+		return
+	}
+
+	filter.ProjectID = projectID
+	filter.Terminal = definition.Terminal
+	if err = filter.Periods(time.Now()); err != nil {
+		return
+	}
+
+	data, err := s.reportRepository.Velocity(ctx, filter)
+	if err != nil {
+		return
+	}
+
+	res = report.ParseVelocity(filter, data)
+
+	return
+}
+
+// GetCumulativeFlow reports the number of tasks in every status at the end of
+// every day, with the same range as the burndown.
+func (s *Service) GetCumulativeFlow(ctx context.Context, projectID string, filter report.Filter) (res report.FlowResponse, err error) {
+	if err = s.reportFilter(ctx, projectID, &filter); err != nil {
+		return
+	}
+
+	data, err := s.reportRepository.CumulativeFlow(ctx, filter)
+	if err != nil {
+		return
+	}
+
+	res = report.ParseFlow(filter, data)
+
+	return
+}
+
+// reportFilter fills in the project, its workflow and the range of a daily
+// report.
+func (s *Service) reportFilter(ctx context.Context, projectID string, filter *report.Filter) (err error) {
+	if _, err = s.projectRepository.Get(ctx, projectID); err != nil {
+		return
+	}
+
+	now := time.Now()
+	if filter.MilestoneID != "" {
+		data, err := s.projectMilestone(ctx, projectID, filter.MilestoneID)
+		if err != nil {
+			return err
+		}
+
+		dates := milestone.ParseFromEntity(data, milestone.Stats{})
+		if filter.From == "" {
+			filter.From = dates.StartDate
+		}
+		if filter.To == "" && dates.EndDate != "" {
+			// a running milestone is reported up to today, dates compare as
+			// strings
+			filter.To = dates.EndDate
+			if today := now.Format("2006-01-02"); today < filter.To {
+				filter.To = today
+			}
+			if filter.To < filter.From {
+				filter.To = filter.From
+			}
+		}
+	}
+
+	definition, err := s.workflowOf(ctx, projectID)
+	if err != nil {
+		return
+	}
+
+	filter.ProjectID = projectID
+	filter.Terminal, filter.Statuses = definition.Terminal, definition.Statuses
+
+	return filter.Days(now)
+}
@@ -0,0 +1,169 @@
+package tasker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hard/internal/domain/access"
+	"hard/internal/domain/audit"
+	"hard/internal/domain/schedule"
+	"hard/internal/domain/task"
+	"hard/pkg/store"
+)
+
+// ListPredecessors returns the tasks that have to finish before the task
+// starts.
+func (s *Service) ListPredecessors(ctx context.Context, id string, page store.Page) (res []task.Response, cursor store.Cursor, err error) {
+	if _, err = s.taskRepository.Get(ctx, id); err != nil {
+		return
+	}
+
+	data, cursor, err := s.predecessorRepository.ListPredecessors(ctx, id, page)
+	if err != nil {
+		return
+	}
+
+	res = task.ParseFromEntities(data)
+
+	return
+}
+
+func (s *Service) AddPredecessor(ctx context.Context, id string, req schedule.Request) (res schedule.LinkResponse, err error) {
+	current, err := s.taskRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+	if err = s.authorize(ctx, access.ActionUpdateTask, taskResource(current)); err != nil {
+		return
+	}
+
+	if *req.PredecessorID == id {
+		return res, schedule.ErrorSelf
+	}
+
+	predecessor, err := s.taskRepository.Get(ctx, *req.PredecessorID)
+	if err != nil {
+		if errors.Is(err, store.ErrorNotFound) {
+			err = schedule.ErrorUnknownPredecessor
+		}
+		return
+	}
+	if !sameProject(current, predecessor) {
+		return res, schedule.ErrorOtherProject
+	}
+
+	if _, err = s.predecessorRepository.Get(ctx, id, predecessor.ID); err == nil {
+		return res, schedule.ErrorExists
+	} else if !errors.Is(err, store.ErrorNotFound) {
+		return
+	}
+
+	// the new link closes a cycle when the task already precedes its predecessor
+	cycle, err := s.predecessorRepository.Precedes(ctx, id, predecessor.ID)
+	if err != nil {
+		return
+	}
+	if cycle {
+		return res, fmt.Errorf("%w: task %s already precedes task %s", schedule.ErrorCycle, id, predecessor.ID)
+	}
+
+	data := schedule.Link{
+		TaskID:        id,
+		PredecessorID: predecessor.ID,
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.predecessorRepository.Add(ctx, data); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityPredecessor, predecessorLinkID(data), audit.OperationCreate, audit.Compare(nil, data))
+	})
+	if err != nil {
+		return
+	}
+
+	if data, err = s.predecessorRepository.Get(ctx, id, predecessor.ID); err != nil {
+		return
+	}
+
+	res = schedule.ParseFromLink(data)
+
+	return
+}
+
+func (s *Service) DeletePredecessor(ctx context.Context, id, predecessorID string) (err error) {
+	current, err := s.taskRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+	if err = s.authorize(ctx, access.ActionUpdateTask, taskResource(current)); err != nil {
+		return
+	}
+
+	data, err := s.predecessorRepository.Get(ctx, id, predecessorID)
+	if err != nil {
+		return
+	}
+
+	return s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.predecessorRepository.Delete(ctx, id, predecessorID); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityPredecessor, predecessorLinkID(data), audit.OperationDelete, audit.Compare(data, nil))
+	})
+}
+
+// GetSchedule computes the schedule of the project from the estimates, dates
+// and predecessors of its tasks.
+func (s *Service) GetSchedule(ctx context.Context, projectID string) (res schedule.Response, err error) {
+	data, err := s.projectRepository.Get(ctx, projectID)
+	if err != nil {
+		return
+	}
+
+	tasks, err := s.taskRepository.ListByProject(ctx, projectID)
+	if err != nil {
+		return
+	}
+
+	links, err := s.predecessorRepository.ListByProject(ctx, projectID)
+	if err != nil {
+		return
+	}
+
+	return schedule.Compute(data, tasks, links)
+}
+
+// checkDates verifies that the due date of the task, after applying the change
+// in data, is not before its start date.
+func checkDates(current, data *task.Entity) (err error) {
+	startDate, dueDate := data.StartDate, data.DueDate
+	if startDate == nil {
+		startDate = current.StartDate
+	}
+	if dueDate == nil {
+		dueDate = current.DueDate
+	}
+	if startDate == nil || dueDate == nil || *startDate == "" || *dueDate == "" {
+		return
+	}
+
+	start, err := schedule.ParseDate(*startDate)
+	if err != nil {
+		return
+	}
+	due, err := schedule.ParseDate(*dueDate)
+	if err != nil {
+		return
+	}
+	if due.Before(start) {
+		return task.ErrorDueDate
+	}
+
+	return
+}
+
+// predecessorLinkID identifies a predecessor link in the audit log.
+func predecessorLinkID(data schedule.Link) string {
+	return data.TaskID + "/" + data.PredecessorID
+}
@@ -0,0 +1,19 @@
+package tasker
+
+import (
+	"context"
+	"hard/internal/domain/search"
+	"hard/pkg/store"
+)
+
+func (s *Service) Search(ctx context.Context, req search.Request, page store.Page) (res []search.Response, cursor store.Cursor, err error) {
+	data, cursor, err := s.searchRepository.Search(ctx, req.Query, req.Types, page)
+	if err != nil {
+		//fmt.Printf("failed to search: %v\n", err)
+		return
+	}
+
+	res = search.ParseFromEntities(data)
+
+	return
+}
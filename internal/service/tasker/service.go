@@ -1,21 +1,74 @@
 package tasker
 
 import (
+	"hard/internal/domain/access"
+	"hard/internal/domain/attachment"
+	"hard/internal/domain/audit"
+	"hard/internal/domain/comment"
+	"hard/internal/domain/dependency"
+	"hard/internal/domain/event"
+	"hard/internal/domain/label"
+	"hard/internal/domain/member"
+	"hard/internal/domain/milestone"
 	"hard/internal/domain/project"
+	"hard/internal/domain/recurrence"
+	"hard/internal/domain/report"
+	"hard/internal/domain/schedule"
+	"hard/internal/domain/search"
 	"hard/internal/domain/task"
+	"hard/internal/domain/timelog"
 	"hard/internal/domain/user"
+	"hard/internal/domain/webhook"
+	"hard/internal/domain/workflow"
+	"hard/pkg/auth"
+	"hard/pkg/pubsub"
+	"hard/pkg/store"
 )
 
 type Configuration func(s *Service) error
 
 type Service struct {
-	userRepository    user.Repository
-	taskRepository    task.Repository
-	projectRepository project.Repository
+	userRepository        user.Repository
+	taskRepository        task.Repository
+	projectRepository     project.Repository
+	searchRepository      search.Repository
+	workflowRepository    workflow.Repository
+	memberRepository      member.Repository
+	auditRepository       audit.Repository
+	dependencyRepository  dependency.Repository
+	predecessorRepository schedule.Repository
+	labelRepository       label.Repository
+	commentRepository     comment.Repository
+	attachmentRepository  attachment.Repository
+	timeEntryRepository   timelog.Repository
+	recurrenceRepository  recurrence.Repository
+	milestoneRepository   milestone.Repository
+	reportRepository      report.Repository
+	webhookRepository     webhook.Repository
+	eventRepository       event.Repository
+	transactor            store.Transactor
+	blobStore             store.BlobStore
+	eventHub              *pubsub.Hub[event.Event]
+
+	identityProvider auth.IdentityProvider
+	tokens           *auth.Tokens
+	policy           access.Policy
+	closePolicy      task.ClosePolicy
+	// crossProject allows dependencies between tasks of different projects.
+	crossProject bool
+	// eventNotify fans the events out to every replica through the event
+	// repository, otherwise they only reach the clients of this one.
+	eventNotify      bool
+	attachmentLimits attachment.Limits
+	webhookLimits    webhook.Limits
 }
 
 func New(configs ...Configuration) (s *Service, err error) {
-	s = &Service{}
+	s = &Service{
+		policy:        access.DefaultPolicy,
+		closePolicy:   task.ClosePolicyBlock,
+		webhookLimits: webhook.DefaultLimits,
+	}
 
 	for _, cfg := range configs {
 		if err = cfg(s); err != nil {
@@ -23,6 +76,10 @@ func New(configs ...Configuration) (s *Service, err error) {
 		}
 	}
 
+	if s.identityProvider == nil {
+		s.identityProvider = NewDirectoryProvider(s.userRepository)
+	}
+
 	return
 }
 
@@ -46,3 +103,185 @@ func WithProjectRepository(projectRepository project.Repository) Configuration {
 		return nil
 	}
 }
+
+func WithSearchRepository(searchRepository search.Repository) Configuration {
+	return func(s *Service) error {
+		s.searchRepository = searchRepository
+		return nil
+	}
+}
+
+func WithWorkflowRepository(workflowRepository workflow.Repository) Configuration {
+	return func(s *Service) error {
+		s.workflowRepository = workflowRepository
+		return nil
+	}
+}
+
+func WithMemberRepository(memberRepository member.Repository) Configuration {
+	return func(s *Service) error {
+		s.memberRepository = memberRepository
+		return nil
+	}
+}
+
+func WithAuditRepository(auditRepository audit.Repository) Configuration {
+	return func(s *Service) error {
+		s.auditRepository = auditRepository
+		return nil
+	}
+}
+
+func WithDependencyRepository(dependencyRepository dependency.Repository) Configuration {
+	return func(s *Service) error {
+		s.dependencyRepository = dependencyRepository
+		return nil
+	}
+}
+
+func WithPredecessorRepository(predecessorRepository schedule.Repository) Configuration {
+	return func(s *Service) error {
+		s.predecessorRepository = predecessorRepository
+		return nil
+	}
+}
+
+func WithLabelRepository(labelRepository label.Repository) Configuration {
+	return func(s *Service) error {
+		s.labelRepository = labelRepository
+		return nil
+	}
+}
+
+func WithCommentRepository(commentRepository comment.Repository) Configuration {
+	return func(s *Service) error {
+		s.commentRepository = commentRepository
+		return nil
+	}
+}
+
+func WithAttachmentRepository(attachmentRepository attachment.Repository) Configuration {
+	return func(s *Service) error {
+		s.attachmentRepository = attachmentRepository
+		return nil
+	}
+}
+
+func WithTimeEntryRepository(timeEntryRepository timelog.Repository) Configuration {
+	return func(s *Service) error {
+		s.timeEntryRepository = timeEntryRepository
+		return nil
+	}
+}
+
+func WithRecurrenceRepository(recurrenceRepository recurrence.Repository) Configuration {
+	return func(s *Service) error {
+		s.recurrenceRepository = recurrenceRepository
+		return nil
+	}
+}
+
+func WithMilestoneRepository(milestoneRepository milestone.Repository) Configuration {
+	return func(s *Service) error {
+		s.milestoneRepository = milestoneRepository
+		return nil
+	}
+}
+
+func WithReportRepository(reportRepository report.Repository) Configuration {
+	return func(s *Service) error {
+		s.reportRepository = reportRepository
+		return nil
+	}
+}
+
+func WithWebhookRepository(webhookRepository webhook.Repository) Configuration {
+	return func(s *Service) error {
+		s.webhookRepository = webhookRepository
+		return nil
+	}
+}
+
+func WithEventRepository(eventRepository event.Repository) Configuration {
+	return func(s *Service) error {
+		s.eventRepository = eventRepository
+		return nil
+	}
+}
+
+func WithTransactor(transactor store.Transactor) Configuration {
+	return func(s *Service) error {
+		s.transactor = transactor
+		return nil
+	}
+}
+
+func WithBlobStore(blobStore store.BlobStore) Configuration {
+	return func(s *Service) error {
+		s.blobStore = blobStore
+		return nil
+	}
+}
+
+func WithEventHub(eventHub *pubsub.Hub[event.Event]) Configuration {
+	return func(s *Service) error {
+		s.eventHub = eventHub
+		return nil
+	}
+}
+
+func WithIdentityProvider(identityProvider auth.IdentityProvider) Configuration {
+	return func(s *Service) error {
+		s.identityProvider = identityProvider
+		return nil
+	}
+}
+
+func WithTokens(tokens *auth.Tokens) Configuration {
+	return func(s *Service) error {
+		s.tokens = tokens
+		return nil
+	}
+}
+
+func WithPolicy(policy access.Policy) Configuration {
+	return func(s *Service) error {
+		s.policy = policy
+		return nil
+	}
+}
+
+func WithClosePolicy(closePolicy task.ClosePolicy) Configuration {
+	return func(s *Service) error {
+		s.closePolicy = closePolicy
+		return nil
+	}
+}
+
+func WithCrossProjectDependencies(allowed bool) Configuration {
+	return func(s *Service) error {
+		s.crossProject = allowed
+		return nil
+	}
+}
+
+func WithEventNotify(notify bool) Configuration {
+	return func(s *Service) error {
+		s.eventNotify = notify
+		return nil
+	}
+}
+
+func WithAttachmentLimits(limits attachment.Limits) Configuration {
+	return func(s *Service) error {
+		s.attachmentLimits = limits
+		return nil
+	}
+}
+
+func WithWebhookLimits(limits webhook.Limits) Configuration {
+	return func(s *Service) error {
+		s.webhookLimits = limits
+		return nil
+	}
+}
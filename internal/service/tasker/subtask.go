@@ -0,0 +1,161 @@
+package tasker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hard/internal/domain/audit"
+	"hard/internal/domain/task"
+	"hard/pkg/store"
+	"slices"
+	"strings"
+)
+
+func (s *Service) ListSubtasks(ctx context.Context, id string, page store.Page) (res []task.Response, cursor store.Cursor, err error) {
+	if _, err = s.taskRepository.Get(ctx, id); err != nil {
+		return
+	}
+
+	data, cursor, err := s.taskRepository.ListSubtasks(ctx, id, page)
+	if err != nil {
+		return
+	}
+
+	res = task.ParseFromEntities(data)
+	err = s.withLabels(ctx, res)
+
+	return
+}
+
+// GetTaskTree returns the task with its subtasks at any depth and the progress
+// rolled up from the leaves.
+func (s *Service) GetTaskTree(ctx context.Context, id string) (res task.Node, err error) {
+	root, err := s.taskRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+
+	descendants, err := s.taskRepository.Descendants(ctx, id)
+	if err != nil {
+		return
+	}
+
+	res = task.BuildTree(root, descendants)
+
+	return
+}
+
+// checkParent validates the parent of a created or updated task: the parent
+// must be a live task of the same project and must not be the task itself or
+// one of its subtasks. An empty ParentID detaches the task.
+func (s *Service) checkParent(ctx context.Context, current, data *task.Entity) (err error) {
+	projectID := data.ProjectID
+	if projectID == nil {
+		projectID = current.ProjectID
+	}
+
+	moving := moved(current, data)
+	if moving {
+		descendants, err := s.taskRepository.Descendants(store.WithDeleted(ctx, false), current.ID)
+		if err != nil {
+			return err
+		}
+		if len(descendants) > 0 {
+			return task.ErrorMoveSubtasks
+		}
+	}
+
+	parentID := data.ParentID
+	if parentID == nil {
+		if !moving || current.ParentID == nil {
+			return
+		}
+		// the current parent stays in the old project
+		parentID = current.ParentID
+	}
+	if *parentID == "" {
+		return
+	}
+
+	parent, err := s.taskRepository.Get(store.WithDeleted(ctx, false), *parentID)
+	if err != nil {
+		if errors.Is(err, store.ErrorNotFound) {
+			err = task.ErrorUnknownParent
+		}
+		return
+	}
+	if projectID != nil && (parent.ProjectID == nil || *parent.ProjectID != *projectID) {
+		return task.ErrorParentProject
+	}
+
+	if current.ID != "" {
+		ancestors, err := s.taskRepository.Ancestors(ctx, *parentID)
+		if err != nil {
+			return err
+		}
+		if slices.Contains(ancestors, current.ID) {
+			return task.ErrorParentCycle
+		}
+	}
+
+	return
+}
+
+// openSubtasks returns the subtasks of a task being closed that have to be
+// closed along with it, according to the close policy. With the block policy
+// any open subtask fails the update.
+func (s *Service) openSubtasks(ctx context.Context, current, data *task.Entity) (res []task.Entity, err error) {
+	if !closes(current, data) || s.closePolicy == task.ClosePolicyAllow {
+		return
+	}
+
+	descendants, err := s.taskRepository.Descendants(store.WithDeleted(ctx, false), current.ID)
+	if err != nil {
+		return
+	}
+
+	ids := make([]string, 0)
+	for _, descendant := range descendants {
+		if descendant.CompletedAt == nil || *descendant.CompletedAt == "" {
+			res = append(res, descendant)
+			ids = append(ids, descendant.ID)
+		}
+	}
+
+	if s.closePolicy == task.ClosePolicyBlock && len(res) > 0 {
+		return nil, fmt.Errorf("%w: %s", task.ErrorOpenSubtasks, strings.Join(ids, ", "))
+	}
+
+	return
+}
+
+// closeSubtasks moves the subtasks to the status of their closed parent. The
+// workflow is not checked, the subtasks follow the parent.
+func (s *Service) closeSubtasks(ctx context.Context, subtasks []task.Entity, parent task.Entity) (err error) {
+	for _, subtask := range subtasks {
+		data := task.Entity{
+			Status:      parent.Status,
+			CompletedAt: parent.CompletedAt,
+		}
+		if err = s.taskRepository.Update(ctx, subtask.ID, data); err != nil {
+			return
+		}
+		if err = s.record(ctx, audit.EntityTask, subtask.ID, audit.OperationUpdate, audit.Changes(subtask, data)); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// moved reports whether the change in data moves an existing task to another
+// project.
+func moved(current, data *task.Entity) bool {
+	return current.ID != "" && data.ProjectID != nil && current.ProjectID != nil && *data.ProjectID != *current.ProjectID
+}
+
+// closes reports whether the change in data completes an open task.
+func closes(current, data *task.Entity) bool {
+	return data.CompletedAt != nil && *data.CompletedAt != "" &&
+		(current.CompletedAt == nil || *current.CompletedAt == "")
+}
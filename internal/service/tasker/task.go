@@ -3,34 +3,37 @@ package tasker
 import (
 	"context"
 	"errors"
+	"hard/internal/domain/access"
+	"hard/internal/domain/audit"
 	"hard/internal/domain/task"
 	"hard/pkg/store"
 )
 
-func (s *Service) ListTasks(ctx context.Context) (res []task.Response, err error) {
-	data, err := s.taskRepository.List(ctx)
+func (s *Service) ListTasks(ctx context.Context, page store.Page) (res []task.Response, cursor store.Cursor, err error) {
+	data, cursor, err := s.taskRepository.List(ctx, page)
 	if err != nil {
 		//fmt.Printf("failed to select: %v", err)
 		return
 	}
 
 	res = task.ParseFromEntities(data)
+	err = s.withLabels(ctx, res)
 
 	return
 }
 
 func (s *Service) CreateTask(ctx context.Context, req task.Request) (res task.Response, err error) {
-	data := task.Entity{
-		Title:       req.Title,
-		Description: req.Description,
-		Priority:    req.Priority,
-		Status:      req.Status,
-		AssigneeID:  req.AssigneeID,
-		ProjectID:   req.ProjectID,
-		CompletedAt: req.CompletedAt,
+	data, err := s.newTask(ctx, req)
+	if err != nil {
+		return
 	}
 
-	data.ID, err = s.taskRepository.Add(ctx, data)
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if data.ID, err = s.taskRepository.Add(ctx, data); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityTask, data.ID, audit.OperationCreate, audit.Compare(nil, data))
+	})
 	if err != nil {
 		//fmt.Printf("failed to create: %v\n", err)
 		return
@@ -41,65 +44,242 @@ func (s *Service) CreateTask(ctx context.Context, req task.Request) (res task.Re
 	return
 }
 
+// newTask builds the task of a create request and checks that the caller may
+// add it to the project and that it fits its parent, workflow, project members
+// and milestone.
+func (s *Service) newTask(ctx context.Context, req task.Request) (data task.Entity, err error) {
+	data = task.Entity{
+		Title:         req.Title,
+		Description:   req.Description,
+		Priority:      req.Priority,
+		Status:        req.Status,
+		AssigneeID:    req.AssigneeID,
+		ProjectID:     req.ProjectID,
+		EstimateHours: req.EstimateHours,
+		CompletedAt:   req.CompletedAt,
+	}
+	if req.ParentID != nil && *req.ParentID != "" {
+		data.ParentID = req.ParentID
+	}
+	if req.StartDate != nil && *req.StartDate != "" {
+		data.StartDate = req.StartDate
+	}
+	if req.DueDate != nil && *req.DueDate != "" {
+		data.DueDate = req.DueDate
+	}
+	if req.MilestoneID != nil && *req.MilestoneID != "" {
+		data.MilestoneID = req.MilestoneID
+	}
+
+	if err = s.authorize(ctx, access.ActionCreateTask, taskResource(data)); err != nil {
+		return
+	}
+	if err = s.checkParent(ctx, &task.Entity{}, &data); err != nil {
+		return
+	}
+	if err = s.applyWorkflow(ctx, &task.Entity{}, &data); err != nil {
+		return
+	}
+	if err = s.checkAssignee(ctx, &task.Entity{}, &data); err != nil {
+		return
+	}
+	err = s.checkMilestone(ctx, &task.Entity{}, &data)
+
+	return
+}
+
 func (s *Service) GetTask(ctx context.Context, id string) (res task.Response, err error) {
 	data, err := s.taskRepository.Get(ctx, id)
-	if err != nil && !errors.Is(err, store.ErrorNotFound) {
+	if err != nil {
 		//fmt.Printf("failed to get by id: %v", err)
 		return
 	}
 
-	res = task.ParseFromEntity(data)
+	list := []task.Response{task.ParseFromEntity(data)}
+	if err = s.withLabels(ctx, list); err != nil {
+		return
+	}
+	res = list[0]
 
 	return
 }
 
-func (s *Service) UpdateTask(ctx context.Context, id string, req task.Request) (err error) {
+func (s *Service) UpdateTask(ctx context.Context, id string, req task.Request, version *int) (err error) {
 	data := task.Entity{
-		Title:       req.Title,
-		Description: req.Description,
-		Priority:    req.Priority,
-		Status:      req.Status,
-		AssigneeID:  req.AssigneeID,
-		ProjectID:   req.ProjectID,
-		CompletedAt: req.CompletedAt,
+		Title:         req.Title,
+		Description:   req.Description,
+		Priority:      req.Priority,
+		Status:        req.Status,
+		AssigneeID:    req.AssigneeID,
+		ProjectID:     req.ProjectID,
+		ParentID:      req.ParentID,
+		StartDate:     req.StartDate,
+		DueDate:       req.DueDate,
+		EstimateHours: req.EstimateHours,
+		MilestoneID:   req.MilestoneID,
+		CompletedAt:   req.CompletedAt,
 	}
 
-	err = s.taskRepository.Update(ctx, id, data)
-	if err != nil && !errors.Is(err, store.ErrorNotFound) {
-		//fmt.Printf("failed to update by id: %v\n", err)
-		return
-	}
+	// the task stays locked until the commit, so that the version, the
+	// workflow transition and the other checks hold for the row being written
+	return s.transaction(ctx, func(ctx context.Context) (err error) {
+		current, err := s.taskRepository.Get(store.WithLock(ctx), id)
+		if err != nil {
+			return
+		}
 
-	return
+		resource := taskResource(current)
+		if err = s.authorize(ctx, access.ActionUpdateTask, resource); err != nil {
+			return
+		}
+		if data.Status != nil && (current.Status == nil || *current.Status != *data.Status) {
+			if err = s.authorize(ctx, access.ActionChangeStatus, resource); err != nil {
+				return
+			}
+		}
+
+		if err = checkVersion(current.Version, version); err != nil {
+			return
+		}
+		data.Version = version
+
+		if err = checkDates(&current, &data); err != nil {
+			return
+		}
+		if err = s.checkParent(ctx, &current, &data); err != nil {
+			return
+		}
+		if data.Status != nil || data.ProjectID != nil {
+			if err = s.applyWorkflow(ctx, &current, &data); err != nil {
+				return
+			}
+		}
+		if err = s.checkAssignee(ctx, &current, &data); err != nil {
+			return
+		}
+		if err = s.checkMilestone(ctx, &current, &data); err != nil {
+			return
+		}
+
+		subtasks, err := s.openSubtasks(ctx, &current, &data)
+		if err != nil {
+			return
+		}
+		if closes(&current, &data) {
+			ids := []string{id}
+			for _, subtask := range subtasks {
+				ids = append(ids, subtask.ID)
+			}
+			if err = s.checkBlockers(ctx, ids...); err != nil {
+				return
+			}
+		}
+
+		if err = s.taskRepository.Update(ctx, id, data); err != nil {
+			return
+		}
+		if err = s.record(ctx, audit.EntityTask, id, audit.OperationUpdate, audit.Changes(current, data)); err != nil {
+			return
+		}
+		if moved(&current, &data) && s.labelRepository != nil {
+			if err = s.labelRepository.DetachOtherProjects(ctx, id); err != nil {
+				return
+			}
+		}
+		return s.closeSubtasks(ctx, subtasks, data)
+	})
 }
 
-func (s *Service) DeleteTask(ctx context.Context, id string) (err error) {
-	err = s.taskRepository.Delete(ctx, id)
-	if err != nil && !errors.Is(err, store.ErrorNotFound) {
-		//return fmt.Errorf("failed to delete by id: %w", err)
+func (s *Service) DeleteTask(ctx context.Context, id string, version *int) (err error) {
+	return s.transaction(ctx, func(ctx context.Context) (err error) {
+		current, err := s.taskRepository.Get(store.WithLock(ctx), id)
+		if err != nil {
+			return
+		}
+		if err = s.authorize(ctx, access.ActionDeleteTask, taskResource(current)); err != nil {
+			return
+		}
+		if err = checkVersion(current.Version, version); err != nil {
+			return
+		}
+
+		if err = s.taskRepository.Delete(ctx, id, version); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityTask, id, audit.OperationDelete, audit.Compare(current, nil))
+	})
+}
+
+// RestoreTask brings back a task of a live project together with the subtasks
+// deleted along with it. Tasks of a deleted project come back with the project,
+// subtasks of a deleted parent come back with the parent.
+func (s *Service) RestoreTask(ctx context.Context, id string) (res task.Response, err error) {
+	var current task.Entity
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if current, err = s.taskRepository.Get(store.WithLock(store.WithDeleted(ctx, true)), id); err != nil {
+			return
+		}
+		if err = s.authorize(ctx, access.ActionDeleteTask, taskResource(current)); err != nil {
+			return
+		}
+		if current.DeletedAt == nil {
+			return store.ErrorNotDeleted
+		}
+
+		if current.ProjectID != nil {
+			if _, err = s.projectRepository.Get(ctx, *current.ProjectID); err != nil {
+				if errors.Is(err, store.ErrorNotFound) {
+					err = task.ErrorProjectDeleted
+				}
+				return
+			}
+		}
+		if current.ParentID != nil {
+			if _, err = s.taskRepository.Get(ctx, *current.ParentID); err != nil {
+				if errors.Is(err, store.ErrorNotFound) {
+					err = task.ErrorParentDeleted
+				}
+				return
+			}
+		}
+
+		if err = s.taskRepository.Restore(ctx, id); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityTask, id, audit.OperationRestore, restored(current.DeletedAt))
+	})
+	if err != nil {
 		return
 	}
 
+	current.DeletedAt = nil
+	current.Version = bumped(current.Version)
+	res = task.ParseFromEntity(current)
+
 	return
 }
 
-func (s *Service) SearchTasks(ctx context.Context, req task.Request) (res []task.Response, err error) {
-	searchData := task.Entity{
-		Title:       req.Title,
-		Description: req.Description,
-		Priority:    req.Priority,
-		Status:      req.Status,
-		AssigneeID:  req.AssigneeID,
-		ProjectID:   req.ProjectID,
-	}
-
-	data, err := s.taskRepository.Search(ctx, searchData)
+func (s *Service) SearchTasks(ctx context.Context, filter task.Filter, page store.Page) (res []task.Response, cursor store.Cursor, err error) {
+	data, cursor, err := s.taskRepository.Search(ctx, filter, page)
 	if err != nil && !errors.Is(err, store.ErrorNotFound) {
 		//fmt.Printf("failed to search tasks: %v\n", err)
 		return
 	}
 
 	res = task.ParseFromEntities(data)
+	if err == nil {
+		err = s.withLabels(ctx, res)
+	}
+
+	return
+}
 
+func taskResource(data task.Entity) (resource access.Resource) {
+	if data.ProjectID != nil {
+		resource.ProjectID = *data.ProjectID
+	}
+	if data.AssigneeID != nil {
+		resource.AssigneeID = *data.AssigneeID
+	}
 	return
 }
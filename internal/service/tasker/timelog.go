@@ -0,0 +1,242 @@
+package tasker
+
+import (
+	"context"
+	"errors"
+	"hard/internal/domain/access"
+	"hard/internal/domain/audit"
+	"hard/internal/domain/timelog"
+	"hard/pkg/auth"
+	"hard/pkg/store"
+	"time"
+)
+
+// StartTimer starts a timer of the caller on the task, a user has at most one
+// running timer.
+func (s *Service) StartTimer(ctx context.Context, taskID string, req timelog.TimerRequest) (res timelog.Response, err error) {
+	current, err := s.taskRepository.Get(ctx, taskID)
+	if err != nil {
+		return
+	}
+	if err = s.authorize(ctx, access.ActionUpdateTask, taskResource(current)); err != nil {
+		return
+	}
+
+	userID, ok := auth.UserID(ctx)
+	if !ok {
+		return res, access.ErrorForbidden
+	}
+
+	if _, err = s.timeEntryRepository.GetRunning(ctx, userID); err == nil {
+		return res, timelog.ErrorRunning
+	} else if !errors.Is(err, store.ErrorNotFound) {
+		return
+	}
+
+	data := timelog.Entity{
+		UserID: userID,
+		TaskID: taskID,
+		Note:   req.Note,
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if data.ID, err = s.timeEntryRepository.Start(ctx, data); err != nil {
+			return
+		}
+		if data, err = s.timeEntryRepository.Get(ctx, data.ID); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityTimeEntry, data.ID, audit.OperationCreate, audit.Compare(nil, data))
+	})
+	if err != nil {
+		return
+	}
+
+	res = timelog.ParseFromEntity(data)
+
+	return
+}
+
+// StopTimer stops the running timer of the caller on the task.
+func (s *Service) StopTimer(ctx context.Context, taskID string, req timelog.TimerRequest) (res timelog.Response, err error) {
+	if _, err = s.taskRepository.Get(ctx, taskID); err != nil {
+		return
+	}
+
+	userID, ok := auth.UserID(ctx)
+	if !ok {
+		return res, access.ErrorForbidden
+	}
+
+	current, err := s.timeEntryRepository.GetRunning(ctx, userID)
+	if errors.Is(err, store.ErrorNotFound) || err == nil && current.TaskID != taskID {
+		return res, timelog.ErrorNotRunning
+	}
+	if err != nil {
+		return
+	}
+
+	var data timelog.Entity
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.timeEntryRepository.Stop(ctx, current.ID, req.Note); err != nil {
+			return
+		}
+		if data, err = s.timeEntryRepository.Get(ctx, current.ID); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityTimeEntry, data.ID, audit.OperationUpdate, audit.Compare(current, data))
+	})
+	if err != nil {
+		return
+	}
+
+	res = timelog.ParseFromEntity(data)
+
+	return
+}
+
+// LogTime adds time the caller spent on the task without a timer.
+func (s *Service) LogTime(ctx context.Context, taskID string, req timelog.Request) (res timelog.Response, err error) {
+	current, err := s.taskRepository.Get(ctx, taskID)
+	if err != nil {
+		return
+	}
+	if err = s.authorize(ctx, access.ActionUpdateTask, taskResource(current)); err != nil {
+		return
+	}
+
+	userID, ok := auth.UserID(ctx)
+	if !ok {
+		return res, access.ErrorForbidden
+	}
+
+	startedAt, endedAt := req.Interval()
+	start, end := startedAt.Format(time.RFC3339), endedAt.Format(time.RFC3339)
+	duration := int64(endedAt.Sub(startedAt).Seconds())
+
+	data := timelog.Entity{
+		UserID:          userID,
+		TaskID:          taskID,
+		StartedAt:       &start,
+		EndedAt:         &end,
+		DurationSeconds: &duration,
+		Note:            req.Note,
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if data.ID, err = s.timeEntryRepository.Add(ctx, data); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityTimeEntry, data.ID, audit.OperationCreate, audit.Compare(nil, data))
+	})
+	if err != nil {
+		return
+	}
+
+	res = timelog.ParseFromEntity(data)
+
+	return
+}
+
+func (s *Service) ListTimeEntries(ctx context.Context, taskID string, page store.Page) (res []timelog.Response, cursor store.Cursor, err error) {
+	if _, err = s.taskRepository.Get(ctx, taskID); err != nil {
+		return
+	}
+
+	data, cursor, err := s.timeEntryRepository.List(ctx, taskID, page)
+	if err != nil {
+		return
+	}
+
+	res = timelog.ParseFromEntities(data)
+
+	return
+}
+
+// DeleteTimeEntry removes an entry of the task, a running timer is discarded.
+func (s *Service) DeleteTimeEntry(ctx context.Context, taskID, id string) (err error) {
+	current, err := s.taskRepository.Get(ctx, taskID)
+	if err != nil {
+		return
+	}
+
+	data, err := s.timeEntryRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+	if data.TaskID != taskID {
+		return store.ErrorNotFound
+	}
+
+	resource := taskResource(current)
+	resource.UserID = data.UserID
+	if err = s.authorize(ctx, access.ActionDeleteTimeEntry, resource); err != nil {
+		return
+	}
+
+	return s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.timeEntryRepository.Delete(ctx, id); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityTimeEntry, id, audit.OperationDelete, audit.Compare(data, nil))
+	})
+}
+
+// GetTaskTime compares the time logged on the task with its estimate.
+func (s *Service) GetTaskTime(ctx context.Context, taskID string) (res timelog.TaskSummary, err error) {
+	data, err := s.taskRepository.Get(ctx, taskID)
+	if err != nil {
+		return
+	}
+
+	totals, err := s.timeEntryRepository.Totals(ctx, timelog.Filter{TaskID: taskID})
+	if err != nil {
+		return
+	}
+
+	res = timelog.SummarizeTask(data, totals)
+
+	return
+}
+
+// GetProjectTime rolls the time logged on the tasks of the project up and
+// compares it with the sum of their estimates.
+func (s *Service) GetProjectTime(ctx context.Context, projectID string) (res timelog.ProjectSummary, err error) {
+	if _, err = s.projectRepository.Get(ctx, projectID); err != nil {
+		return
+	}
+
+	tasks, err := s.taskRepository.ListByProject(ctx, projectID)
+	if err != nil {
+		return
+	}
+
+	totals, err := s.timeEntryRepository.Totals(ctx, timelog.Filter{ProjectID: projectID})
+	if err != nil {
+		return
+	}
+
+	res = timelog.SummarizeProject(projectID, tasks, totals)
+
+	return
+}
+
+// GetUserTime totals the time the user logged between the dates of the filter.
+func (s *Service) GetUserTime(ctx context.Context, userID string, filter timelog.Filter) (res timelog.UserSummary, err error) {
+	if _, err = s.userRepository.Get(ctx, userID); err != nil {
+		return
+	}
+	if err = s.authorize(ctx, access.ActionReadTime, access.Resource{UserID: userID}); err != nil {
+		return
+	}
+
+	filter.UserID = userID
+	totals, err := s.timeEntryRepository.Totals(ctx, filter)
+	if err != nil {
+		return
+	}
+
+	res = timelog.SummarizeUser(userID, filter, totals)
+
+	return
+}
@@ -3,13 +3,15 @@ package tasker
 import (
 	"context"
 	"errors"
+	"hard/internal/domain/access"
+	"hard/internal/domain/audit"
 	"hard/internal/domain/task"
 	"hard/internal/domain/user"
 	"hard/pkg/store"
 )
 
-func (s *Service) ListUsers(ctx context.Context) (res []user.Response, err error) {
-	data, err := s.userRepository.List(ctx)
+func (s *Service) ListUsers(ctx context.Context, page store.Page) (res []user.Response, cursor store.Cursor, err error) {
+	data, cursor, err := s.userRepository.List(ctx, page)
 	if err != nil {
 		//fmt.Printf("failed to select: %v", err)
 		return
@@ -21,13 +23,22 @@ func (s *Service) ListUsers(ctx context.Context) (res []user.Response, err error
 }
 
 func (s *Service) CreateUser(ctx context.Context, req user.Request) (res user.Response, err error) {
+	if err = s.authorize(ctx, access.ActionCreateUser, access.Resource{}); err != nil {
+		return
+	}
+
 	data := user.Entity{
 		FullName: req.FullName,
 		Email:    req.Email,
 		Role:     req.Role,
 	}
 
-	data.ID, err = s.userRepository.Add(ctx, data)
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if data.ID, err = s.userRepository.Add(ctx, data); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityUser, data.ID, audit.OperationCreate, audit.Compare(nil, data))
+	})
 	if err != nil {
 		//fmt.Printf("failed to create: %v\n", err)
 		return
@@ -40,7 +51,7 @@ func (s *Service) CreateUser(ctx context.Context, req user.Request) (res user.Re
 
 func (s *Service) GetUser(ctx context.Context, id string) (res user.Response, err error) {
 	data, err := s.userRepository.Get(ctx, id)
-	if err != nil && !errors.Is(err, store.ErrorNotFound) {
+	if err != nil {
 		//fmt.Printf("failed to get by id: %v", err)
 		return
 	}
@@ -50,33 +61,93 @@ func (s *Service) GetUser(ctx context.Context, id string) (res user.Response, er
 	return
 }
 
-func (s *Service) UpdateUser(ctx context.Context, id string, req user.Request) (err error) {
+func (s *Service) UpdateUser(ctx context.Context, id string, req user.Request, version *int) (err error) {
 	data := user.Entity{
 		FullName: req.FullName,
 		Email:    req.Email,
 		Role:     req.Role,
 	}
-	err = s.userRepository.Update(ctx, id, data)
-	if err != nil && !errors.Is(err, store.ErrorNotFound) {
-		//fmt.Printf("failed to update by id: %v\n", err)
+
+	if err = s.authorize(ctx, access.ActionUpdateUser, access.Resource{UserID: id}); err != nil {
 		return
 	}
+	if data.Role != nil {
+		if err = s.authorize(ctx, access.ActionChangeRole, access.Resource{UserID: id}); err != nil {
+			return
+		}
+	}
 
-	return
+	current, err := s.userRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+	if err = checkVersion(current.Version, version); err != nil {
+		return
+	}
+	data.Version = version
+
+	return s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.userRepository.Update(ctx, id, data); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityUser, id, audit.OperationUpdate, audit.Changes(current, data))
+	})
 }
 
-func (s *Service) DeleteUser(ctx context.Context, id string) (err error) {
-	err = s.userRepository.Delete(ctx, id)
-	if err != nil && !errors.Is(err, store.ErrorNotFound) {
-		//fmt.Printf("failed to delete by id: %v\n", err)
+func (s *Service) DeleteUser(ctx context.Context, id string, version *int) (err error) {
+	if err = s.authorize(ctx, access.ActionDeleteUser, access.Resource{UserID: id}); err != nil {
+		return
+	}
+
+	current, err := s.userRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+	if err = checkVersion(current.Version, version); err != nil {
+		return
+	}
+
+	return s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.userRepository.Delete(ctx, id, version); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityUser, id, audit.OperationDelete, audit.Compare(current, nil))
+	})
+}
+
+func (s *Service) RestoreUser(ctx context.Context, id string) (res user.Response, err error) {
+	if err = s.authorize(ctx, access.ActionDeleteUser, access.Resource{UserID: id}); err != nil {
+		return
+	}
+
+	current, err := s.userRepository.Get(store.WithDeleted(ctx, true), id)
+	if err != nil {
+		return
+	}
+	if current.DeletedAt == nil {
+		err = store.ErrorNotDeleted
+		return
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.userRepository.Restore(ctx, id); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityUser, id, audit.OperationRestore, restored(current.DeletedAt))
+	})
+	if err != nil {
 		return
 	}
 
+	current.DeletedAt = nil
+	current.Version = bumped(current.Version)
+	res = user.ParseFromEntity(current)
+
 	return
 }
 
-func (s *Service) SearchUser(ctx context.Context, name string, email string) (res []user.Response, err error) {
-	data, err := s.userRepository.Search(ctx, name, email)
+func (s *Service) SearchUser(ctx context.Context, name string, email string, page store.Page) (res []user.Response, cursor store.Cursor, err error) {
+	data, cursor, err := s.userRepository.Search(ctx, name, email, page)
 	if err != nil && !errors.Is(err, store.ErrorNotFound) {
 		//fmt.Printf("failed to search user: %v\n", err)
 		return
@@ -87,14 +158,49 @@ func (s *Service) SearchUser(ctx context.Context, name string, email string) (re
 	return
 }
 
-func (s *Service) GetTasksByUser(ctx context.Context, id string) (res []task.Response, err error) {
-	data, err := s.userRepository.ListTasks(ctx, id)
+func (s *Service) GetTasksByUser(ctx context.Context, id string, page store.Page) (res []task.Response, cursor store.Cursor, err error) {
+	data, cursor, err := s.userRepository.ListTasks(ctx, id, page)
 	if err != nil && !errors.Is(err, store.ErrorNotFound) {
 		//fmt.Printf("failed to search tasks by user: %v\n", err)
 		return
 	}
 
 	res = task.ParseFromEntities(data)
+	if err == nil {
+		err = s.withLabels(ctx, res)
+	}
+
+	return
+}
+
+// GetUsers returns the users with the given ids by id, the missing ones are
+// left out.
+func (s *Service) GetUsers(ctx context.Context, ids []string) (res map[string]user.Response, err error) {
+	data, err := s.userRepository.GetMany(ctx, ids)
+	if err != nil {
+		return
+	}
+
+	res = make(map[string]user.Response, len(data))
+	for _, object := range data {
+		res[object.ID] = user.ParseFromEntity(object)
+	}
+
+	return
+}
+
+// GetTasksByUsers returns up to limit tasks assigned to each of the users in
+// one query, without their labels.
+func (s *Service) GetTasksByUsers(ctx context.Context, ids []string, limit int) (res map[string][]task.Response, err error) {
+	data, err := s.taskRepository.ListByAssignees(ctx, ids, store.Page{Limit: limit}.Size())
+	if err != nil {
+		return
+	}
+
+	res = make(map[string][]task.Response, len(data))
+	for id, tasks := range data {
+		res[id] = task.ParseFromEntities(tasks)
+	}
 
 	return
 }
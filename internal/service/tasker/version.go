@@ -0,0 +1,27 @@
+package tasker
+
+import (
+	"hard/pkg/store"
+)
+
+// checkVersion fails fast when the caller based its change on another version
+// than the current one. The repositories check the version again when
+// writing, so that concurrent changes are caught too.
+func checkVersion(current, expected *int) error {
+	if expected == nil {
+		return nil
+	}
+	if current == nil || *current != *expected {
+		return store.ErrorConflict
+	}
+	return nil
+}
+
+// bumped is the version a write leaves behind.
+func bumped(version *int) *int {
+	if version == nil {
+		return nil
+	}
+	next := *version + 1
+	return &next
+}
@@ -0,0 +1,279 @@
+package tasker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hard/internal/domain/access"
+	"hard/internal/domain/audit"
+	"hard/internal/domain/webhook"
+	"hard/pkg/store"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// webhookBatch is how many deliveries a dispatch run claims at most.
+const webhookBatch = 50
+
+func (s *Service) ListWebhooks(ctx context.Context, page store.Page) (res []webhook.Response, cursor store.Cursor, err error) {
+	if err = s.authorize(ctx, access.ActionManageWebhooks, access.Resource{}); err != nil {
+		return
+	}
+
+	data, cursor, err := s.webhookRepository.List(ctx, page)
+	if err != nil {
+		return
+	}
+
+	res = webhook.ParseFromEntities(data)
+
+	return
+}
+
+// CreateWebhook subscribes the URL to the events, a secret is generated when
+// none is given. The secret is only returned here.
+func (s *Service) CreateWebhook(ctx context.Context, req webhook.Request) (res webhook.Response, err error) {
+	if err = s.authorize(ctx, access.ActionManageWebhooks, access.Resource{}); err != nil {
+		return
+	}
+
+	var secret string
+	if req.Secret != nil {
+		secret = strings.TrimSpace(*req.Secret)
+	} else if secret, err = webhook.GenerateSecret(); err != nil {
+		return
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	data := webhook.Entity{
+		URL:    req.URL,
+		Events: req.Events,
+		Secret: &secret,
+		Active: &active,
+	}
+	if data.Events == nil {
+		data.Events = webhook.Events{"*"}
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if data.ID, err = s.webhookRepository.Add(ctx, data); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityWebhook, data.ID, audit.OperationCreate, audit.Compare(nil, data.Redacted()))
+	})
+	if err != nil {
+		return
+	}
+
+	res = webhook.ParseFromEntity(data)
+	res.Secret = secret
+
+	return
+}
+
+func (s *Service) GetWebhook(ctx context.Context, id string) (res webhook.Response, err error) {
+	if err = s.authorize(ctx, access.ActionManageWebhooks, access.Resource{}); err != nil {
+		return
+	}
+
+	data, err := s.webhookRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+
+	res = webhook.ParseFromEntity(data)
+
+	return
+}
+
+func (s *Service) UpdateWebhook(ctx context.Context, id string, req webhook.Request) (err error) {
+	if err = s.authorize(ctx, access.ActionManageWebhooks, access.Resource{}); err != nil {
+		return
+	}
+
+	current, err := s.webhookRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+
+	data := webhook.Entity{
+		URL:    req.URL,
+		Events: req.Events,
+		Active: req.Active,
+	}
+	if req.Secret != nil {
+		secret := strings.TrimSpace(*req.Secret)
+		data.Secret = &secret
+	}
+
+	return s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.webhookRepository.Update(ctx, id, data); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityWebhook, id, audit.OperationUpdate, audit.Changes(current.Redacted(), data.Redacted()))
+	})
+}
+
+// DeleteWebhook removes the webhook with its delivery log, its pending
+// deliveries are dropped.
+func (s *Service) DeleteWebhook(ctx context.Context, id string) (err error) {
+	if err = s.authorize(ctx, access.ActionManageWebhooks, access.Resource{}); err != nil {
+		return
+	}
+
+	current, err := s.webhookRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+
+	return s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.webhookRepository.Delete(ctx, id); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityWebhook, id, audit.OperationDelete, audit.Compare(current.Redacted(), nil))
+	})
+}
+
+func (s *Service) ListWebhookDeliveries(ctx context.Context, id string, filter webhook.DeliveryFilter, page store.Page) (res []webhook.DeliveryResponse, cursor store.Cursor, err error) {
+	if err = s.authorize(ctx, access.ActionManageWebhooks, access.Resource{}); err != nil {
+		return
+	}
+
+	if _, err = s.webhookRepository.Get(ctx, id); err != nil {
+		return
+	}
+
+	data, cursor, err := s.webhookRepository.ListDeliveries(ctx, id, filter, page)
+	if err != nil {
+		return
+	}
+
+	res = webhook.ParseFromDeliveries(data)
+
+	return
+}
+
+// RedeliverWebhook sends a delivered or dead delivery of the webhook again on
+// the next dispatch run.
+func (s *Service) RedeliverWebhook(ctx context.Context, id, deliveryID string) (err error) {
+	if err = s.authorize(ctx, access.ActionManageWebhooks, access.Resource{}); err != nil {
+		return
+	}
+
+	data, err := s.webhookRepository.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return
+	}
+	if data.WebhookID != id {
+		return store.ErrorNotFound
+	}
+	if data.Status == webhook.StatusPending {
+		return webhook.ErrorPending
+	}
+
+	return s.webhookRepository.Redeliver(ctx, deliveryID)
+}
+
+// DispatchWebhooks sends the due deliveries and returns how many were
+// delivered. A failed delivery is retried with an exponential backoff until
+// it has been attempted the maximum number of times, then it is dead. Several
+// instances may dispatch at once, a delivery is claimed by one of them.
+func (s *Service) DispatchWebhooks(ctx context.Context) (n int, err error) {
+	if s.webhookRepository == nil {
+		return
+	}
+
+	// a claimed delivery is left alone for as long as the whole batch may take
+	lease := time.Duration(webhookBatch) * s.webhookLimits.Timeout
+	if lease < time.Minute {
+		lease = time.Minute
+	}
+
+	jobs, err := s.webhookRepository.Claim(ctx, webhookBatch, lease)
+	if err != nil {
+		return
+	}
+
+	client := &http.Client{Timeout: s.webhookLimits.Timeout}
+
+	var errs []error
+	for _, job := range jobs {
+		attempt := s.deliver(ctx, client, job)
+		if err := s.webhookRepository.Complete(ctx, job.DeliveryID, attempt); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if attempt.Status == webhook.StatusDelivered {
+			n++
+		}
+	}
+
+	return n, errors.Join(errs...)
+}
+
+// deliver posts the event to the webhook, any 2xx response delivers it.
+func (s *Service) deliver(ctx context.Context, client *http.Client, job webhook.Job) webhook.Attempt {
+	body, err := json.Marshal(webhook.ParsePayload(job.Event))
+	if err != nil {
+		return job.Failed(s.webhookLimits.MaxAttempts, nil, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, job.URL, bytes.NewReader(body))
+	if err != nil {
+		return job.Failed(s.webhookLimits.MaxAttempts, nil, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhook.HeaderEvent, job.Event.Event)
+	req.Header.Set(webhook.HeaderDelivery, job.DeliveryID)
+	req.Header.Set(webhook.HeaderSignature, webhook.Sign(job.Secret, body))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return job.Failed(s.webhookLimits.MaxAttempts, nil, err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	statusCode := resp.StatusCode
+	if statusCode < 200 || statusCode > 299 {
+		return job.Failed(s.webhookLimits.MaxAttempts, &statusCode, fmt.Errorf("receiver responded with %s", resp.Status))
+	}
+
+	return webhook.Attempt{Status: webhook.StatusDelivered, StatusCode: &statusCode}
+}
+
+// publish writes the webhook events of a change to the outbox, a status
+// change of a task is also raised as task.status_changed.
+func (s *Service) publish(ctx context.Context, entity, id string, operation audit.Operation, diff audit.Diff, actorID *string) (err error) {
+	if s.webhookRepository == nil {
+		return
+	}
+
+	events := []string{webhook.EventName(entity, operation)}
+	if _, ok := diff["status"]; ok && entity == audit.EntityTask && operation == audit.OperationUpdate {
+		events = append(events, webhook.EventTaskStatusChanged)
+	}
+
+	for _, event := range events {
+		data := webhook.Event{
+			Event:    event,
+			Entity:   entity,
+			EntityID: id,
+			ActorID:  actorID,
+			Changes:  diff,
+		}
+		if err = s.webhookRepository.Enqueue(ctx, data); err != nil {
+			return
+		}
+	}
+
+	return
+}
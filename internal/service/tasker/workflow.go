@@ -0,0 +1,146 @@
+package tasker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hard/internal/domain/access"
+	"hard/internal/domain/audit"
+	"hard/internal/domain/task"
+	"hard/internal/domain/workflow"
+	"hard/pkg/store"
+	"time"
+)
+
+func (s *Service) GetWorkflow(ctx context.Context, projectID string) (res workflow.Response, err error) {
+	if _, err = s.projectRepository.Get(ctx, projectID); err != nil {
+		return
+	}
+
+	data, err := s.workflowRepository.Get(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, store.ErrorNotFound) {
+			return workflow.ParseFromDefault(projectID), nil
+		}
+		return
+	}
+
+	res = workflow.ParseFromEntity(data)
+
+	return
+}
+
+func (s *Service) SaveWorkflow(ctx context.Context, projectID string, req workflow.Request) (res workflow.Response, err error) {
+	if _, err = s.projectRepository.Get(ctx, projectID); err != nil {
+		return
+	}
+	if err = s.authorize(ctx, access.ActionUpdateWorkflow, access.Resource{ProjectID: projectID}); err != nil {
+		return
+	}
+
+	data := workflow.Entity{
+		ProjectID:  projectID,
+		Definition: req.Definition(),
+	}
+
+	operation, current := audit.OperationUpdate, any(nil)
+	switch saved, err := s.workflowRepository.Get(ctx, projectID); {
+	case errors.Is(err, store.ErrorNotFound):
+		operation = audit.OperationCreate
+	case err != nil:
+		return res, err
+	default:
+		current = saved
+	}
+
+	err = s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.workflowRepository.Save(ctx, data); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityWorkflow, projectID, operation, audit.Compare(current, data))
+	})
+	if err != nil {
+		return
+	}
+
+	res = workflow.ParseFromEntity(data)
+
+	return
+}
+
+// DeleteWorkflow resets the project to the default workflow.
+func (s *Service) DeleteWorkflow(ctx context.Context, projectID string) (err error) {
+	if err = s.authorize(ctx, access.ActionUpdateWorkflow, access.Resource{ProjectID: projectID}); err != nil {
+		return
+	}
+
+	current, err := s.workflowRepository.Get(ctx, projectID)
+	if err != nil {
+		return
+	}
+
+	return s.transaction(ctx, func(ctx context.Context) (err error) {
+		if err = s.workflowRepository.Delete(ctx, projectID); err != nil {
+			return
+		}
+		return s.record(ctx, audit.EntityWorkflow, projectID, audit.OperationDelete, audit.Compare(current, nil))
+	})
+}
+
+func (s *Service) workflowOf(ctx context.Context, projectID string) (res workflow.Definition, err error) {
+	data, err := s.workflowRepository.Get(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, store.ErrorNotFound) {
+			return workflow.Default, nil
+		}
+		return
+	}
+
+	return data.Definition, nil
+}
+
+// applyWorkflow checks the status change of a task against the workflow of
+// its project and keeps completed_at in sync with terminal statuses. An empty
+// CompletedAt clears the column. An existing task must be read locked in the
+// transaction of the update, or another writer may change the status the
+// transition starts from.
+func (s *Service) applyWorkflow(ctx context.Context, current, data *task.Entity) (err error) {
+	projectID, status := data.ProjectID, data.Status
+	if projectID == nil {
+		projectID = current.ProjectID
+	}
+	if status == nil {
+		status = current.Status
+	}
+	if projectID == nil || status == nil {
+		return
+	}
+
+	definition, err := s.workflowOf(ctx, *projectID)
+	if err != nil {
+		return
+	}
+
+	if !definition.HasStatus(*status) {
+		return fmt.Errorf("%w: %q, allowed: %v", workflow.ErrorUnknownStatus, *status, definition.Statuses)
+	}
+
+	from := ""
+	if current.Status != nil {
+		from = *current.Status
+	}
+	if from != "" && !definition.CanTransition(from, *status) {
+		return fmt.Errorf("%w: %q -> %q", workflow.ErrorTransitionNotAllowed, from, *status)
+	}
+
+	switch terminal := definition.IsTerminal(*status); {
+	case terminal && (from == "" || !definition.IsTerminal(from)) && data.CompletedAt == nil:
+		today := time.Now().Format("2006-01-02")
+		data.CompletedAt = &today
+	case !terminal && from != "" && definition.IsTerminal(from):
+		cleared := ""
+		data.CompletedAt = &cleared
+	}
+
+	return
+}
@@ -0,0 +1,177 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"os"
+	"time"
+)
+
+var (
+	ErrorInvalidToken       = errors.New("invalid or expired token")
+	ErrorInvalidCredentials = errors.New("invalid credentials")
+	ErrorSigningDisabled    = errors.New("auth: token signing is not configured")
+)
+
+const defaultTTL = 24 * time.Hour
+
+type Config struct {
+	// Secret enables HS256 tokens.
+	Secret string
+	// JWKSFile is a JSON Web Key Set with the RSA public keys accepted for RS256 tokens.
+	JWKSFile string
+	// PrivateKeyFile is a PEM encoded RSA key used to issue RS256 tokens, KeyID is put in their header.
+	PrivateKeyFile string
+	KeyID          string
+	Issuer         string
+	Audience       string
+	TTL            time.Duration
+}
+
+// Tokens issues and verifies the bearer tokens of the API.
+type Tokens struct {
+	config     Config
+	secret     []byte
+	publicKeys map[string]*rsa.PublicKey
+	privateKey *rsa.PrivateKey
+	methods    []string
+}
+
+func New(cfg Config) (t *Tokens, err error) {
+	t = &Tokens{config: cfg}
+	if t.config.TTL <= 0 {
+		t.config.TTL = defaultTTL
+	}
+
+	if cfg.Secret != "" {
+		t.secret = []byte(cfg.Secret)
+		t.methods = append(t.methods, jwt.SigningMethodHS256.Alg())
+	}
+
+	if cfg.JWKSFile != "" {
+		if t.publicKeys, err = loadJWKS(cfg.JWKSFile); err != nil {
+			return
+		}
+	}
+
+	if cfg.PrivateKeyFile != "" {
+		var data []byte
+		if data, err = os.ReadFile(cfg.PrivateKeyFile); err != nil {
+			return
+		}
+		if t.privateKey, err = jwt.ParseRSAPrivateKeyFromPEM(data); err != nil {
+			return
+		}
+		if t.publicKeys == nil {
+			t.publicKeys = map[string]*rsa.PublicKey{}
+		}
+		if _, ok := t.publicKeys[cfg.KeyID]; !ok {
+			t.publicKeys[cfg.KeyID] = &t.privateKey.PublicKey
+		}
+	}
+
+	if len(t.publicKeys) > 0 {
+		t.methods = append(t.methods, jwt.SigningMethodRS256.Alg())
+	}
+
+	if len(t.methods) == 0 {
+		err = errors.New("auth: neither a secret nor RSA keys are configured")
+	}
+
+	return
+}
+
+// Issue signs a token for the user, RS256 is preferred over HS256 when a
+// private key is configured.
+func (t *Tokens) Issue(userID string) (token string, expiresAt time.Time, err error) {
+	now := time.Now()
+	expiresAt = now.Add(t.config.TTL)
+
+	claims := jwt.RegisteredClaims{
+		Subject:   userID,
+		Issuer:    t.config.Issuer,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}
+	if t.config.Audience != "" {
+		claims.Audience = jwt.ClaimStrings{t.config.Audience}
+	}
+
+	switch {
+	case t.privateKey != nil:
+		unsigned := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		if t.config.KeyID != "" {
+			unsigned.Header["kid"] = t.config.KeyID
+		}
+		token, err = unsigned.SignedString(t.privateKey)
+	case t.secret != nil:
+		token, err = jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(t.secret)
+	default:
+		err = ErrorSigningDisabled
+	}
+
+	return
+}
+
+// Verify validates the token and returns the ID of the user it was issued to.
+func (t *Tokens) Verify(token string) (userID string, err error) {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(t.methods),
+		jwt.WithExpirationRequired(),
+	}
+	if t.config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(t.config.Issuer))
+	}
+	if t.config.Audience != "" {
+		options = append(options, jwt.WithAudience(t.config.Audience))
+	}
+
+	claims := jwt.RegisteredClaims{}
+	if _, err = jwt.ParseWithClaims(token, &claims, t.key, options...); err != nil || claims.Subject == "" {
+		return "", ErrorInvalidToken
+	}
+
+	return claims.Subject, nil
+}
+
+func (t *Tokens) key(token *jwt.Token) (any, error) {
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		return t.secret, nil
+	case jwt.SigningMethodRS256.Alg():
+		kid, _ := token.Header["kid"].(string)
+		if key, ok := t.publicKeys[kid]; ok {
+			return key, nil
+		}
+		if kid == "" && len(t.publicKeys) == 1 {
+			for _, key := range t.publicKeys {
+				return key, nil
+			}
+		}
+		return nil, fmt.Errorf("auth: unknown key %q", kid)
+	default:
+		return nil, fmt.Errorf("auth: unexpected signing method %s", token.Method.Alg())
+	}
+}
+
+type contextKey struct{}
+
+// WithUserID stores the authenticated user in the context.
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, contextKey{}, userID)
+}
+
+// UserID returns the authenticated user stored by WithUserID.
+func UserID(ctx context.Context) (userID string, ok bool) {
+	userID, ok = ctx.Value(contextKey{}).(string)
+	return
+}
+
+// IdentityProvider checks the credentials presented at login and returns the
+// ID of the user they belong to.
+type IdentityProvider interface {
+	Authenticate(ctx context.Context, email, password string) (userID string, err error)
+}
@@ -0,0 +1,76 @@
+package loader
+
+import (
+	"context"
+	"sync"
+)
+
+// Batch fetches the values of several keys at once, the keys missing from the
+// result have no value.
+type Batch[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader turns lookups of single keys into batches. Load only queues the key,
+// the queued keys are fetched together by the first thunk called, so that the
+// lookups made while a level of a GraphQL query is resolved cost one query.
+// Fetched keys are cached for the lifetime of the loader, which is a request.
+type Loader[K comparable, V any] struct {
+	ctx   context.Context
+	batch Batch[K, V]
+
+	mu      sync.Mutex
+	pending []K
+	queued  map[K]struct{}
+	values  map[K]V
+	errors  map[K]error
+}
+
+func New[K comparable, V any](ctx context.Context, batch Batch[K, V]) *Loader[K, V] {
+	return &Loader[K, V]{
+		ctx:    ctx,
+		batch:  batch,
+		queued: map[K]struct{}{},
+		values: map[K]V{},
+		errors: map[K]error{},
+	}
+}
+
+// Load queues the key and returns the thunk of its value, ok is false when the
+// batch had no value for the key.
+func (l *Loader[K, V]) Load(key K) func() (value V, ok bool, err error) {
+	l.mu.Lock()
+	if _, ok := l.queued[key]; !ok {
+		l.queued[key] = struct{}{}
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (value V, ok bool, err error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		// errors has an entry, maybe nil, for every key fetched
+		if _, done := l.errors[key]; !done {
+			l.dispatch()
+		}
+
+		if err = l.errors[key]; err != nil {
+			return
+		}
+		value, ok = l.values[key]
+		return
+	}
+}
+
+// dispatch fetches the pending keys, it is called with mu held.
+func (l *Loader[K, V]) dispatch() {
+	keys := l.pending
+	l.pending = nil
+
+	values, err := l.batch(l.ctx, keys)
+	for _, key := range keys {
+		l.errors[key] = err
+		if value, ok := values[key]; ok && err == nil {
+			l.values[key] = value
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package pubsub
+
+import (
+	"sync"
+)
+
+// subscriberBuffer is how many messages a subscriber may fall behind before it
+// is dropped.
+const subscriberBuffer = 64
+
+// Message is a published value with the ID its publisher gave it.
+type Message[T any] struct {
+	ID   int64
+	Data T
+}
+
+// Hub fans messages out to in-process subscribers and keeps the latest ones,
+// so that a subscriber reconnecting with the ID of the last message it got
+// receives those it missed.
+type Hub[T any] struct {
+	mu     sync.Mutex
+	size   int
+	buffer []Message[T]
+	// since is the ID after which every message is still buffered, started is
+	// false until the first message.
+	since   int64
+	last    int64
+	started bool
+	closed  bool
+
+	subscribers map[*Subscription[T]]struct{}
+}
+
+func NewHub[T any](size int) *Hub[T] {
+	return &Hub[T]{
+		size:        size,
+		subscribers: map[*Subscription[T]]struct{}{},
+	}
+}
+
+// Subscription receives the matching messages on C until it is closed. C is
+// closed when the subscriber falls too far behind or the hub is closed.
+type Subscription[T any] struct {
+	C <-chan Message[T]
+
+	c     chan Message[T]
+	match func(T) bool
+	hub   *Hub[T]
+}
+
+// Publish sends the message to the matching subscribers. The ID comes from the
+// publisher, such as a database sequence shared by several hubs, and should
+// grow with every message.
+func (h *Hub[T]) Publish(id int64, data T) Message[T] {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.started {
+		h.since, h.started = id-1, true
+	}
+	if id > h.last {
+		h.last = id
+	}
+
+	msg := Message[T]{ID: id, Data: data}
+	h.buffer = append(h.buffer, msg)
+	if len(h.buffer) > h.size {
+		h.since = h.buffer[0].ID
+		h.buffer = h.buffer[1:]
+	}
+
+	for sub := range h.subscribers {
+		if !sub.match(data) {
+			continue
+		}
+		select {
+		case sub.c <- msg:
+		default:
+			h.remove(sub)
+		}
+	}
+
+	return msg
+}
+
+// Subscribe starts receiving the messages data matches. When after is the ID
+// of the last message the subscriber got, replay are the matching messages
+// published since. complete is false when some of them are no longer
+// buffered or the ID is unknown, the subscriber should reload its state then.
+func (h *Hub[T]) Subscribe(after int64, match func(T) bool) (sub *Subscription[T], replay []Message[T], complete bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	c := make(chan Message[T], subscriberBuffer)
+	sub = &Subscription[T]{C: c, c: c, match: match, hub: h}
+	if h.closed {
+		close(c)
+		return sub, nil, true
+	}
+	h.subscribers[sub] = struct{}{}
+
+	if after == 0 {
+		return sub, nil, true
+	}
+
+	complete = h.started && h.since <= after && after <= h.last
+	for _, msg := range h.buffer {
+		if msg.ID > after && match(msg.Data) {
+			replay = append(replay, msg)
+		}
+	}
+
+	return
+}
+
+// Reset forgets the buffered messages, subscribers resuming from an earlier
+// ID are told they missed some.
+func (h *Hub[T]) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.buffer, h.started = nil, false
+}
+
+// Close ends every subscription, later ones end at once.
+func (h *Hub[T]) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for sub := range h.subscribers {
+		h.remove(sub)
+	}
+}
+
+func (h *Hub[T]) remove(sub *Subscription[T]) {
+	if _, ok := h.subscribers[sub]; ok {
+		delete(h.subscribers, sub)
+		close(sub.c)
+	}
+}
+
+// Close stops the subscription and closes C.
+func (s *Subscription[T]) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	s.hub.remove(s)
+}
@@ -0,0 +1,70 @@
+package interceptor
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"hard/pkg/auth"
+	"strings"
+)
+
+// Authenticate rejects calls without a valid bearer token in the
+// authorization metadata and stores the ID of the authenticated user in the
+// call context. The services listed in public, such as the health check, are
+// left open.
+func Authenticate(tokens *auth.Tokens, public ...string) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	authenticate := func(ctx context.Context, method string) (context.Context, error) {
+		for _, service := range public {
+			if strings.HasPrefix(method, "/"+service+"/") {
+				return ctx, nil
+			}
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get("authorization")
+		if len(values) == 0 {
+			return nil, status.Error(codes.Unauthenticated, auth.ErrorInvalidToken.Error())
+		}
+		token, found := strings.CutPrefix(values[0], "Bearer ")
+		if !found || token == "" {
+			return nil, status.Error(codes.Unauthenticated, auth.ErrorInvalidToken.Error())
+		}
+
+		userID, err := tokens.Verify(token)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+
+		return auth.WithUserID(ctx, userID), nil
+	}
+
+	unary := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+
+	stream := func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+
+	return unary, stream
+}
+
+// serverStream is a stream whose context carries the authenticated user.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
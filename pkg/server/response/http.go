@@ -6,9 +6,11 @@ import (
 )
 
 type Object struct {
-	Data    any    `json:"data,omitempty"`
-	Message string `json:"message,omitempty"`
-	Success bool   `json:"success"`
+	Data       any    `json:"data,omitempty"`
+	Message    string `json:"message,omitempty"`
+	Success    bool   `json:"success"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
 func OK(c *gin.Context, data any) {
@@ -19,6 +21,16 @@ func OK(c *gin.Context, data any) {
 	c.JSON(http.StatusOK, h)
 }
 
+func OKPage(c *gin.Context, data any, next, prev string) {
+	h := Object{
+		Success:    true,
+		Data:       data,
+		NextCursor: next,
+		PrevCursor: prev,
+	}
+	c.JSON(http.StatusOK, h)
+}
+
 func Created(c *gin.Context, data any) {
 	h := Object{
 		Success: true,
@@ -36,6 +48,23 @@ func BadRequest(c *gin.Context, err error, data any) {
 	c.JSON(http.StatusBadRequest, h)
 }
 
+func Unauthorized(c *gin.Context, err error) {
+	h := Object{
+		Success: false,
+		Message: err.Error(),
+	}
+	c.Header("WWW-Authenticate", "Bearer")
+	c.JSON(http.StatusUnauthorized, h)
+}
+
+func Forbidden(c *gin.Context, err error) {
+	h := Object{
+		Success: false,
+		Message: err.Error(),
+	}
+	c.JSON(http.StatusForbidden, h)
+}
+
 func NotFound(c *gin.Context, err error) {
 	h := Object{
 		Success: false,
@@ -44,6 +73,46 @@ func NotFound(c *gin.Context, err error) {
 	c.JSON(http.StatusNotFound, h)
 }
 
+func Conflict(c *gin.Context, err error) {
+	h := Object{
+		Success: false,
+		Message: err.Error(),
+	}
+	c.JSON(http.StatusConflict, h)
+}
+
+func PreconditionFailed(c *gin.Context, err error) {
+	h := Object{
+		Success: false,
+		Message: err.Error(),
+	}
+	c.JSON(http.StatusPreconditionFailed, h)
+}
+
+func RequestEntityTooLarge(c *gin.Context, err error) {
+	h := Object{
+		Success: false,
+		Message: err.Error(),
+	}
+	c.JSON(http.StatusRequestEntityTooLarge, h)
+}
+
+func UnsupportedMediaType(c *gin.Context, err error) {
+	h := Object{
+		Success: false,
+		Message: err.Error(),
+	}
+	c.JSON(http.StatusUnsupportedMediaType, h)
+}
+
+func UnprocessableEntity(c *gin.Context, err error) {
+	h := Object{
+		Success: false,
+		Message: err.Error(),
+	}
+	c.JSON(http.StatusUnprocessableEntity, h)
+}
+
 func InternalServerError(c *gin.Context, err error) {
 	h := Object{
 		Success: false,
@@ -52,12 +121,24 @@ func InternalServerError(c *gin.Context, err error) {
 	c.JSON(http.StatusInternalServerError, h)
 }
 
+// Error answers with the given status, for failures that carry data such as
+// the per-item results of a bulk request.
+func Error(c *gin.Context, status int, err error, data any) {
+	h := Object{
+		Success: false,
+		Message: err.Error(),
+		Data:    data,
+	}
+	c.JSON(status, h)
+}
+
 func MethodNotAllowedMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		allowedMethods := map[string]bool{
 			http.MethodGet:    true,
 			http.MethodPost:   true,
 			http.MethodPut:    true,
+			http.MethodPatch:  true,
 			http.MethodDelete: true,
 		}
 		if !allowedMethods[c.Request.Method] {
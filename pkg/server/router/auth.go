@@ -0,0 +1,51 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"hard/pkg/auth"
+	"hard/pkg/server/response"
+	"strings"
+)
+
+const UserIDKey = "user_id"
+
+// Authenticate rejects requests without a valid bearer token and stores the
+// ID of the authenticated user in the request context.
+func Authenticate(tokens *auth.Tokens) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		token, found := strings.CutPrefix(header, "Bearer ")
+		if !found || token == "" {
+			response.Unauthorized(c, auth.ErrorInvalidToken)
+			c.Abort()
+			return
+		}
+
+		userID, err := tokens.Verify(token)
+		if err != nil {
+			response.Unauthorized(c, err)
+			c.Abort()
+			return
+		}
+
+		c.Set(UserIDKey, userID)
+		c.Request = c.Request.WithContext(auth.WithUserID(c.Request.Context(), userID))
+
+		c.Next()
+	}
+}
+
+// AuthenticateStream is Authenticate that also accepts the token in the
+// access_token query parameter, since browsers cannot set headers on
+// EventSource and WebSocket requests.
+func AuthenticateStream(tokens *auth.Tokens) gin.HandlerFunc {
+	authenticate := Authenticate(tokens)
+
+	return func(c *gin.Context) {
+		if token := c.Query("access_token"); token != "" && c.GetHeader("Authorization") == "" {
+			c.Request.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		authenticate(c)
+	}
+}
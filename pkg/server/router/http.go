@@ -8,11 +8,14 @@ import (
 
 func New() *gin.Engine {
 	r := gin.New()
+	// lets handlers pass *gin.Context to services that read request scoped values
+	r.ContextWithFallback = true
 
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"GET", "PUT", "POST", "DELETE"},
+		AllowMethods:     []string{"GET", "PUT", "PATCH", "POST", "DELETE"},
 		AllowHeaders:     []string{"*"},
+		ExposeHeaders:    []string{"ETag"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
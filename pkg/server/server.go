@@ -2,13 +2,17 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"google.golang.org/grpc"
 	"net"
 	"net/http"
 )
 
 type Server struct {
 	http     *http.Server
+	grpc     *grpc.Server
+	grpcAddr string
 	listener net.Listener
 }
 
@@ -27,26 +31,53 @@ func New(configs ...Configuration) (r *Server, err error) {
 func (s *Server) Run() (err error) {
 	if s.http != nil {
 		go func() {
-			if err = s.http.ListenAndServe(); err != nil {
+			if err := s.http.ListenAndServe(); err != nil {
 				fmt.Printf("Error running: %v", err)
 				return
 			}
 		}()
 	}
+
+	if s.grpc != nil {
+		// listen before returning, so that a taken port fails the start
+		if s.listener, err = net.Listen("tcp", s.grpcAddr); err != nil {
+			return
+		}
+		go func() {
+			if err := s.grpc.Serve(s.listener); err != nil {
+				fmt.Printf("Error running: %v", err)
+				return
+			}
+		}()
+	}
+
 	return
 }
-func (s *Server) Stop(ctx context.Context) (err error) {
+func (s *Server) Stop(ctx context.Context) error {
+	var httpErr, grpcErr error
+
 	if s.http != nil {
-		if err = s.http.Shutdown(ctx); err != nil {
-			return
-		}
+		httpErr = s.http.Shutdown(ctx)
 	}
 
-	//if s.grpc != nil {
-	//	s.grpc.GracefulStop()
-	//}
+	// gRPC is stopped even when the HTTP shutdown failed, so that its listener
+	// does not outlive the process
+	if s.grpc != nil {
+		// streams last until the client leaves, they are cut when ctx is done
+		stopped := make(chan struct{})
+		go func() {
+			s.grpc.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			s.grpc.Stop()
+			grpcErr = ctx.Err()
+		}
+	}
 
-	return
+	return errors.Join(httpErr, grpcErr)
 }
 func WithHTTPServer(handler http.Handler, port string) Configuration {
 	return func(s *Server) error {
@@ -57,3 +88,11 @@ func WithHTTPServer(handler http.Handler, port string) Configuration {
 		return nil
 	}
 }
+
+func WithGRPCServer(server *grpc.Server, port string) Configuration {
+	return func(s *Server) error {
+		s.grpc = server
+		s.grpcAddr = ":" + port
+		return nil
+	}
+}
@@ -0,0 +1,33 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+)
+
+var ErrorInvalidKey = errors.New("invalid blob key")
+
+// BlobStore keeps the content of uploaded files under slash separated keys
+// such as "tasks/5/3f9a". Implementations report missing blobs with
+// ErrorNotFound.
+type BlobStore interface {
+	// Put stores size bytes read from content under key, replacing any blob
+	// already stored there.
+	Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+	// Open returns the blob for reading, seeking lets callers serve ranges
+	// without reading the whole blob.
+	Open(ctx context.Context, key string) (io.ReadSeekCloser, error)
+	// Delete removes the blob, deleting a missing blob is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// checkKey rejects keys that are empty, absolute or escape the store with "..".
+func checkKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || strings.HasPrefix(key, "../") || key == ".." {
+		return ErrorInvalidKey
+	}
+	return nil
+}
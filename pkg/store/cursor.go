@@ -0,0 +1,173 @@
+package store
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// Page describes a keyset page requested by the client. After and Before are
+// opaque cursors previously returned in Cursor and are mutually exclusive.
+type Page struct {
+	Limit  int
+	After  string
+	Before string
+}
+
+// Cursor holds the opaque cursors pointing to the neighbouring pages.
+type Cursor struct {
+	Next string `json:"next_cursor,omitempty"`
+	Prev string `json:"prev_cursor,omitempty"`
+}
+
+func (p Page) Size() int {
+	if p.Limit <= 0 {
+		return DefaultLimit
+	}
+	if p.Limit > MaxLimit {
+		return MaxLimit
+	}
+	return p.Limit
+}
+
+// Backward reports whether the page is fetched in reverse order.
+func (p Page) Backward() bool {
+	return p.Before != ""
+}
+
+func EncodeCursor(values ...string) string {
+	data, _ := json.Marshal(values)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(cursor string) (values []string, err error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrorInvalidCursor
+	}
+	if err = json.Unmarshal(data, &values); err != nil || len(values) == 0 {
+		return nil, ErrorInvalidCursor
+	}
+	return
+}
+
+// Key is an ordering expression used for keyset pagination.
+type Key struct {
+	Expr string
+	Desc bool
+}
+
+// Keyset appends the cursor condition, ordering and limit for a query ordered
+// by the given integer column. The query must already contain a WHERE clause.
+func (p Page) Keyset(query, column string, args []any) (string, []any, error) {
+	if cursor := p.cursor(); cursor != "" {
+		values, err := DecodeCursor(cursor)
+		if err != nil {
+			return "", nil, err
+		}
+		if _, err = strconv.ParseInt(values[0], 10, 64); err != nil || len(values) != 1 {
+			return "", nil, ErrorInvalidCursor
+		}
+	}
+
+	return p.KeysetBy(query, args, Key{Expr: column})
+}
+
+// KeysetBy is Keyset for an ordering over several expressions. The last key
+// must be unique, cursor values are passed as untyped parameters.
+func (p Page) KeysetBy(query string, args []any, keys ...Key) (string, []any, error) {
+	if cursor := p.cursor(); cursor != "" {
+		values, err := DecodeCursor(cursor)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(values) != len(keys) {
+			return "", nil, ErrorInvalidCursor
+		}
+
+		first := len(args) + 1
+		for _, v := range values {
+			args = append(args, v)
+		}
+
+		var or []string
+		for i, key := range keys {
+			var and []string
+			for j := 0; j < i; j++ {
+				and = append(and, fmt.Sprintf("%s = $%d", keys[j].Expr, first+j))
+			}
+			op := ">"
+			if key.Desc != p.Backward() {
+				op = "<"
+			}
+			and = append(and, fmt.Sprintf("%s %s $%d", key.Expr, op, first+i))
+			or = append(or, "("+strings.Join(and, " AND ")+")")
+		}
+		query += " AND (" + strings.Join(or, " OR ") + ")"
+	}
+
+	var orders []string
+	for _, key := range keys {
+		order := "ASC"
+		if key.Desc != p.Backward() {
+			order = "DESC"
+		}
+		orders = append(orders, key.Expr+" "+order)
+	}
+
+	args = append(args, p.Size()+1)
+	query += fmt.Sprintf(" ORDER BY %s LIMIT $%d", strings.Join(orders, ", "), len(args))
+
+	return query, args, nil
+}
+
+func (p Page) cursor() string {
+	if p.Backward() {
+		return p.Before
+	}
+	return p.After
+}
+
+// Paginate trims the extra row fetched by Keyset, restores ascending order for
+// backward pages and builds the cursors from the boundary rows.
+func Paginate[T any](p Page, rows []T, key func(T) []string) ([]T, Cursor) {
+	cursor := Cursor{}
+
+	more := len(rows) > p.Size()
+	if more {
+		rows = rows[:p.Size()]
+	}
+	if p.Backward() {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+	if len(rows) == 0 {
+		return rows, cursor
+	}
+
+	first, last := EncodeCursor(key(rows[0])...), EncodeCursor(key(rows[len(rows)-1])...)
+	switch {
+	case p.Backward():
+		cursor.Next = last
+		if more {
+			cursor.Prev = first
+		}
+	default:
+		if more {
+			cursor.Next = last
+		}
+		if p.After != "" {
+			cursor.Prev = first
+		}
+	}
+
+	return rows, cursor
+}
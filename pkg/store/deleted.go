@@ -0,0 +1,22 @@
+package store
+
+import (
+	"context"
+)
+
+type deletedKey struct{}
+
+// WithDeleted returns a context in which repositories also return soft
+// deleted rows, or stop returning them when include is false.
+func WithDeleted(ctx context.Context, include bool) context.Context {
+	return context.WithValue(ctx, deletedKey{}, include)
+}
+
+// NotDeleted is the condition excluding the soft deleted rows of a table by its
+// deleted_at column. It is empty when ctx includes them.
+func NotDeleted(ctx context.Context, column string) string {
+	if include, _ := ctx.Value(deletedKey{}).(bool); include {
+		return ""
+	}
+	return " AND " + column + " IS NULL"
+}
@@ -5,5 +5,9 @@ import (
 )
 
 var (
-	ErrorNotFound = errors.New("error not found")
+	ErrorNotFound      = errors.New("error not found")
+	ErrorInvalidCursor = errors.New("invalid cursor")
+	ErrorNotDeleted    = errors.New("error not deleted")
+	ErrorConflict      = errors.New("error conflict")
+	ErrorInvalidInput  = errors.New("invalid input")
 )
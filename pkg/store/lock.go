@@ -0,0 +1,22 @@
+package store
+
+import (
+	"context"
+)
+
+type lockKey struct{}
+
+// WithLock returns a context in which repositories lock the rows they read
+// until the transaction carried by the context ends.
+func WithLock(ctx context.Context) context.Context {
+	return context.WithValue(ctx, lockKey{}, true)
+}
+
+// Locked is the locking clause of a query reading rows. It is empty unless
+// ctx asks for a lock.
+func Locked(ctx context.Context) string {
+	if lock, _ := ctx.Value(lockKey{}).(bool); lock {
+		return " FOR UPDATE"
+	}
+	return ""
+}
@@ -0,0 +1,79 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+)
+
+type txKey struct{}
+
+// Transactor runs a function in a database transaction. Repositories called
+// with the context passed to fn take part in the transaction.
+type Transactor interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// DB is a connection pool whose queries run in the transaction carried by the
+// context, if any.
+type DB struct {
+	*sqlx.DB
+}
+
+func NewDB(db *sqlx.DB) DB {
+	return DB{DB: db}
+}
+
+// Transaction commits when fn succeeds and rolls back otherwise. A call made
+// inside another transaction joins it.
+func (db DB) Transaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	return fn(context.WithValue(ctx, txKey{}, tx))
+}
+
+func (db DB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return tx.ExecContext(ctx, query, args...)
+	}
+	return db.DB.ExecContext(ctx, query, args...)
+}
+
+func (db DB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return tx.QueryRowContext(ctx, query, args...)
+	}
+	return db.DB.QueryRowContext(ctx, query, args...)
+}
+
+func (db DB) GetContext(ctx context.Context, dest any, query string, args ...any) error {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return tx.GetContext(ctx, dest, query, args...)
+	}
+	return db.DB.GetContext(ctx, dest, query, args...)
+}
+
+func (db DB) SelectContext(ctx context.Context, dest any, query string, args ...any) error {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return tx.SelectContext(ctx, dest, query, args...)
+	}
+	return db.DB.SelectContext(ctx, dest, query, args...)
+}
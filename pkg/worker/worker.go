@@ -0,0 +1,69 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Job is run by a Worker on every tick.
+type Job func(ctx context.Context) error
+
+// Worker runs a job periodically in the background until it is stopped.
+type Worker struct {
+	name     string
+	interval time.Duration
+	job      Job
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func New(name string, interval time.Duration, job Job) *Worker {
+	return &Worker{
+		name:     name,
+		interval: interval,
+		job:      job,
+	}
+}
+
+// Run starts the worker, the job first runs one interval later.
+func (w *Worker) Run() {
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	w.done = make(chan struct{})
+
+	go func() {
+		defer close(w.done)
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := w.job(ctx); err != nil && ctx.Err() == nil {
+					fmt.Printf("Error running %s: %v\n", w.name, err)
+				}
+			}
+		}
+	}()
+}
+
+// Stop cancels the running job and waits for the worker to exit.
+func (w *Worker) Stop(ctx context.Context) (err error) {
+	if w.cancel == nil {
+		return
+	}
+	w.cancel()
+
+	select {
+	case <-w.done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	return
+}